@@ -0,0 +1,13 @@
+package main
+
+import (
+	"log"
+
+	"github.com/unerror/waffy/pkg/cmd/waffy"
+)
+
+func main() {
+	if err := waffy.Start(); err != nil {
+		log.Fatalf("waffy: %s", err)
+	}
+}
@@ -0,0 +1,109 @@
+package acme
+
+import (
+	"crypto/rsa"
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+	"golang.org/x/crypto/acme"
+
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+const (
+	challengeHTTP    = "http-01"
+	challengeTLSALPN = "tls-alpn-01"
+
+	// challengePath is the path HTTP-01 challenges are requested on
+	challengePath = "/.well-known/acme-challenge/"
+)
+
+// prepare stores the response to the challenge, so that any node can answer it, and returns the
+// key it is stored under
+func (m *Manager) prepare(client *acme.Client, domain string, chal *acme.Challenge) (string, error) {
+	switch chal.Type {
+	case challengeHTTP:
+		resp, err := client.HTTP01ChallengeResponse(chal.Token)
+		if err != nil {
+			return "", fmt.Errorf("unable to create HTTP-01 response: %s", err)
+		}
+
+		key := challengeHTTP + "/" + chal.Token
+		return key, repository.SetAcmeChallenge(m.db, key, []byte(resp))
+	case challengeTLSALPN:
+		key, err := crypto.NewPrivateKey(keyBits)
+		if err != nil {
+			return "", err
+		}
+
+		cert, err := client.TLSALPN01ChallengeCert(chal.Token, domain, acme.WithKey(key.(*rsa.PrivateKey)))
+		if err != nil {
+			return "", fmt.Errorf("unable to create TLS-ALPN-01 certificate: %s", err)
+		}
+
+		c := sites.SiteCertificate{
+			Hostname:    domain,
+			Certificate: crypto.EncodePEMChain(cert.Certificate),
+			Key:         crypto.EncodePEM(key),
+		}
+		mBytes, err := c.Marshal()
+		if err != nil {
+			return "", err
+		}
+
+		k := challengeTLSALPN + "/" + domain
+		return k, repository.SetAcmeChallenge(m.db, k, mBytes)
+	}
+
+	return "", fmt.Errorf("unknown ACME challenge type %s", chal.Type)
+}
+
+// ServeChallenge answers an HTTP-01 challenge request, returning false if the request is not for
+// a challenge
+func (m *Manager) ServeChallenge(ctx *fasthttp.RequestCtx) bool {
+	path := string(ctx.Path())
+	if !strings.HasPrefix(path, challengePath) {
+		return false
+	}
+
+	token := strings.TrimPrefix(path, challengePath)
+	resp, err := repository.FindAcmeChallenge(m.db.Weak(), challengeHTTP+"/"+token)
+	if err != nil {
+		ctx.Error("unknown challenge", fasthttp.StatusNotFound)
+		return true
+	}
+
+	ctx.SetContentType("text/plain")
+	ctx.SetBody(resp)
+	return true
+}
+
+// IsChallenge returns if the TLS handshake is the ACME server validating a TLS-ALPN-01 challenge
+func IsChallenge(hello *tls.ClientHelloInfo) bool {
+	for _, p := range hello.SupportedProtos {
+		if p == acme.ALPNProto {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ChallengeCertificate returns the pending TLS-ALPN-01 challenge certificate for the domain
+func (m *Manager) ChallengeCertificate(domain string) (*tls.Certificate, error) {
+	mBytes, err := repository.FindAcmeChallenge(m.db.Weak(), challengeTLSALPN+"/"+strings.ToLower(domain))
+	if err != nil {
+		return nil, fmt.Errorf("no pending challenge for %s", domain)
+	}
+
+	c := sites.SiteCertificate{}
+	if err := c.Unmarshal(mBytes); err != nil {
+		return nil, err
+	}
+
+	return crypto.KeyPair(c.Certificate, c.Key)
+}
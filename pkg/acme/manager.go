@@ -0,0 +1,352 @@
+// Package acme issues and renews certificates for Sites with autoencrypt set, through an ACME v2
+// directory such as LetsEncrypt
+package acme
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/acme"
+
+	"github.com/unerror/waffy/pkg/config"
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+const (
+	// RenewBefore is how long before expiry a certificate is renewed
+	RenewBefore = time.Hour * 24 * 30
+
+	// CheckInterval is how often the certificates are checked for renewal
+	CheckInterval = time.Minute
+
+	// RetryInterval is how long to wait before retrying a failed issuance for a Site
+	RetryInterval = time.Hour
+
+	// ALPNProto is the TLS ALPN protocol of the TLS-ALPN-01 challenge, that a TLS listener should
+	// advertise
+	ALPNProto = acme.ALPNProto
+
+	keyBits   = 2048
+	orderWait = time.Minute * 5
+)
+
+// Manager issues certificates through ACME on the leader, and serves the pending challenges on every
+// node from the Raft store
+type Manager struct {
+	db        data.Consensus
+	directory string
+	email     string
+	challenge string
+	client    *http.Client
+
+	retry map[string]time.Time
+}
+
+// NewManager creates a Manager for the ACME directory in the config
+func NewManager(db data.Consensus, cfg *config.Config) (*Manager, error) {
+	if cfg.ACMEChallenge != challengeHTTP && cfg.ACMEChallenge != challengeTLSALPN {
+		return nil, fmt.Errorf("unknown ACME challenge type %s", cfg.ACMEChallenge)
+	}
+
+	client := http.DefaultClient
+	if cfg.ACMECA != "" {
+		pem, err := ioutil.ReadFile(cfg.ACMECA)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ACME CA: %s", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in ACME CA %s", cfg.ACMECA)
+		}
+
+		client = &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: pool},
+				Proxy:           http.ProxyFromEnvironment,
+			},
+		}
+	}
+
+	return &Manager{
+		db:        db,
+		directory: cfg.ACMEDirectory,
+		email:     cfg.ACMEEmail,
+		challenge: cfg.ACMEChallenge,
+		client:    client,
+		retry:     make(map[string]time.Time),
+	}, nil
+}
+
+// Run issues and renews certificates on the leader every CheckInterval, until stop is closed
+func (m *Manager) Run(stop <-chan struct{}) {
+	for {
+		if m.db.Leader() {
+			m.renew()
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(CheckInterval):
+		}
+	}
+}
+
+// renew issues certificates for autoencrypt Sites that have none, that expire within RenewBefore, or
+// whose aliases changed
+func (m *Manager) renew() {
+	list, err := repository.ListSites(m.db)
+	if err != nil {
+		log.Printf("unable to list sites: %s", err)
+		return
+	}
+
+	for _, s := range list {
+		if !s.Autoencrypt {
+			continue
+		}
+
+		if next, ok := m.retry[s.Hostname]; ok && time.Now().Before(next) {
+			continue
+		}
+
+		names := siteNames(s)
+		c, err := repository.FindSiteCertificate(m.db, s.Hostname)
+		if err == nil && !needsRenewal(c, names) {
+			continue
+		}
+
+		log.Printf("issuing ACME certificate for %s", strings.Join(names, ", "))
+		if err := m.issue(s.Hostname, names); err != nil {
+			log.Printf("unable to issue ACME certificate for %s: %s", s.Hostname, err)
+			m.retry[s.Hostname] = time.Now().Add(RetryInterval)
+			continue
+		}
+
+		delete(m.retry, s.Hostname)
+	}
+}
+
+// issue orders a certificate for the names, and stores it for the Site hostname
+func (m *Manager) issue(hostname string, names []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), orderWait)
+	defer cancel()
+
+	client, err := m.account(ctx)
+	if err != nil {
+		return err
+	}
+
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(names...))
+	if err != nil {
+		return fmt.Errorf("unable to create order: %s", err)
+	}
+
+	for _, u := range order.AuthzURLs {
+		if err := m.authorize(ctx, client, u); err != nil {
+			return err
+		}
+	}
+
+	if _, err := client.WaitOrder(ctx, order.URI); err != nil {
+		return fmt.Errorf("unable to wait for order: %s", err)
+	}
+
+	key, err := crypto.NewPrivateKey(keyBits)
+	if err != nil {
+		return err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: hostname},
+		DNSNames: names,
+	}, key)
+	if err != nil {
+		return fmt.Errorf("unable to create certificate request: %s", err)
+	}
+
+	chain, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		// CAs that finalize asynchronously may not return the order location the client waits on,
+		// so wait on the order we created before giving up
+		if chain, err = fetch(ctx, client, order.URI); err != nil {
+			return fmt.Errorf("unable to finalize order: %s", err)
+		}
+	}
+
+	leaf, err := x509.ParseCertificate(chain[0])
+	if err != nil {
+		return fmt.Errorf("unable to parse issued certificate: %s", err)
+	}
+
+	return repository.SaveSiteCertificate(m.db, &sites.SiteCertificate{
+		Hostname:    hostname,
+		Certificate: crypto.EncodePEMChain(chain),
+		Key:         crypto.EncodePEM(key),
+		NotAfter:    leaf.NotAfter.Unix(),
+		Acme:        true,
+	})
+}
+
+// authorize completes the challenge of a pending authorization
+func (m *Manager) authorize(ctx context.Context, client *acme.Client, url string) error {
+	z, err := client.GetAuthorization(ctx, url)
+	if err != nil {
+		return fmt.Errorf("unable to get authorization: %s", err)
+	}
+
+	if z.Status == acme.StatusValid {
+		return nil
+	}
+
+	var chal *acme.Challenge
+	for _, c := range z.Challenges {
+		if c.Type == m.challenge {
+			chal = c
+			break
+		}
+	}
+	if chal == nil {
+		return fmt.Errorf("no %s challenge offered for %s", m.challenge, z.Identifier.Value)
+	}
+
+	key, err := m.prepare(client, z.Identifier.Value, chal)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := repository.DeleteAcmeChallenge(m.db, key); err != nil {
+			log.Printf("unable to remove ACME challenge %s: %s", key, err)
+		}
+	}()
+
+	if _, err := client.Accept(ctx, chal); err != nil {
+		return fmt.Errorf("unable to accept challenge: %s", err)
+	}
+
+	if _, err := client.WaitAuthorization(ctx, z.URI); err != nil {
+		return fmt.Errorf("unable to authorize %s: %s", z.Identifier.Value, err)
+	}
+
+	return nil
+}
+
+// account returns an ACME client for the stored account of the directory, registering one if there
+// is none
+func (m *Manager) account(ctx context.Context) (*acme.Client, error) {
+	client := &acme.Client{
+		DirectoryURL: m.directory,
+		HTTPClient:   m.client,
+		UserAgent:    fmt.Sprintf("waffy/%s", config.Version),
+	}
+
+	if a, err := repository.FindAcmeAccount(m.db, m.directory); err == nil {
+		key, err := crypto.DecodePrivateKeyPEM(a.Key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse ACME account key: %s", err)
+		}
+
+		client.Key = key.(*rsa.PrivateKey)
+		client.KID = acme.KeyID(a.Uri)
+		return client, nil
+	}
+
+	key, err := crypto.NewPrivateKey(keyBits)
+	if err != nil {
+		return nil, err
+	}
+	client.Key = key.(*rsa.PrivateKey)
+
+	account := &acme.Account{}
+	if m.email != "" {
+		account.Contact = []string{"mailto:" + m.email}
+	}
+
+	account, err = client.Register(ctx, account, acme.AcceptTOS)
+	if err != nil {
+		return nil, fmt.Errorf("unable to register ACME account: %s", err)
+	}
+
+	err = repository.SaveAcmeAccount(m.db, &sites.AcmeAccount{
+		Directory: m.directory,
+		Uri:       account.URI,
+		Key:       crypto.EncodePEM(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// fetch waits for the order at url to be issued, and returns the certificate chain
+func fetch(ctx context.Context, client *acme.Client, url string) ([][]byte, error) {
+	order, err := client.WaitOrder(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != acme.StatusValid {
+		return nil, fmt.Errorf("order is %s", order.Status)
+	}
+
+	return client.FetchCert(ctx, order.CertURL, true)
+}
+
+// siteNames returns the hostname and aliases of the Site, lowercased and deduplicated
+func siteNames(s *sites.Site) []string {
+	names := []string{strings.ToLower(s.Hostname)}
+	seen := map[string]bool{names[0]: true}
+	for _, a := range s.Alias {
+		a = strings.ToLower(a)
+		if !seen[a] {
+			seen[a] = true
+			names = append(names, a)
+		}
+	}
+
+	return names
+}
+
+// needsRenewal returns if an ACME certificate expires within RenewBefore, or does not cover exactly
+// the names. Certificates not issued through ACME are never renewed.
+func needsRenewal(c *sites.SiteCertificate, names []string) bool {
+	if !c.Acme {
+		return false
+	}
+
+	if time.Until(time.Unix(c.NotAfter, 0)) < RenewBefore {
+		return true
+	}
+
+	leaf, err := crypto.DecodeCertificatePEM(c.Certificate)
+	if err != nil {
+		return true
+	}
+
+	have := make([]string, len(leaf.DNSNames))
+	for i, n := range leaf.DNSNames {
+		have[i] = strings.ToLower(n)
+	}
+
+	want := append([]string(nil), names...)
+	sort.Strings(have)
+	sort.Strings(want)
+
+	return strings.Join(have, ",") != strings.Join(want, ",")
+}
@@ -0,0 +1,465 @@
+package acme
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/valyala/fasthttp"
+
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// testConsensus is a data.Consensus of a single node on a data.Store. The Manager only reads and
+// writes the Buckets of the store, so it has no values of its own.
+type testConsensus struct {
+	data.Store
+	leader bool
+}
+
+func (c *testConsensus) List() ([]data.Node, error)        { return nil, errNoValues }
+func (c *testConsensus) Get(k []byte) ([]byte, error)      { return nil, errNoValues }
+func (c *testConsensus) Set(n data.Node) error             { return errNoValues }
+func (c *testConsensus) Delete(n data.Node) error          { return errNoValues }
+func (c *testConsensus) Seek(k []byte) ([]byte, error)     { return nil, errNoValues }
+func (c *testConsensus) GetWeak(k []byte) ([]byte, error)  { return nil, errNoValues }
+func (c *testConsensus) ListWeak() ([]data.Node, error)    { return nil, errNoValues }
+func (c *testConsensus) SeekWeak(k []byte) ([]byte, error) { return nil, errNoValues }
+func (c *testConsensus) Join(addr string) error            { return nil }
+func (c *testConsensus) Leave(addr string) error           { return nil }
+func (c *testConsensus) Leader() bool                      { return c.leader }
+func (c *testConsensus) Weak() data.Bucket                 { return c }
+
+// errNoValues is the error of reading or writing a value of a testConsensus outside of a Bucket
+var errNoValues = errors.New("no values outside of a bucket")
+
+// newTestConsensus creates a testConsensus in a temporary directory, and the func that removes it
+func newTestConsensus(t *testing.T, leader bool) (*testConsensus, func()) {
+	dir, err := ioutil.TempDir("", "waffy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := data.NewDB(filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testConsensus{Store: db, leader: leader}, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// fakeDirectory is an ACME directory that validates challenges by asking the Manager for their
+// responses, and issues certificates from a self-signed CA. Requests are not verified.
+type fakeDirectory struct {
+	*httptest.Server
+	m *Manager
+
+	mu sync.Mutex
+
+	// reject rejects new orders
+	reject bool
+
+	// orders are the orders created, and served the names of the last
+	orders int
+	names  []string
+
+	// valid are the names whose challenge was answered
+	valid map[string]bool
+
+	// answered are the challenges the Manager had stored when they were accepted
+	answered []string
+
+	issued []byte
+}
+
+// newFakeDirectory starts a fakeDirectory for the Manager, pointing the Manager at it
+func newFakeDirectory(m *Manager) *fakeDirectory {
+	f := &fakeDirectory{m: m, valid: make(map[string]bool)}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	m.directory = f.URL + "/dir"
+	m.client = f.Client()
+	return f
+}
+
+// payload returns the payload of the JWS the client posted
+func payload(req *http.Request, v interface{}) {
+	var jws struct {
+		Payload string `json:"payload"`
+	}
+	json.NewDecoder(req.Body).Decode(&jws)
+
+	b, _ := base64.RawURLEncoding.DecodeString(jws.Payload)
+	json.Unmarshal(b, v)
+}
+
+func (f *fakeDirectory) serve(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Replay-Nonce", base64.RawURLEncoding.EncodeToString([]byte(time.Now().String())))
+	reply := func(status int, location string, v interface{}) {
+		if location != "" {
+			w.Header().Set("Location", f.URL+location)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(v)
+	}
+
+	switch path := req.URL.Path; {
+	case path == "/dir":
+		reply(http.StatusOK, "", map[string]string{
+			"newNonce":   f.URL + "/nonce",
+			"newAccount": f.URL + "/account",
+			"newOrder":   f.URL + "/order",
+		})
+	case path == "/nonce":
+		w.WriteHeader(http.StatusOK)
+	case path == "/account":
+		reply(http.StatusCreated, "/account/1", map[string]string{"status": "valid"})
+	case path == "/order":
+		f.orders++
+		if f.reject {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"type": "urn:ietf:params:acme:error:rejectedIdentifier", "detail": "rejected"}`))
+			return
+		}
+
+		var order struct {
+			Identifiers []struct{ Value string }
+		}
+		payload(req, &order)
+		f.names, f.issued = nil, nil
+		for _, id := range order.Identifiers {
+			f.names = append(f.names, id.Value)
+		}
+		reply(http.StatusCreated, "/order/1", f.order())
+	case path == "/order/1":
+		reply(http.StatusOK, "/order/1", f.order())
+	case strings.HasPrefix(path, "/authz/"):
+		reply(http.StatusOK, "", f.authz(strings.TrimPrefix(path, "/authz/")))
+	case strings.HasPrefix(path, "/chal/"):
+		name := strings.TrimPrefix(path, "/chal/")
+		f.validate(name)
+		reply(http.StatusOK, "", map[string]string{"type": f.m.challenge, "url": f.URL + path, "status": "valid"})
+	case path == "/finalize/1":
+		var finalize struct{ CSR string }
+		payload(req, &finalize)
+		der, _ := base64.RawURLEncoding.DecodeString(finalize.CSR)
+		csr, err := x509.ParseCertificateRequest(der)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.issue(csr)
+		reply(http.StatusOK, "/order/1", f.order())
+	case path == "/cert/1":
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		w.Write(f.issued)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// order returns the last order, ready once each of its names is valid
+func (f *fakeDirectory) order() map[string]interface{} {
+	status := "ready"
+	var ids []map[string]string
+	var authz []string
+	for _, n := range f.names {
+		ids = append(ids, map[string]string{"type": "dns", "value": n})
+		authz = append(authz, f.URL+"/authz/"+n)
+		if !f.valid[n] {
+			status = "pending"
+		}
+	}
+
+	o := map[string]interface{}{
+		"status":         status,
+		"identifiers":    ids,
+		"authorizations": authz,
+		"finalize":       f.URL + "/finalize/1",
+	}
+	if f.issued != nil {
+		o["status"] = "valid"
+		o["certificate"] = f.URL + "/cert/1"
+	}
+
+	return o
+}
+
+// authz returns the authorization of the name, offering both challenges
+func (f *fakeDirectory) authz(name string) map[string]interface{} {
+	status := "pending"
+	if f.valid[name] {
+		status = "valid"
+	}
+
+	var challenges []map[string]string
+	for _, typ := range []string{challengeHTTP, challengeTLSALPN} {
+		challenges = append(challenges, map[string]string{
+			"type":   typ,
+			"url":    f.URL + "/chal/" + name,
+			"token":  token(name),
+			"status": status,
+		})
+	}
+
+	return map[string]interface{}{
+		"status":     status,
+		"identifier": map[string]string{"type": "dns", "value": name},
+		"challenges": challenges,
+	}
+}
+
+// token returns the challenge token of the name
+func token(name string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(name))
+}
+
+// validate marks the name valid if the Manager answers its challenge, as the CA would see it
+func (f *fakeDirectory) validate(name string) {
+	switch f.m.challenge {
+	case challengeHTTP:
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.SetRequestURI(challengePath + token(name))
+		if f.m.ServeChallenge(ctx) && ctx.Response.StatusCode() == fasthttp.StatusOK &&
+			strings.HasPrefix(string(ctx.Response.Body()), token(name)+".") {
+			f.valid[name] = true
+		}
+	case challengeTLSALPN:
+		cert, err := f.m.ChallengeCertificate(strings.ToUpper(name))
+		if err == nil && len(cert.Leaf.DNSNames) == 1 && cert.Leaf.DNSNames[0] == name {
+			f.valid[name] = true
+		}
+	}
+
+	if f.valid[name] {
+		f.answered = append(f.answered, f.m.challenge+"/"+name)
+	}
+}
+
+// issue issues a self-signed certificate for the request
+func (f *fakeDirectory) issue(csr *x509.CertificateRequest) {
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: csr.Subject.CommonName},
+		DNSNames:     csr.DNSNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour * 24 * 90),
+	}
+	key, _ := crypto.NewPrivateKey(1024)
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, csr.PublicKey, key)
+	if err != nil {
+		return
+	}
+
+	f.issued = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestRenew(t *testing.T) {
+	Convey("With autoencrypt Sites and an ACME directory", t, func() {
+		db, cleanup := newTestConsensus(t, true)
+		defer cleanup()
+
+		So(repository.SaveBalancer(db, &sites.Balancer{Proto: "https", Port: "443"}), ShouldBeNil)
+		So(repository.CreateSite(db, "443", &sites.Site{Hostname: "Example.com", Alias: []string{"www.example.com", "WWW.example.com"}, Autoencrypt: true}), ShouldBeNil)
+		So(repository.CreateSite(db, "443", &sites.Site{Hostname: "manual.example.com"}), ShouldBeNil)
+
+		m := &Manager{db: db, challenge: challengeHTTP, retry: make(map[string]time.Time)}
+		ca := newFakeDirectory(m)
+		defer ca.Close()
+
+		// run runs the Manager until it has checked the certificates once
+		run := func() {
+			stop := make(chan struct{})
+			close(stop)
+			m.Run(stop)
+		}
+
+		Convey("A follower should not issue certificates", func() {
+			db.leader = false
+			run()
+
+			So(ca.orders, ShouldEqual, 0)
+			_, err := repository.FindSiteCertificate(db, "Example.com")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("The leader should issue a certificate for the hostname and aliases of each autoencrypt Site", func() {
+			run()
+
+			So(ca.orders, ShouldEqual, 1)
+			So(ca.names, ShouldResemble, []string{"example.com", "www.example.com"})
+
+			c, err := repository.FindSiteCertificate(db, "Example.com")
+			So(err, ShouldBeNil)
+			So(c.Acme, ShouldBeTrue)
+			So(needsRenewal(c, ca.names), ShouldBeFalse)
+			_, err = crypto.KeyPair(c.Certificate, c.Key)
+			So(err, ShouldBeNil)
+
+			_, err = repository.FindSiteCertificate(db, "manual.example.com")
+			So(err, ShouldNotBeNil)
+
+			Convey("and not again while it does not need renewal", func() {
+				run()
+				So(ca.orders, ShouldEqual, 1)
+			})
+		})
+
+		Convey("HTTP-01 challenges should be answered by any node, and removed once authorized", func() {
+			run()
+
+			So(ca.answered, ShouldResemble, []string{"http-01/example.com", "http-01/www.example.com"})
+			for _, n := range ca.names {
+				_, err := repository.FindAcmeChallenge(db, challengeHTTP+"/"+token(n))
+				So(err, ShouldNotBeNil)
+			}
+		})
+
+		Convey("TLS-ALPN-01 challenges should be answered by any node, and removed once authorized", func() {
+			m.challenge = challengeTLSALPN
+			run()
+
+			So(ca.answered, ShouldResemble, []string{"tls-alpn-01/example.com", "tls-alpn-01/www.example.com"})
+			_, err := repository.FindSiteCertificate(db, "Example.com")
+			So(err, ShouldBeNil)
+
+			_, err = m.ChallengeCertificate("example.com")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("A Site whose issuance failed should not be retried before RetryInterval", func() {
+			ca.reject = true
+			run()
+			So(ca.orders, ShouldEqual, 1)
+			So(m.retry["Example.com"], ShouldHappenWithin, time.Minute, time.Now().Add(RetryInterval))
+
+			ca.reject = false
+			run()
+			So(ca.orders, ShouldEqual, 1)
+
+			Convey("and should be retried after it, forgetting the failure once issued", func() {
+				m.retry["Example.com"] = time.Now().Add(-time.Second)
+				run()
+
+				So(ca.orders, ShouldEqual, 2)
+				So(m.retry, ShouldNotContainKey, "Example.com")
+				_, err := repository.FindSiteCertificate(db, "Example.com")
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestNeedsRenewal(t *testing.T) {
+	// certificate returns a Site certificate for the names that expires in the duration
+	certificate := func(expires time.Duration, names ...string) *sites.SiteCertificate {
+		tmpl := &x509.Certificate{SerialNumber: big.NewInt(1), DNSNames: names, NotAfter: time.Now().Add(expires)}
+		key, err := crypto.NewPrivateKey(1024)
+		So(err, ShouldBeNil)
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.(*rsa.PrivateKey).Public(), key)
+		So(err, ShouldBeNil)
+
+		return &sites.SiteCertificate{
+			Certificate: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			NotAfter:    tmpl.NotAfter.Unix(),
+			Acme:        true,
+		}
+	}
+	names := []string{"example.com", "www.example.com"}
+
+	Convey("An ACME certificate for the names that does not expire soon should not be renewed", t, func() {
+		So(needsRenewal(certificate(RenewBefore*2, "WWW.example.com", "example.com"), names), ShouldBeFalse)
+	})
+
+	Convey("An ACME certificate that expires within RenewBefore should be renewed", t, func() {
+		So(needsRenewal(certificate(RenewBefore/2, names...), names), ShouldBeTrue)
+	})
+
+	Convey("An ACME certificate for other names should be renewed", t, func() {
+		So(needsRenewal(certificate(RenewBefore*2, "example.com"), names), ShouldBeTrue)
+		So(needsRenewal(certificate(RenewBefore*2, "example.com", "www.example.com", "old.example.com"), names), ShouldBeTrue)
+	})
+
+	Convey("A certificate not issued through ACME should never be renewed", t, func() {
+		c := certificate(time.Hour, "example.com")
+		c.Acme = false
+		So(needsRenewal(c, names), ShouldBeFalse)
+	})
+
+	Convey("The names of a Site should be its hostname then aliases, lowercased once each", t, func() {
+		So(siteNames(&sites.Site{Hostname: "Example.com", Alias: []string{"WWW.example.com", "www.example.com", "example.COM"}}),
+			ShouldResemble, names)
+	})
+}
+
+func TestServeChallenge(t *testing.T) {
+	db, cleanup := newTestConsensus(t, false)
+	defer cleanup()
+	m := &Manager{db: db}
+
+	if err := repository.SetAcmeChallenge(db, challengeHTTP+"/abc", []byte("abc.thumbprint")); err != nil {
+		t.Fatal(err)
+	}
+
+	// challenge returns the response to a request of the path, and if it was for a challenge
+	challenge := func(path string) (*fasthttp.RequestCtx, bool) {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.SetRequestURI(path)
+		return ctx, m.ServeChallenge(ctx)
+	}
+
+	Convey("A pending HTTP-01 challenge should be answered with its key authorization", t, func() {
+		ctx, ok := challenge(challengePath + "abc")
+		So(ok, ShouldBeTrue)
+		So(ctx.Response.StatusCode(), ShouldEqual, fasthttp.StatusOK)
+		So(string(ctx.Response.Body()), ShouldEqual, "abc.thumbprint")
+	})
+
+	Convey("An unknown HTTP-01 challenge should not be found", t, func() {
+		ctx, ok := challenge(challengePath + "xyz")
+		So(ok, ShouldBeTrue)
+		So(ctx.Response.StatusCode(), ShouldEqual, fasthttp.StatusNotFound)
+	})
+
+	Convey("A request that is not for a challenge should be left to the proxy", t, func() {
+		_, ok := challenge("/.well-known/other")
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Only handshakes that offer the TLS-ALPN-01 protocol should be challenges", t, func() {
+		So(IsChallenge(&tls.ClientHelloInfo{SupportedProtos: []string{"h2", ALPNProto}}), ShouldBeTrue)
+		So(IsChallenge(&tls.ClientHelloInfo{SupportedProtos: []string{"h2", "http/1.1"}}), ShouldBeFalse)
+	})
+
+	Convey("A domain with no pending TLS-ALPN-01 challenge should have no challenge certificate", t, func() {
+		_, err := m.ChallengeCertificate("example.com")
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,165 @@
+package waffy
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/unerror/waffy/pkg/services/protos/certificates"
+)
+
+var certificateLookupFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "serial",
+		Usage: "Hex encoded serial number of the certificate",
+	},
+	cli.StringFlag{
+		Name:  "subject",
+		Usage: "Common name or email of the certificate subject",
+	},
+}
+
+func init() {
+	Cmds = append(Cmds, cli.Command{
+		Name:     "certs",
+		Usage:    "Audit the certificates issued by the waffy CA",
+		Category: "CERTIFICATES",
+		Subcommands: []cli.Command{
+			{
+				Name:  "list",
+				Usage: "List the issued certificates, ordered by expiry",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "expiring",
+						Usage: "Only list certificates expiring within the duration, e.g. 30d or 72h",
+					},
+				},
+				Action: withClient(listCerts),
+			},
+			{
+				Name:   "get",
+				Usage:  "Show the details of a certificate",
+				Flags:  certificateLookupFlags,
+				Action: withClient(getCert),
+			},
+			{
+				Name:   "export",
+				Usage:  "Export a PEM encoded certificate with its CA chain",
+				Flags:  certificateLookupFlags,
+				Action: withClient(exportCert),
+			},
+		},
+	})
+}
+
+func listCerts(ctx *cli.Context, conn *grpc.ClientConn) error {
+	req := &certificates.ListRequest{}
+	if expiring := ctx.String("expiring"); expiring != "" {
+		d, err := parseDuration(expiring)
+		if err != nil {
+			return err
+		}
+		req.ExpiringWithin = int64(d.Seconds())
+	}
+
+	resp, err := certificates.NewCertificatesServiceClient(conn).List(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("unable to list certificates: %s", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SERIAL\tOWNER\tISSUER\tKEY\tNOT AFTER\tSANS")
+	for _, c := range resp.Certificates {
+		fmt.Fprintf(w, "%x\t%s\t%s\t%s\t%s\t%s\n",
+			c.Certificate.SerialNumber,
+			owner(c),
+			c.Issuer,
+			c.KeyType,
+			time.Unix(c.NotAfter, 0).UTC().Format(time.RFC3339),
+			strings.Join(sans(c), ","),
+		)
+	}
+
+	return w.Flush()
+}
+
+func getCert(ctx *cli.Context, conn *grpc.ClientConn) error {
+	req, err := lookupRequest(ctx)
+	if err != nil {
+		return err
+	}
+
+	c, err := certificates.NewCertificatesServiceClient(conn).Get(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("unable to get certificate: %s", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "Serial:\t%x\n", c.Certificate.SerialNumber)
+	fmt.Fprintf(w, "Owner:\t%s\n", owner(c))
+	fmt.Fprintf(w, "Issuer:\t%s\n", c.Issuer)
+	fmt.Fprintf(w, "Key:\t%s\n", c.KeyType)
+	fmt.Fprintf(w, "Not Before:\t%s\n", time.Unix(c.NotBefore, 0).UTC().Format(time.RFC3339))
+	fmt.Fprintf(w, "Not After:\t%s\n", time.Unix(c.NotAfter, 0).UTC().Format(time.RFC3339))
+	fmt.Fprintf(w, "SANs:\t%s\n", strings.Join(sans(c), ", "))
+
+	return w.Flush()
+}
+
+func exportCert(ctx *cli.Context, conn *grpc.ClientConn) error {
+	req, err := lookupRequest(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := certificates.NewCertificatesServiceClient(conn).Export(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("unable to export certificate: %s", err)
+	}
+
+	if _, err := os.Stdout.Write(resp.Certificate); err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(resp.Chain)
+	return err
+}
+
+// lookupRequest builds the certificates.GetRequest from the --serial or --subject flags
+func lookupRequest(ctx *cli.Context) (*certificates.GetRequest, error) {
+	req := &certificates.GetRequest{
+		Subject: ctx.String("subject"),
+	}
+
+	if serial := ctx.String("serial"); serial != "" {
+		s, err := hex.DecodeString(serial)
+		if err != nil {
+			return nil, fmt.Errorf("invalid serial %s: %s", serial, err)
+		}
+		req.SerialNumber = s
+	}
+
+	if len(req.SerialNumber) == 0 && req.Subject == "" {
+		return nil, fmt.Errorf("--serial or --subject is required")
+	}
+
+	return req, nil
+}
+
+func owner(c *certificates.CertificateInfo) string {
+	return fmt.Sprintf("%s:%s", strings.ToLower(c.OwnerType.String()), c.Owner)
+}
+
+func sans(c *certificates.CertificateInfo) []string {
+	var names []string
+	names = append(names, c.DnsNames...)
+	names = append(names, c.IpAddresses...)
+	names = append(names, c.EmailAddresses...)
+	return append(names, c.Uris...)
+}
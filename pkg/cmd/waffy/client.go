@@ -0,0 +1,66 @@
+package waffy
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/unerror/waffy/pkg/config"
+)
+
+// withClient dials the waffyd RPC with the user's client certificate, and passes the connection to f
+func withClient(f func(ctx *cli.Context, conn *grpc.ClientConn) error) func(*cli.Context) error {
+	return func(ctx *cli.Context) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		user := ctx.GlobalString("user")
+		if user == "" {
+			return fmt.Errorf("--user is required")
+		}
+
+		ca, err := config.LoadCACert()
+		if err != nil {
+			return err
+		}
+
+		cert, key, err := config.LoadClientCert(user)
+		if err != nil {
+			return err
+		}
+
+		roots := x509.NewCertPool()
+		roots.AddCert(ca)
+
+		keypair := &tls.Certificate{
+			Certificate: [][]byte{cert.Raw},
+			PrivateKey:  key,
+			Leaf:        cert,
+		}
+
+		creds := credentials.NewTLS(&tls.Config{
+			ServerName: cfg.RPCName,
+			RootCAs:    roots,
+			MinVersion: tls.VersionTLS12,
+			// the client certificate is issued by an intermediate rather than the root the server
+			// advertises, so always send it and let the server verify the chain
+			GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+				return keypair, nil
+			},
+		})
+
+		conn, err := grpc.Dial(ctx.GlobalString("server"), grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return fmt.Errorf("unable to connect to %s: %s", ctx.GlobalString("server"), err)
+		}
+		defer conn.Close()
+
+		return f(ctx, conn)
+	}
+}
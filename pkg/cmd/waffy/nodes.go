@@ -0,0 +1,118 @@
+package waffy
+
+import (
+	"fmt"
+	"log"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/unerror/waffy/pkg/config"
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/services/protos/certificates"
+	"github.com/unerror/waffy/pkg/services/protos/nodes"
+)
+
+func init() {
+	Cmds = append(Cmds, cli.Command{
+		Name:     "nodes",
+		Usage:    "Manage load balancer nodes",
+		Category: "NODES",
+		Subcommands: []cli.Command{
+			{
+				Name:  "enroll",
+				Usage: "Generate a node key and have its certificate signed by the waffy CA",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "hostname",
+						Usage: "Hostname of the node, used as the common name",
+					},
+					cli.IntFlag{
+						Name:  "key-size",
+						Usage: "Key size to use for the node key",
+						Value: 4096,
+					},
+					cli.StringSliceFlag{
+						Name:  "dns",
+						Usage: "DNS subject alternative name, can be repeated",
+					},
+					cli.StringSliceFlag{
+						Name:  "ip",
+						Usage: "IP address subject alternative name, can be repeated",
+					},
+					cli.StringSliceFlag{
+						Name:  "uri",
+						Usage: "URI subject alternative name, can be repeated",
+					},
+					cli.StringSliceFlag{
+						Name:  "organization",
+						Usage: "Organization of the node",
+					},
+					cli.StringSliceFlag{
+						Name:  "country",
+						Usage: "Country of the node",
+					},
+					cli.StringSliceFlag{
+						Name:  "province",
+						Usage: "Province or state of the node",
+					},
+					cli.StringSliceFlag{
+						Name:  "locality",
+						Usage: "Locality or city of the node",
+					},
+				},
+				Action: withClient(enrollNode),
+			},
+		},
+	})
+}
+
+func enrollNode(ctx *cli.Context, conn *grpc.ClientConn) error {
+	hostname := ctx.String("hostname")
+	if hostname == "" {
+		return fmt.Errorf("--hostname is required")
+	}
+
+	key, err := crypto.NewPrivateKey(ctx.Int("key-size"))
+	if err != nil {
+		return err
+	}
+
+	csr, err := crypto.NewCertificateRequest(key, hostname)
+	if err != nil {
+		return err
+	}
+
+	resp, err := nodes.NewJoinServiceClient(conn).Enroll(context.Background(), &nodes.EnrollRequest{
+		Hostname: hostname,
+		Subject: &certificates.Subject{
+			Organization: ctx.StringSlice("organization"),
+			Country:      ctx.StringSlice("country"),
+			Province:     ctx.StringSlice("province"),
+			Locality:     ctx.StringSlice("locality"),
+		},
+		DnsNames:    ctx.StringSlice("dns"),
+		IpAddresses: ctx.StringSlice("ip"),
+		Uris:        ctx.StringSlice("uri"),
+		Csr:         csr,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to enroll %s: %s", hostname, err)
+	}
+
+	cert, err := crypto.DecodeCertificatePEM(resp.Node.Certificate.Certificate)
+	if err != nil {
+		return fmt.Errorf("unable to decode enrolled certificate: %s", err)
+	}
+
+	if err := config.SaveKey(hostname, key); err != nil {
+		return fmt.Errorf("unable to save private key: %s", err)
+	}
+	if err := config.SaveCert(hostname, cert); err != nil {
+		return fmt.Errorf("unable to save certificate: %s", err)
+	}
+
+	log.Printf("enrolled %s with certificate %x", hostname, cert.SerialNumber.Bytes())
+	return nil
+}
@@ -0,0 +1,41 @@
+// Package waffy is the waffy command line client for the waffyd RPC
+package waffy
+
+import (
+	"os"
+
+	"github.com/unerror/waffy/pkg/config"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// Cmds are the cli.Commands that are Commands on the waffy App
+var Cmds []cli.Command
+
+// Start starts the waffy client tool
+func Start() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	app := cli.NewApp()
+	app.Name = "waffy"
+	app.Usage = "waffy firewall and load balancer client"
+	app.Version = cfg.Version
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:   "server",
+			Usage:  "Address of the waffyd RPC",
+			EnvVar: "WAFFY_SERVER",
+			Value:  cfg.APIListen,
+		},
+		cli.StringFlag{
+			Name:   "user",
+			Usage:  "Email of the user whose client certificate is used",
+			EnvVar: "WAFFY_USER",
+		},
+	}
+	app.Commands = Cmds
+
+	return app.Run(os.Args)
+}
@@ -0,0 +1,2210 @@
+package waffy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+func init() {
+	Cmds = append(Cmds, cli.Command{
+		Name:     "sites",
+		Usage:    "Manage the Sites served by waffy",
+		Category: "SITES",
+		Subcommands: []cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List the Sites, and the ports of the Balancers that serve them",
+				Action: withClient(listSites),
+			},
+			{
+				Name:  "status",
+				Usage: "Show the health of the Endpoints of the Sites and Balancers, and the ejections of the server's node",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "hostname",
+						Usage: "Only show the Endpoints of the Site, or of the Balancer for :port",
+					},
+				},
+				Action: withClient(siteStatus),
+			},
+			{
+				Name:  "mirrors",
+				Usage: "Compare the primary and shadow responses of mirrored Routes, as seen by the server",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "hostname",
+						Usage: "Only show the mirrors of the Site",
+					},
+				},
+				Action: withClient(mirrorStats),
+			},
+			{
+				Name:  "caches",
+				Usage: "Show the hits, misses and size of the caches of the Sites, as seen by the server",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "hostname",
+						Usage: "Only show the cache of the Site",
+					},
+				},
+				Action: withClient(cacheStats),
+			},
+			{
+				Name:   "get",
+				Usage:  "Show a Site and its Upstreams",
+				Flags:  []cli.Flag{hostnameFlag},
+				Action: withClient(getSite),
+			},
+			{
+				Name:  "create",
+				Usage: "Create a Site on the Balancer on a port",
+				Flags: append([]cli.Flag{
+					hostnameFlag,
+					cli.StringFlag{
+						Name:  "port",
+						Usage: "The port of the Balancer that serves the Site",
+					},
+				}, siteFlags...),
+				Action: withClient(createSite),
+			},
+			{
+				Name:   "update",
+				Usage:  "Change the aliases and TLS settings of a Site",
+				Flags:  append([]cli.Flag{hostnameFlag}, siteFlags...),
+				Action: withClient(updateSite),
+			},
+			{
+				Name:   "resilience",
+				Usage:  "Replace the timeouts, retries and circuit breaker of a Site",
+				Flags:  append([]cli.Flag{hostnameFlag}, resilienceFlags...),
+				Action: withClient(setResilience),
+			},
+			{
+				Name:  "affinity",
+				Usage: "Pin the clients of a Site to the Endpoint that served them with a signed cookie",
+				Flags: []cli.Flag{
+					hostnameFlag,
+					cli.StringFlag{
+						Name:  "cookie",
+						Usage: "The name of the affinity cookie",
+						Value: "waffy_affinity",
+					},
+					cli.DurationFlag{
+						Name:  "ttl",
+						Usage: "How long clients stay pinned, until the browser closes if 0",
+					},
+					cli.BoolFlag{
+						Name:  "disable",
+						Usage: "Remove the affinity of the Site, falling back to that of its Balancer",
+					},
+				},
+				Action: withClient(setAffinity),
+			},
+			{
+				Name:  "websocket",
+				Usage: "Replace the limits of the WebSocket and other Upgrade connections of a Site",
+				Flags: []cli.Flag{
+					hostnameFlag,
+					cli.BoolFlag{
+						Name:  "disable",
+						Usage: "Reject Upgrade requests rather than tunneling them",
+					},
+					cli.DurationFlag{
+						Name:  "idle-timeout",
+						Usage: "Close connections with no traffic either way for this long",
+						Value: time.Minute * 5,
+					},
+					cli.Int64Flag{
+						Name:  "max-frame-size",
+						Usage: "Close connections whose client sends a frame of more bytes, unlimited if 0",
+					},
+					cli.Int64Flag{
+						Name:  "max-message-size",
+						Usage: "Close connections whose client sends a message of more bytes, unlimited if 0",
+					},
+					cli.StringSliceFlag{
+						Name:  "deny",
+						Usage: "Close connections whose client sends a text message matching the regex, across its frames, can be repeated",
+					},
+				},
+				Action: withClient(setWebSocket),
+			},
+			{
+				Name:  "cache",
+				Usage: "Replace the response cache of a Site",
+				Flags: []cli.Flag{
+					hostnameFlag,
+					cli.BoolFlag{
+						Name:  "disable",
+						Usage: "Send every request upstream rather than caching responses",
+					},
+					cli.Int64Flag{
+						Name:  "max-size",
+						Usage: "The bytes of responses cached in memory, 64MB if 0",
+					},
+					cli.Int64Flag{
+						Name:  "max-object-size",
+						Usage: "The bytes of the largest response cached, 8MB if 0",
+					},
+					cli.DurationFlag{
+						Name:  "default-ttl",
+						Usage: "How long responses without Cache-Control or Expires are fresh, not cached if 0",
+					},
+					cli.StringFlag{
+						Name:  "disk-path",
+						Usage: "The directory responses evicted from memory are cached in, not cached on disk if empty",
+					},
+					cli.Int64Flag{
+						Name:  "disk-max-size",
+						Usage: "The bytes of responses cached on disk, 1GB if 0",
+					},
+					cli.StringFlag{
+						Name:  "tag-header",
+						Usage: "The response header listing the tags responses can be purged by, Cache-Tag if empty",
+					},
+				},
+				Action: withClient(setCache),
+			},
+			{
+				Name:  "compression",
+				Usage: "Replace the compression of the responses of a Site",
+				Flags: []cli.Flag{
+					hostnameFlag,
+					cli.BoolFlag{
+						Name:  "disable",
+						Usage: "Send responses as the Upstreams sent them",
+					},
+					cli.StringSliceFlag{
+						Name:  "encoding",
+						Usage: "An encoding responses are compressed with, br, zstd or gzip, preferred in order, all if unset, can be repeated",
+					},
+					cli.StringSliceFlag{
+						Name:  "content-type",
+						Usage: "A content type compressed, as type/subtype or type/*, text, script, data and font types if unset, can be repeated",
+					},
+					cli.Int64Flag{
+						Name:  "min-size",
+						Usage: "The bytes of the smallest response compressed, 1KB if 0",
+					},
+				},
+				Action: withClient(setCompression),
+			},
+			{
+				Name:  "purge",
+				Usage: "Remove cached responses of a Site from every node",
+				Flags: []cli.Flag{
+					hostnameFlag,
+					cli.StringSliceFlag{
+						Name:  "url",
+						Usage: "Purge the responses of the path and query, can be repeated",
+					},
+					cli.StringSliceFlag{
+						Name:  "prefix",
+						Usage: "Purge the responses of paths starting with the prefix, can be repeated",
+					},
+					cli.StringSliceFlag{
+						Name:  "tag",
+						Usage: "Purge the responses tagged with the tag, can be repeated",
+					},
+					cli.BoolFlag{
+						Name:  "all",
+						Usage: "Purge every response",
+					},
+				},
+				Action: withClient(purgeCache),
+			},
+			{
+				Name:  "delete",
+				Usage: "Delete a Site",
+				Flags: []cli.Flag{
+					hostnameFlag,
+					cli.StringFlag{
+						Name:  "port",
+						Usage: "Only delete the Site from the Balancer on the port",
+					},
+				},
+				Action: withClient(deleteSite),
+			},
+			{
+				Name:  "upstream",
+				Usage: "Manage the Upstreams of a Site",
+				Subcommands: []cli.Command{
+					{
+						Name:  "put",
+						Usage: "Create or replace an Upstream of a Site",
+						Flags: append([]cli.Flag{
+							hostnameFlag,
+							cli.StringFlag{
+								Name:  "name",
+								Usage: "The name of the Upstream",
+							},
+							cli.StringSliceFlag{
+								Name:  "endpoint",
+								Usage: "An Endpoint, as scheme://host:port with scheme http, https or h2c, and options ?weight=N&max-connections=N&server-name=S&ca=FILE&insecure=true&label=k:v, can be repeated",
+							},
+							cli.StringFlag{
+								Name:  "strategy",
+								Usage: "How Endpoints are picked: round-robin, weighted-round-robin, least-connections, power-of-two or consistent-hash",
+								Value: "round-robin",
+							},
+							cli.StringFlag{
+								Name:  "hash-source",
+								Usage: "What consistent-hash hashes: client-ip, header or cookie",
+								Value: "client-ip",
+							},
+							cli.StringFlag{
+								Name:  "hash-name",
+								Usage: "The header or cookie consistent-hash hashes",
+							},
+						}, healthFlags...),
+						Action: withClient(putUpstream),
+					},
+					{
+						Name:  "delete",
+						Usage: "Delete an Upstream of a Site",
+						Flags: []cli.Flag{
+							hostnameFlag,
+							cli.StringFlag{
+								Name:  "name",
+								Usage: "The name of the Upstream",
+							},
+						},
+						Action: withClient(deleteUpstream),
+					},
+				},
+			},
+			{
+				Name:  "route",
+				Usage: "Manage the Routes of a Site, that send matching requests to an Upstream",
+				Subcommands: []cli.Command{
+					{
+						Name:  "put",
+						Usage: "Create or replace a Route of a Site",
+						Flags: append([]cli.Flag{
+							hostnameFlag,
+							cli.StringFlag{
+								Name:  "name",
+								Usage: "The name of the Route",
+							},
+							cli.StringFlag{
+								Name:  "prefix",
+								Usage: "Match paths that start with the prefix",
+							},
+							cli.StringFlag{
+								Name:  "exact",
+								Usage: "Match the exact path",
+							},
+							cli.StringFlag{
+								Name:  "regex",
+								Usage: "Match paths that match the regex",
+							},
+							cli.StringFlag{
+								Name:  "grpc",
+								Usage: "Match gRPC calls to the service, as package.Service, or to one method, as package.Service/Method",
+							},
+							cli.StringSliceFlag{
+								Name:  "method",
+								Usage: "Match requests with the method, can be repeated",
+							},
+							cli.StringSliceFlag{
+								Name:  "header",
+								Usage: "Match requests with the header, as name, name=value or name~regex, can be repeated",
+							},
+							cli.StringSliceFlag{
+								Name:  "query",
+								Usage: "Match requests with the query parameter, as name, name=value or name~regex, can be repeated",
+							},
+							cli.StringFlag{
+								Name:  "upstream",
+								Usage: "The name of the Upstream matching requests are sent to",
+							},
+							cli.BoolFlag{
+								Name:  "deny",
+								Usage: "Refuse matching requests, with PERMISSION_DENIED for gRPC calls, instead of sending them to an Upstream",
+							},
+							cli.BoolFlag{
+								Name:  "strip-prefix",
+								Usage: "Remove the matched prefix from the path",
+							},
+							cli.StringFlag{
+								Name:  "rewrite",
+								Usage: "Replace the matched prefix, exact path, or regex match, with $1 expansion",
+							},
+							cli.StringSliceFlag{
+								Name:  "split",
+								Usage: "Split requests across Upstreams instead, as upstream=weight, can be repeated",
+							},
+							cli.StringFlag{
+								Name:  "sticky",
+								Usage: "Keep split requests on the same Upstream by cookie:NAME, header:NAME or client-ip",
+							},
+							cli.StringFlag{
+								Name:  "mirror",
+								Usage: "Send copies of the requests to the named shadow Upstream, discarding its responses",
+							},
+							cli.IntFlag{
+								Name:  "mirror-percent",
+								Usage: "The percent of requests that are mirrored, 0 to pause the mirror, every request if unset",
+							},
+							cli.StringFlag{
+								Name:  "before",
+								Usage: "Insert a new Route before the named Route, instead of last",
+							},
+						}, resilienceFlags...),
+						Action: withClient(putRoute),
+					},
+					{
+						Name:  "split",
+						Usage: "Change the weights of the split of a Route, at once or over a ramp",
+						Flags: []cli.Flag{
+							hostnameFlag,
+							cli.StringFlag{
+								Name:  "name",
+								Usage: "The name of the Route",
+							},
+							cli.StringSliceFlag{
+								Name:  "split",
+								Usage: "The weight of an Upstream, as upstream=weight, can be repeated",
+							},
+							cli.DurationFlag{
+								Name:  "ramp",
+								Usage: "Move to the new weights from the current weights over the duration",
+							},
+						},
+						Action: withClient(setSplit),
+					},
+					{
+						Name:  "delete",
+						Usage: "Delete a Route of a Site",
+						Flags: []cli.Flag{
+							hostnameFlag,
+							cli.StringFlag{
+								Name:  "name",
+								Usage: "The name of the Route",
+							},
+						},
+						Action: withClient(deleteRoute),
+					},
+				},
+			},
+			{
+				Name:  "redirect",
+				Usage: "Manage the Redirects of a Site, that answer matching requests with a redirect before the Routes",
+				Subcommands: []cli.Command{
+					{
+						Name:  "put",
+						Usage: "Create or replace a Redirect of a Site",
+						Flags: []cli.Flag{
+							hostnameFlag,
+							cli.StringFlag{
+								Name:  "name",
+								Usage: "The name of the Redirect",
+							},
+							cli.StringFlag{
+								Name:  "regex",
+								Usage: "Match paths and queries that match the regex, any if unset",
+							},
+							cli.StringFlag{
+								Name:  "replacement",
+								Usage: "Replace the regex match, with $1 expansion, or the path and query if there is no regex, or redirect to the URL",
+							},
+							cli.StringFlag{
+								Name:  "host",
+								Usage: "Redirect to the host, such as to canonicalize www, so only requests to other hosts are redirected",
+							},
+							cli.BoolFlag{
+								Name:  "https",
+								Usage: "Redirect to https, so only requests made over http are redirected",
+							},
+							cli.UintFlag{
+								Name:  "status",
+								Usage: "The status of the redirect, 301, 302, 307 or 308",
+								Value: 301,
+							},
+							cli.StringFlag{
+								Name:  "before",
+								Usage: "Insert a new Redirect before the named Redirect, instead of last",
+							},
+						},
+						Action: withClient(putRedirect),
+					},
+					{
+						Name:  "delete",
+						Usage: "Delete a Redirect of a Site",
+						Flags: []cli.Flag{
+							hostnameFlag,
+							cli.StringFlag{
+								Name:  "name",
+								Usage: "The name of the Redirect",
+							},
+						},
+						Action: withClient(deleteRedirect),
+					},
+				},
+			},
+			{
+				Name:  "rewrite",
+				Usage: "Manage the Rewrites of a Site, that change the paths and queries of requests before the Routes",
+				Subcommands: []cli.Command{
+					{
+						Name:  "put",
+						Usage: "Create or replace a Rewrite of a Site",
+						Flags: []cli.Flag{
+							hostnameFlag,
+							cli.StringFlag{
+								Name:  "name",
+								Usage: "The name of the Rewrite",
+							},
+							cli.StringFlag{
+								Name:  "regex",
+								Usage: "The regex matched in paths and queries",
+							},
+							cli.StringFlag{
+								Name:  "replacement",
+								Usage: "Replace the regex match, with $1 expansion",
+							},
+							cli.StringFlag{
+								Name:  "before",
+								Usage: "Insert a new Rewrite before the named Rewrite, instead of last",
+							},
+						},
+						Action: withClient(putRewrite),
+					},
+					{
+						Name:  "delete",
+						Usage: "Delete a Rewrite of a Site",
+						Flags: []cli.Flag{
+							hostnameFlag,
+							cli.StringFlag{
+								Name:  "name",
+								Usage: "The name of the Rewrite",
+							},
+						},
+						Action: withClient(deleteRewrite),
+					},
+				},
+			},
+			{
+				Name:  "headers",
+				Usage: "Replace the rules that change the headers of the requests and responses of a Site",
+				Flags: []cli.Flag{
+					hostnameFlag,
+					cli.StringSliceFlag{
+						Name:  "request-remove",
+						Usage: "Remove the header from requests, can be repeated",
+					},
+					cli.StringSliceFlag{
+						Name:  "request-set",
+						Usage: "Replace the header of requests, as name=value, can be repeated",
+					},
+					cli.StringSliceFlag{
+						Name:  "request-add",
+						Usage: "Add a line to the header of requests, as name=value, can be repeated",
+					},
+					cli.StringSliceFlag{
+						Name:  "response-remove",
+						Usage: "Remove the header from responses, can be repeated",
+					},
+					cli.StringSliceFlag{
+						Name:  "response-set",
+						Usage: "Replace the header of responses, as name=value, can be repeated",
+					},
+					cli.StringSliceFlag{
+						Name:  "response-add",
+						Usage: "Add a line to the header of responses, as name=value, can be repeated",
+					},
+				},
+				Action: withClient(setHeaders),
+			},
+			{
+				Name:  "security-headers",
+				Usage: "Replace the security headers set on the responses of a Site",
+				Flags: []cli.Flag{
+					hostnameFlag,
+					cli.BoolFlag{
+						Name:  "disable",
+						Usage: "Send the security headers of the Upstreams",
+					},
+					cli.DurationFlag{
+						Name:  "hsts-max-age",
+						Usage: "The max-age of Strict-Transport-Security on responses over https, not sent if 0",
+					},
+					cli.BoolFlag{
+						Name:  "hsts-include-subdomains",
+						Usage: "Apply Strict-Transport-Security to the subdomains of the Site",
+					},
+					cli.BoolFlag{
+						Name:  "hsts-preload",
+						Usage: "Allow browsers to preload the Site as https only",
+					},
+					cli.StringFlag{
+						Name:  "csp",
+						Usage: "The Content-Security-Policy, not sent if unset",
+					},
+					cli.StringFlag{
+						Name:  "frame-options",
+						Usage: "The X-Frame-Options, DENY or SAMEORIGIN, not sent if unset",
+					},
+					cli.BoolFlag{
+						Name:  "nosniff",
+						Usage: "Send X-Content-Type-Options: nosniff",
+					},
+					cli.StringFlag{
+						Name:  "referrer-policy",
+						Usage: "The Referrer-Policy, not sent if unset",
+					},
+					cli.StringFlag{
+						Name:  "permissions-policy",
+						Usage: "The Permissions-Policy, not sent if unset",
+					},
+				},
+				Action: withClient(setSecurityHeaders),
+			},
+			{
+				Name:  "error-page",
+				Usage: "Manage the pages the proxy answers requests to a Site with itself, such as when its Upstreams fail",
+				Subcommands: []cli.Command{
+					{
+						Name:  "put",
+						Usage: "Create or replace the error page of a Site for a status",
+						Flags: []cli.Flag{
+							hostnameFlag,
+							cli.UintFlag{
+								Name:  "status",
+								Usage: "The status of the error page, 403, 429, 502, 503 or 504",
+							},
+							cli.StringFlag{
+								Name:  "template",
+								Usage: "Path to the Go template of the page",
+							},
+							cli.StringFlag{
+								Name:  "content-type",
+								Usage: "The content type of the page, HTML if unset",
+							},
+						},
+						Action: withClient(putErrorPage),
+					},
+					{
+						Name:  "delete",
+						Usage: "Delete the error page of a Site for a status",
+						Flags: []cli.Flag{
+							hostnameFlag,
+							cli.UintFlag{
+								Name:  "status",
+								Usage: "The status of the error page",
+							},
+						},
+						Action: withClient(deleteErrorPage),
+					},
+				},
+			},
+			{
+				Name:  "maintenance",
+				Usage: "Put a Site in or out of maintenance, answering its requests with a 503 page",
+				Flags: []cli.Flag{
+					hostnameFlag,
+					cli.BoolFlag{
+						Name:  "disable",
+						Usage: "Take the Site out of maintenance",
+					},
+					cli.StringSliceFlag{
+						Name:  "allow",
+						Usage: "A CIDR or IP of clients that bypass maintenance, may be repeated",
+					},
+					cli.StringSliceFlag{
+						Name:  "user",
+						Usage: "The email of a user that bypasses maintenance with their client certificate, may be repeated",
+					},
+					cli.StringFlag{
+						Name:  "template",
+						Usage: "Path to the Go template of the page, the 503 error page of the Site if unset",
+					},
+					cli.StringFlag{
+						Name:  "content-type",
+						Usage: "The content type of the page, HTML if unset",
+					},
+					cli.DurationFlag{
+						Name:  "retry-after",
+						Usage: "The Retry-After of the responses, not sent if 0",
+					},
+				},
+				Action: withClient(setMaintenance),
+			},
+			{
+				Name:  "upload-cert",
+				Usage: "Serve a Site with a custom certificate, instead of one issued through ACME",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "hostname",
+						Usage: "The hostname of the Site",
+					},
+					cli.StringFlag{
+						Name:  "cert",
+						Usage: "Path to the PEM encoded certificate chain, leaf first",
+					},
+					cli.StringFlag{
+						Name:  "key",
+						Usage: "Path to the PEM encoded private key",
+					},
+				},
+				Action: withClient(uploadSiteCert),
+			},
+			{
+				Name:  "delete-cert",
+				Usage: "Remove the certificate of a Site",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "hostname",
+						Usage: "The hostname of the Site",
+					},
+				},
+				Action: withClient(deleteSiteCert),
+			},
+		},
+	})
+}
+
+var hostnameFlag = cli.StringFlag{
+	Name:  "hostname",
+	Usage: "The hostname of the Site",
+}
+
+var healthFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "check",
+		Usage: "Actively check the Endpoints with http or tcp, unchecked if unset",
+	},
+	cli.StringFlag{
+		Name:  "check-path",
+		Usage: "The path http checks GET",
+		Value: "/",
+	},
+	cli.UintFlag{
+		Name:  "check-status",
+		Usage: "The status http checks expect",
+		Value: 200,
+	},
+	cli.StringFlag{
+		Name:  "check-body",
+		Usage: "A regex the body of http checks must match",
+	},
+	cli.DurationFlag{
+		Name:  "check-interval",
+		Usage: "The interval between checks",
+		Value: time.Second * 10,
+	},
+	cli.DurationFlag{
+		Name:  "check-timeout",
+		Usage: "The timeout of a check",
+		Value: time.Second * 2,
+	},
+	cli.UintFlag{
+		Name:  "healthy-threshold",
+		Usage: "The passed checks in a row that mark an Endpoint healthy",
+		Value: 2,
+	},
+	cli.UintFlag{
+		Name:  "unhealthy-threshold",
+		Usage: "The failed checks in a row that mark an Endpoint unhealthy",
+		Value: 3,
+	},
+	cli.UintFlag{
+		Name:  "eject-5xx",
+		Usage: "Eject an Endpoint after this many 5xx responses in a row, never if 0. Each node ejects on its own requests, until it restarts",
+	},
+	cli.UintFlag{
+		Name:  "eject-connect-errors",
+		Usage: "Eject an Endpoint after this many connection errors in a row, never if 0. Each node ejects on its own requests, until it restarts",
+	},
+	cli.DurationFlag{
+		Name:  "eject-base",
+		Usage: "How long the first ejection lasts, doubled for each ejection in a row",
+		Value: time.Second * 30,
+	},
+	cli.DurationFlag{
+		Name:  "eject-max",
+		Usage: "The longest an ejection lasts",
+		Value: time.Minute * 5,
+	},
+	cli.UintFlag{
+		Name:  "proxy-protocol",
+		Usage: "Give the Endpoints the client address with PROXY protocol version 1 or 2, not sent if 0",
+	},
+}
+
+var resilienceFlags = []cli.Flag{
+	cli.DurationFlag{
+		Name:  "connect-timeout",
+		Usage: "The timeout of connecting to an Endpoint",
+		Value: time.Second * 3,
+	},
+	cli.DurationFlag{
+		Name:  "try-timeout",
+		Usage: "The timeout of each try, from waiting for a connection to reading the whole response, unlimited if 0",
+	},
+	cli.DurationFlag{
+		Name:  "timeout",
+		Usage: "The timeout of a request including retries, unlimited if 0",
+	},
+	cli.UintFlag{
+		Name:  "retries",
+		Usage: "The retries of an idempotent request that fails to connect, times out or gets a 502, 503 or 504",
+	},
+	cli.UintFlag{
+		Name:  "retry-budget",
+		Usage: "The percent of active requests to an Upstream that can be retrying",
+		Value: 20,
+	},
+	cli.DurationFlag{
+		Name:  "retry-backoff",
+		Usage: "The backoff before the first retry, doubled for each retry",
+		Value: time.Millisecond * 25,
+	},
+	cli.DurationFlag{
+		Name:  "retry-max-backoff",
+		Usage: "The longest backoff before a retry",
+		Value: time.Millisecond * 250,
+	},
+	cli.UintFlag{
+		Name:  "max-pending",
+		Usage: "The most requests to an Upstream at once, unlimited if 0",
+	},
+	cli.UintFlag{
+		Name:  "max-connections",
+		Usage: "The most connections to each Endpoint, the Endpoint max-connections if 0",
+	},
+	cli.UintFlag{
+		Name:  "error-percent",
+		Usage: "The percent of failed requests to an Upstream that opens its circuit breaker, never if 0",
+	},
+	cli.UintFlag{
+		Name:  "min-requests",
+		Usage: "The requests in the window before the error percent applies",
+		Value: 20,
+	},
+	cli.DurationFlag{
+		Name:  "breaker-window",
+		Usage: "The window failed requests are counted over",
+		Value: time.Second * 10,
+	},
+	cli.DurationFlag{
+		Name:  "breaker-open",
+		Usage: "How long an open circuit breaker rejects requests",
+		Value: time.Second * 30,
+	},
+}
+
+var siteFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "alias",
+		Usage: "An alias hostname of the Site, can be repeated",
+	},
+	cli.BoolFlag{
+		Name:  "secure",
+		Usage: "Serve the Site over TLS",
+	},
+	cli.BoolFlag{
+		Name:  "autoencrypt",
+		Usage: "Issue the Site certificate through ACME",
+	},
+}
+
+func listSites(ctx *cli.Context, conn *grpc.ClientConn) error {
+	resp, err := sites.NewSitesServiceClient(conn).ListSites(context.Background(), &sites.ListSitesRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to list sites: %s", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "HOSTNAME\tPORTS\tSECURE\tAUTOENCRYPT\tUPSTREAMS\tALIASES")
+	for _, info := range resp.Sites {
+		var upstreams []string
+		for _, u := range info.Site.Upstreams {
+			upstreams = append(upstreams, u.Name)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%t\t%t\t%s\t%s\n",
+			info.Site.Hostname,
+			strings.Join(info.Ports, ","),
+			info.Site.Secure,
+			info.Site.Autoencrypt,
+			strings.Join(upstreams, ","),
+			strings.Join(info.Site.Alias, ","),
+		)
+	}
+
+	return w.Flush()
+}
+
+func getSite(ctx *cli.Context, conn *grpc.ClientConn) error {
+	info, err := sites.NewSitesServiceClient(conn).GetSite(context.Background(), &sites.GetSiteRequest{
+		Hostname: ctx.String("hostname"),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get site: %s", err)
+	}
+
+	printSite(info)
+	return nil
+}
+
+func createSite(ctx *cli.Context, conn *grpc.ClientConn) error {
+	if ctx.String("hostname") == "" || ctx.String("port") == "" {
+		return fmt.Errorf("--hostname and --port are required")
+	}
+
+	info, err := sites.NewSitesServiceClient(conn).CreateSite(context.Background(), &sites.CreateSiteRequest{
+		Port: ctx.String("port"),
+		Site: &sites.Site{
+			Hostname:    ctx.String("hostname"),
+			Alias:       ctx.StringSlice("alias"),
+			Secure:      ctx.Bool("secure"),
+			Autoencrypt: ctx.Bool("autoencrypt"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create site: %s", err)
+	}
+
+	printSite(info)
+	return nil
+}
+
+func updateSite(ctx *cli.Context, conn *grpc.ClientConn) error {
+	client := sites.NewSitesServiceClient(conn)
+	info, err := client.GetSite(context.Background(), &sites.GetSiteRequest{Hostname: ctx.String("hostname")})
+	if err != nil {
+		return fmt.Errorf("unable to get site: %s", err)
+	}
+
+	site := info.Site
+	if ctx.IsSet("alias") {
+		site.Alias = ctx.StringSlice("alias")
+	}
+	if ctx.IsSet("secure") {
+		site.Secure = ctx.Bool("secure")
+	}
+	if ctx.IsSet("autoencrypt") {
+		site.Autoencrypt = ctx.Bool("autoencrypt")
+	}
+
+	info, err = client.UpdateSite(context.Background(), &sites.UpdateSiteRequest{Site: site})
+	if err != nil {
+		return fmt.Errorf("unable to update site: %s", err)
+	}
+
+	printSite(info)
+	return nil
+}
+
+func setResilience(ctx *cli.Context, conn *grpc.ClientConn) error {
+	client := sites.NewSitesServiceClient(conn)
+	info, err := client.GetSite(context.Background(), &sites.GetSiteRequest{Hostname: ctx.String("hostname")})
+	if err != nil {
+		return fmt.Errorf("unable to get site: %s", err)
+	}
+
+	site := info.Site
+	site.Resilience = parseResilience(ctx)
+
+	info, err = client.UpdateSite(context.Background(), &sites.UpdateSiteRequest{Site: site})
+	if err != nil {
+		return fmt.Errorf("unable to update site: %s", err)
+	}
+
+	printSite(info)
+	return nil
+}
+
+func setAffinity(ctx *cli.Context, conn *grpc.ClientConn) error {
+	client := sites.NewSitesServiceClient(conn)
+	info, err := client.GetSite(context.Background(), &sites.GetSiteRequest{Hostname: ctx.String("hostname")})
+	if err != nil {
+		return fmt.Errorf("unable to get site: %s", err)
+	}
+
+	site := info.Site
+	site.Affinity = nil
+	if !ctx.Bool("disable") {
+		site.Affinity = &sites.Affinity{
+			Cookie: ctx.String("cookie"),
+			Ttl:    int64(ctx.Duration("ttl").Seconds()),
+		}
+	}
+
+	info, err = client.UpdateSite(context.Background(), &sites.UpdateSiteRequest{Site: site})
+	if err != nil {
+		return fmt.Errorf("unable to update site: %s", err)
+	}
+
+	printSite(info)
+	return nil
+}
+
+func setWebSocket(ctx *cli.Context, conn *grpc.ClientConn) error {
+	client := sites.NewSitesServiceClient(conn)
+	info, err := client.GetSite(context.Background(), &sites.GetSiteRequest{Hostname: ctx.String("hostname")})
+	if err != nil {
+		return fmt.Errorf("unable to get site: %s", err)
+	}
+
+	site := info.Site
+	site.Websocket = &sites.WebSocket{
+		Disabled:       ctx.Bool("disable"),
+		IdleTimeout:    int64(ctx.Duration("idle-timeout").Seconds()),
+		MaxFrameSize:   ctx.Int64("max-frame-size"),
+		MaxMessageSize: ctx.Int64("max-message-size"),
+		Deny:           ctx.StringSlice("deny"),
+	}
+
+	info, err = client.UpdateSite(context.Background(), &sites.UpdateSiteRequest{Site: site})
+	if err != nil {
+		return fmt.Errorf("unable to update site: %s", err)
+	}
+
+	printSite(info)
+	return nil
+}
+
+func setCache(ctx *cli.Context, conn *grpc.ClientConn) error {
+	client := sites.NewSitesServiceClient(conn)
+	info, err := client.GetSite(context.Background(), &sites.GetSiteRequest{Hostname: ctx.String("hostname")})
+	if err != nil {
+		return fmt.Errorf("unable to get site: %s", err)
+	}
+
+	site := info.Site
+	site.Cache = nil
+	if !ctx.Bool("disable") {
+		site.Cache = &sites.Cache{
+			MaxSize:       ctx.Int64("max-size"),
+			MaxObjectSize: ctx.Int64("max-object-size"),
+			DefaultTtl:    int64(ctx.Duration("default-ttl").Seconds()),
+			DiskPath:      ctx.String("disk-path"),
+			DiskMaxSize:   ctx.Int64("disk-max-size"),
+			TagHeader:     ctx.String("tag-header"),
+		}
+	}
+
+	info, err = client.UpdateSite(context.Background(), &sites.UpdateSiteRequest{Site: site})
+	if err != nil {
+		return fmt.Errorf("unable to update site: %s", err)
+	}
+
+	printSite(info)
+	return nil
+}
+
+func setCompression(ctx *cli.Context, conn *grpc.ClientConn) error {
+	client := sites.NewSitesServiceClient(conn)
+	info, err := client.GetSite(context.Background(), &sites.GetSiteRequest{Hostname: ctx.String("hostname")})
+	if err != nil {
+		return fmt.Errorf("unable to get site: %s", err)
+	}
+
+	site := info.Site
+	site.Compression = nil
+	if !ctx.Bool("disable") {
+		site.Compression = &sites.Compression{
+			Encodings:    ctx.StringSlice("encoding"),
+			ContentTypes: ctx.StringSlice("content-type"),
+			MinSize:      ctx.Int64("min-size"),
+		}
+	}
+
+	info, err = client.UpdateSite(context.Background(), &sites.UpdateSiteRequest{Site: site})
+	if err != nil {
+		return fmt.Errorf("unable to update site: %s", err)
+	}
+
+	printSite(info)
+	return nil
+}
+
+func purgeCache(ctx *cli.Context, conn *grpc.ClientConn) error {
+	resp, err := sites.NewSitesServiceClient(conn).PurgeCache(context.Background(), &sites.PurgeCacheRequest{
+		Hostname: ctx.String("hostname"),
+		Urls:     ctx.StringSlice("url"),
+		Prefixes: ctx.StringSlice("prefix"),
+		Tags:     ctx.StringSlice("tag"),
+		All:      ctx.Bool("all"),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to purge cache: %s", err)
+	}
+
+	fmt.Printf("Purge %s of %s will be applied by every node on its next reload\n", resp.Purge.Id, resp.Purge.Hostname)
+	return nil
+}
+
+// parseResilience returns the Resilience of the resilienceFlags
+func parseResilience(ctx *cli.Context) *sites.Resilience {
+	r := &sites.Resilience{
+		Timeouts: &sites.Timeouts{
+			Connect: ms(ctx.Duration("connect-timeout")),
+			PerTry:  ms(ctx.Duration("try-timeout")),
+			Overall: ms(ctx.Duration("timeout")),
+		},
+	}
+
+	if ctx.Uint("retries") != 0 {
+		r.Retry = &sites.RetryPolicy{
+			Attempts:      uint32(ctx.Uint("retries")),
+			BudgetPercent: uint32(ctx.Uint("retry-budget")),
+			BaseBackoff:   ms(ctx.Duration("retry-backoff")),
+			MaxBackoff:    ms(ctx.Duration("retry-max-backoff")),
+		}
+	}
+
+	if ctx.Uint("max-pending") != 0 || ctx.Uint("max-connections") != 0 || ctx.Uint("error-percent") != 0 {
+		r.CircuitBreaker = &sites.CircuitBreaker{
+			MaxPending:     uint32(ctx.Uint("max-pending")),
+			MaxConnections: uint32(ctx.Uint("max-connections")),
+			ErrorPercent:   uint32(ctx.Uint("error-percent")),
+			MinRequests:    uint32(ctx.Uint("min-requests")),
+			Window:         int64(ctx.Duration("breaker-window").Seconds()),
+			Open:           int64(ctx.Duration("breaker-open").Seconds()),
+		}
+	}
+
+	return r
+}
+
+// ms returns d in milliseconds
+func ms(d time.Duration) int64 {
+	return int64(d / time.Millisecond)
+}
+
+func deleteSite(ctx *cli.Context, conn *grpc.ClientConn) error {
+	_, err := sites.NewSitesServiceClient(conn).DeleteSite(context.Background(), &sites.DeleteSiteRequest{
+		Hostname: ctx.String("hostname"),
+		Port:     ctx.String("port"),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to delete site: %s", err)
+	}
+
+	fmt.Printf("deleted site %s\n", ctx.String("hostname"))
+	return nil
+}
+
+func putUpstream(ctx *cli.Context, conn *grpc.ClientConn) error {
+	if ctx.String("hostname") == "" || ctx.String("name") == "" {
+		return fmt.Errorf("--hostname and --name are required")
+	}
+
+	strategy, ok := sites.Strategy_value[enumName(ctx.String("strategy"))]
+	if !ok {
+		return fmt.Errorf("unknown strategy %s", ctx.String("strategy"))
+	}
+
+	source, ok := sites.HashSource_value[enumName(ctx.String("hash-source"))]
+	if !ok {
+		return fmt.Errorf("unknown hash source %s", ctx.String("hash-source"))
+	}
+
+	u := &sites.Upstream{
+		Name:     ctx.String("name"),
+		Strategy: sites.Strategy(strategy),
+		Hash: &sites.HashPolicy{
+			Source: sites.HashSource(source),
+			Name:   ctx.String("hash-name"),
+		},
+		ProxyProtocol: uint32(ctx.Uint("proxy-protocol")),
+	}
+	for _, spec := range ctx.StringSlice("endpoint") {
+		e, err := parseEndpoint(spec)
+		if err != nil {
+			return err
+		}
+		u.Endpoints = append(u.Endpoints, e)
+	}
+
+	if check := ctx.String("check"); check != "" {
+		t, ok := sites.HealthCheckType_value[enumName(check)]
+		if !ok {
+			return fmt.Errorf("unknown check %s", check)
+		}
+
+		u.HealthCheck = &sites.HealthCheck{
+			Type:               sites.HealthCheckType(t),
+			Path:               ctx.String("check-path"),
+			ExpectedStatus:     uint32(ctx.Uint("check-status")),
+			BodyRegex:          ctx.String("check-body"),
+			Interval:           int64(ctx.Duration("check-interval").Seconds()),
+			Timeout:            int64(ctx.Duration("check-timeout").Seconds()),
+			HealthyThreshold:   uint32(ctx.Uint("healthy-threshold")),
+			UnhealthyThreshold: uint32(ctx.Uint("unhealthy-threshold")),
+		}
+	}
+
+	if ctx.Uint("eject-5xx") != 0 || ctx.Uint("eject-connect-errors") != 0 {
+		u.OutlierDetection = &sites.OutlierDetection{
+			Consecutive_5Xx:          uint32(ctx.Uint("eject-5xx")),
+			ConsecutiveConnectErrors: uint32(ctx.Uint("eject-connect-errors")),
+			BaseEjection:             int64(ctx.Duration("eject-base").Seconds()),
+			MaxEjection:              int64(ctx.Duration("eject-max").Seconds()),
+		}
+	}
+
+	info, err := sites.NewSitesServiceClient(conn).PutUpstream(context.Background(), &sites.PutUpstreamRequest{
+		Hostname: ctx.String("hostname"),
+		Upstream: u,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to put upstream: %s", err)
+	}
+
+	printSite(info)
+	return nil
+}
+
+func deleteUpstream(ctx *cli.Context, conn *grpc.ClientConn) error {
+	info, err := sites.NewSitesServiceClient(conn).DeleteUpstream(context.Background(), &sites.DeleteUpstreamRequest{
+		Hostname: ctx.String("hostname"),
+		Name:     ctx.String("name"),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to delete upstream: %s", err)
+	}
+
+	printSite(info)
+	return nil
+}
+
+func siteStatus(ctx *cli.Context, conn *grpc.ClientConn) error {
+	resp, err := sites.NewSitesServiceClient(conn).Status(context.Background(), &sites.StatusRequest{
+		Hostname: ctx.String("hostname"),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get status: %s", err)
+	}
+
+	// health is shared by every node, ejections and active requests are counted by each on its own
+	fmt.Printf("Seen by node %s, which ejects Endpoints and counts their requests on its own\n\n", resp.Node)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "HOSTNAME\tUPSTREAM\tENDPOINT\tHEALTH\tACTIVE\tSINCE\tREASON")
+	for _, e := range resp.Endpoints {
+		health := "healthy"
+		switch {
+		case !e.Health.Healthy:
+			health = "unhealthy"
+		case e.EjectedUntil != 0:
+			health = fmt.Sprintf("ejected on node until %s", time.Unix(e.EjectedUntil, 0).Format(time.RFC3339))
+		}
+
+		since := "-"
+		if e.Health.ChangedAt != 0 {
+			since = time.Unix(e.Health.ChangedAt, 0).Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n", e.Health.Hostname, e.Health.Upstream, e.Health.Address,
+			health, e.ActiveRequests, since, e.Health.Reason)
+	}
+	w.Flush()
+
+	return nil
+}
+
+func putRoute(ctx *cli.Context, conn *grpc.ClientConn) error {
+	if ctx.String("hostname") == "" || ctx.String("name") == "" {
+		return fmt.Errorf("--hostname and --name are required")
+	}
+
+	if ctx.String("upstream") == "" && len(ctx.StringSlice("split")) == 0 && !ctx.Bool("deny") {
+		return fmt.Errorf("--upstream, --split or --deny is required")
+	}
+
+	split, err := parseSplit(ctx.StringSlice("split"))
+	if err != nil {
+		return err
+	}
+
+	sticky, err := parseSticky(ctx.String("sticky"))
+	if err != nil {
+		return err
+	}
+
+	r := &sites.Route{
+		Name:        ctx.String("name"),
+		Methods:     ctx.StringSlice("method"),
+		Upstream:    ctx.String("upstream"),
+		Deny:        ctx.Bool("deny"),
+		StripPrefix: ctx.Bool("strip-prefix"),
+		Rewrite:     ctx.String("rewrite"),
+		Split:       split,
+		Sticky:      sticky,
+	}
+
+	if ctx.String("mirror") != "" {
+		// a percent of -1 mirrors every request, as 0 pauses the mirror
+		r.Mirror = &sites.Mirror{Upstream: ctx.String("mirror"), Percent: -1}
+		if ctx.IsSet("mirror-percent") {
+			r.Mirror.Percent = int32(ctx.Int("mirror-percent"))
+		}
+	}
+
+	switch grpc := strings.Trim(ctx.String("grpc"), "/"); {
+	case strings.Contains(grpc, "/"):
+		r.PathMatch, r.Path = sites.PathMatch_EXACT, "/"+grpc
+	case grpc != "":
+		r.PathMatch, r.Path = sites.PathMatch_PREFIX, "/"+grpc+"/"
+	case ctx.String("exact") != "":
+		r.PathMatch, r.Path = sites.PathMatch_EXACT, ctx.String("exact")
+	case ctx.String("regex") != "":
+		r.PathMatch, r.Path = sites.PathMatch_REGEX, ctx.String("regex")
+	default:
+		r.PathMatch, r.Path = sites.PathMatch_PREFIX, ctx.String("prefix")
+	}
+
+	// gRPC calls are told apart from other requests to the same path by their content type
+	if ctx.String("grpc") != "" {
+		r.Headers = append(r.Headers, &sites.ValueMatch{Name: "Content-Type", Value: "^application/grpc", Regex: true})
+	}
+	for _, spec := range ctx.StringSlice("header") {
+		r.Headers = append(r.Headers, parseValueMatch(spec))
+	}
+	for _, spec := range ctx.StringSlice("query") {
+		r.Query = append(r.Query, parseValueMatch(spec))
+	}
+
+	for _, f := range resilienceFlags {
+		if ctx.IsSet(f.GetName()) {
+			r.Resilience = parseResilience(ctx)
+			break
+		}
+	}
+
+	client := sites.NewSitesServiceClient(conn)
+	info, err := client.GetSite(context.Background(), &sites.GetSiteRequest{Hostname: ctx.String("hostname")})
+	if err != nil {
+		return fmt.Errorf("unable to get site: %s", err)
+	}
+
+	routes := info.Site.Routes
+	replaced := false
+	for i, existing := range routes {
+		if existing.Name == r.Name {
+			routes[i] = r
+			replaced = true
+		}
+	}
+
+	if !replaced {
+		i := len(routes)
+		for j, existing := range routes {
+			if existing.Name == ctx.String("before") {
+				i = j
+			}
+		}
+		routes = append(routes[:i], append([]*sites.Route{r}, routes[i:]...)...)
+	}
+
+	info, err = client.SetRoutes(context.Background(), &sites.SetRoutesRequest{
+		Hostname: ctx.String("hostname"),
+		Routes:   routes,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to set routes: %s", err)
+	}
+
+	printSite(info)
+	return nil
+}
+
+func deleteRoute(ctx *cli.Context, conn *grpc.ClientConn) error {
+	client := sites.NewSitesServiceClient(conn)
+	info, err := client.GetSite(context.Background(), &sites.GetSiteRequest{Hostname: ctx.String("hostname")})
+	if err != nil {
+		return fmt.Errorf("unable to get site: %s", err)
+	}
+
+	var routes []*sites.Route
+	for _, r := range info.Site.Routes {
+		if r.Name != ctx.String("name") {
+			routes = append(routes, r)
+		}
+	}
+	if len(routes) == len(info.Site.Routes) {
+		return fmt.Errorf("route %s does not exist on %s", ctx.String("name"), ctx.String("hostname"))
+	}
+
+	info, err = client.SetRoutes(context.Background(), &sites.SetRoutesRequest{
+		Hostname: ctx.String("hostname"),
+		Routes:   routes,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to set routes: %s", err)
+	}
+
+	printSite(info)
+	return nil
+}
+
+func putRedirect(ctx *cli.Context, conn *grpc.ClientConn) error {
+	if ctx.String("hostname") == "" || ctx.String("name") == "" {
+		return fmt.Errorf("--hostname and --name are required")
+	}
+
+	r := &sites.Redirect{
+		Name:        ctx.String("name"),
+		Regex:       ctx.String("regex"),
+		Replacement: ctx.String("replacement"),
+		Host:        ctx.String("host"),
+		Https:       ctx.Bool("https"),
+		Status:      uint32(ctx.Uint("status")),
+	}
+
+	return updateSiteWith(ctx, conn, func(site *sites.Site) error {
+		for i, existing := range site.Redirects {
+			if existing.Name == r.Name {
+				site.Redirects[i] = r
+				return nil
+			}
+		}
+
+		i := len(site.Redirects)
+		for j, existing := range site.Redirects {
+			if existing.Name == ctx.String("before") {
+				i = j
+			}
+		}
+		site.Redirects = append(site.Redirects[:i], append([]*sites.Redirect{r}, site.Redirects[i:]...)...)
+		return nil
+	})
+}
+
+func deleteRedirect(ctx *cli.Context, conn *grpc.ClientConn) error {
+	return updateSiteWith(ctx, conn, func(site *sites.Site) error {
+		var redirects []*sites.Redirect
+		for _, r := range site.Redirects {
+			if r.Name != ctx.String("name") {
+				redirects = append(redirects, r)
+			}
+		}
+		if len(redirects) == len(site.Redirects) {
+			return fmt.Errorf("redirect %s does not exist on %s", ctx.String("name"), site.Hostname)
+		}
+
+		site.Redirects = redirects
+		return nil
+	})
+}
+
+func putRewrite(ctx *cli.Context, conn *grpc.ClientConn) error {
+	if ctx.String("hostname") == "" || ctx.String("name") == "" || ctx.String("regex") == "" {
+		return fmt.Errorf("--hostname, --name and --regex are required")
+	}
+
+	r := &sites.Rewrite{
+		Name:        ctx.String("name"),
+		Regex:       ctx.String("regex"),
+		Replacement: ctx.String("replacement"),
+	}
+
+	return updateSiteWith(ctx, conn, func(site *sites.Site) error {
+		for i, existing := range site.Rewrites {
+			if existing.Name == r.Name {
+				site.Rewrites[i] = r
+				return nil
+			}
+		}
+
+		i := len(site.Rewrites)
+		for j, existing := range site.Rewrites {
+			if existing.Name == ctx.String("before") {
+				i = j
+			}
+		}
+		site.Rewrites = append(site.Rewrites[:i], append([]*sites.Rewrite{r}, site.Rewrites[i:]...)...)
+		return nil
+	})
+}
+
+func deleteRewrite(ctx *cli.Context, conn *grpc.ClientConn) error {
+	return updateSiteWith(ctx, conn, func(site *sites.Site) error {
+		var rewrites []*sites.Rewrite
+		for _, r := range site.Rewrites {
+			if r.Name != ctx.String("name") {
+				rewrites = append(rewrites, r)
+			}
+		}
+		if len(rewrites) == len(site.Rewrites) {
+			return fmt.Errorf("rewrite %s does not exist on %s", ctx.String("name"), site.Hostname)
+		}
+
+		site.Rewrites = rewrites
+		return nil
+	})
+}
+
+func setHeaders(ctx *cli.Context, conn *grpc.ClientConn) error {
+	request, err := parseHeaderRules(ctx, "request")
+	if err != nil {
+		return err
+	}
+
+	response, err := parseHeaderRules(ctx, "response")
+	if err != nil {
+		return err
+	}
+
+	return updateSiteWith(ctx, conn, func(site *sites.Site) error {
+		site.RequestHeaders, site.ResponseHeaders = request, response
+		return nil
+	})
+}
+
+func setSecurityHeaders(ctx *cli.Context, conn *grpc.ClientConn) error {
+	return updateSiteWith(ctx, conn, func(site *sites.Site) error {
+		site.SecurityHeaders = nil
+		if !ctx.Bool("disable") {
+			site.SecurityHeaders = &sites.SecurityHeaders{
+				HstsMaxAge:            int64(ctx.Duration("hsts-max-age").Seconds()),
+				HstsIncludeSubdomains: ctx.Bool("hsts-include-subdomains"),
+				HstsPreload:           ctx.Bool("hsts-preload"),
+				ContentSecurityPolicy: ctx.String("csp"),
+				FrameOptions:          ctx.String("frame-options"),
+				Nosniff:               ctx.Bool("nosniff"),
+				ReferrerPolicy:        ctx.String("referrer-policy"),
+				PermissionsPolicy:     ctx.String("permissions-policy"),
+			}
+		}
+		return nil
+	})
+}
+
+func putErrorPage(ctx *cli.Context, conn *grpc.ClientConn) error {
+	if ctx.String("hostname") == "" || ctx.Uint("status") == 0 || ctx.String("template") == "" {
+		return fmt.Errorf("--hostname, --status and --template are required")
+	}
+
+	tmpl, err := ioutil.ReadFile(ctx.String("template"))
+	if err != nil {
+		return fmt.Errorf("unable to read template: %s", err)
+	}
+
+	ep := &sites.ErrorPage{
+		Status:      uint32(ctx.Uint("status")),
+		ContentType: ctx.String("content-type"),
+		Template:    string(tmpl),
+	}
+
+	return updateSiteWith(ctx, conn, func(site *sites.Site) error {
+		for i, existing := range site.ErrorPages {
+			if existing.Status == ep.Status {
+				site.ErrorPages[i] = ep
+				return nil
+			}
+		}
+
+		site.ErrorPages = append(site.ErrorPages, ep)
+		return nil
+	})
+}
+
+func deleteErrorPage(ctx *cli.Context, conn *grpc.ClientConn) error {
+	return updateSiteWith(ctx, conn, func(site *sites.Site) error {
+		var pages []*sites.ErrorPage
+		for _, ep := range site.ErrorPages {
+			if ep.Status != uint32(ctx.Uint("status")) {
+				pages = append(pages, ep)
+			}
+		}
+		if len(pages) == len(site.ErrorPages) {
+			return fmt.Errorf("error page %d does not exist on %s", ctx.Uint("status"), site.Hostname)
+		}
+
+		site.ErrorPages = pages
+		return nil
+	})
+}
+
+func setMaintenance(ctx *cli.Context, conn *grpc.ClientConn) error {
+	var tmpl []byte
+	if path := ctx.String("template"); path != "" && !ctx.Bool("disable") {
+		var err error
+		if tmpl, err = ioutil.ReadFile(path); err != nil {
+			return fmt.Errorf("unable to read template: %s", err)
+		}
+	}
+
+	return updateSiteWith(ctx, conn, func(site *sites.Site) error {
+		if ctx.Bool("disable") {
+			if site.Maintenance == nil {
+				return fmt.Errorf("%s is not in maintenance", site.Hostname)
+			}
+
+			site.Maintenance.Enabled = false
+			return nil
+		}
+
+		site.Maintenance = &sites.Maintenance{
+			Enabled:     true,
+			Allow:       ctx.StringSlice("allow"),
+			Users:       ctx.StringSlice("user"),
+			Template:    string(tmpl),
+			ContentType: ctx.String("content-type"),
+			RetryAfter:  int64(ctx.Duration("retry-after").Seconds()),
+		}
+		return nil
+	})
+}
+
+// updateSiteWith gets the Site of the hostname flag, changes it with change, and replaces it
+func updateSiteWith(ctx *cli.Context, conn *grpc.ClientConn, change func(*sites.Site) error) error {
+	client := sites.NewSitesServiceClient(conn)
+	info, err := client.GetSite(context.Background(), &sites.GetSiteRequest{Hostname: ctx.String("hostname")})
+	if err != nil {
+		return fmt.Errorf("unable to get site: %s", err)
+	}
+
+	if err := change(info.Site); err != nil {
+		return err
+	}
+
+	info, err = client.UpdateSite(context.Background(), &sites.UpdateSiteRequest{Site: info.Site})
+	if err != nil {
+		return fmt.Errorf("unable to update site: %s", err)
+	}
+
+	printSite(info)
+	return nil
+}
+
+// parseHeaderRules returns the HeaderRules of the flags with the prefix, or nil if none are set
+func parseHeaderRules(ctx *cli.Context, prefix string) (*sites.HeaderRules, error) {
+	rules := &sites.HeaderRules{Remove: ctx.StringSlice(prefix + "-remove")}
+
+	var err error
+	if rules.Set, err = parseHeaders(ctx.StringSlice(prefix + "-set")); err != nil {
+		return nil, err
+	}
+	if rules.Add, err = parseHeaders(ctx.StringSlice(prefix + "-add")); err != nil {
+		return nil, err
+	}
+
+	if len(rules.Remove) == 0 && len(rules.Set) == 0 && len(rules.Add) == 0 {
+		return nil, nil
+	}
+
+	return rules, nil
+}
+
+// parseHeaders parses headers as name=value
+func parseHeaders(specs []string) ([]*sites.Header, error) {
+	var headers []*sites.Header
+	for _, spec := range specs {
+		kv := strings.SplitN(spec, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid header %s, expected name=value", spec)
+		}
+		headers = append(headers, &sites.Header{Name: kv[0], Value: kv[1]})
+	}
+
+	return headers, nil
+}
+
+func mirrorStats(ctx *cli.Context, conn *grpc.ClientConn) error {
+	resp, err := sites.NewSitesServiceClient(conn).MirrorStats(context.Background(), &sites.MirrorStatsRequest{
+		Hostname: ctx.String("hostname"),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get mirror stats: %s", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "HOSTNAME\tROUTE\tSHADOW\tMIRRORED\tDROPPED\tFAILED\tMATCHED\tMISMATCHED\tPRIMARY\tSHADOW\tSTATUSES")
+	for _, m := range resp.Mirrors {
+		var statuses []string
+		for _, c := range m.Statuses {
+			statuses = append(statuses, fmt.Sprintf("%s %d/%d", c.Class, c.Primary, c.Shadow))
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%s\t%s\t%s\n", m.Hostname, m.Route, m.Upstream,
+			m.Mirrored, m.Dropped, m.Failed, m.Matched, m.Mismatched,
+			time.Duration(m.PrimaryLatency)*time.Microsecond, time.Duration(m.ShadowLatency)*time.Microsecond,
+			strings.Join(statuses, ", "))
+	}
+	w.Flush()
+
+	return nil
+}
+
+func cacheStats(ctx *cli.Context, conn *grpc.ClientConn) error {
+	resp, err := sites.NewSitesServiceClient(conn).CacheStats(context.Background(), &sites.CacheStatsRequest{
+		Hostname: ctx.String("hostname"),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get cache stats: %s", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "HOSTNAME\tHITS\tMISSES\tREVALIDATED\tHIT RATE\tSTORED\tEVICTED\tPURGED\tENTRIES\tBYTES\tDISK ENTRIES\tDISK BYTES")
+	for _, c := range resp.Caches {
+		rate := 0.0
+		if c.Hits+c.Revalidated+c.Misses > 0 {
+			rate = float64(c.Hits+c.Revalidated) * 100 / float64(c.Hits+c.Revalidated+c.Misses)
+		}
+
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%.1f%%\t%d\t%d\t%d\t%d\t%d\t%d\t%d\n", c.Hostname,
+			c.Hits, c.Misses, c.Revalidated, rate, c.Stored, c.Evicted, c.Purged,
+			c.Entries, c.Bytes, c.DiskEntries, c.DiskBytes)
+	}
+	w.Flush()
+
+	return nil
+}
+
+func setSplit(ctx *cli.Context, conn *grpc.ClientConn) error {
+	split, err := parseSplit(ctx.StringSlice("split"))
+	if err != nil {
+		return err
+	}
+
+	info, err := sites.NewSitesServiceClient(conn).SetSplit(context.Background(), &sites.SetSplitRequest{
+		Hostname: ctx.String("hostname"),
+		Route:    ctx.String("name"),
+		Split:    split,
+		Ramp:     int64(ctx.Duration("ramp").Seconds()),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to set split: %s", err)
+	}
+
+	printSite(info)
+	return nil
+}
+
+// parseSplit parses the weights of a split from upstream=weight
+func parseSplit(specs []string) ([]*sites.WeightedUpstream, error) {
+	var split []*sites.WeightedUpstream
+	for _, spec := range specs {
+		kv := strings.SplitN(spec, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid split %s, expected upstream=weight", spec)
+		}
+
+		weight, err := strconv.ParseUint(kv[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight in split %s", spec)
+		}
+
+		split = append(split, &sites.WeightedUpstream{Upstream: kv[0], Weight: uint32(weight)})
+	}
+
+	return split, nil
+}
+
+// parseSticky parses the HashPolicy a split sticks by from cookie:NAME, header:NAME or client-ip
+func parseSticky(spec string) (*sites.HashPolicy, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	kv := strings.SplitN(spec, ":", 2)
+	source, ok := sites.HashSource_value[enumName(kv[0])]
+	if !ok {
+		return nil, fmt.Errorf("unknown sticky source %s", kv[0])
+	}
+
+	sticky := &sites.HashPolicy{Source: sites.HashSource(source)}
+	if len(kv) == 2 {
+		sticky.Name = kv[1]
+	}
+
+	return sticky, nil
+}
+
+// parseValueMatch parses a ValueMatch from name, name=value or name~regex
+func parseValueMatch(spec string) *sites.ValueMatch {
+	if i := strings.IndexAny(spec, "=~"); i > 0 {
+		return &sites.ValueMatch{
+			Name:  spec[:i],
+			Value: spec[i+1:],
+			Regex: spec[i] == '~',
+		}
+	}
+
+	return &sites.ValueMatch{Name: spec}
+}
+
+// routeString describes the matches and rewrites of a Route
+func routeString(r *sites.Route) string {
+	parts := []string{fmt.Sprintf("%s %s", strings.ToLower(r.PathMatch.String()), r.Path)}
+	if len(r.Methods) > 0 {
+		parts = append(parts, strings.Join(r.Methods, "|"))
+	}
+	for _, m := range r.Headers {
+		parts = append(parts, "header "+valueMatchString(m))
+	}
+	for _, m := range r.Query {
+		parts = append(parts, "query "+valueMatchString(m))
+	}
+	switch {
+	case r.Deny:
+		parts = append(parts, "denied")
+	case len(r.Split) == 0:
+		parts = append(parts, "-> "+r.Upstream)
+	default:
+		var split []string
+		for _, w := range r.Split {
+			split = append(split, fmt.Sprintf("%s=%d", w.Upstream, w.Weight))
+		}
+		parts = append(parts, "-> "+strings.Join(split, ","))
+
+		if r.Sticky != nil {
+			parts = append(parts, "sticky by "+strings.ToLower(strings.Replace(r.Sticky.Source.String(), "_", "-", -1)), r.Sticky.Name)
+		}
+		if r.Ramp != nil && r.Ramp.End > time.Now().Unix() {
+			parts = append(parts, "ramping until "+time.Unix(r.Ramp.End, 0).Format(time.RFC3339))
+		}
+	}
+
+	if r.Mirror != nil {
+		if r.Mirror.Percent < 0 {
+			parts = append(parts, "mirroring to "+r.Mirror.Upstream)
+		} else {
+			parts = append(parts, fmt.Sprintf("mirroring %d%% to %s", r.Mirror.Percent, r.Mirror.Upstream))
+		}
+	}
+
+	switch {
+	case r.StripPrefix:
+		parts = append(parts, "stripping prefix")
+	case r.Rewrite != "":
+		parts = append(parts, "rewriting to "+r.Rewrite)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// valueMatchString describes a ValueMatch in the form parseValueMatch accepts
+func valueMatchString(m *sites.ValueMatch) string {
+	switch {
+	case m.Regex:
+		return m.Name + "~" + m.Value
+	case m.Value != "":
+		return m.Name + "=" + m.Value
+	}
+
+	return m.Name
+}
+
+// parseEndpoint parses an Endpoint from scheme://host:port?options
+func parseEndpoint(spec string) (*sites.Endpoint, error) {
+	if !strings.Contains(spec, "://") {
+		spec = "http://" + spec
+	}
+
+	u, err := url.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %s: %s", spec, err)
+	}
+
+	e := &sites.Endpoint{
+		Address: u.Hostname(),
+		Scheme:  u.Scheme,
+	}
+
+	if port := u.Port(); port != "" {
+		p, err := strconv.ParseUint(port, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port in endpoint %s", spec)
+		}
+		e.Port = uint32(p)
+	}
+
+	q := u.Query()
+	for name, parse := range map[string]*uint32{"weight": &e.Weight, "max-connections": &e.MaxConnections} {
+		if v := q.Get(name); v != "" {
+			n, err := strconv.ParseUint(v, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s in endpoint %s", name, spec)
+			}
+			*parse = uint32(n)
+		}
+	}
+
+	if q.Get("server-name") != "" || q.Get("ca") != "" || q.Get("insecure") != "" {
+		e.Tls = &sites.EndpointTLS{
+			ServerName:         q.Get("server-name"),
+			InsecureSkipVerify: q.Get("insecure") == "true",
+		}
+
+		if ca := q.Get("ca"); ca != "" {
+			if e.Tls.Ca, err = ioutil.ReadFile(ca); err != nil {
+				return nil, fmt.Errorf("unable to read CA for endpoint %s: %s", spec, err)
+			}
+		}
+	}
+
+	for _, label := range q["label"] {
+		kv := strings.SplitN(label, ":", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid label %s in endpoint %s, expected key:value", label, spec)
+		}
+
+		if e.Labels == nil {
+			e.Labels = make(map[string]string)
+		}
+		e.Labels[kv[0]] = kv[1]
+	}
+
+	return e, nil
+}
+
+// enumName returns the protobuf enum name for a flag value, e.g. LEAST_CONNECTIONS for
+// least-connections
+func enumName(name string) string {
+	return strings.ToUpper(strings.Replace(name, "-", "_", -1))
+}
+
+// printSite prints a Site and its Upstreams
+func printSite(info *sites.SiteInfo) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "Hostname:\t%s\n", info.Site.Hostname)
+	fmt.Fprintf(w, "Aliases:\t%s\n", strings.Join(info.Site.Alias, ", "))
+	fmt.Fprintf(w, "Ports:\t%s\n", strings.Join(info.Ports, ", "))
+	fmt.Fprintf(w, "Secure:\t%t\n", info.Site.Secure)
+	fmt.Fprintf(w, "Autoencrypt:\t%t\n", info.Site.Autoencrypt)
+	if r := info.Site.Resilience; r != nil {
+		fmt.Fprintf(w, "Resilience:\t%s\n", resilienceString(r))
+	}
+	if a := info.Site.Affinity; a != nil {
+		fmt.Fprintf(w, "Affinity:\t%s\n", affinityString(a))
+	}
+	if ws := info.Site.Websocket; ws != nil {
+		fmt.Fprintf(w, "WebSocket:\t%s\n", webSocketString(ws))
+	}
+	if c := info.Site.Cache; c != nil {
+		fmt.Fprintf(w, "Cache:\t%s\n", cacheString(c))
+	}
+	if c := info.Site.Compression; c != nil {
+		fmt.Fprintf(w, "Compression:\t%s\n", compressionString(c))
+	}
+	if sh := info.Site.SecurityHeaders; sh != nil {
+		fmt.Fprintf(w, "Security headers:\t%s\n", securityHeadersString(sh))
+	}
+	if r := info.Site.RequestHeaders; r != nil {
+		fmt.Fprintf(w, "Request headers:\t%s\n", headerRulesString(r))
+	}
+	if r := info.Site.ResponseHeaders; r != nil {
+		fmt.Fprintf(w, "Response headers:\t%s\n", headerRulesString(r))
+	}
+	if m := info.Site.Maintenance; m != nil && m.Enabled {
+		fmt.Fprintf(w, "Maintenance:\t%s\n", maintenanceString(m))
+	}
+	for _, ep := range info.Site.ErrorPages {
+		fmt.Fprintf(w, "Error page %d:\t%s\n", ep.Status, pageTypeString(ep.ContentType))
+	}
+	for _, r := range info.Site.Redirects {
+		fmt.Fprintf(w, "Redirect %s:\t%s\n", r.Name, redirectString(r))
+	}
+	for _, r := range info.Site.Rewrites {
+		fmt.Fprintf(w, "Rewrite %s:\t%s -> %s\n", r.Name, r.Regex, r.Replacement)
+	}
+	for _, u := range info.Site.Upstreams {
+		fmt.Fprintf(w, "Upstream %s:\t%s\n", u.Name, strings.ToLower(strings.Replace(u.Strategy.String(), "_", "-", -1)))
+		if c := u.HealthCheck; c != nil {
+			fmt.Fprintf(w, "\tcheck %s every %ds\n", strings.ToLower(c.Type.String()), c.Interval)
+		}
+		if o := u.OutlierDetection; o != nil {
+			fmt.Fprintf(w, "\teject after %d 5xx or %d connection errors\n", o.Consecutive_5Xx, o.ConsecutiveConnectErrors)
+		}
+		if u.ProxyProtocol != 0 {
+			fmt.Fprintf(w, "\tPROXY protocol v%d\n", u.ProxyProtocol)
+		}
+		for _, e := range u.Endpoints {
+			fmt.Fprintf(w, "\t%s\n", endpointString(e))
+		}
+	}
+	for _, r := range info.Site.Routes {
+		fmt.Fprintf(w, "Route %s:\t%s\n", r.Name, routeString(r))
+	}
+	w.Flush()
+}
+
+// resilienceString describes the timeouts, retries and circuit breaker of a Resilience
+func resilienceString(r *sites.Resilience) string {
+	var parts []string
+	if t := r.Timeouts; t != nil {
+		parts = append(parts, fmt.Sprintf("connect %dms, per try %dms, overall %dms", t.Connect, t.PerTry, t.Overall))
+	}
+	if rt := r.Retry; rt != nil {
+		parts = append(parts, fmt.Sprintf("%d retries within %d%%", rt.Attempts, rt.BudgetPercent))
+	}
+	if c := r.CircuitBreaker; c != nil {
+		parts = append(parts, fmt.Sprintf("breaker at %d pending, %d connections, %d%% errors", c.MaxPending, c.MaxConnections, c.ErrorPercent))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// affinityString describes the cookie and ttl of an Affinity
+func affinityString(a *sites.Affinity) string {
+	cookie := a.Cookie
+	if cookie == "" {
+		cookie = "waffy_affinity"
+	}
+
+	if a.Ttl == 0 {
+		return fmt.Sprintf("cookie %s until the browser closes", cookie)
+	}
+
+	return fmt.Sprintf("cookie %s for %s", cookie, time.Duration(a.Ttl)*time.Second)
+}
+
+// webSocketString describes the limits of a WebSocket
+func webSocketString(ws *sites.WebSocket) string {
+	if ws.Disabled {
+		return "upgrades disabled"
+	}
+
+	parts := []string{fmt.Sprintf("idle %s", time.Duration(ws.IdleTimeout)*time.Second)}
+	if ws.MaxFrameSize != 0 {
+		parts = append(parts, fmt.Sprintf("frames up to %d bytes", ws.MaxFrameSize))
+	}
+	if ws.MaxMessageSize != 0 {
+		parts = append(parts, fmt.Sprintf("messages up to %d bytes", ws.MaxMessageSize))
+	}
+	if len(ws.Deny) > 0 {
+		parts = append(parts, fmt.Sprintf("deny %s", strings.Join(ws.Deny, ", ")))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// cacheString describes the sizes and disk tier of a Cache
+func cacheString(c *sites.Cache) string {
+	parts := []string{fmt.Sprintf("%s in memory, objects up to %s", bytesString(c.MaxSize, "64MB"), bytesString(c.MaxObjectSize, "8MB"))}
+	if c.DefaultTtl != 0 {
+		parts = append(parts, fmt.Sprintf("default ttl %s", time.Duration(c.DefaultTtl)*time.Second))
+	}
+	if c.DiskPath != "" {
+		parts = append(parts, fmt.Sprintf("%s on disk in %s", bytesString(c.DiskMaxSize, "1GB"), c.DiskPath))
+	}
+	if c.TagHeader != "" {
+		parts = append(parts, fmt.Sprintf("tagged by %s", c.TagHeader))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// compressionString describes the encodings, content types and min size of a Compression
+func compressionString(c *sites.Compression) string {
+	encodings, types := "br, zstd, gzip", "text, script, data and font types"
+	if len(c.Encodings) > 0 {
+		encodings = strings.Join(c.Encodings, ", ")
+	}
+	if len(c.ContentTypes) > 0 {
+		types = strings.Join(c.ContentTypes, ", ")
+	}
+
+	return fmt.Sprintf("%s of %s from %s", encodings, types, bytesString(c.MinSize, "1KB"))
+}
+
+// redirectString describes what a Redirect matches and where it redirects to
+func redirectString(r *sites.Redirect) string {
+	var parts []string
+	if r.Regex != "" {
+		parts = append(parts, "regex "+r.Regex)
+	}
+	if r.Replacement != "" {
+		parts = append(parts, "to "+r.Replacement)
+	}
+	if r.Host != "" {
+		parts = append(parts, "host "+r.Host)
+	}
+	if r.Https {
+		parts = append(parts, "https")
+	}
+
+	status := r.Status
+	if status == 0 {
+		status = 301
+	}
+
+	return fmt.Sprintf("%s with %d", strings.Join(parts, ", "), status)
+}
+
+// maintenanceString describes the clients that bypass a Maintenance, and how it is answered
+func maintenanceString(m *sites.Maintenance) string {
+	parts := []string{"enabled"}
+	if len(m.Allow) > 0 {
+		parts = append(parts, "allow "+strings.Join(m.Allow, ", "))
+	}
+	if len(m.Users) > 0 {
+		parts = append(parts, "users "+strings.Join(m.Users, ", "))
+	}
+	if m.Template != "" {
+		parts = append(parts, "page "+pageTypeString(m.ContentType))
+	}
+	if m.RetryAfter > 0 {
+		parts = append(parts, fmt.Sprintf("retry after %s", time.Duration(m.RetryAfter)*time.Second))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// pageTypeString describes the content type of an error or maintenance page
+func pageTypeString(contentType string) string {
+	if contentType == "" {
+		return "html"
+	}
+
+	return contentType
+}
+
+// headerRulesString describes the headers HeaderRules remove, set and add
+func headerRulesString(r *sites.HeaderRules) string {
+	var parts []string
+	for _, k := range r.Remove {
+		parts = append(parts, "remove "+k)
+	}
+	for _, h := range r.Set {
+		parts = append(parts, fmt.Sprintf("set %s: %s", h.Name, h.Value))
+	}
+	for _, h := range r.Add {
+		parts = append(parts, fmt.Sprintf("add %s: %s", h.Name, h.Value))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// securityHeadersString describes the headers of SecurityHeaders
+func securityHeadersString(sh *sites.SecurityHeaders) string {
+	var parts []string
+	if sh.HstsMaxAge > 0 {
+		hsts := fmt.Sprintf("hsts %s", time.Duration(sh.HstsMaxAge)*time.Second)
+		if sh.HstsIncludeSubdomains {
+			hsts += " with subdomains"
+		}
+		if sh.HstsPreload {
+			hsts += ", preload"
+		}
+		parts = append(parts, hsts)
+	}
+	if sh.ContentSecurityPolicy != "" {
+		parts = append(parts, "csp "+sh.ContentSecurityPolicy)
+	}
+	if sh.FrameOptions != "" {
+		parts = append(parts, "frame "+sh.FrameOptions)
+	}
+	if sh.Nosniff {
+		parts = append(parts, "nosniff")
+	}
+	if sh.ReferrerPolicy != "" {
+		parts = append(parts, "referrer "+sh.ReferrerPolicy)
+	}
+	if sh.PermissionsPolicy != "" {
+		parts = append(parts, "permissions "+sh.PermissionsPolicy)
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// bytesString describes a size in bytes, or the default if it is 0
+func bytesString(n int64, def string) string {
+	if n == 0 {
+		return def
+	}
+
+	return fmt.Sprintf("%d bytes", n)
+}
+
+// endpointString describes an Endpoint in the form parseEndpoint accepts
+func endpointString(e *sites.Endpoint) string {
+	scheme := e.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	host := e.Address
+	if e.Port != 0 {
+		host = net.JoinHostPort(e.Address, strconv.Itoa(int(e.Port)))
+	}
+
+	var opts []string
+	if e.Weight != 0 {
+		opts = append(opts, fmt.Sprintf("weight=%d", e.Weight))
+	}
+	if e.MaxConnections != 0 {
+		opts = append(opts, fmt.Sprintf("max-connections=%d", e.MaxConnections))
+	}
+	for k, v := range e.Labels {
+		opts = append(opts, fmt.Sprintf("label=%s:%s", k, v))
+	}
+	sort.Strings(opts)
+
+	if len(opts) == 0 {
+		return fmt.Sprintf("%s://%s", scheme, host)
+	}
+
+	return fmt.Sprintf("%s://%s?%s", scheme, host, strings.Join(opts, "&"))
+}
+
+func uploadSiteCert(ctx *cli.Context, conn *grpc.ClientConn) error {
+	hostname := ctx.String("hostname")
+	if hostname == "" || ctx.String("cert") == "" || ctx.String("key") == "" {
+		return fmt.Errorf("--hostname, --cert and --key are required")
+	}
+
+	cert, err := ioutil.ReadFile(ctx.String("cert"))
+	if err != nil {
+		return fmt.Errorf("unable to read certificate: %s", err)
+	}
+
+	key, err := ioutil.ReadFile(ctx.String("key"))
+	if err != nil {
+		return fmt.Errorf("unable to read key: %s", err)
+	}
+
+	resp, err := sites.NewSitesServiceClient(conn).UploadCertificate(context.Background(), &sites.UploadCertificateRequest{
+		Hostname:    hostname,
+		Certificate: cert,
+		Key:         key,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to upload certificate: %s", err)
+	}
+
+	fmt.Printf("uploaded certificate for %s (%s), expires %s\n",
+		resp.Hostname,
+		strings.Join(resp.DnsNames, ", "),
+		time.Unix(resp.NotAfter, 0).UTC().Format(time.RFC3339),
+	)
+	return nil
+}
+
+func deleteSiteCert(ctx *cli.Context, conn *grpc.ClientConn) error {
+	hostname := ctx.String("hostname")
+	if hostname == "" {
+		return fmt.Errorf("--hostname is required")
+	}
+
+	_, err := sites.NewSitesServiceClient(conn).DeleteCertificate(context.Background(), &sites.DeleteCertificateRequest{
+		Hostname: hostname,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to delete certificate: %s", err)
+	}
+
+	fmt.Printf("deleted certificate for %s\n", hostname)
+	return nil
+}
@@ -0,0 +1,82 @@
+package waffy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/unerror/waffy/pkg/services"
+	"github.com/unerror/waffy/pkg/services/protos/users"
+)
+
+func init() {
+	Cmds = append(Cmds, cli.Command{
+		Name:     "users",
+		Usage:    "Manage waffy users",
+		Category: "USERS",
+		Subcommands: []cli.Command{
+			{
+				Name:  "export",
+				Usage: "Export a user's client certificate as a bundle",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "email",
+						Usage: "The user's email address, defaults to --user",
+					},
+					cli.StringFlag{
+						Name:  "format",
+						Usage: "Bundle format, p12 or pem-bundle",
+						Value: "p12",
+					},
+					cli.StringFlag{
+						Name:   "password",
+						Usage:  "Password protecting the PKCS#12 bundle",
+						EnvVar: "WAFFY_EXPORT_PASSWORD",
+					},
+					cli.StringFlag{
+						Name:  "out",
+						Usage: "File to write the bundle to, defaults to <email>.p12 or <email>.pem",
+					},
+				},
+				Action: withClient(exportUser),
+			},
+		},
+	})
+}
+
+func exportUser(ctx *cli.Context, conn *grpc.ClientConn) error {
+	email := ctx.String("email")
+	if email == "" {
+		email = ctx.GlobalString("user")
+	}
+
+	format, err := services.ParseBundleFormat(ctx.String("format"))
+	if err != nil {
+		return err
+	}
+
+	resp, err := users.NewUsersServiceClient(conn).Export(context.Background(), &users.ExportRequest{
+		Email:    email,
+		Format:   format,
+		Password: ctx.String("password"),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to export %s: %s", email, err)
+	}
+
+	out := ctx.String("out")
+	if out == "" {
+		out = fmt.Sprintf("%s.%s", email, services.BundleExtension(format))
+	}
+
+	if err := ioutil.WriteFile(out, resp.Bundle, 0600); err != nil {
+		return fmt.Errorf("unable to write bundle: %s", err)
+	}
+
+	log.Printf("exported %s to %s", email, out)
+	return nil
+}
@@ -0,0 +1,144 @@
+package waffyd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/unerror/waffy/pkg/config"
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+)
+
+const (
+	// DefaultRotationOverlap is how long a rotated intermediate CA stays trusted
+	DefaultRotationOverlap = time.Hour * 24 * 30 // 30 days
+)
+
+func init() {
+	Cmds = append(Cmds, cli.Command{
+		Name:     "ca",
+		Usage:    "Manage the intermediate CA",
+		Category: "CERTIFICATES",
+		Subcommands: []cli.Command{
+			{
+				Name:  "rotate",
+				Usage: "Roll to a new intermediate CA, keeping the old one trusted during the overlap",
+				Flags: []cli.Flag{
+					certificateFlags[0],
+					cli.DurationFlag{
+						Name:  "overlap",
+						Usage: "How long the old intermediate CA stays trusted",
+						Value: DefaultRotationOverlap,
+					},
+				},
+				Action: withConfig(rotate),
+			},
+			{
+				Name:  "revoke",
+				Usage: "Revoke a certificate, so the OCSP responder reports it as revoked",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "serial",
+						Usage: "Hex encoded serial number of the certificate",
+					},
+					cli.IntFlag{
+						Name:  "reason",
+						Usage: "RFC 5280 CRLReason code, e.g. 1 for key compromise or 4 for superseded",
+					},
+				},
+				Action: withConsensus(revoke),
+			},
+		},
+	})
+}
+
+func rotate(ctx *cli.Context, cfg *config.Config) error {
+	keySize, err := strconv.Atoi(ctx.String("key-size"))
+	if err != nil {
+		return fmt.Errorf("unable to load key size: %s", err)
+	}
+
+	current, _, err := config.LoadIntermediate()
+	if err != nil {
+		return fmt.Errorf("unable to load intermediate CA: %s", err)
+	}
+
+	chain, err := config.LoadIntermediates()
+	if err != nil {
+		return fmt.Errorf("unable to load intermediate chain: %s", err)
+	}
+
+	// retire the current intermediate at the end of the overlap, and drop those no longer trusted
+	now := time.Now()
+	retire := now.Add(ctx.Duration("overlap"))
+
+	var trusted []config.Intermediate
+	for _, i := range chain {
+		if i.Certificate.Equal(current) && (i.Retire.IsZero() || i.Retire.After(retire)) {
+			i.Retire = retire
+		}
+
+		if i.Trusted(now) {
+			trusted = append(trusted, i)
+		}
+	}
+
+	if err := issueIntermediate(keySize, trusted); err != nil {
+		return fmt.Errorf("unable to rotate intermediate CA: %s", err)
+	}
+
+	log.Printf("rotated intermediate CA %s, trusted until %s, send SIGHUP to a running waffyd to load the new one", current.Subject.CommonName, retire.Format(time.RFC3339))
+	return nil
+}
+
+func revoke(ctx *cli.Context, db data.Consensus) error {
+	serial, err := hex.DecodeString(ctx.String("serial"))
+	if err != nil || len(serial) == 0 {
+		return fmt.Errorf("--serial is required as a hex encoded serial number")
+	}
+
+	c, err := repository.RevokeCertificate(db, serial, int32(ctx.Int("reason")))
+	if err != nil {
+		return fmt.Errorf("unable to revoke certificate %x: %s", serial, err)
+	}
+
+	log.Printf("revoked certificate %x for %s", serial, c.Subject.GetCommonName())
+	return nil
+}
+
+// issueIntermediate creates a new intermediate CA signed by the root, makes it the active signer and
+// adds it to the trusted chain. The intermediate gets its delegated OCSP signer at the same time, so
+// OCSP keeps answering for its certificates after it is rotated out.
+func issueIntermediate(keySize int, chain []config.Intermediate) error {
+	root, rootKey, err := config.LoadCA()
+	if err != nil {
+		return fmt.Errorf("unable to load CA: %s", err)
+	}
+
+	ca, key, err := crypto.NewIntermediateCA(root, rootKey, keySize)
+	if err != nil {
+		return err
+	}
+
+	if err := config.SaveIntermediate(ca, key); err != nil {
+		return err
+	}
+
+	ocspCert, ocspKey, err := crypto.NewOCSPSigner(ca, key, keySize)
+	if err != nil {
+		return err
+	}
+
+	if err := config.SaveOCSPSigner(ca, ocspCert, ocspKey); err != nil {
+		return err
+	}
+
+	chain = append(chain, config.Intermediate{Certificate: ca})
+	return config.SaveIntermediates(chain)
+}
@@ -1,9 +1,14 @@
 package waffyd
 
 import (
+	"crypto/x509/pkix"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"gopkg.in/urfave/cli.v1"
+
+	"github.com/unerror/waffy/pkg/crypto"
 )
 
 const (
@@ -22,3 +27,82 @@ var certificateFlags = []cli.Flag{
 		Usage: "Overwrite the existing CA data",
 	},
 }
+
+var subjectFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "dns",
+		Usage: "DNS subject alternative name, can be repeated",
+	},
+	cli.StringSliceFlag{
+		Name:  "ip",
+		Usage: "IP address subject alternative name, can be repeated",
+	},
+	cli.StringSliceFlag{
+		Name:  "uri",
+		Usage: "URI subject alternative name, can be repeated",
+	},
+	cli.StringSliceFlag{
+		Name:  "organization",
+		Usage: "Organization of the subject",
+	},
+	cli.StringSliceFlag{
+		Name:  "country",
+		Usage: "Country of the subject",
+	},
+	cli.StringSliceFlag{
+		Name:  "province",
+		Usage: "Province or state of the subject",
+	},
+	cli.StringSliceFlag{
+		Name:  "locality",
+		Usage: "Locality or city of the subject",
+	},
+}
+
+// subjectFromFlags builds the crypto.Subject for commonName from the subjectFlags
+func subjectFromFlags(ctx *cli.Context, commonName string) (crypto.Subject, error) {
+	subject := crypto.Subject{
+		Name: pkix.Name{
+			CommonName:   commonName,
+			Organization: ctx.StringSlice("organization"),
+			Country:      ctx.StringSlice("country"),
+			Province:     ctx.StringSlice("province"),
+			Locality:     ctx.StringSlice("locality"),
+		},
+	}
+
+	err := subject.AddAltNames(ctx.StringSlice("dns"), ctx.StringSlice("ip"), ctx.StringSlice("uri"))
+	return subject, err
+}
+
+var exportFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "email",
+		Usage: "The user's email address",
+	},
+	cli.StringFlag{
+		Name:  "format",
+		Usage: "Bundle format, p12 or pem-bundle",
+		Value: "p12",
+	},
+	cli.StringFlag{
+		Name:   "password",
+		Usage:  "Password protecting the PKCS#12 bundle",
+		EnvVar: "WAFFY_EXPORT_PASSWORD",
+	},
+	cli.StringFlag{
+		Name:  "out",
+		Usage: "File to write the bundle to, defaults to <email>.p12 or <email>.pem",
+	},
+}
+
+// parseEnum returns the value of a protobuf enum by name, accepting lowercase and dashes, e.g.
+// least-connections for LEAST_CONNECTIONS
+func parseEnum(values map[string]int32, name string) (int32, error) {
+	v, ok := values[strings.ToUpper(strings.Replace(name, "-", "_", -1))]
+	if !ok {
+		return 0, fmt.Errorf("unknown value %s", name)
+	}
+
+	return v, nil
+}
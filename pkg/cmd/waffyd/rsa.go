@@ -10,7 +10,6 @@ import (
 	"github.com/unerror/waffy/pkg/crypto"
 	"github.com/unerror/waffy/pkg/data"
 	"github.com/unerror/waffy/pkg/repository"
-	"github.com/unerror/waffy/pkg/services/protos/certificates"
 )
 
 func init() {
@@ -21,17 +20,19 @@ func init() {
 		Subcommands: []cli.Command{
 			{
 				Name:   "genca",
-				Usage:  "Generate CA certificates for RPC",
+				Usage:  "Generate the root and intermediate CA certificates for RPC",
 				Flags:  certificateFlags,
 				Action: genca,
 			},
 			{
 				Name:  "gencert",
 				Usage: "Generate a server certificaate for RPC",
-				Flags: append(certificateFlags, cli.StringFlag{
-					Name:  "common-name",
-					Usage: "Common Name of the server for the certificate",
-				}),
+				Flags: append(append([]cli.Flag{
+					cli.StringFlag{
+						Name:  "common-name",
+						Usage: "Common Name of the server for the certificate",
+					},
+				}, certificateFlags...), subjectFlags...),
 				Action: withConsensus(gencert),
 			},
 		},
@@ -58,6 +59,10 @@ func genca(ctx *cli.Context) {
 		if err := config.SaveCA(ca, key); err != nil {
 			log.Fatalf("unable to save CA: %s", err)
 		}
+
+		if err := issueIntermediate(keySize, nil); err != nil {
+			log.Fatalf("unable to create intermediate CA: %s", err)
+		}
 	} else {
 		log.Fatalf("unable to save CA: --overwrite to force and overwrite")
 	}
@@ -70,7 +75,12 @@ func gencert(ctx *cli.Context, db data.Consensus) error {
 		log.Fatalf("--common-name is required")
 	}
 
-	_, err := config.LoadCert(cn)
+	subject, err := subjectFromFlags(ctx, cn)
+	if err != nil {
+		log.Fatalf("invalid subject: %s", err)
+	}
+
+	_, err = config.LoadCert(cn)
 	if err != nil {
 		write = true
 	}
@@ -81,9 +91,9 @@ func gencert(ctx *cli.Context, db data.Consensus) error {
 			log.Fatalf("unable to load key size: %s", err)
 		}
 
-		ca, caKey, err := config.LoadCA()
+		ca, caKey, err := config.LoadIntermediate()
 		if err != nil {
-			log.Fatalf("unable to load CA: %s", err)
+			log.Fatalf("unable to load intermediate CA: %s", err)
 		}
 
 		key, err := crypto.NewPrivateKey(keySize)
@@ -91,7 +101,7 @@ func gencert(ctx *cli.Context, db data.Consensus) error {
 			log.Fatalf("unable to generate new private key: %s", err)
 		}
 
-		cert, err := crypto.NewCertificate(ca, caKey, key, true, cn)
+		cert, err := crypto.NewSubjectCertificate(ca, caKey, crypto.PublicKey(key), true, subject)
 		if err != nil {
 			log.Fatalf("unable to generate new certificate: %s", err)
 		}
@@ -103,14 +113,7 @@ func gencert(ctx *cli.Context, db data.Consensus) error {
 			log.Fatalf("unable to save certificate: %s", err)
 		}
 
-		c := &certificates.Certificate{
-			Certificate:  crypto.EncodePEM(cert),
-			SerialNumber: cert.SerialNumber.Bytes(),
-			Subject: &certificates.Subject{
-				CommonName: cn,
-			},
-		}
-		return repository.CreateCertificate(db, c)
+		return repository.CreateCertificate(db, repository.NewCertificate(cert))
 	}
 
 	log.Fatalf("unable to save certificate for %s, already exists. --overwrite to force", cn)
@@ -0,0 +1,549 @@
+package waffyd
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/proxy"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func init() {
+	Cmds = append(Cmds, cli.Command{
+		Name:     "sites",
+		Usage:    "Manage Balancers and the Sites they serve",
+		Category: "LEADER SITE MANAGEMENT",
+		Subcommands: []cli.Command{
+			{
+				Name:  "balancer",
+				Usage: "Create a Balancer listening on a port",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "port",
+						Usage: "The port the Balancer listens on",
+					},
+					cli.StringFlag{
+						Name:  "proto",
+						Usage: "The protocol the Balancer listens with: http, https, h2 (HTTP/2 over TLS), h2c (cleartext HTTP/2), tcp or udp",
+						Value: "http",
+					},
+					cli.StringFlag{
+						Name:  "strategy",
+						Usage: "How Endpoints are picked: round-robin, weighted-round-robin, least-connections, power-of-two or consistent-hash",
+						Value: "round-robin",
+					},
+					cli.StringFlag{
+						Name:  "hash-source",
+						Usage: "What consistent-hash hashes: client-ip, header or cookie",
+						Value: "client-ip",
+					},
+					cli.StringFlag{
+						Name:  "hash-name",
+						Usage: "The header or cookie consistent-hash hashes",
+					},
+					cli.StringFlag{
+						Name:  "affinity-cookie",
+						Usage: "Pin the clients of Sites with no affinity of their own to an Endpoint with the signed cookie",
+					},
+					cli.DurationFlag{
+						Name:  "affinity-ttl",
+						Usage: "How long clients stay pinned, until the browser closes if 0",
+					},
+					cli.StringSliceFlag{
+						Name:  "allow",
+						Usage: "Only accept clients from the CIDR, can be repeated",
+					},
+					cli.StringSliceFlag{
+						Name:  "deny",
+						Usage: "Refuse clients from the CIDR, even if allowed, can be repeated",
+					},
+					cli.DurationFlag{
+						Name:  "idle-timeout",
+						Usage: "Close tcp connections and udp sessions with no traffic for the duration, 5m for tcp and 1m for udp if 0",
+					},
+					cli.StringFlag{
+						Name:  "default-site",
+						Usage: "The Site of a tcp or udp Balancer that serves what matches no Site by TLS SNI, instead of the Balancer endpoints",
+					},
+					cli.StringSliceFlag{
+						Name:  "proxy-protocol-from",
+						Usage: "Require a PROXY protocol header giving the client address from connections from the CIDR, can be repeated",
+					},
+					cli.UintFlag{
+						Name:  "proxy-protocol",
+						Usage: "Give the Balancer endpoints the client address with PROXY protocol version 1 or 2, not sent if 0",
+					},
+					cli.StringSliceFlag{
+						Name:  "trusted-proxy",
+						Usage: "Read the client address from the forwarding header of requests from the CIDR, such as a CDN, can be repeated",
+					},
+					cli.StringFlag{
+						Name:  "forwarded-header",
+						Usage: "The forwarding header trusted proxies give the client address in: x-forwarded-for or forwarded",
+						Value: "x-forwarded-for",
+					},
+				},
+				Action: withConsensus(createBalancer),
+			},
+			{
+				Name:  "acl",
+				Usage: "Replace the CIDRs clients may and may not connect to a Balancer from",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "port",
+						Usage: "The port of the Balancer",
+					},
+					cli.StringSliceFlag{
+						Name:  "allow",
+						Usage: "Only accept clients from the CIDR, can be repeated, any if unset",
+					},
+					cli.StringSliceFlag{
+						Name:  "deny",
+						Usage: "Refuse clients from the CIDR, even if allowed, can be repeated",
+					},
+				},
+				Action: withConsensus(setACL),
+			},
+			{
+				Name:  "proxy-protocol",
+				Usage: "Replace the load balancers a Balancer reads PROXY protocol headers from, and the version its endpoints are sent",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "port",
+						Usage: "The port of the Balancer",
+					},
+					cli.StringSliceFlag{
+						Name:  "from",
+						Usage: "Require a PROXY protocol header giving the client address from connections from the CIDR, can be repeated",
+					},
+					cli.UintFlag{
+						Name:  "send",
+						Usage: "Give the Balancer endpoints the client address with PROXY protocol version 1 or 2, not sent if 0",
+					},
+				},
+				Action: withConsensus(setProxyProtocol),
+			},
+			{
+				Name:  "trusted-proxies",
+				Usage: "Replace the proxies whose forwarding headers give the client address of requests to a Balancer",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "port",
+						Usage: "The port of the Balancer",
+					},
+					cli.StringSliceFlag{
+						Name:  "cidr",
+						Usage: "Read the client address from the forwarding header of requests from the CIDR, can be repeated",
+					},
+					cli.StringFlag{
+						Name:  "header",
+						Usage: "The forwarding header trusted proxies give the client address in: x-forwarded-for or forwarded",
+						Value: "x-forwarded-for",
+					},
+				},
+				Action: withConsensus(setTrustedProxies),
+			},
+			{
+				Name:  "health-check",
+				Usage: "Replace the health check and outlier detection of the Endpoints of a Balancer",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "port",
+						Usage: "The port of the Balancer",
+					},
+					cli.StringFlag{
+						Name:  "check",
+						Usage: "Actively check the Endpoints with http or tcp, unchecked if unset",
+					},
+					cli.StringFlag{
+						Name:  "check-path",
+						Usage: "The path http checks GET",
+						Value: "/",
+					},
+					cli.UintFlag{
+						Name:  "check-status",
+						Usage: "The status http checks expect",
+						Value: 200,
+					},
+					cli.StringFlag{
+						Name:  "check-body",
+						Usage: "A regex the body of http checks must match",
+					},
+					cli.DurationFlag{
+						Name:  "check-interval",
+						Usage: "The interval between checks",
+						Value: time.Second * 10,
+					},
+					cli.DurationFlag{
+						Name:  "check-timeout",
+						Usage: "The timeout of a check",
+						Value: time.Second * 2,
+					},
+					cli.UintFlag{
+						Name:  "healthy-threshold",
+						Usage: "The passed checks in a row that mark an Endpoint healthy",
+						Value: 2,
+					},
+					cli.UintFlag{
+						Name:  "unhealthy-threshold",
+						Usage: "The failed checks in a row that mark an Endpoint unhealthy",
+						Value: 3,
+					},
+					cli.UintFlag{
+						Name:  "eject-5xx",
+						Usage: "Eject an Endpoint after this many 5xx responses in a row, never if 0",
+					},
+					cli.UintFlag{
+						Name:  "eject-connect-errors",
+						Usage: "Eject an Endpoint after this many connection errors in a row, never if 0",
+					},
+					cli.DurationFlag{
+						Name:  "eject-base",
+						Usage: "How long the first ejection lasts, doubled for each ejection in a row",
+						Value: time.Second * 30,
+					},
+					cli.DurationFlag{
+						Name:  "eject-max",
+						Usage: "The longest an ejection lasts",
+						Value: time.Minute * 5,
+					},
+				},
+				Action: withConsensus(setHealthCheck),
+			},
+			{
+				Name:  "endpoint",
+				Usage: "Add a backend Endpoint to a Balancer",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "port",
+						Usage: "The port of the Balancer",
+					},
+					cli.StringFlag{
+						Name:  "address",
+						Usage: "The host:port of the backend",
+					},
+					cli.UintFlag{
+						Name:  "weight",
+						Usage: "The relative weight of the backend",
+						Value: 1,
+					},
+				},
+				Action: withConsensus(addEndpoint),
+			},
+			{
+				Name:  "create",
+				Usage: "Create a Site served by a Balancer",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "port",
+						Usage: "The port of the Balancer that serves the Site",
+					},
+					cli.StringFlag{
+						Name:  "hostname",
+						Usage: "The hostname of the Site",
+					},
+					cli.StringSliceFlag{
+						Name:  "alias",
+						Usage: "An alias hostname of the Site, can be repeated",
+					},
+					cli.BoolFlag{
+						Name:  "secure",
+						Usage: "Serve the Site over TLS",
+					},
+					cli.BoolFlag{
+						Name:  "autoencrypt",
+						Usage: "Issue the Site certificate through ACME",
+					},
+				},
+				Action: withConsensus(createSite),
+			},
+		},
+	})
+}
+
+func createBalancer(ctx *cli.Context, db data.Consensus) error {
+	port := ctx.String("port")
+	if port == "" {
+		return fmt.Errorf("--port is required")
+	}
+
+	switch ctx.String("proto") {
+	case proxy.ProtoHTTP, proxy.ProtoHTTPS, proxy.ProtoH2, proxy.ProtoH2C, proxy.ProtoTCP, proxy.ProtoUDP:
+	default:
+		return fmt.Errorf("invalid --proto: %s", ctx.String("proto"))
+	}
+
+	if err := validateCIDRs(append(ctx.StringSlice("allow"), ctx.StringSlice("deny")...)); err != nil {
+		return err
+	}
+
+	if err := validateCIDRs(ctx.StringSlice("proxy-protocol-from")); err != nil {
+		return err
+	}
+
+	if ctx.Uint("proxy-protocol") > 2 {
+		return fmt.Errorf("invalid --proxy-protocol: %d", ctx.Uint("proxy-protocol"))
+	}
+
+	if err := validateCIDRs(ctx.StringSlice("trusted-proxy")); err != nil {
+		return err
+	}
+
+	header, err := parseEnum(sites.ForwardedHeader_value, ctx.String("forwarded-header"))
+	if err != nil {
+		return fmt.Errorf("invalid --forwarded-header: %s", err)
+	}
+
+	strategy, err := parseEnum(sites.Strategy_value, ctx.String("strategy"))
+	if err != nil {
+		return fmt.Errorf("invalid --strategy: %s", err)
+	}
+
+	source, err := parseEnum(sites.HashSource_value, ctx.String("hash-source"))
+	if err != nil {
+		return fmt.Errorf("invalid --hash-source: %s", err)
+	}
+
+	if sites.HashSource(source) != sites.HashSource_CLIENT_IP && ctx.String("hash-name") == "" {
+		return fmt.Errorf("--hash-name is required to hash on a %s", ctx.String("hash-source"))
+	}
+
+	var affinity *sites.Affinity
+	if ctx.IsSet("affinity-cookie") || ctx.IsSet("affinity-ttl") {
+		affinity = &sites.Affinity{
+			Cookie: ctx.String("affinity-cookie"),
+			Ttl:    int64(ctx.Duration("affinity-ttl").Seconds()),
+		}
+	}
+
+	err = repository.CreateBalancer(db, &sites.Balancer{
+		Port:     port,
+		Proto:    ctx.String("proto"),
+		Strategy: sites.Strategy(strategy),
+		Hash: &sites.HashPolicy{
+			Source: sites.HashSource(source),
+			Name:   ctx.String("hash-name"),
+		},
+		Affinity:    affinity,
+		Allow:       ctx.StringSlice("allow"),
+		Deny:        ctx.StringSlice("deny"),
+		IdleTimeout: int64(ctx.Duration("idle-timeout").Seconds()),
+		DefaultSite: ctx.String("default-site"),
+
+		ProxyProtocolFrom: ctx.StringSlice("proxy-protocol-from"),
+		ProxyProtocol:     uint32(ctx.Uint("proxy-protocol")),
+
+		TrustedProxies:  ctx.StringSlice("trusted-proxy"),
+		ForwardedHeader: sites.ForwardedHeader(header),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create balancer: %s", err)
+	}
+
+	log.Printf("created %s balancer on port %s", ctx.String("proto"), port)
+	return nil
+}
+
+func setACL(ctx *cli.Context, db data.Consensus) error {
+	port := ctx.String("port")
+	if port == "" {
+		return fmt.Errorf("--port is required")
+	}
+
+	if err := validateCIDRs(append(ctx.StringSlice("allow"), ctx.StringSlice("deny")...)); err != nil {
+		return err
+	}
+
+	b, err := repository.FindBalancer(db, port)
+	if err != nil {
+		return err
+	}
+
+	b.Allow, b.Deny = ctx.StringSlice("allow"), ctx.StringSlice("deny")
+	if err := repository.SaveBalancer(db, b); err != nil {
+		return fmt.Errorf("unable to save balancer: %s", err)
+	}
+
+	log.Printf("replaced the acl of port %s", port)
+	return nil
+}
+
+func setProxyProtocol(ctx *cli.Context, db data.Consensus) error {
+	port := ctx.String("port")
+	if port == "" {
+		return fmt.Errorf("--port is required")
+	}
+
+	if err := validateCIDRs(ctx.StringSlice("from")); err != nil {
+		return err
+	}
+
+	if ctx.Uint("send") > 2 {
+		return fmt.Errorf("invalid --send: %d", ctx.Uint("send"))
+	}
+
+	b, err := repository.FindBalancer(db, port)
+	if err != nil {
+		return err
+	}
+
+	b.ProxyProtocolFrom, b.ProxyProtocol = ctx.StringSlice("from"), uint32(ctx.Uint("send"))
+	if err := repository.SaveBalancer(db, b); err != nil {
+		return fmt.Errorf("unable to save balancer: %s", err)
+	}
+
+	log.Printf("replaced the proxy protocol of port %s", port)
+	return nil
+}
+
+func setTrustedProxies(ctx *cli.Context, db data.Consensus) error {
+	port := ctx.String("port")
+	if port == "" {
+		return fmt.Errorf("--port is required")
+	}
+
+	if err := validateCIDRs(ctx.StringSlice("cidr")); err != nil {
+		return err
+	}
+
+	header, err := parseEnum(sites.ForwardedHeader_value, ctx.String("header"))
+	if err != nil {
+		return fmt.Errorf("invalid --header: %s", err)
+	}
+
+	b, err := repository.FindBalancer(db, port)
+	if err != nil {
+		return err
+	}
+
+	b.TrustedProxies, b.ForwardedHeader = ctx.StringSlice("cidr"), sites.ForwardedHeader(header)
+	if err := repository.SaveBalancer(db, b); err != nil {
+		return fmt.Errorf("unable to save balancer: %s", err)
+	}
+
+	log.Printf("replaced the trusted proxies of port %s", port)
+	return nil
+}
+
+func setHealthCheck(ctx *cli.Context, db data.Consensus) error {
+	port := ctx.String("port")
+	if port == "" {
+		return fmt.Errorf("--port is required")
+	}
+
+	var check *sites.HealthCheck
+	if name := ctx.String("check"); name != "" {
+		t, err := parseEnum(sites.HealthCheckType_value, name)
+		if err != nil {
+			return fmt.Errorf("invalid --check: %s", err)
+		}
+
+		if _, err := regexp.Compile(ctx.String("check-body")); err != nil {
+			return fmt.Errorf("invalid --check-body: %s", err)
+		}
+		if ctx.Duration("check-timeout") > ctx.Duration("check-interval") {
+			return fmt.Errorf("--check-timeout is longer than --check-interval")
+		}
+
+		check = &sites.HealthCheck{
+			Type:               sites.HealthCheckType(t),
+			Path:               ctx.String("check-path"),
+			ExpectedStatus:     uint32(ctx.Uint("check-status")),
+			BodyRegex:          ctx.String("check-body"),
+			Interval:           int64(ctx.Duration("check-interval").Seconds()),
+			Timeout:            int64(ctx.Duration("check-timeout").Seconds()),
+			HealthyThreshold:   uint32(ctx.Uint("healthy-threshold")),
+			UnhealthyThreshold: uint32(ctx.Uint("unhealthy-threshold")),
+		}
+	}
+
+	var outliers *sites.OutlierDetection
+	if ctx.Uint("eject-5xx") != 0 || ctx.Uint("eject-connect-errors") != 0 {
+		outliers = &sites.OutlierDetection{
+			Consecutive_5Xx:          uint32(ctx.Uint("eject-5xx")),
+			ConsecutiveConnectErrors: uint32(ctx.Uint("eject-connect-errors")),
+			BaseEjection:             int64(ctx.Duration("eject-base").Seconds()),
+			MaxEjection:              int64(ctx.Duration("eject-max").Seconds()),
+		}
+	}
+
+	b, err := repository.FindBalancer(db, port)
+	if err != nil {
+		return err
+	}
+
+	b.HealthCheck, b.OutlierDetection = check, outliers
+	if err := repository.SaveBalancer(db, b); err != nil {
+		return fmt.Errorf("unable to save balancer: %s", err)
+	}
+
+	log.Printf("replaced the health check of port %s", port)
+	return nil
+}
+
+// validateCIDRs checks each CIDR of an ACL parses
+func validateCIDRs(cidrs []string) error {
+	for _, c := range cidrs {
+		if _, err := proxy.ParseCIDR(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addEndpoint(ctx *cli.Context, db data.Consensus) error {
+	port := ctx.String("port")
+	address := ctx.String("address")
+	if port == "" || address == "" {
+		return fmt.Errorf("--port and --address are required")
+	}
+
+	b, err := repository.FindBalancer(db, port)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range b.Endpoints {
+		if e.Address == address {
+			return fmt.Errorf("endpoint %s already exists on port %s", address, port)
+		}
+	}
+
+	b.Endpoints = append(b.Endpoints, &sites.Endpoint{
+		Address: address,
+		Weight:  uint32(ctx.Uint("weight")),
+	})
+	if err := repository.SaveBalancer(db, b); err != nil {
+		return fmt.Errorf("unable to save balancer: %s", err)
+	}
+
+	log.Printf("added endpoint %s to port %s", address, port)
+	return nil
+}
+
+func createSite(ctx *cli.Context, db data.Consensus) error {
+	port := ctx.String("port")
+	hostname := ctx.String("hostname")
+	if port == "" || hostname == "" {
+		return fmt.Errorf("--port and --hostname are required")
+	}
+
+	err := repository.CreateSite(db, port, &sites.Site{
+		Hostname:    hostname,
+		Alias:       ctx.StringSlice("alias"),
+		Secure:      ctx.Bool("secure"),
+		Autoencrypt: ctx.Bool("autoencrypt"),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create site: %s", err)
+	}
+
+	log.Printf("created site %s on port %s", hostname, port)
+	return nil
+}
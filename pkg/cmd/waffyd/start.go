@@ -5,9 +5,17 @@ import (
 	"crypto/x509"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/unerror/waffy/pkg/acme"
 	"github.com/unerror/waffy/pkg/config"
 	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/ocsp"
+	"github.com/unerror/waffy/pkg/proxy"
 	"github.com/unerror/waffy/pkg/services"
 	"gopkg.in/urfave/cli.v1"
 )
@@ -16,53 +24,128 @@ func init() {
 	Cmds = append(Cmds, cli.Command{
 		Name:   "start",
 		Usage:  "Start the waffyd service",
-		Action: start,
+		Action: withConsensus(start),
 	})
 }
 
-func start(c *cli.Context) {
+func start(c *cli.Context, db data.Consensus) error {
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("unable to load config: %s", err)
 	}
 
-	ca, _, err := config.LoadCA()
+	ca, err := config.LoadCACert()
 	if ca == nil {
 		log.Fatalf("unable to load CA cert: %s", err)
 	}
 
-	pool := x509.NewCertPool()
-	pool.AddCert(ca)
+	intermediates, err := config.LoadTrustedIntermediates()
+	if err != nil {
+		log.Fatalf("unable to load intermediate CA certs: %s", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	intermediatePool, err := config.NewIntermediatePool()
+	if err != nil {
+		log.Fatalf("unable to load intermediate CA certs: %s", err)
+	}
 
-	keypair, err := loadServerKeypair(cfg.RPCName)
+	keypair, err := loadServerKeypair(cfg.RPCName, intermediates)
 	if err != nil {
 		log.Fatalf("unable to load server keypair: %s", err)
 	}
 
+	responder, err := ocsp.NewResponder(db, intermediates, DefaultBits)
+	if err != nil {
+		log.Fatalf("unable to create OCSP responder: %s", err)
+	}
+
+	go func() {
+		log.Printf("starting OCSP responder on %s", cfg.OCSPListen)
+		if err := http.ListenAndServe(cfg.OCSPListen, responder); err != nil {
+			log.Fatalf("unable to serve OCSP: %s", err)
+		}
+	}()
+
+	stapler := ocsp.NewStapler(*keypair, responder.StapleLocal())
+	go stapler.Run(nil)
+
+	manager, err := acme.NewManager(db, cfg)
+	if err != nil {
+		log.Fatalf("unable to create ACME manager: %s", err)
+	}
+	go manager.Run(nil)
+
+	px := proxy.New(db, manager, stapler.GetCertificate, roots, intermediatePool)
+	stop, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		px.Run(stop)
+		close(stopped)
+	}()
+	go handleSignals(px, intermediatePool, stop, stopped)
+
 	log.Printf("starting RPC for %s server on %s", cfg.RPCName, cfg.APIListen)
-	if err := services.Serve(cfg.APIListen, pool, *keypair); err != nil {
+	if err := services.Serve(cfg.APIListen, roots, intermediatePool, stapler.GetCertificate, db, px); err != nil {
 		log.Fatalf("unable to serve RPC: %s", err)
 	}
+
+	return nil
 }
 
-func loadServerKeypair(hostname string) (*tls.Certificate, error) {
-	certName := fmt.Sprintf("%s.crt", hostname)
-	keyName := fmt.Sprintf("%s.key", hostname)
+// handleSignals reloads the proxy and the intermediates on SIGHUP. On SIGINT or SIGTERM it closes stop,
+// and exits once the proxy has drained the requests it is serving and closed stopped, or at once on a
+// second signal.
+func handleSignals(px *proxy.Proxy, intermediates *config.IntermediatePool, stop chan<- struct{}, stopped <-chan struct{}) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM)
+
+	for sig := range sigs {
+		if sig == syscall.SIGHUP {
+			log.Printf("reloading balancers and intermediate CA certs")
+			if err := intermediates.Reload(); err != nil {
+				log.Printf("unable to reload intermediate CA certs: %s", err)
+			}
+			px.Reload()
+			continue
+		}
 
-	cert, err := config.LoadCert(certName)
+		log.Printf("received %s, draining balancers", sig)
+		close(stop)
+		break
+	}
+
+	for {
+		select {
+		case <-stopped:
+			log.Printf("balancers drained, exiting")
+			os.Exit(0)
+		case sig := <-sigs:
+			if sig != syscall.SIGHUP {
+				log.Printf("received %s while draining, exiting", sig)
+				os.Exit(1)
+			}
+		}
+	}
+}
+
+// loadServerKeypair loads the node keypair, with the intermediate that issued it so clients
+// can verify the full chain
+func loadServerKeypair(hostname string, intermediates []*x509.Certificate) (*tls.Certificate, error) {
+	cert, err := config.LoadCert(hostname)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load server certificate for %s: %s", hostname, err)
 	}
 
-	key, err := config.LoadKey(keyName)
+	key, err := config.LoadKey(hostname)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load server key: %s", err)
 	}
 
-	keypair, err := tls.X509KeyPair(
-		crypto.EncodePEM(cert),
-		crypto.EncodePEM(key),
-	)
-
-	return &keypair, err
+	return &tls.Certificate{
+		Certificate: crypto.Chain(cert, intermediates),
+		PrivateKey:  key,
+		Leaf:        cert,
+	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"strconv"
 
@@ -11,7 +12,7 @@ import (
 	"github.com/unerror/waffy/pkg/crypto"
 	"github.com/unerror/waffy/pkg/data"
 	"github.com/unerror/waffy/pkg/repository"
-	"github.com/unerror/waffy/pkg/services/protos/certificates"
+	"github.com/unerror/waffy/pkg/services"
 	"github.com/unerror/waffy/pkg/services/protos/users"
 	"gopkg.in/urfave/cli.v1"
 )
@@ -41,6 +42,12 @@ func init() {
 				),
 				Action: withConsensus(createUser),
 			},
+			{
+				Name:   "export",
+				Usage:  "Export a user's client certificate as a bundle",
+				Flags:  exportFlags,
+				Action: exportUser,
+			},
 		},
 	})
 }
@@ -84,6 +91,35 @@ func createUser(ctx *cli.Context, db data.Consensus) error {
 	return nil
 }
 
+func exportUser(ctx *cli.Context) error {
+	email := ctx.String("email")
+	if email == "" {
+		return fmt.Errorf("--email is required")
+	}
+
+	format, err := services.ParseBundleFormat(ctx.String("format"))
+	if err != nil {
+		return err
+	}
+
+	bundle, err := services.ExportUserBundle(email, format, ctx.String("password"))
+	if err != nil {
+		return fmt.Errorf("unable to export %s: %s", email, err)
+	}
+
+	out := ctx.String("out")
+	if out == "" {
+		out = fmt.Sprintf("%s.%s", email, services.BundleExtension(format))
+	}
+
+	if err := ioutil.WriteFile(out, bundle, 0600); err != nil {
+		return fmt.Errorf("unable to write bundle: %s", err)
+	}
+
+	log.Printf("exported %s to %s", email, out)
+	return nil
+}
+
 func _newUser(name, email, roleStr string, keySize int) (*users.User, *x509.Certificate, *rsa.PrivateKey, error) {
 	var role users.Role
 
@@ -106,7 +142,7 @@ func _newUser(name, email, roleStr string, keySize int) (*users.User, *x509.Cert
 		Role:  role,
 	}
 
-	ca, caKey, err := config.LoadCA()
+	ca, caKey, err := config.LoadIntermediate()
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -121,13 +157,8 @@ func _newUser(name, email, roleStr string, keySize int) (*users.User, *x509.Cert
 		return nil, nil, nil, err
 	}
 
-	u.Certificate = &certificates.Certificate{
-		Subject: &certificates.Subject{
-			Email: u.Email,
-		},
-		SerialNumber: cert.SerialNumber.Bytes(),
-		Certificate:  crypto.EncodePEM(cert),
-	}
+	u.Certificate = repository.NewCertificate(cert)
+	u.Certificate.Subject.Email = u.Email
 
 	return &u, cert, key.(*rsa.PrivateKey), nil
 }
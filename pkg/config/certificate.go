@@ -11,8 +11,35 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 )
 
+const (
+	intermediateCert  = "intermediate.crt"
+	intermediateKey   = "intermediate.key"
+	intermediateChain = "ca-chain.crt"
+
+	// retireHeader is the PEM header used to mark when a retired intermediate stops being trusted
+	retireHeader = "Trusted-Until"
+)
+
+// Intermediate is an intermediate CA certificate trusted by waffy. An intermediate that has been
+// rotated out stays trusted until Retire, so certificates it signed keep working during the overlap.
+type Intermediate struct {
+	Certificate *x509.Certificate
+	Retire      time.Time
+}
+
+// Trusted returns if the intermediate is still trusted at the given time
+func (i Intermediate) Trusted(t time.Time) bool {
+	if t.After(i.Certificate.NotAfter) {
+		return false
+	}
+
+	return i.Retire.IsZero() || t.Before(i.Retire)
+}
+
 // SaveCA saves the certificate to the filesystem
 func SaveCA(certificate *x509.Certificate, key crypto.PrivateKey) error {
 	err := saveCert("ca.crt", certificate)
@@ -29,24 +56,257 @@ func SaveCA(certificate *x509.Certificate, key crypto.PrivateKey) error {
 
 // LoadCA loads the public and private key data about the CA
 func LoadCA() (*x509.Certificate, crypto.PrivateKey, error) {
+	cert, err := LoadCACert()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kf, err := loadFile("ca.key")
+	if err != nil {
+		return cert, nil, fmt.Errorf("could not load ca key: %s", err)
+	}
+
+	key, err := loadKey(kf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load CA private key: %s", err)
+	}
+
+	return cert, key, nil
+}
+
+// LoadCACert loads the CA certificate, for clients that only need to verify it
+func LoadCACert() (*x509.Certificate, error) {
 	cf, err := loadFile("ca.crt")
 	if err != nil {
-		return nil, nil, fmt.Errorf("could not load ca certificate")
+		return nil, fmt.Errorf("could not load ca certificate")
 	}
 
 	cert, err := loadCert(cf)
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to load CA certificate: %s", err)
+		return nil, fmt.Errorf("unable to load CA certificate: %s", err)
 	}
 
-	kf, err := loadFile("ca.key")
+	return cert, nil
+}
+
+// SaveIntermediate saves the active intermediate CA, used to sign leaf certificates, to the filesystem
+func SaveIntermediate(certificate *x509.Certificate, key crypto.PrivateKey) error {
+	err := saveCert(intermediateCert, certificate)
 	if err != nil {
-		return cert, nil, fmt.Errorf("could not load ca key: %s", err)
+		return fmt.Errorf("unable to save intermediate certificate: %s", err)
+	}
+	err = saveKey(intermediateKey, key)
+	if err != nil {
+		return fmt.Errorf("unable to save intermediate key: %s", err)
+	}
+
+	return nil
+}
+
+// LoadIntermediate loads the public and private key data about the active intermediate CA
+func LoadIntermediate() (*x509.Certificate, crypto.PrivateKey, error) {
+	cf, err := loadFile(intermediateCert)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not load intermediate certificate: %s", err)
+	}
+
+	cert, err := loadCert(cf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load intermediate certificate: %s", err)
+	}
+
+	kf, err := loadFile(intermediateKey)
+	if err != nil {
+		return cert, nil, fmt.Errorf("could not load intermediate key: %s", err)
 	}
 
 	key, err := loadKey(kf)
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to load CA private key: %s", err)
+		return nil, nil, fmt.Errorf("unable to load intermediate private key: %s", err)
+	}
+
+	return cert, key, nil
+}
+
+// SaveIntermediates saves the bundle of trusted intermediate CA certificates to the filesystem
+func SaveIntermediates(intermediates []Intermediate) error {
+	f, err := ensureFile(intermediateChain)
+	if err != nil {
+		return fmt.Errorf("cannot save intermediate chain: %s", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	for _, i := range intermediates {
+		block := pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: i.Certificate.Raw,
+		}
+		if !i.Retire.IsZero() {
+			block.Headers = map[string]string{
+				retireHeader: i.Retire.UTC().Format(time.RFC3339),
+			}
+		}
+
+		if err := pem.Encode(w, &block); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// LoadIntermediates loads every intermediate CA certificate in the bundle, including retired ones
+func LoadIntermediates() ([]Intermediate, error) {
+	f, err := loadFile(intermediateChain)
+	if err != nil {
+		return nil, fmt.Errorf("could not load intermediate chain: %s", err)
+	}
+	defer f.Close()
+
+	blocks, err := decodePEMBlocks(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode intermediate chain: %s", err)
+	}
+
+	var intermediates []Intermediate
+	for _, block := range blocks {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse intermediate certificate: %s", err)
+		}
+
+		i := Intermediate{Certificate: cert}
+		if retire, ok := block.Headers[retireHeader]; ok {
+			i.Retire, err = time.Parse(time.RFC3339, retire)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse %s for %s: %s", retireHeader, cert.Subject.CommonName, err)
+			}
+		}
+
+		intermediates = append(intermediates, i)
+	}
+
+	return intermediates, nil
+}
+
+// LoadTrustedIntermediates loads the intermediate CA certificates that are currently trusted
+func LoadTrustedIntermediates() ([]*x509.Certificate, error) {
+	intermediates, err := LoadIntermediates()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var trusted []*x509.Certificate
+	for _, i := range intermediates {
+		if i.Trusted(now) {
+			trusted = append(trusted, i.Certificate)
+		}
+	}
+
+	return trusted, nil
+}
+
+// IntermediatePool holds the intermediate CA certificates client certificates are verified through.
+// Each is only trusted until its Trusted-Until, and Reload picks up rotations without a restart.
+type IntermediatePool struct {
+	mu            sync.RWMutex
+	intermediates []Intermediate
+}
+
+// NewIntermediatePool creates an IntermediatePool of the intermediates in the bundle
+func NewIntermediatePool() (*IntermediatePool, error) {
+	p := &IntermediatePool{}
+	return p, p.Reload()
+}
+
+// Reload reloads the intermediates from the bundle, keeping those loaded before if it can not be read
+func (p *IntermediatePool) Reload() error {
+	intermediates, err := LoadIntermediates()
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.intermediates = intermediates
+	p.mu.Unlock()
+
+	return nil
+}
+
+// Trusted returns the pool of the intermediates trusted at the given time
+func (p *IntermediatePool) Trusted(t time.Time) *x509.CertPool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	pool := x509.NewCertPool()
+	for _, i := range p.intermediates {
+		if i.Trusted(t) {
+			pool.AddCert(i.Certificate)
+		}
+	}
+
+	return pool
+}
+
+// LoadChain loads the CA chain for cert, from the intermediate that issued it to the root
+func LoadChain(cert *x509.Certificate) ([]*x509.Certificate, error) {
+	root, err := LoadCACert()
+	if err != nil {
+		return nil, err
+	}
+
+	intermediates, err := LoadIntermediates()
+	if err != nil {
+		return nil, err
+	}
+
+	var chain []*x509.Certificate
+	for _, i := range intermediates {
+		if cert.CheckSignatureFrom(i.Certificate) == nil {
+			chain = append(chain, i.Certificate)
+			break
+		}
+	}
+
+	return append(chain, root), nil
+}
+
+// SaveOCSPSigner saves the delegated OCSP signing certificate for the issuer to the filesystem
+func SaveOCSPSigner(issuer *x509.Certificate, certificate *x509.Certificate, key crypto.PrivateKey) error {
+	dir := filepath.Join("ocsp", fmt.Sprintf("%x", issuer.SubjectKeyId))
+	if err := saveCert(filepath.Join(dir, "ocsp.crt"), certificate); err != nil {
+		return fmt.Errorf("unable to save OCSP certificate: %s", err)
+	}
+
+	if err := saveKey(filepath.Join(dir, "ocsp.key"), key); err != nil {
+		return fmt.Errorf("unable to save OCSP key: %s", err)
+	}
+
+	return nil
+}
+
+// LoadOCSPSigner loads the delegated OCSP signing certificate and key for the issuer
+func LoadOCSPSigner(issuer *x509.Certificate) (*x509.Certificate, crypto.PrivateKey, error) {
+	dir := filepath.Join("ocsp", fmt.Sprintf("%x", issuer.SubjectKeyId))
+	cf, err := loadFile(filepath.Join(dir, "ocsp.crt"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not load OCSP certificate: %s", err)
+	}
+
+	cert, err := loadCert(cf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load OCSP certificate: %s", err)
+	}
+
+	kf, err := loadFile(filepath.Join(dir, "ocsp.key"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not load OCSP key: %s", err)
+	}
+
+	key, err := loadKey(kf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load OCSP private key: %s", err)
 	}
 
 	return cert, key, nil
@@ -64,6 +324,31 @@ func SaveClientCert(email string, c *x509.Certificate, k *rsa.PrivateKey) error
 	return saveKey(keyFile, k)
 }
 
+// LoadClientCert loads a client Certificate and key from the filesystem
+func LoadClientCert(email string) (*x509.Certificate, crypto.PrivateKey, error) {
+	cf, err := loadFile(filepath.Join("users", email, "user.crt"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load client certificate for %s: %s", email, err)
+	}
+
+	cert, err := loadCert(cf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load client certificate for %s: %s", email, err)
+	}
+
+	kf, err := loadFile(filepath.Join("users", email, "user.key"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load client key for %s: %s", email, err)
+	}
+
+	key, err := loadKey(kf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load client key for %s: %s", email, err)
+	}
+
+	return cert, key, nil
+}
+
 // SaveCert saves the certificate data to the file system
 func SaveCert(name string, certificate *x509.Certificate) error {
 	certFile := filepath.Join("nodes", name, "node.crt")
@@ -167,6 +452,30 @@ func decodePEMBlock(f io.Reader) (*pem.Block, error) {
 	return block, err
 }
 
+func decodePEMBlocks(f io.Reader) ([]*pem.Block, error) {
+	certBytes, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read certificate file: %s", err)
+	}
+
+	var blocks []*pem.Block
+	for {
+		var block *pem.Block
+		block, certBytes = pem.Decode(certBytes)
+		if block == nil {
+			break
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("no PEM blocks found")
+	}
+
+	return blocks, nil
+}
+
 func ensureFile(filename string) (*os.File, error) {
 	cfg, err := Load()
 	if err != nil {
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/unerror/waffy/pkg/crypto"
+)
+
+func TestIntermediatePool(t *testing.T) {
+	root, rootKey, err := crypto.NewCertificateAuthority(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	retired, retiredKey, err := crypto.NewIntermediateCA(root, rootKey, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	active, _, err := crypto.NewIntermediateCA(root, rootKey, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, _ := crypto.NewPrivateKey(1024)
+	leaf, err := crypto.NewCertificate(retired, retiredKey, key, false, "user@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+
+	now := time.Now()
+	p := &IntermediatePool{intermediates: []Intermediate{
+		{Certificate: retired, Retire: now.Add(time.Hour)},
+		{Certificate: active},
+	}}
+
+	Convey("A retired intermediate should be trusted until its Trusted-Until", t, func() {
+		So(crypto.Verify(leaf, roots, p.Trusted(now), x509.ExtKeyUsageClientAuth), ShouldBeNil)
+	})
+
+	Convey("A retired intermediate should not be trusted after its Trusted-Until", t, func() {
+		So(crypto.Verify(leaf, roots, p.Trusted(now.Add(2*time.Hour)), x509.ExtKeyUsageClientAuth), ShouldNotBeNil)
+	})
+
+	Convey("An intermediate should not be trusted after it expires", t, func() {
+		So(p.Trusted(active.NotAfter.Add(time.Second)).Subjects(), ShouldBeEmpty)
+	})
+}
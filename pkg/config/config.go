@@ -26,6 +26,15 @@ const (
 
 	// DefaultRaftListen is the default Raft listen address
 	DefaultRaftListen = "127.0.0.1:8501"
+
+	// DefaultOCSPListen is the default listen address for the OCSP responder
+	DefaultOCSPListen = "0.0.0.0:8502"
+
+	// DefaultACMEDirectory is the default ACME v2 directory URL Site certificates are issued from
+	DefaultACMEDirectory = "https://acme-v02.api.letsencrypt.org/directory"
+
+	// DefaultACMEChallenge is the default ACME challenge type used to validate Site hostnames
+	DefaultACMEChallenge = "http-01"
 )
 
 // Version is the version of the software
@@ -53,13 +62,30 @@ type Config struct {
 
 	// RaftListen is the listen address of the Raft consensus
 	RaftListen string
+
+	// OCSPListen is the listen address of the OCSP responder
+	OCSPListen string
+
+	// ACMEDirectory is the ACME v2 directory URL Site certificates are issued from
+	ACMEDirectory string
+
+	// ACMEEmail is the contact email of the ACME account
+	ACMEEmail string
+
+	// ACMECA is the path to PEM encoded CA certificates to trust for the ACME directory, e.g. for
+	// testing against a local Pebble server
+	ACMECA string
+
+	// ACMEChallenge is the ACME challenge type used to validate Site hostnames, http-01 or tls-alpn-01
+	ACMEChallenge string
 }
 
 var cfg *Config
 
 func init() {
+	// a missing .env leaves the environment and defaults, so packages can be tested without one
 	c, err := godotenv.Read()
-	if err != nil {
+	if err != nil && !os.IsNotExist(err) {
 		panic("cannot read configuration environment")
 	}
 
@@ -70,6 +96,12 @@ func init() {
 		RPCName:    getEnv("WAFFY_RPC_NAME", c, DefaultRPCName),
 		RaftDIR:    getEnv("WAFFY_RAFT_DIR", c, DefaultRaftDIR),
 		RaftListen: getEnv("WAFFY_RAFT_LISTEN", c, DefaultRaftListen),
+		OCSPListen: getEnv("WAFFY_OCSP_LISTEN", c, DefaultOCSPListen),
+
+		ACMEDirectory: getEnv("WAFFY_ACME_DIRECTORY", c, DefaultACMEDirectory),
+		ACMEEmail:     getEnv("WAFFY_ACME_EMAIL", c, ""),
+		ACMECA:        getEnv("WAFFY_ACME_CA", c, ""),
+		ACMEChallenge: getEnv("WAFFY_ACME_CHALLENGE", c, DefaultACMEChallenge),
 
 		Version: Version,
 	}
@@ -0,0 +1,38 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// EncodePKCS12 encodes the key and certificate, along with its CA chain, as a password protected
+// PKCS#12 bundle that can be imported into browsers and key stores
+func EncodePKCS12(key crypto.PrivateKey, cert *x509.Certificate, chain []*x509.Certificate, password string) ([]byte, error) {
+	if password == "" {
+		return nil, fmt.Errorf("a password is required for PKCS#12 bundles")
+	}
+
+	p12, err := pkcs12.Encode(rand.Reader, key, cert, chain, password)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode PKCS#12 bundle: %s", err)
+	}
+
+	return p12, nil
+}
+
+// EncodePEMBundle encodes the key and certificate, along with its CA chain, as a single PEM file
+func EncodePEMBundle(key crypto.PrivateKey, cert *x509.Certificate, chain []*x509.Certificate) []byte {
+	var b bytes.Buffer
+	b.Write(EncodePEM(key))
+	b.Write(EncodePEM(cert))
+	for _, c := range chain {
+		b.Write(EncodePEM(c))
+	}
+
+	return b.Bytes()
+}
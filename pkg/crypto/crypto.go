@@ -6,13 +6,23 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
-	"math/big"
 	"time"
 )
 
 const (
 	// DefaultExpiryTime is the default time for CertificatesV
 	DefaultExpiryTime = time.Hour * 24 * 365 * 2 // 2 year
+	// DefaultIntermediateExpiryTime is the default time for intermediate CA Certificates
+	DefaultIntermediateExpiryTime = time.Hour * 24 * 365 * 5 // 5 year
+	// DefaultRootExpiryTime is the default time for the root CA Certificate
+	DefaultRootExpiryTime = time.Hour * 24 * 365 * 20 // 20 year
+
+	// Organization is the organization name used in the subject of CA Certificates
+	Organization = "waffy"
+	// RootCommonName is the common name of the root CA Certificate
+	RootCommonName = "waffy Root CA"
+	// IntermediateCommonName is the common name prefix of intermediate CA Certificates
+	IntermediateCommonName = "waffy Intermediate CA"
 
 	hostKeyUsage = x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageKeyAgreement
 )
@@ -26,41 +36,70 @@ func NewCertificate(
 	commonName string,
 	alt ...string,
 ) (*x509.Certificate, error) {
-	rsaKey, subjectID, err := keyAndSubjectID(signee)
+	rsaKey, _, err := keyAndSubjectID(signee)
 	if err != nil {
 		return nil, err
 	}
 
-	serialLim := new(big.Int).Lsh(big.NewInt(1), 128)
-	serial, err := rand.Int(rand.Reader, serialLim)
+	subject := Subject{Name: pkix.Name{CommonName: commonName}}
+	if server {
+		subject.DNSNames = alt
+	} else {
+		subject.EmailAddresses = alt
+	}
+
+	return NewSubjectCertificate(ca, signer, &rsaKey.PublicKey, server, subject)
+}
+
+// NewSubjectCertificate generates a new x509 Certificate for the public key of the signee and the
+// given Subject, signed by a given CA. The common name is always included in the subject alternative
+// names, as a DNS name or IP for servers and an email address for clients.
+func NewSubjectCertificate(
+	ca *x509.Certificate,
+	signer crypto.PrivateKey,
+	signee crypto.PublicKey,
+	server bool,
+	subject Subject,
+) (*x509.Certificate, error) {
+	subjectID, err := getSubjectKeyID(signee)
 	if err != nil {
-		return nil, fmt.Errorf("unable to generate certificate serial")
+		return nil, err
 	}
 
+	serial, err := newSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
 	var template = x509.Certificate{
 		SerialNumber: serial,
-		Subject: pkix.Name{
-			CommonName: commonName,
-		},
-		NotBefore: time.Now(),
-		NotAfter:  time.Now().Add(DefaultExpiryTime),
+		Subject:      subject.Name,
+		NotBefore:    now,
+		NotAfter:     notAfter(ca, now.Add(DefaultExpiryTime)),
 
 		KeyUsage: hostKeyUsage,
 		ExtKeyUsage: []x509.ExtKeyUsage{
 			x509.ExtKeyUsageServerAuth,
 			x509.ExtKeyUsageClientAuth,
 		},
-		SubjectKeyId: subjectID,
+		BasicConstraintsValid: true,
+		SubjectKeyId:          subjectID,
+		AuthorityKeyId:        ca.SubjectKeyId,
+
+		DNSNames:       subject.DNSNames,
+		EmailAddresses: subject.EmailAddresses,
+		IPAddresses:    subject.IPAddresses,
+		URIs:           subject.URIs,
 	}
 
 	if server {
-		template.DNSNames = alt
+		template.DNSNames, template.IPAddresses = subject.withCommonNameHost()
 	} else {
-		emails := []string{commonName}
-		template.EmailAddresses = append(emails, alt...)
+		template.EmailAddresses = subject.withCommonNameEmail()
 	}
 
-	cert, err := x509.CreateCertificate(rand.Reader, &template, ca, &rsaKey.PublicKey, signer)
+	cert, err := x509.CreateCertificate(rand.Reader, &template, ca, signee, signer)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create server cert: %s", err)
 	}
@@ -68,7 +107,7 @@ func NewCertificate(
 	return x509.ParseCertificate(cert)
 }
 
-// NewCertificateAuthority generates a new x509 certificate that can be used as a CA
+// NewCertificateAuthority generates a new self-signed x509 root certificate that can be used as a CA
 func NewCertificateAuthority(bits int) (*x509.Certificate, crypto.PrivateKey, error) {
 	privKey, err := NewPrivateKey(bits)
 	if err != nil {
@@ -82,3 +121,59 @@ func NewCertificateAuthority(bits int) (*x509.Certificate, crypto.PrivateKey, er
 
 	return ca, privKey, nil
 }
+
+// NewIntermediateCA generates a new x509 intermediate certificate, signed by the given root CA,
+// that can be used to sign leaf certificates
+func NewIntermediateCA(root *x509.Certificate, rootKey crypto.PrivateKey, bits int) (*x509.Certificate, crypto.PrivateKey, error) {
+	privKey, err := NewPrivateKey(bits)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create private key: %s", err)
+	}
+
+	ca, err := newIntermediateCA(root, rootKey, privKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create intermediate certificate authority: %s", err)
+	}
+
+	return ca, privKey, nil
+}
+
+// NewOCSPSigner generates a new delegated OCSP signing certificate, signed by the issuer CA, that
+// signs OCSP responses for the certificates the issuer has issued
+func NewOCSPSigner(issuer *x509.Certificate, issuerKey crypto.PrivateKey, bits int) (*x509.Certificate, crypto.PrivateKey, error) {
+	privKey, err := NewPrivateKey(bits)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create private key: %s", err)
+	}
+
+	cert, err := newOCSPSigner(issuer, issuerKey, privKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create OCSP signer: %s", err)
+	}
+
+	return cert, privKey, nil
+}
+
+// Verify verifies that cert chains up to one of the roots, through the intermediates, for the given usage
+func Verify(cert *x509.Certificate, roots, intermediates *x509.CertPool, usage x509.ExtKeyUsage) error {
+	_, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	})
+
+	return err
+}
+
+// Chain returns the DER encoded chain for cert, followed by the intermediate that issued it, suitable
+// for tls.Certificate
+func Chain(cert *x509.Certificate, intermediates []*x509.Certificate) [][]byte {
+	chain := [][]byte{cert.Raw}
+	for _, i := range intermediates {
+		if cert.CheckSignatureFrom(i) == nil {
+			return append(chain, i.Raw)
+		}
+	}
+
+	return chain
+}
@@ -0,0 +1,60 @@
+package crypto
+
+import (
+	"crypto/x509"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testBits = 1024
+
+func TestCertificateChain(t *testing.T) {
+	root, rootKey, err := NewCertificateAuthority(testBits)
+
+	Convey("Creating a root CA should be self-signed with a path length of 1", t, func() {
+		So(err, ShouldBeNil)
+		So(root.IsCA, ShouldBeTrue)
+		So(root.MaxPathLen, ShouldEqual, 1)
+		So(root.Subject.CommonName, ShouldEqual, RootCommonName)
+		So(root.NotAfter.After(root.NotBefore), ShouldBeTrue)
+		So(root.CheckSignatureFrom(root), ShouldBeNil)
+	})
+
+	intermediate, intermediateKey, err := NewIntermediateCA(root, rootKey, testBits)
+
+	Convey("Creating an intermediate CA should be signed by the root and unable to sign other CAs", t, func() {
+		So(err, ShouldBeNil)
+		So(intermediate.IsCA, ShouldBeTrue)
+		So(intermediate.MaxPathLen, ShouldEqual, 0)
+		So(intermediate.MaxPathLenZero, ShouldBeTrue)
+		So(intermediate.NotAfter.After(root.NotAfter), ShouldBeFalse)
+		So(intermediate.CheckSignatureFrom(root), ShouldBeNil)
+	})
+
+	Convey("A leaf signed by the intermediate should verify through the chain", t, func() {
+		key, err := NewPrivateKey(testBits)
+		So(err, ShouldBeNil)
+
+		cert, err := NewCertificate(intermediate, intermediateKey, key, true, "node1", "node1.waffy.local")
+		So(err, ShouldBeNil)
+		So(cert.IsCA, ShouldBeFalse)
+
+		roots := x509.NewCertPool()
+		roots.AddCert(root)
+		intermediates := x509.NewCertPool()
+		intermediates.AddCert(intermediate)
+
+		So(Verify(cert, roots, intermediates, x509.ExtKeyUsageServerAuth), ShouldBeNil)
+
+		Convey("Without the intermediate it should not verify", func() {
+			So(Verify(cert, roots, x509.NewCertPool(), x509.ExtKeyUsageServerAuth), ShouldNotBeNil)
+		})
+
+		Convey("The chain should include the issuing intermediate", func() {
+			chain := Chain(cert, []*x509.Certificate{root, intermediate})
+			So(chain, ShouldHaveLength, 2)
+			So(chain[1], ShouldResemble, intermediate.Raw)
+		})
+	})
+}
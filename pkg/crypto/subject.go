@@ -0,0 +1,128 @@
+package crypto
+
+import (
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// Subject is the identity of a certificate, along with its subject alternative names
+type Subject struct {
+	pkix.Name
+
+	DNSNames       []string
+	EmailAddresses []string
+	IPAddresses    []net.IP
+	URIs           []*url.URL
+}
+
+// AddAltNames parses and adds DNS, IP and URI subject alternative names to the Subject. A DNS name
+// must be a hostname, or a wildcard of one, and no name may be added twice.
+func (s *Subject) AddAltNames(dnsNames, ips, uris []string) error {
+	for _, n := range dnsNames {
+		if !validDNSName(n) {
+			return fmt.Errorf("invalid DNS name %s", n)
+		}
+
+		for _, d := range s.DNSNames {
+			if strings.EqualFold(d, n) {
+				return fmt.Errorf("DNS name %s is given more than once", n)
+			}
+		}
+		s.DNSNames = append(s.DNSNames, n)
+	}
+
+	for _, i := range ips {
+		ip := net.ParseIP(i)
+		if ip == nil {
+			return fmt.Errorf("invalid IP address %s", i)
+		}
+
+		for _, a := range s.IPAddresses {
+			if a.Equal(ip) {
+				return fmt.Errorf("IP address %s is given more than once", i)
+			}
+		}
+		s.IPAddresses = append(s.IPAddresses, ip)
+	}
+
+	for _, u := range uris {
+		uri, err := url.Parse(u)
+		if err != nil || uri.Scheme == "" {
+			return fmt.Errorf("invalid URI %s", u)
+		}
+
+		for _, a := range s.URIs {
+			if a.String() == uri.String() {
+				return fmt.Errorf("URI %s is given more than once", u)
+			}
+		}
+		s.URIs = append(s.URIs, uri)
+	}
+
+	return nil
+}
+
+// validDNSName returns if the name is a hostname of letters, digits and hyphens, that may start with
+// a wildcard label
+func validDNSName(name string) bool {
+	if len(name) > 253 {
+		return false
+	}
+
+	labels := strings.Split(strings.TrimPrefix(name, "*."), ".")
+	if len(labels) < 2 && strings.HasPrefix(name, "*.") {
+		return false
+	}
+
+	for _, l := range labels {
+		if len(l) == 0 || len(l) > 63 || l[0] == '-' || l[len(l)-1] == '-' {
+			return false
+		}
+
+		for _, c := range l {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// withCommonNameHost returns the DNS and IP names, including the common name as whichever fits
+func (s Subject) withCommonNameHost() ([]string, []net.IP) {
+	dnsNames, ips := s.DNSNames, s.IPAddresses
+	if s.CommonName == "" {
+		return dnsNames, ips
+	}
+
+	if ip := net.ParseIP(s.CommonName); ip != nil {
+		for _, i := range ips {
+			if i.Equal(ip) {
+				return dnsNames, ips
+			}
+		}
+		return dnsNames, append([]net.IP{ip}, ips...)
+	}
+
+	for _, n := range dnsNames {
+		if n == s.CommonName {
+			return dnsNames, ips
+		}
+	}
+	return append([]string{s.CommonName}, dnsNames...), ips
+}
+
+// withCommonNameEmail returns the email addresses, including the common name
+func (s Subject) withCommonNameEmail() []string {
+	for _, e := range s.EmailAddresses {
+		if e == s.CommonName {
+			return s.EmailAddresses
+		}
+	}
+
+	return append([]string{s.CommonName}, s.EmailAddresses...)
+}
@@ -0,0 +1,61 @@
+package crypto
+
+import (
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAddAltNames(t *testing.T) {
+	Convey("Valid alternative names should be added to the Subject", t, func() {
+		s := Subject{}
+		So(s.AddAltNames(
+			[]string{"node1.waffy.local", "*.waffy.local", "localhost"},
+			[]string{"10.0.0.1", "::1"},
+			[]string{"spiffe://waffy/node1"},
+		), ShouldBeNil)
+
+		So(s.DNSNames, ShouldResemble, []string{"node1.waffy.local", "*.waffy.local", "localhost"})
+		So(s.IPAddresses, ShouldHaveLength, 2)
+		So(s.IPAddresses[1].Equal(net.IPv6loopback), ShouldBeTrue)
+		So(s.URIs, ShouldHaveLength, 1)
+		So(s.URIs[0].Host, ShouldEqual, "waffy")
+	})
+
+	Convey("Invalid alternative names should be refused", t, func() {
+		for _, n := range []string{"", "node 1", "node1..local", "-node1.local", "node1-.local", "*", "*.", "a.*.local", "node1.local/", "_node1.local"} {
+			So((&Subject{}).AddAltNames([]string{n}, nil, nil), ShouldNotBeNil)
+		}
+
+		for _, i := range []string{"", "10.0.0", "node1.local"} {
+			So((&Subject{}).AddAltNames(nil, []string{i}, nil), ShouldNotBeNil)
+		}
+
+		for _, u := range []string{"", "node1", "%zz://node1"} {
+			So((&Subject{}).AddAltNames(nil, nil, []string{u}), ShouldNotBeNil)
+		}
+	})
+
+	Convey("An alternative name given more than once should be refused", t, func() {
+		So((&Subject{}).AddAltNames([]string{"node1.local", "NODE1.local"}, nil, nil), ShouldNotBeNil)
+		So((&Subject{}).AddAltNames(nil, []string{"10.0.0.1", "10.0.0.1"}, nil), ShouldNotBeNil)
+		So((&Subject{}).AddAltNames(nil, []string{"::ffff:10.0.0.1", "10.0.0.1"}, nil), ShouldNotBeNil)
+		So((&Subject{}).AddAltNames(nil, nil, []string{"spiffe://waffy/node1", "spiffe://waffy/node1"}), ShouldNotBeNil)
+
+		s := Subject{DNSNames: []string{"node1.local"}}
+		So(s.AddAltNames([]string{"node1.local"}, nil, nil), ShouldNotBeNil)
+	})
+
+	Convey("The common name should be a DNS or IP name of the certificate, but not twice", t, func() {
+		s := Subject{Name: pkix.Name{CommonName: "node1.local"}, DNSNames: []string{"node1.local"}}
+		dnsNames, _ := s.withCommonNameHost()
+		So(dnsNames, ShouldResemble, []string{"node1.local"})
+
+		s = Subject{Name: pkix.Name{CommonName: "10.0.0.1"}, DNSNames: []string{"node1.local"}}
+		dnsNames, ips := s.withCommonNameHost()
+		So(dnsNames, ShouldResemble, []string{"node1.local"})
+		So(ips, ShouldHaveLength, 1)
+	})
+}
@@ -2,9 +2,11 @@ package crypto
 
 import (
 	"crypto"
+	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha1"
+	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
@@ -48,24 +50,35 @@ func EncodePEM(keydata interface{}) []byte {
 	return pem.EncodeToMemory(&block)
 }
 
-// x590CertificateAuthority generates generates a new *x590.Certificate
+// newCertificateAuthority generates a new self-signed root *x509.Certificate
 func newCertificateAuthority(key crypto.PrivateKey) (*x509.Certificate, error) {
 	rsaKey, subjectID, err := keyAndSubjectID(key)
 	if err != nil {
 		return nil, err
 	}
 
+	serial, err := newSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
 	var template = x509.Certificate{
-		SerialNumber: big.NewInt(1),
-		Subject:      pkix.Name{},
-		NotBefore:    time.Now(),
-		NotAfter:     time.Time{},
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   RootCommonName,
+			Organization: []string{Organization},
+		},
+		NotBefore: now,
+		NotAfter:  now.Add(DefaultRootExpiryTime),
 
 		KeyUsage:    caKeyUsage,
 		ExtKeyUsage: nil,
 
-		IsCA:         true,
-		SubjectKeyId: subjectID[:],
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLen:            1,
+		SubjectKeyId:          subjectID[:],
 	}
 
 	cert, err := x509.CreateCertificate(rand.Reader, &template, &template, &rsaKey.PublicKey, rsaKey)
@@ -76,14 +89,121 @@ func newCertificateAuthority(key crypto.PrivateKey) (*x509.Certificate, error) {
 	return x509.ParseCertificate(cert)
 }
 
+// newIntermediateCA generates an intermediate *x509.Certificate signed by the root, that can
+// only sign leaf certificates
+func newIntermediateCA(root *x509.Certificate, rootKey, key crypto.PrivateKey) (*x509.Certificate, error) {
+	rsaKey, subjectID, err := keyAndSubjectID(key)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := newSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var template = x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   fmt.Sprintf("%s %s", IntermediateCommonName, now.UTC().Format("2006-01-02")),
+			Organization: []string{Organization},
+		},
+		NotBefore: now,
+		NotAfter:  notAfter(root, now.Add(DefaultIntermediateExpiryTime)),
+
+		KeyUsage:    caKeyUsage,
+		ExtKeyUsage: nil,
+
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLen:            0,
+		MaxPathLenZero:        true,
+		SubjectKeyId:          subjectID,
+		AuthorityKeyId:        root.SubjectKeyId,
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, &template, root, &rsaKey.PublicKey, rootKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return x509.ParseCertificate(cert)
+}
+
+// oidOCSPNoCheck is id-pkix-ocsp-nocheck, so clients don't check the revocation of the OCSP signer
+var oidOCSPNoCheck = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 5}
+
+// newOCSPSigner generates a delegated OCSP signing *x509.Certificate signed by the issuer
+func newOCSPSigner(issuer *x509.Certificate, issuerKey, key crypto.PrivateKey) (*x509.Certificate, error) {
+	rsaKey, subjectID, err := keyAndSubjectID(key)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := newSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	// the extension value is an ASN.1 NULL
+	noCheck := pkix.Extension{Id: oidOCSPNoCheck, Value: []byte{0x05, 0x00}}
+
+	now := time.Now()
+	var template = x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   fmt.Sprintf("%s OCSP", issuer.Subject.CommonName),
+			Organization: []string{Organization},
+		},
+		NotBefore: now,
+		NotAfter:  notAfter(issuer, now.Add(DefaultExpiryTime)),
+
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageOCSPSigning},
+
+		BasicConstraintsValid: true,
+		SubjectKeyId:          subjectID,
+		AuthorityKeyId:        issuer.SubjectKeyId,
+		ExtraExtensions:       []pkix.Extension{noCheck},
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, &template, issuer, &rsaKey.PublicKey, issuerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return x509.ParseCertificate(cert)
+}
+
+// newSerial returns a random 128 bit certificate serial number
+func newSerial() (*big.Int, error) {
+	serialLim := new(big.Int).Lsh(big.NewInt(1), 128)
+	serial, err := rand.Int(rand.Reader, serialLim)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate certificate serial")
+	}
+
+	return serial, nil
+}
+
+// notAfter caps the expiry t of a certificate to the expiry of the issuer
+func notAfter(issuer *x509.Certificate, t time.Time) time.Time {
+	if issuer.NotAfter.Before(t) {
+		return issuer.NotAfter
+	}
+
+	return t
+}
+
 func keyAndSubjectID(key crypto.PrivateKey) (*rsa.PrivateKey, []byte, error) {
 	rsaKey, ok := key.(*rsa.PrivateKey)
 	if !ok {
 		return nil, nil, fmt.Errorf("unable to parse private key for generation")
 	}
 
-	subjectKeyID, err := getSubjectKeyID(rsaKey.PublicKey)
-	if err == nil {
+	subjectKeyID, err := getSubjectKeyID(&rsaKey.PublicKey)
+	if err != nil {
 		return nil, nil, fmt.Errorf("unable to parse SubjectKeyID: %s", err)
 	}
 
@@ -97,7 +217,7 @@ func getSubjectKeyID(pub crypto.PublicKey) ([]byte, error) {
 		return nil, fmt.Errorf("unable to parse public key for SubjectKeyId")
 	}
 
-	pubBytes, err := asn1.Marshal(cert)
+	pubBytes, err := asn1.Marshal(*cert)
 	if err != nil {
 		return nil, fmt.Errorf("unable to marshal key: %s", err)
 	}
@@ -105,3 +225,92 @@ func getSubjectKeyID(pub crypto.PublicKey) ([]byte, error) {
 	hash := sha1.Sum(pubBytes)
 	return hash[:], nil
 }
+
+// DecodeCertificatePEM decodes a single PEM encoded certificate
+func DecodeCertificatePEM(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// KeyType returns a description of the certificate public key, e.g. RSA-4096
+func KeyType(cert *x509.Certificate) string {
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA-%d", pub.N.BitLen())
+	case *ecdsa.PublicKey:
+		return fmt.Sprintf("ECDSA-%s", pub.Curve.Params().Name)
+	}
+
+	return cert.PublicKeyAlgorithm.String()
+}
+
+// PublicKey returns the public key for the private key
+func PublicKey(key crypto.PrivateKey) crypto.PublicKey {
+	if signer, ok := key.(crypto.Signer); ok {
+		return signer.Public()
+	}
+
+	return nil
+}
+
+// NewCertificateRequest creates a PEM encoded certificate request for the common name, signed by key
+func NewCertificateRequest(key crypto.PrivateKey, commonName string) ([]byte, error) {
+	template := x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName: commonName,
+		},
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &template, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create certificate request: %s", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE REQUEST",
+		Bytes: csr,
+	}), nil
+}
+
+// DecodePrivateKeyPEM decodes a single PEM encoded RSA private key
+func DecodePrivateKeyPEM(data []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "RSA PRIVATE KEY" {
+		return nil, fmt.Errorf("no PEM encoded private key found")
+	}
+
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+// EncodePEMChain encodes DER encoded certificates as consecutive PEM blocks
+func EncodePEMChain(chain [][]byte) []byte {
+	var out []byte
+	for _, der := range chain {
+		out = append(out, pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: der,
+		})...)
+	}
+
+	return out
+}
+
+// KeyPair parses a PEM encoded certificate chain and private key into a tls.Certificate, with the
+// leaf parsed
+func KeyPair(certPEM, keyPEM []byte) (*tls.Certificate, error) {
+	keypair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	keypair.Leaf, err = x509.ParseCertificate(keypair.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return &keypair, nil
+}
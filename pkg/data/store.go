@@ -75,4 +75,11 @@ type Consensus interface {
 
 	// Leave leaves a Raft node from the consensus
 	Leave(addr string) error
+
+	// Leader returns if this node is the consensus leader, the only node that can write
+	Leader() bool
+
+	// Weak returns a read-only view of the Bucket that reads weakly consistent values from this
+	// node, so that followers can serve reads
+	Weak() Bucket
 }
@@ -396,6 +396,10 @@ func (sm *fsm) Apply(l *raft.Log) interface{} {
 		panic(fmt.Sprintf("failed to unmarshal command data for raft log: %s", err))
 	}
 
+	// serialize with weakly consistent reads, which share the underlying store
+	sm.l.Lock()
+	defer sm.l.Unlock()
+
 	var b Bucket
 	if cmd.BucketPath != "" {
 		var err error
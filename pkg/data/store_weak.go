@@ -0,0 +1,70 @@
+package data
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/raft"
+)
+
+// errReadOnly is returned when writing to a weakly consistent view
+var errReadOnly = fmt.Errorf("unable to write to a weakly consistent view")
+
+// Leader returns if this node is the Raft leader
+func (s *Raft) Leader() bool {
+	return s.r.State() == raft.Leader
+}
+
+// Weak returns a read-only view of the Bucket, that reads weakly consistent values from the local
+// store without going through the Raft log
+func (s *Raft) Weak() Bucket {
+	return (*weakRaft)(s)
+}
+
+// weakRaft is a read-only Bucket over the local store of a Raft node
+type weakRaft Raft
+
+// Bucket returns the weak view of a child Bucket. Buckets are not created through the consensus, so
+// reads from a Bucket that does not exist yet return empty
+func (s *weakRaft) Bucket(name string) (Bucket, error) {
+	return &weakRaft{
+		s:    s.s,
+		r:    s.r,
+		l:    s.l,
+		path: fmt.Sprintf("%s%s/", s.path, name),
+	}, nil
+}
+
+// DeleteBucket is not allowed on a weak view
+func (s *weakRaft) DeleteBucket(name string) error {
+	return errReadOnly
+}
+
+// Close is a no-op, the Raft store owns the connection
+func (s *weakRaft) Close() error {
+	return nil
+}
+
+// Get returns the weakly consistent value for key k
+func (s *weakRaft) Get(k []byte) ([]byte, error) {
+	return (*Raft)(s).GetWeak(k)
+}
+
+// List returns the weakly consistent Nodes in the Bucket
+func (s *weakRaft) List() ([]Node, error) {
+	return (*Raft)(s).ListWeak()
+}
+
+// Seek finds the weakly consistent value for key k
+func (s *weakRaft) Seek(k []byte) ([]byte, error) {
+	return (*Raft)(s).SeekWeak(k)
+}
+
+// Set is not allowed on a weak view
+func (s *weakRaft) Set(n Node) error {
+	return errReadOnly
+}
+
+// Delete is not allowed on a weak view
+func (s *weakRaft) Delete(n Node) error {
+	return errReadOnly
+}
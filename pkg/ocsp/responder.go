@@ -0,0 +1,241 @@
+// Package ocsp answers OCSP requests for certificates issued by the waffy CA, and staples responses
+// to TLS certificates
+package ocsp
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/unerror/waffy/pkg/config"
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+)
+
+const (
+	// DefaultValidity is how long an OCSP response is valid for
+	DefaultValidity = time.Hour * 24
+
+	maxRequestSize = 4096
+)
+
+// signer is a delegated OCSP signing certificate for an issuing CA
+type signer struct {
+	issuer    *x509.Certificate
+	issuerKey []byte
+	cert      *x509.Certificate
+	key       *rsa.PrivateKey
+}
+
+// Responder answers OCSP requests from the certificates bucket, signing responses with the delegated
+// OCSP signer of the intermediate that issued the certificate
+type Responder struct {
+	db      data.Store
+	signers []signer
+}
+
+// NewResponder creates a Responder for the certificates issued by the intermediates. An intermediate
+// created before it had an OCSP signer gets one if it is the active intermediate, and is skipped if not.
+func NewResponder(db data.Store, intermediates []*x509.Certificate, bits int) (*Responder, error) {
+	r := &Responder{db: db}
+	for _, i := range intermediates {
+		cert, key, err := loadSigner(i, bits)
+		if err != nil {
+			log.Printf("no OCSP signer for %s: %s", i.Subject.CommonName, err)
+			continue
+		}
+
+		issuerKey, err := publicKeyBits(i)
+		if err != nil {
+			return nil, err
+		}
+
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("unable to parse OCSP signer key for %s", i.Subject.CommonName)
+		}
+
+		r.signers = append(r.signers, signer{
+			issuer:    i,
+			issuerKey: issuerKey,
+			cert:      cert,
+			key:       rsaKey,
+		})
+	}
+
+	return r, nil
+}
+
+// loadSigner loads the OCSP signer for the intermediate, issuing one for the active intermediate if
+// it has none
+func loadSigner(intermediate *x509.Certificate, bits int) (*x509.Certificate, interface{}, error) {
+	cert, key, err := config.LoadOCSPSigner(intermediate)
+	if err == nil {
+		return cert, key, nil
+	}
+
+	active, activeKey, activeErr := config.LoadIntermediate()
+	if activeErr != nil || !active.Equal(intermediate) {
+		return nil, nil, err
+	}
+
+	cert, key, err = crypto.NewOCSPSigner(active, activeKey, bits)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cert, key, config.SaveOCSPSigner(active, cert, key)
+}
+
+// Respond returns the signed OCSP response for a DER encoded OCSP request
+func (r *Responder) Respond(der []byte) ([]byte, error) {
+	req, err := ocsp.ParseRequest(der)
+	if err != nil {
+		return ocsp.MalformedRequestErrorResponse, nil
+	}
+
+	s := r.signerFor(req)
+	if s == nil {
+		return ocsp.UnauthorizedErrorResponse, nil
+	}
+
+	return r.respond(s, req.SerialNumber)
+}
+
+// RespondSerial returns the signed OCSP response for the certificate with the given serial number
+func (r *Responder) RespondSerial(serial *big.Int) ([]byte, error) {
+	c, err := repository.FindCertificateBySerial(r.db, serial.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("certificate %x not found", serial.Bytes())
+	}
+
+	cert, err := crypto.DecodeCertificatePEM(c.Certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range r.signers {
+		if cert.CheckSignatureFrom(r.signers[i].issuer) == nil {
+			return r.respond(&r.signers[i], serial)
+		}
+	}
+
+	return nil, fmt.Errorf("certificate %x was not issued by a trusted intermediate", serial.Bytes())
+}
+
+// respond builds and signs the OCSP response for serial, as good, revoked or unknown
+func (r *Responder) respond(s *signer, serial *big.Int) ([]byte, error) {
+	now := time.Now().Truncate(time.Minute)
+	template := ocsp.Response{
+		Status:       ocsp.Unknown,
+		SerialNumber: serial,
+		ThisUpdate:   now,
+		NextUpdate:   now.Add(DefaultValidity),
+		Certificate:  s.cert,
+	}
+
+	c, err := repository.FindCertificateBySerial(r.db, serial.Bytes())
+	if err == nil {
+		cert, err := crypto.DecodeCertificatePEM(c.Certificate)
+		if err == nil && cert.CheckSignatureFrom(s.issuer) == nil {
+			template.Status = ocsp.Good
+			if c.RevokedAt != 0 {
+				template.Status = ocsp.Revoked
+				template.RevokedAt = time.Unix(c.RevokedAt, 0)
+				template.RevocationReason = int(c.RevocationReason)
+			}
+		}
+	}
+
+	return ocsp.CreateResponse(s.issuer, s.cert, template, s.key)
+}
+
+// signerFor finds the signer for the issuer of the certificate in the request
+func (r *Responder) signerFor(req *ocsp.Request) *signer {
+	if !req.HashAlgorithm.Available() {
+		return nil
+	}
+
+	for i := range r.signers {
+		h := req.HashAlgorithm.New()
+		h.Write(r.signers[i].issuerKey)
+		if bytes.Equal(req.IssuerKeyHash, h.Sum(nil)) {
+			return &r.signers[i]
+		}
+	}
+
+	return nil
+}
+
+// ServeHTTP serves OCSP requests over HTTP, as a POST body, base64 encoded in a GET path (RFC 6960
+// Appendix A), or by hex encoded serial number as a GET to /serial/<serial>
+func (r *Responder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var (
+		resp []byte
+		err  error
+	)
+
+	switch {
+	case req.Method == http.MethodPost:
+		var der []byte
+		der, err = ioutil.ReadAll(http.MaxBytesReader(w, req.Body, maxRequestSize))
+		if err == nil {
+			resp, err = r.Respond(der)
+		}
+	case req.Method == http.MethodGet && strings.HasPrefix(req.URL.Path, "/serial/"):
+		serial, ok := new(big.Int).SetString(strings.TrimPrefix(req.URL.Path, "/serial/"), 16)
+		if !ok {
+			http.Error(w, "invalid serial number", http.StatusBadRequest)
+			return
+		}
+		resp, err = r.RespondSerial(serial)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+	case req.Method == http.MethodGet:
+		var der []byte
+		der, err = base64.StdEncoding.DecodeString(strings.TrimPrefix(req.URL.Path, "/"))
+		if err != nil {
+			resp, err = ocsp.MalformedRequestErrorResponse, nil
+			break
+		}
+		resp, err = r.Respond(der)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err != nil {
+		log.Printf("unable to respond to OCSP request: %s", err)
+		resp = ocsp.InternalErrorErrorResponse
+	}
+
+	w.Header().Set("Content-Type", "application/ocsp-response")
+	w.Write(resp)
+}
+
+// publicKeyBits returns the issuer's public key bits, which OCSP requests identify the issuer by a hash of
+func publicKeyBits(issuer *x509.Certificate) ([]byte, error) {
+	var spki struct {
+		Algorithm asn1.RawValue
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(issuer.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return nil, fmt.Errorf("unable to parse public key of %s: %s", issuer.Subject.CommonName, err)
+	}
+
+	return spki.PublicKey.RightAlign(), nil
+}
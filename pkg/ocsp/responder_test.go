@@ -0,0 +1,155 @@
+package ocsp
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+)
+
+const testBits = 1024
+
+func TestResponder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "waffy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := data.NewDB(filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	root, rootKey, _ := crypto.NewCertificateAuthority(testBits)
+	intermediate, intermediateKey, _ := crypto.NewIntermediateCA(root, rootKey, testBits)
+	other, otherKey, _ := crypto.NewIntermediateCA(root, rootKey, testBits)
+
+	signerCert, signerKey, err := crypto.NewOCSPSigner(intermediate, intermediateKey, testBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuerKey, err := publicKeyBits(intermediate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Responder{db: db, signers: []signer{{
+		issuer:    intermediate,
+		issuerKey: issuerKey,
+		cert:      signerCert,
+		key:       signerKey.(*rsa.PrivateKey),
+	}}}
+
+	issue := func(ca *x509.Certificate, caKey interface{}, name string, store bool) *x509.Certificate {
+		key, _ := crypto.NewPrivateKey(testBits)
+		cert, err := crypto.NewCertificate(ca, caKey, key, false, name)
+		So(err, ShouldBeNil)
+		if store {
+			So(repository.CreateCertificate(db, repository.NewCertificate(cert)), ShouldBeNil)
+		}
+		return cert
+	}
+
+	// respond asks the responder about cert, as issued by issuer, and parses its answer
+	respond := func(cert, issuer *x509.Certificate) *ocsp.Response {
+		req, err := ocsp.CreateRequest(cert, issuer, nil)
+		So(err, ShouldBeNil)
+
+		der, err := r.Respond(req)
+		So(err, ShouldBeNil)
+
+		resp, err := ocsp.ParseResponseForCert(der, cert, intermediate)
+		So(err, ShouldBeNil)
+		return resp
+	}
+
+	Convey("A stored certificate should be good, signed by the delegated signer", t, func() {
+		cert := issue(intermediate, intermediateKey, "good@example.com", true)
+
+		resp := respond(cert, intermediate)
+		So(resp.Status, ShouldEqual, ocsp.Good)
+		So(resp.SerialNumber.Cmp(cert.SerialNumber), ShouldEqual, 0)
+		So(resp.Certificate.Equal(signerCert), ShouldBeTrue)
+		So(resp.NextUpdate.Sub(resp.ThisUpdate), ShouldEqual, DefaultValidity)
+	})
+
+	Convey("A revoked certificate should be revoked with its reason", t, func() {
+		cert := issue(intermediate, intermediateKey, "revoked@example.com", true)
+		_, err := repository.RevokeCertificate(db, cert.SerialNumber.Bytes(), ocsp.KeyCompromise)
+		So(err, ShouldBeNil)
+
+		resp := respond(cert, intermediate)
+		So(resp.Status, ShouldEqual, ocsp.Revoked)
+		So(resp.RevocationReason, ShouldEqual, ocsp.KeyCompromise)
+		So(resp.RevokedAt.IsZero(), ShouldBeFalse)
+	})
+
+	Convey("A certificate that is not stored should be unknown", t, func() {
+		cert := issue(intermediate, intermediateKey, "unknown@example.com", false)
+
+		So(respond(cert, intermediate).Status, ShouldEqual, ocsp.Unknown)
+	})
+
+	Convey("A request for an issuer without a signer should be unauthorized", t, func() {
+		cert := issue(other, otherKey, "other@example.com", true)
+		req, err := ocsp.CreateRequest(cert, other, nil)
+		So(err, ShouldBeNil)
+
+		der, err := r.Respond(req)
+		So(err, ShouldBeNil)
+		So(der, ShouldResemble, ocsp.UnauthorizedErrorResponse)
+	})
+
+	Convey("A malformed request should get a malformed request response", t, func() {
+		der, err := r.Respond([]byte("not ocsp"))
+		So(err, ShouldBeNil)
+		So(der, ShouldResemble, ocsp.MalformedRequestErrorResponse)
+	})
+
+	Convey("Serving over HTTP", t, func() {
+		cert := issue(intermediate, intermediateKey, "http@example.com", true)
+
+		Convey("A POST should be answered for the certificate", func() {
+			req, err := ocsp.CreateRequest(cert, intermediate, nil)
+			So(err, ShouldBeNil)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(req)))
+			So(w.Header().Get("Content-Type"), ShouldEqual, "application/ocsp-response")
+
+			resp, err := ocsp.ParseResponseForCert(w.Body.Bytes(), cert, intermediate)
+			So(err, ShouldBeNil)
+			So(resp.Status, ShouldEqual, ocsp.Good)
+		})
+
+		Convey("A GET by serial should be answered for the certificate", func() {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/serial/"+cert.SerialNumber.Text(16), nil))
+
+			resp, err := ocsp.ParseResponseForCert(w.Body.Bytes(), cert, intermediate)
+			So(err, ShouldBeNil)
+			So(resp.Status, ShouldEqual, ocsp.Good)
+		})
+
+		Convey("A GET by the serial of no stored certificate should not be found", func() {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/serial/"+big.NewInt(42).Text(16), nil))
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
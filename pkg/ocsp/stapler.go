@@ -0,0 +1,100 @@
+package ocsp
+
+import (
+	"crypto/tls"
+	"log"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// StapleFunc returns the DER encoded OCSP response for a certificate
+type StapleFunc func(keypair *tls.Certificate) ([]byte, error)
+
+// Stapler keeps the OCSP response stapled to a keypair fresh, and serves it for TLS handshakes
+type Stapler struct {
+	staple StapleFunc
+
+	mu      sync.RWMutex
+	keypair tls.Certificate
+	next    time.Time
+}
+
+// NewStapler creates a Stapler for the keypair, stapling the first response straight away
+func NewStapler(keypair tls.Certificate, staple StapleFunc) *Stapler {
+	s := &Stapler{
+		staple:  staple,
+		keypair: keypair,
+	}
+
+	if err := s.refresh(); err != nil {
+		log.Printf("unable to staple OCSP response: %s", err)
+	}
+
+	return s
+}
+
+// GetCertificate returns the stapled keypair, for tls.Config
+func (s *Stapler) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keypair := s.keypair
+	return &keypair, nil
+}
+
+// Run refreshes the staple half way through the validity of each response, until stop is closed
+func (s *Stapler) Run(stop <-chan struct{}) {
+	for {
+		s.mu.RLock()
+		wait := time.Until(s.next)
+		s.mu.RUnlock()
+
+		if wait < time.Minute {
+			wait = time.Minute
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(wait):
+			if err := s.refresh(); err != nil {
+				log.Printf("unable to refresh OCSP staple: %s", err)
+			}
+		}
+	}
+}
+
+// refresh staples a new OCSP response to the keypair
+func (s *Stapler) refresh() error {
+	s.mu.RLock()
+	keypair := s.keypair
+	s.mu.RUnlock()
+
+	staple, err := s.staple(&keypair)
+	if err != nil {
+		return err
+	}
+
+	resp, err := ocsp.ParseResponse(staple, nil)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keypair.OCSPStaple = staple
+	s.next = resp.ThisUpdate.Add(resp.NextUpdate.Sub(resp.ThisUpdate) / 2)
+
+	return nil
+}
+
+// StapleLocal returns a StapleFunc that answers from the Responder directly, for waffyd's own
+// node certificate
+func (r *Responder) StapleLocal() StapleFunc {
+	return func(keypair *tls.Certificate) ([]byte, error) {
+		return r.RespondSerial(keypair.Leaf.SerialNumber)
+	}
+}
@@ -0,0 +1,139 @@
+package proxy
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// cidrs is a list of CIDRs
+type cidrs []*net.IPNet
+
+// newCIDRs parses the CIDRs, or single IP addresses
+func newCIDRs(list []string) (cidrs, error) {
+	var c cidrs
+	for _, s := range list {
+		n, err := ParseCIDR(s)
+		if err != nil {
+			return nil, err
+		}
+		c = append(c, n)
+	}
+
+	return c, nil
+}
+
+// addrIP returns the IP of a TCP or UDP address, or nil for other addresses
+func addrIP(addr net.Addr) net.IP {
+	switch addr := addr.(type) {
+	case *net.TCPAddr:
+		return addr.IP
+	case *net.UDPAddr:
+		return addr.IP
+	}
+
+	return nil
+}
+
+// contains returns if the IP is in one of the CIDRs
+func (c cidrs) contains(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range c {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// acl is the allow and deny CIDRs of a Balancer
+type acl struct {
+	allow, deny cidrs
+}
+
+// newACL creates the acl for the allow and deny CIDRs of a Balancer
+func newACL(allow, deny []string) (*acl, error) {
+	a := &acl{}
+
+	var err error
+	if a.allow, err = newCIDRs(allow); err != nil {
+		return nil, err
+	}
+	if a.deny, err = newCIDRs(deny); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// ParseCIDR parses a CIDR, or a single IP address as the CIDR of that address alone
+func ParseCIDR(s string) (*net.IPNet, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid address %s", s)
+		}
+
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 8*net.IPv4len
+		}
+
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR %s: %s", s, err)
+	}
+
+	return n, nil
+}
+
+// permits returns if a client can connect from the IP. Denied CIDRs win over allowed CIDRs, and
+// every IP is allowed if there are none.
+func (a *acl) permits(ip net.IP) bool {
+	if a.deny.contains(ip) {
+		return false
+	}
+
+	return len(a.allow) == 0 || a.allow.contains(ip)
+}
+
+// clientListener resolves the clients of the connections it accepts for the Balancer on its port,
+// from the PROXY protocol header of those from trusted load balancers, and closes the connections of
+// clients the ACL of the Balancer does not permit
+type clientListener struct {
+	net.Listener
+
+	p    *Proxy
+	port string
+}
+
+func (l *clientListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		b := l.p.balancer(l.port)
+		switch {
+		case b == nil:
+			return conn, nil
+		case b.proxyFrom.contains(addrIP(conn.RemoteAddr())):
+			// the header is read by the goroutine serving the connection, so a slow load balancer
+			// does not hold up the others
+			return &proxyConn{Conn: conn, r: bufio.NewReader(conn), acl: b.acl}, nil
+		case b.acl.permits(addrIP(conn.RemoteAddr())):
+			return conn, nil
+		}
+
+		conn.Close()
+	}
+}
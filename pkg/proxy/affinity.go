@@ -0,0 +1,142 @@
+package proxy
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/unerror/waffy/pkg/repository"
+)
+
+const (
+	// AffinityCookie is the name of the affinity cookie of Sites that do not name one
+	AffinityCookie = "waffy_affinity"
+
+	// affinityKeySize is the size of the HMAC key affinity cookies are signed with
+	affinityKeySize = 32
+)
+
+// loadAffinityKey loads the key affinity cookies are signed with, creating it if this node is the
+// leader and there is none yet. Until a node has the key it does not pin clients.
+func (p *Proxy) loadAffinityKey() {
+	p.mu.RLock()
+	loaded := p.affinityKey != nil
+	p.mu.RUnlock()
+	if loaded {
+		return
+	}
+
+	key, err := repository.FindAffinityKey(p.db.Weak())
+	if err == nil {
+		p.mu.Lock()
+		p.affinityKey = key
+		p.mu.Unlock()
+		return
+	}
+
+	if !p.db.Leader() {
+		return
+	}
+
+	key = make([]byte, affinityKeySize)
+	if _, err := rand.Read(key); err != nil {
+		log.Printf("unable to generate affinity key: %s", err)
+		return
+	}
+
+	if err := repository.CreateAffinityKey(p.db, key); err != nil {
+		log.Printf("unable to save affinity key: %s", err)
+		return
+	}
+
+	p.mu.Lock()
+	p.affinityKey = key
+	p.mu.Unlock()
+}
+
+// pinned returns the backend of the upstream that the affinity cookie of the request pins it to, or
+// nil if the cookie is missing or invalid, or the backend is no longer available
+func (p *Proxy) pinned(ctx *fasthttp.RequestCtx, s *site, u *upstream) *backend {
+	cookie := ctx.Request.Header.Cookie(affinityCookie(s))
+	i := bytes.LastIndexByte(cookie, '.')
+	if i < 0 {
+		return nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(string(cookie[:i]))
+	if err != nil {
+		return nil
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(string(cookie[i+1:]))
+	if err != nil || !hmac.Equal(mac, p.sign(s.Hostname, payload)) {
+		return nil
+	}
+
+	parts := strings.SplitN(string(payload), "\x00", 2)
+	if len(parts) != 2 || parts[0] != u.Name {
+		return nil
+	}
+
+	for _, be := range u.backends {
+		if be.client.Addr == parts[1] && be.health.available() {
+			return be
+		}
+	}
+
+	return nil
+}
+
+// pin sets the affinity cookie on the response, pinning the client to the backend of the upstream
+func (p *Proxy) pin(ctx *fasthttp.RequestCtx, s *site, u *upstream, be *backend) {
+	payload := []byte(u.Name + "\x00" + be.client.Addr)
+	mac := p.sign(s.Hostname, payload)
+	if mac == nil {
+		return
+	}
+
+	c := fasthttp.AcquireCookie()
+	defer fasthttp.ReleaseCookie(c)
+
+	c.SetKey(affinityCookie(s))
+	c.SetValue(base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(mac))
+	c.SetPath("/")
+	c.SetHTTPOnly(true)
+	if s.affinity.Ttl > 0 {
+		c.SetExpire(time.Now().Add(time.Duration(s.affinity.Ttl) * time.Second))
+	}
+	ctx.Response.Header.SetCookie(c)
+}
+
+// sign returns the HMAC of the affinity payload for the Site hostname, or nil if this node does not
+// have the key yet. The hostname is signed so a cookie can not be replayed against another Site.
+func (p *Proxy) sign(hostname string, payload []byte) []byte {
+	p.mu.RLock()
+	key := p.affinityKey
+	p.mu.RUnlock()
+
+	if key == nil {
+		return nil
+	}
+
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(strings.ToLower(hostname)))
+	h.Write([]byte{0})
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// affinityCookie returns the name of the affinity cookie of the site
+func affinityCookie(s *site) string {
+	if s.affinity.Cookie == "" {
+		return AffinityCookie
+	}
+
+	return s.affinity.Cookie
+}
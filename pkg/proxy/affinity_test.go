@@ -0,0 +1,162 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/base64"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/valyala/fasthttp"
+
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// pinCookie returns the affinity cookie the proxy pins a client of the site to the backend with
+func pinCookie(p *Proxy, s *site, u *upstream, be *backend) string {
+	ctx := &fasthttp.RequestCtx{}
+	p.pin(ctx, s, u, be)
+
+	c := fasthttp.AcquireCookie()
+	defer fasthttp.ReleaseCookie(c)
+	c.SetKey(affinityCookie(s))
+	So(ctx.Response.Header.Cookie(c), ShouldBeTrue)
+	return string(c.Value())
+}
+
+// withCookie returns a request to the site with the affinity cookie
+func withCookie(s *site, value string) *fasthttp.RequestCtx {
+	ctx := testRequest("GET", "http://"+s.Hostname+"/")
+	ctx.Request.Header.SetCookie(affinityCookie(s), value)
+	return ctx
+}
+
+func TestAffinity(t *testing.T) {
+	Convey("With a Site with affinity", t, func() {
+		p := &Proxy{affinityKey: bytes.Repeat([]byte{1}, affinityKeySize)}
+		backends := testBackends(1, 1, 1)
+		u := &upstream{Upstream: &sites.Upstream{Name: "web"}, backends: backends}
+		s := &site{Site: &sites.Site{Hostname: "example.com"}, affinity: &sites.Affinity{Ttl: 60}}
+
+		Convey("A client should be pinned to the backend its cookie names", func() {
+			for _, be := range backends {
+				So(p.pinned(withCookie(s, pinCookie(p, s, u, be)), s, u), ShouldEqual, be)
+			}
+		})
+
+		Convey("An affinity cookie should be named by its Site, and expire after its TTL", func() {
+			named := &site{Site: &sites.Site{Hostname: "example.com"}, affinity: &sites.Affinity{Cookie: "sticky"}}
+			ctx := &fasthttp.RequestCtx{}
+			p.pin(ctx, named, u, backends[0])
+
+			c := fasthttp.AcquireCookie()
+			defer fasthttp.ReleaseCookie(c)
+			c.SetKey("sticky")
+			So(ctx.Response.Header.Cookie(c), ShouldBeTrue)
+			So(c.HTTPOnly(), ShouldBeTrue)
+			So(c.Expire(), ShouldEqual, fasthttp.CookieExpireUnlimited)
+
+			c.SetKey(AffinityCookie)
+			ctx = &fasthttp.RequestCtx{}
+			p.pin(ctx, s, u, backends[0])
+			So(ctx.Response.Header.Cookie(c), ShouldBeTrue)
+			So(c.Expire(), ShouldHappenWithin, time.Second*2, time.Now().Add(time.Minute))
+		})
+
+		Convey("A tampered cookie should be ignored", func() {
+			cookie := pinCookie(p, s, u, backends[0])
+			i := bytes.LastIndexByte([]byte(cookie), '.')
+			forged := base64.RawURLEncoding.EncodeToString([]byte("web\x00"+second)) + cookie[i:]
+
+			for _, value := range []string{forged, cookie[:i], cookie + "x", "", "."} {
+				So(p.pinned(withCookie(s, value), s, u), ShouldBeNil)
+			}
+		})
+
+		Convey("A cookie for another Site should be refused, even for a backend of the same address", func() {
+			other := &site{Site: &sites.Site{Hostname: "example.org"}, affinity: s.affinity}
+			cookie := pinCookie(p, other, u, backends[0])
+			So(p.pinned(withCookie(s, cookie), s, u), ShouldBeNil)
+			So(p.pinned(withCookie(other, cookie), other, u), ShouldEqual, backends[0])
+		})
+
+		Convey("A cookie for another Upstream should be refused", func() {
+			api := &upstream{Upstream: &sites.Upstream{Name: "api"}, backends: backends}
+			So(p.pinned(withCookie(s, pinCookie(p, s, api, backends[0])), s, u), ShouldBeNil)
+		})
+
+		Convey("A client pinned to a backend that is unavailable should be pinned again", func() {
+			cookie := pinCookie(p, s, u, backends[0])
+			atomic.StoreInt64(&backends[0].health.ejectedUntil, time.Now().Add(time.Minute).UnixNano())
+
+			ctx := withCookie(s, cookie)
+			pinned := p.pinned(ctx, s, u)
+			So(pinned, ShouldBeNil)
+
+			p.stick(ctx, &target{site: s, upstream: u}, backends[1], pinned)
+			c := fasthttp.AcquireCookie()
+			defer fasthttp.ReleaseCookie(c)
+			c.SetKey(AffinityCookie)
+			So(ctx.Response.Header.Cookie(c), ShouldBeTrue)
+			So(p.pinned(withCookie(s, string(c.Value())), s, u), ShouldEqual, backends[1])
+		})
+
+		Convey("A client served by the backend it is pinned to should not be pinned again", func() {
+			ctx := withCookie(s, pinCookie(p, s, u, backends[2]))
+			pinned := p.pinned(ctx, s, u)
+			p.stick(ctx, &target{site: s, upstream: u}, pinned, pinned)
+			So(ctx.Response.Header.PeekCookie(AffinityCookie), ShouldBeNil)
+		})
+
+		Convey("A node without the key should not pin clients", func() {
+			ctx := &fasthttp.RequestCtx{}
+			(&Proxy{}).pin(ctx, s, u, backends[0])
+			So(ctx.Response.Header.PeekCookie(AffinityCookie), ShouldBeNil)
+		})
+	})
+}
+
+func TestLoadAffinityKey(t *testing.T) {
+	Convey("The leader should create the affinity key once, and followers should load it", t, func() {
+		db, cleanup := newTestConsensus(t, false)
+		defer cleanup()
+
+		follower := &Proxy{db: db}
+		follower.loadAffinityKey()
+		So(follower.affinityKey, ShouldBeNil)
+		_, err := repository.FindAffinityKey(db)
+		So(err, ShouldNotBeNil)
+
+		db.leader = true
+		leader := &Proxy{db: db}
+		leader.loadAffinityKey()
+		So(leader.affinityKey, ShouldHaveLength, affinityKeySize)
+		key := leader.affinityKey
+
+		again := &Proxy{db: db}
+		again.loadAffinityKey()
+		So(again.affinityKey, ShouldResemble, key)
+
+		db.leader = false
+		follower.loadAffinityKey()
+		So(follower.affinityKey, ShouldResemble, key)
+
+		stored, err := repository.FindAffinityKey(db)
+		So(err, ShouldBeNil)
+		So(stored, ShouldResemble, key)
+	})
+
+	Convey("A second key should not replace the first", t, func() {
+		db, cleanup := newTestConsensus(t, true)
+		defer cleanup()
+
+		So(repository.CreateAffinityKey(db, []byte("first")), ShouldBeNil)
+		So(repository.CreateAffinityKey(db, []byte("second")), ShouldNotBeNil)
+
+		p := &Proxy{db: db}
+		p.loadAffinityKey()
+		So(string(p.affinityKey), ShouldEqual, "first")
+	})
+}
@@ -0,0 +1,427 @@
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"golang.org/x/net/http2"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// hashReplicas is the number of points each unit of weight has on the consistent hash ring
+const hashReplicas = 100
+
+// backendIdleTimeout is how long the idle connections of the transport of a backend are kept open
+const backendIdleTimeout = time.Second * 90
+
+// backend is an Endpoint with its connection pool, its health, and the number of requests active on it.
+// Requests to HTTP/2 Balancers and to h2c Endpoints are sent with the transport, others with the client.
+type backend struct {
+	*sites.Endpoint
+
+	client    *fasthttp.HostClient
+	transport http.RoundTripper
+	health    *health
+	active    int64
+
+	// proxyProtocol is the PROXY protocol version each connection starts with, or 0 if none. Such
+	// connections carry the requests of a single client, so are not kept open for others.
+	proxyProtocol uint32
+}
+
+// endpointAddr returns the host:port the Endpoint is connected to
+func endpointAddr(e *sites.Endpoint) string {
+	if e.Port == 0 {
+		return e.Address
+	}
+
+	return net.JoinHostPort(e.Address, strconv.Itoa(int(e.Port)))
+}
+
+// newBackend creates a backend for the Endpoint, with its health h, connecting with the timeouts
+// and connection limit of r, and sending the version of the PROXY protocol if it is set
+func newBackend(e *sites.Endpoint, h *health, r *sites.Resilience, proxyProtocol uint32) (*backend, error) {
+	addr := endpointAddr(e)
+
+	connect := defaultConnectTimeout
+	if r.GetTimeouts() != nil {
+		connect = millis(r.Timeouts.Connect, defaultConnectTimeout)
+	}
+
+	// the circuit breaker caps the connections of the Endpoint, when it has a lower cap of its own
+	maxConns := e.MaxConnections
+	if c := r.GetCircuitBreaker(); c != nil && c.MaxConnections != 0 && (maxConns == 0 || c.MaxConnections < maxConns) {
+		maxConns = c.MaxConnections
+	}
+
+	client := &fasthttp.HostClient{
+		Addr:     addr,
+		MaxConns: int(maxConns),
+		Dial: func(addr string) (net.Conn, error) {
+			return fasthttp.DialTimeout(addr, connect)
+		},
+	}
+
+	dialer := &net.Dialer{Timeout: connect}
+	transport := &http.Transport{
+		DialContext:     dialer.DialContext,
+		MaxConnsPerHost: int(maxConns),
+		IdleConnTimeout: backendIdleTimeout,
+	}
+
+	b := &backend{Endpoint: e, client: client, transport: transport, health: h, proxyProtocol: proxyProtocol}
+	if proxyProtocol != 0 {
+		transport.DisableKeepAlives = true
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return b.dial(peerFrom(ctx))
+		}
+	}
+
+	switch e.Scheme {
+	case SchemeHTTPS:
+		config, err := endpointTLS(e, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		client.IsTLS = true
+		client.TLSConfig = config
+
+		transport.TLSClientConfig = config.Clone()
+		transport.TLSClientConfig.NextProtos = []string{"h2", "http/1.1"}
+		transport.ForceAttemptHTTP2 = true
+	case SchemeH2C:
+		if proxyProtocol != 0 {
+			return nil, fmt.Errorf("h2c endpoints can not be sent the PROXY protocol")
+		}
+
+		b.transport = &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, addr)
+			},
+		}
+	}
+
+	return b, nil
+}
+
+// dial connects to the backend with the connect timeout of its client, sending the PROXY protocol
+// header for the peer first if the backend takes it
+func (b *backend) dial(from *peer) (net.Conn, error) {
+	conn, err := b.client.Dial(b.client.Addr)
+	if err != nil || b.proxyProtocol == 0 {
+		return conn, err
+	}
+
+	if _, err := conn.Write(proxyHeader(b.proxyProtocol, from)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// url returns the URL of the backend for the request URI, as sent with its transport
+func (b *backend) url(uri string) string {
+	scheme := SchemeHTTP
+	if b.Scheme == SchemeHTTPS {
+		scheme = SchemeHTTPS
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, b.client.Addr, uri)
+}
+
+// endpointTLS returns the tls.Config to connect to the Endpoint at addr
+func endpointTLS(e *sites.Endpoint, addr string) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		config.ServerName = host
+	}
+
+	if e.Tls == nil {
+		return config, nil
+	}
+
+	if e.Tls.ServerName != "" {
+		config.ServerName = e.Tls.ServerName
+	}
+	config.InsecureSkipVerify = e.Tls.InsecureSkipVerify
+
+	if len(e.Tls.Ca) > 0 {
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(e.Tls.Ca) {
+			return nil, fmt.Errorf("no certificates found in CA")
+		}
+	}
+
+	return config, nil
+}
+
+// weight returns the weight of the backend, 1 if unset
+func (b *backend) weight() int64 {
+	if b.Weight == 0 {
+		return 1
+	}
+
+	return int64(b.Weight)
+}
+
+// load returns the active requests of the backend relative to its weight
+func (b *backend) load() float64 {
+	return float64(atomic.LoadInt64(&b.active)) / float64(b.weight())
+}
+
+// picker picks an available backend for a request, or nil if there are none
+type picker interface {
+	pick(ctx *fasthttp.RequestCtx) *backend
+}
+
+// newPicker creates the picker for the strategy over the backends
+func newPicker(strategy sites.Strategy, hash *sites.HashPolicy, backends []*backend) (picker, error) {
+	switch strategy {
+	case sites.Strategy_ROUND_ROBIN:
+		return &roundRobin{backends: backends}, nil
+	case sites.Strategy_WEIGHTED_ROUND_ROBIN:
+		return newWeightedRoundRobin(backends), nil
+	case sites.Strategy_LEAST_CONNECTIONS:
+		return &leastConnections{backends: backends}, nil
+	case sites.Strategy_POWER_OF_TWO:
+		return &powerOfTwo{backends: backends}, nil
+	case sites.Strategy_CONSISTENT_HASH:
+		if hash == nil {
+			hash = &sites.HashPolicy{}
+		}
+		if hash.Source != sites.HashSource_CLIENT_IP && hash.Name == "" {
+			return nil, fmt.Errorf("a %s hash needs a name", hash.Source)
+		}
+
+		return newConsistentHash(hash, backends), nil
+	}
+
+	return nil, fmt.Errorf("unknown strategy %s", strategy)
+}
+
+// roundRobin picks each backend in turn
+type roundRobin struct {
+	backends []*backend
+	next     uint64
+}
+
+func (r *roundRobin) pick(*fasthttp.RequestCtx) *backend {
+	if len(r.backends) == 0 {
+		return nil
+	}
+
+	for range r.backends {
+		n := atomic.AddUint64(&r.next, 1)
+		if b := r.backends[(n-1)%uint64(len(r.backends))]; b.health.available() {
+			return b
+		}
+	}
+
+	return nil
+}
+
+// weightedRoundRobin picks each backend in turn in proportion to its weight, spreading the picks of
+// heavy backends out rather than sending them in bursts
+type weightedRoundRobin struct {
+	mu       sync.Mutex
+	backends []*backend
+	current  []int64
+}
+
+func newWeightedRoundRobin(backends []*backend) *weightedRoundRobin {
+	return &weightedRoundRobin{
+		backends: backends,
+		current:  make([]int64, len(backends)),
+	}
+}
+
+func (w *weightedRoundRobin) pick(*fasthttp.RequestCtx) *backend {
+	if len(w.backends) == 0 {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	best := -1
+	var total int64
+	for i, b := range w.backends {
+		if !b.health.available() {
+			continue
+		}
+
+		w.current[i] += b.weight()
+		total += b.weight()
+		if best == -1 || w.current[i] > w.current[best] {
+			best = i
+		}
+	}
+	if best == -1 {
+		return nil
+	}
+	w.current[best] -= total
+
+	return w.backends[best]
+}
+
+// leastConnections picks the backend with the fewest active requests for its weight
+type leastConnections struct {
+	backends []*backend
+}
+
+func (l *leastConnections) pick(*fasthttp.RequestCtx) *backend {
+	var best *backend
+	for _, b := range l.backends {
+		if !b.health.available() {
+			continue
+		}
+
+		if best == nil || b.load() < best.load() {
+			best = b
+		}
+	}
+
+	return best
+}
+
+// powerOfTwo picks the least loaded of two random backends, which avoids herding onto the single
+// least loaded backend
+type powerOfTwo struct {
+	backends []*backend
+}
+
+func (p *powerOfTwo) pick(*fasthttp.RequestCtx) *backend {
+	backends := make([]*backend, 0, len(p.backends))
+	for _, b := range p.backends {
+		if b.health.available() {
+			backends = append(backends, b)
+		}
+	}
+
+	switch len(backends) {
+	case 0:
+		return nil
+	case 1:
+		return backends[0]
+	}
+
+	i := rand.Intn(len(backends))
+	j := rand.Intn(len(backends) - 1)
+	if j >= i {
+		j++
+	}
+
+	a, b := backends[i], backends[j]
+	if b.load() < a.load() {
+		return b
+	}
+
+	return a
+}
+
+// consistentHash picks the backend for a hash of the request on a ring, so the same key keeps going
+// to the same backend while the backends don't change
+type consistentHash struct {
+	hash   *sites.HashPolicy
+	points []uint32
+	ring   map[uint32]*backend
+
+	fallback roundRobin
+}
+
+func newConsistentHash(hash *sites.HashPolicy, backends []*backend) *consistentHash {
+	c := &consistentHash{
+		hash:     hash,
+		ring:     make(map[uint32]*backend),
+		fallback: roundRobin{backends: backends},
+	}
+
+	for _, b := range backends {
+		for i := int64(0); i < b.weight()*hashReplicas; i++ {
+			point := hashKey([]byte(fmt.Sprintf("%s-%d", b.client.Addr, i)))
+			if _, ok := c.ring[point]; ok {
+				continue
+			}
+
+			c.ring[point] = b
+			c.points = append(c.points, point)
+		}
+	}
+	sort.Slice(c.points, func(i, j int) bool { return c.points[i] < c.points[j] })
+
+	return c
+}
+
+func (c *consistentHash) pick(ctx *fasthttp.RequestCtx) *backend {
+	if len(c.points) == 0 {
+		return nil
+	}
+
+	key := c.key(ctx)
+	if len(key) == 0 {
+		return c.fallback.pick(ctx)
+	}
+
+	// walk the ring past unavailable backends, so only the keys of those backends move
+	h := hashKey(key)
+	i := sort.Search(len(c.points), func(i int) bool { return c.points[i] >= h })
+	for range c.points {
+		if i == len(c.points) {
+			i = 0
+		}
+
+		if b := c.ring[c.points[i]]; b.health.available() {
+			return b
+		}
+		i++
+	}
+
+	return nil
+}
+
+// key returns the part of the request that is hashed
+func (c *consistentHash) key(ctx *fasthttp.RequestCtx) []byte {
+	switch c.hash.Source {
+	case sites.HashSource_HEADER:
+		return ctx.Request.Header.Peek(c.hash.Name)
+	case sites.HashSource_COOKIE:
+		return ctx.Request.Header.Cookie(c.hash.Name)
+	}
+
+	return []byte(clientIP(ctx).String())
+}
+
+// hashKey returns the point of the key on the ring. FNV-1a alone barely mixes keys that differ only in
+// their last bytes, such as the points of a backend or sequential ids, which bunch them together on
+// the ring, so its hash is mixed with the finalizer of MurmurHash3.
+func hashKey(key []byte) uint32 {
+	h := fnv.New32a()
+	h.Write(key)
+
+	k := h.Sum32()
+	k ^= k >> 16
+	k *= 0x85ebca6b
+	k ^= k >> 13
+	k *= 0xc2b2ae35
+	k ^= k >> 16
+	return k
+}
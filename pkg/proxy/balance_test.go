@@ -0,0 +1,203 @@
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/valyala/fasthttp"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// testBackends returns a backend for each weight, at 10.0.0.1, 10.0.0.2 and so on
+func testBackends(weights ...uint32) []*backend {
+	backends := make([]*backend, len(weights))
+	for i, w := range weights {
+		addr := fmt.Sprintf("10.0.0.%d:80", i+1)
+		b, err := newBackend(&sites.Endpoint{Address: addr, Weight: w}, newHealth("example.com", "web", addr), nil, 0)
+		So(err, ShouldBeNil)
+		backends[i] = b
+	}
+
+	return backends
+}
+
+// picks returns the addresses of n picks of the picker
+func picks(p picker, ctx *fasthttp.RequestCtx, n int) []string {
+	var addrs []string
+	for i := 0; i < n; i++ {
+		b := p.pick(ctx)
+		if b == nil {
+			addrs = append(addrs, "")
+			continue
+		}
+		addrs = append(addrs, b.client.Addr)
+	}
+
+	return addrs
+}
+
+const (
+	first  = "10.0.0.1:80"
+	second = "10.0.0.2:80"
+	third  = "10.0.0.3:80"
+)
+
+func TestRoundRobin(t *testing.T) {
+	Convey("Round robin should pick each backend in turn, skipping unavailable ones", t, func() {
+		backends := testBackends(1, 1, 1)
+		p, err := newPicker(sites.Strategy_ROUND_ROBIN, nil, backends)
+		So(err, ShouldBeNil)
+		So(picks(p, nil, 4), ShouldResemble, []string{first, second, third, first})
+
+		backends[1].health.setHealthy(false)
+		So(picks(p, nil, 4), ShouldResemble, []string{third, first, third, first})
+	})
+
+	Convey("Round robin should pick nothing when no backend is available", t, func() {
+		backends := testBackends(1)
+		backends[0].health.setHealthy(false)
+		So((&roundRobin{backends: backends}).pick(nil), ShouldBeNil)
+		So((&roundRobin{}).pick(nil), ShouldBeNil)
+	})
+}
+
+func TestWeightedRoundRobin(t *testing.T) {
+	Convey("Weighted round robin should spread the picks of heavy backends out, smoothly", t, func() {
+		p := newWeightedRoundRobin(testBackends(5, 1, 1))
+		expected := []string{first, first, second, first, third, first, first}
+		So(picks(p, nil, 7), ShouldResemble, expected)
+		So(picks(p, nil, 7), ShouldResemble, expected)
+	})
+
+	Convey("Weighted round robin should pick in proportion to the weights of the available backends", t, func() {
+		backends := testBackends(3, 2, 1)
+		backends[2].health.setHealthy(false)
+		p := newWeightedRoundRobin(backends)
+
+		counts := make(map[string]int)
+		for _, addr := range picks(p, nil, 500) {
+			counts[addr]++
+		}
+		So(counts, ShouldResemble, map[string]int{first: 300, second: 200})
+	})
+
+	Convey("Unset weights should count as 1", t, func() {
+		p := newWeightedRoundRobin(testBackends(0, 0))
+		So(picks(p, nil, 4), ShouldResemble, []string{first, second, first, second})
+	})
+}
+
+func TestLeastConnections(t *testing.T) {
+	Convey("Least connections should pick the available backend with the fewest requests for its weight", t, func() {
+		backends := testBackends(1, 4, 1)
+		backends[0].active, backends[1].active, backends[2].active = 2, 4, 0
+		p := &leastConnections{backends: backends}
+		So(p.pick(nil).client.Addr, ShouldEqual, third)
+
+		backends[2].health.setHealthy(false)
+		So(p.pick(nil).client.Addr, ShouldEqual, second)
+	})
+}
+
+func TestPowerOfTwo(t *testing.T) {
+	Convey("Power of two should never pick an unavailable or the busiest of three backends", t, func() {
+		backends := testBackends(1, 1, 1, 1)
+		backends[0].active, backends[1].active, backends[2].active = 1, 2, 100
+		backends[3].health.setHealthy(false)
+
+		p := &powerOfTwo{backends: backends}
+		for _, addr := range picks(p, nil, 200) {
+			So(addr, ShouldBeIn, []string{first, second})
+		}
+	})
+}
+
+func TestConsistentHash(t *testing.T) {
+	header := &sites.HashPolicy{Source: sites.HashSource_HEADER, Name: "X-User"}
+	request := func(user string) *fasthttp.RequestCtx {
+		ctx := &fasthttp.RequestCtx{}
+		if user != "" {
+			ctx.Request.Header.Set("X-User", user)
+		}
+		return ctx
+	}
+
+	Convey("A hash other than of the client IP should need a name", t, func() {
+		_, err := newPicker(sites.Strategy_CONSISTENT_HASH, &sites.HashPolicy{Source: sites.HashSource_COOKIE}, nil)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("The same key should keep going to the same backend, and keys should spread out", t, func() {
+		p := newConsistentHash(header, testBackends(1, 1, 1))
+		seen := make(map[string]bool)
+		for i := 0; i < 100; i++ {
+			ctx := request(fmt.Sprintf("user-%d", i))
+			addr := p.pick(ctx).client.Addr
+			So(picks(p, ctx, 5), ShouldResemble, []string{addr, addr, addr, addr, addr})
+			seen[addr] = true
+		}
+		So(seen, ShouldHaveLength, 3)
+	})
+
+	Convey("Sequential keys should spread out evenly for the weights", t, func() {
+		p := newConsistentHash(header, testBackends(1, 1, 2))
+		counts := make(map[string]int)
+		for i := 0; i < 4000; i++ {
+			counts[p.pick(request(fmt.Sprintf("user-%d", i))).client.Addr]++
+		}
+
+		So(counts[first], ShouldBeBetween, 800, 1200)
+		So(counts[second], ShouldBeBetween, 800, 1200)
+		So(counts[third], ShouldBeBetween, 1600, 2400)
+	})
+
+	Convey("Only the keys of an unavailable backend should move", t, func() {
+		backends := testBackends(1, 1, 1)
+		p := newConsistentHash(header, backends)
+
+		before := make(map[string]string)
+		for i := 0; i < 300; i++ {
+			user := fmt.Sprintf("user-%d", i)
+			before[user] = p.pick(request(user)).client.Addr
+		}
+
+		backends[1].health.setHealthy(false)
+		for user, addr := range before {
+			moved := p.pick(request(user)).client.Addr
+			if addr == second {
+				So(moved, ShouldNotEqual, second)
+			} else {
+				So(moved, ShouldEqual, addr)
+			}
+		}
+
+		backends[1].health.setHealthy(true)
+		for user, addr := range before {
+			So(p.pick(request(user)).client.Addr, ShouldEqual, addr)
+		}
+	})
+
+	Convey("The ring should be the same for the same backends, so every node picks alike", t, func() {
+		a := newConsistentHash(header, testBackends(1, 2, 1))
+		b := newConsistentHash(header, testBackends(1, 2, 1))
+		for i := 0; i < 100; i++ {
+			ctx := request(fmt.Sprintf("user-%d", i))
+			So(a.pick(ctx).client.Addr, ShouldEqual, b.pick(ctx).client.Addr)
+		}
+	})
+
+	Convey("Requests without the key should be picked in turn", t, func() {
+		p := newConsistentHash(header, testBackends(1, 1))
+		So(picks(p, request(""), 3), ShouldResemble, []string{first, second, first})
+	})
+
+	Convey("A hash of the client IP should pick alike for the same client", t, func() {
+		p := newConsistentHash(&sites.HashPolicy{}, testBackends(1, 1, 1))
+		client := connCtx(&net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1})
+		sameClient := connCtx(&net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 2})
+		So(p.pick(client).client.Addr, ShouldEqual, p.pick(sameClient).client.Addr)
+	})
+}
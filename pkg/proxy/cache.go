@@ -0,0 +1,968 @@
+package proxy
+
+import (
+	"bytes"
+	"container/list"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+const (
+	// DefaultCacheSize is the most bytes of responses a Site caches in memory, for Caches that do not
+	// set a max size
+	DefaultCacheSize = 64 << 20
+
+	// DefaultCacheObjectSize is the largest response body a Site caches, for Caches that do not set one
+	DefaultCacheObjectSize = 8 << 20
+
+	// DefaultCacheDiskSize is the most bytes of responses a Site caches on disk, for Caches with a disk
+	// path that do not set a disk max size
+	DefaultCacheDiskSize = 1 << 30
+
+	// DefaultCacheTagHeader lists the tags of a response, for Caches that do not set a tag header
+	DefaultCacheTagHeader = "Cache-Tag"
+
+	// CacheHeader is set on the responses of cacheable requests: HIT for those served from the cache,
+	// REVALIDATED for stale responses an Endpoint confirmed were unchanged, and MISS for the others
+	CacheHeader = "X-Waffy-Cache"
+)
+
+// cacheableStatus are the statuses of responses that can be cached, RFC 9110 15.1
+var cacheableStatus = map[int]bool{
+	200: true, 203: true, 204: true, 300: true, 301: true, 308: true,
+	404: true, 405: true, 410: true, 414: true, 501: true,
+}
+
+// uncachedHeaders are the response headers that are not cached with a response, as they only apply to
+// the response that carried them
+var uncachedHeaders = []string{"Age", "Content-Length", "Set-Cookie", CacheHeader}
+
+// cachedResponse is a cached response, with what it varies on and how long it is fresh for
+type cachedResponse struct {
+	URI    string
+	Vary   []string
+	Tags   []string
+	Status int
+	Header [][2]string
+	Body   []byte
+
+	// Received is when the response was received, already InitialAge old, and it is fresh for TTL
+	Received   time.Time
+	InitialAge time.Duration
+	TTL        time.Duration
+}
+
+// age returns the age of the response, RFC 9111 4.2.3
+func (r *cachedResponse) age(now time.Time) time.Duration {
+	return r.InitialAge + now.Sub(r.Received)
+}
+
+// fresh returns if the response can be served without revalidating it
+func (r *cachedResponse) fresh(now time.Time) bool {
+	return r.age(now) < r.TTL
+}
+
+// size returns the bytes the response takes up in the cache
+func (r *cachedResponse) size() int64 {
+	n := int64(len(r.URI) + len(r.Body))
+	for _, h := range r.Header {
+		n += int64(len(h[0]) + len(h[1]))
+	}
+
+	return n
+}
+
+// header returns the first value of the response header
+func (r *cachedResponse) header(name string) string {
+	return headerValue(r.Header, name)
+}
+
+// headerValue returns the first value of the header in h
+func headerValue(h [][2]string, name string) string {
+	for _, kv := range h {
+		if strings.EqualFold(kv[0], name) {
+			return kv[1]
+		}
+	}
+
+	return ""
+}
+
+// headerValues returns every value of the header in h
+func headerValues(h [][2]string, name string) []string {
+	var values []string
+	for _, kv := range h {
+		if strings.EqualFold(kv[0], name) {
+			values = append(values, kv[1])
+		}
+	}
+
+	return values
+}
+
+// headerList returns the comma separated elements of the values of a header
+func headerList(values []string) []string {
+	var list []string
+	for _, v := range values {
+		for _, e := range strings.Split(v, ",") {
+			if e = strings.TrimSpace(e); e != "" {
+				list = append(list, e)
+			}
+		}
+	}
+
+	return list
+}
+
+// cacheControl is the directives of a Cache-Control header, by lowercase name, RFC 9111 5.2
+type cacheControl map[string]string
+
+func parseCacheControl(values []string) cacheControl {
+	cc := make(cacheControl)
+	for _, d := range headerList(values) {
+		kv := strings.SplitN(d, "=", 2)
+		name := strings.ToLower(strings.TrimSpace(kv[0]))
+		if len(kv) == 2 {
+			cc[name] = strings.Trim(strings.TrimSpace(kv[1]), `"`)
+			continue
+		}
+		cc[name] = ""
+	}
+
+	return cc
+}
+
+func (cc cacheControl) has(directive string) bool {
+	_, ok := cc[directive]
+	return ok
+}
+
+// seconds returns the delta-seconds of the directive, if it is set and valid
+func (cc cacheControl) seconds(directive string) (time.Duration, bool) {
+	v, ok := cc[directive]
+	if !ok {
+		return 0, false
+	}
+
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+
+	return time.Duration(n) * time.Second, true
+}
+
+// freshness returns how long a response with the headers is fresh for, RFC 9111 4.2.1, or the
+// default ttl if it does not say, and if it can be stored
+func freshness(h [][2]string, defaultTTL time.Duration, now time.Time) (time.Duration, bool) {
+	cc := parseCacheControl(headerValues(h, "Cache-Control"))
+	switch {
+	case cc.has("no-store") || cc.has("private"):
+		return 0, false
+	case cc.has("no-cache"):
+		return 0, true
+	}
+
+	if ttl, ok := cc.seconds("s-maxage"); ok {
+		return ttl, true
+	}
+	if ttl, ok := cc.seconds("max-age"); ok {
+		return ttl, true
+	}
+
+	if v := headerValue(h, "Expires"); v != "" {
+		// an invalid Expires is in the past
+		expires, err := http.ParseTime(v)
+		if err != nil {
+			return 0, true
+		}
+
+		date := now
+		if d, err := http.ParseTime(headerValue(h, "Date")); err == nil {
+			date = d
+		}
+		if expires.After(date) {
+			return expires.Sub(date), true
+		}
+		return 0, true
+	}
+
+	return defaultTTL, defaultTTL > 0
+}
+
+// cache is the cache of the responses of a Site, kept across reloads of the Balancers. Responses are
+// kept in memory, least recently used first to go, and in the disk tier once they go if there is one.
+type cache struct {
+	hostname string
+
+	mu  sync.Mutex
+	cfg *sites.Cache
+
+	// entries are the elements of lru holding the responses, by variant key
+	entries map[string]*list.Element
+	lru     *list.List
+	uris    uriIndex
+	size    int64
+
+	// vary are the request headers the responses to a URL vary on, by primary key
+	vary map[string][]string
+
+	disk *diskCache
+
+	// generation changes with every purge, so responses read or written outside the lock are not
+	// kept if they were purged meanwhile
+	generation uint64
+	purges     map[string]bool
+
+	stats sites.CacheStats
+}
+
+// cacheEntry is an element of the lru of a cache
+type cacheEntry struct {
+	key string
+	r   *cachedResponse
+}
+
+// siteCache returns the cache of the Site hostname, creating it if the Site was not cached
+func (p *Proxy) siteCache(hostname string, cfg *sites.Cache) *cache {
+	p.mu.Lock()
+
+	key := strings.ToLower(hostname)
+	c, ok := p.caches[key]
+	if !ok {
+		c = &cache{
+			hostname: hostname,
+			entries:  make(map[string]*list.Element),
+			lru:      list.New(),
+			uris:     make(uriIndex),
+			vary:     make(map[string][]string),
+			purges:   make(map[string]bool),
+			stats:    sites.CacheStats{Hostname: hostname},
+		}
+		p.caches[key] = c
+	}
+	p.mu.Unlock()
+
+	c.configure(cfg)
+	return c
+}
+
+// configure applies the limits of cfg to the cache, opening its disk tier if the disk path changed.
+// Requests to the Site wait while the responses of a disk tier are indexed.
+func (c *cache) configure(cfg *sites.Cache) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cfg == nil || c.cfg.DiskPath != cfg.DiskPath {
+		c.disk = nil
+		if cfg.DiskPath != "" {
+			c.disk = openDiskCache(cfg.DiskPath, c.hostname)
+		}
+	}
+
+	c.cfg = cfg
+	c.evict()
+}
+
+func (c *cache) maxSize() int64 {
+	if c.cfg.MaxSize > 0 {
+		return c.cfg.MaxSize
+	}
+	return DefaultCacheSize
+}
+
+func (c *cache) maxObjectSize() int {
+	if c.cfg.MaxObjectSize > 0 {
+		return int(c.cfg.MaxObjectSize)
+	}
+	return DefaultCacheObjectSize
+}
+
+func (c *cache) diskMaxSize() int64 {
+	if c.cfg.DiskMaxSize > 0 {
+		return c.cfg.DiskMaxSize
+	}
+	return DefaultCacheDiskSize
+}
+
+func (c *cache) tagHeader() string {
+	if c.cfg.TagHeader != "" {
+		return c.cfg.TagHeader
+	}
+	return DefaultCacheTagHeader
+}
+
+// primaryKey returns the key of the responses to the URI of a Site host from the upstream. Routes that
+// split requests across upstreams send clients different responses for the same URI.
+func primaryKey(host, upstream, uri string) string {
+	return host + " " + upstream + " " + uri
+}
+
+// variantKey returns the key of the response to the request under the primary key, with the values
+// of the request headers the responses vary on
+func variantKey(primary string, vary []string, ctx *fasthttp.RequestCtx) string {
+	if len(vary) == 0 {
+		return primary
+	}
+
+	var b strings.Builder
+	b.WriteString(primary)
+	for _, name := range vary {
+		b.WriteString("\n")
+		b.WriteString(strings.ToLower(name))
+		b.WriteString(":")
+		b.Write(ctx.Request.Header.Peek(name))
+	}
+
+	return b.String()
+}
+
+// get returns the response with the key from memory, or from the disk tier, promoting it to memory
+func (c *cache) get(key string) *cachedResponse {
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(el)
+		c.mu.Unlock()
+		return el.Value.(*cacheEntry).r
+	}
+
+	var file string
+	if c.disk != nil {
+		file = c.disk.file(key)
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	if file == "" {
+		return nil
+	}
+
+	// the file is read without holding up requests, and not kept if it was purged meanwhile
+	r := readCachedFile(file)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case r == nil:
+		if c.disk != nil {
+			c.disk.remove(key)
+		}
+	case c.generation == generation:
+		c.put(key, r)
+	}
+	return r
+}
+
+// put adds the response to memory under the key, replacing any there, then evicts responses down to
+// the max size. The cache must be locked.
+func (c *cache) put(key string, r *cachedResponse) {
+	c.remove(key)
+	if c.disk != nil {
+		c.disk.remove(key)
+	}
+
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, r: r})
+	c.uris.add(r.URI, key)
+	c.size += r.size()
+	c.evict()
+}
+
+// remove removes the response with the key from memory. The cache must be locked.
+func (c *cache) remove(key string) bool {
+	el, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+
+	r := el.Value.(*cacheEntry).r
+	c.lru.Remove(el)
+	delete(c.entries, key)
+	c.uris.remove(r.URI, key)
+	c.size -= r.size()
+	return true
+}
+
+// evict removes the least recently used responses from memory until they fit the max size, moving
+// them to the disk tier if there is one. The cache must be locked.
+func (c *cache) evict() {
+	var spilled []*cacheEntry
+	for c.size > c.maxSize() && c.lru.Len() > 0 {
+		e := c.lru.Back().Value.(*cacheEntry)
+		c.remove(e.key)
+
+		if c.disk == nil {
+			c.stats.Evicted++
+			continue
+		}
+		spilled = append(spilled, e)
+	}
+
+	if len(spilled) == 0 {
+		return
+	}
+
+	// responses are written to disk without holding up requests, and dropped if purged meanwhile
+	disk, generation := c.disk, c.generation
+	go func() {
+		for _, e := range spilled {
+			file, err := writeCachedFile(disk.dir, e.key, e.r)
+			if err != nil {
+				log.Printf("unable to cache response on disk: %s", err)
+				continue
+			}
+
+			c.mu.Lock()
+			if c.generation == generation && c.disk == disk {
+				c.stats.Evicted += disk.add(e.key, e.r, file, c.diskMaxSize())
+			} else {
+				os.Remove(file)
+			}
+			c.mu.Unlock()
+		}
+	}()
+}
+
+// purge removes the responses matching the purge from memory and disk, returning how many it removed
+func (c *cache) purge(p *sites.CachePurge) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := c.drop(p.Urls)
+	all := len(p.Urls) == 0 && len(p.Prefixes) == 0 && len(p.Tags) == 0
+	if all || len(p.Prefixes) > 0 || len(p.Tags) > 0 {
+		match := func(uri string, tags []string) bool {
+			return all || purges(p, uri, tags)
+		}
+
+		for key, el := range c.entries {
+			r := el.Value.(*cacheEntry).r
+			if match(r.URI, r.Tags) && c.remove(key) {
+				n++
+			}
+		}
+		if c.disk != nil {
+			n += c.disk.purge(match)
+		}
+	}
+
+	c.stats.Purged += n
+	return n
+}
+
+// invalidate removes the responses to the URI, after an unsafe request to it succeeded
+func (c *cache) invalidate(uri string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.drop([]string{uri})
+}
+
+// drop removes the responses to the URIs from memory and disk, returning how many it removed. The
+// cache must be locked.
+func (c *cache) drop(uris []string) uint64 {
+	c.generation++
+
+	var n uint64
+	for _, uri := range uris {
+		for _, key := range c.uris.keys(uri) {
+			if c.remove(key) {
+				n++
+			}
+		}
+		if c.disk == nil {
+			continue
+		}
+		for _, key := range c.disk.uris.keys(uri) {
+			if c.disk.remove(key) {
+				n++
+			}
+		}
+	}
+
+	return n
+}
+
+// uriIndex is the keys of the responses to each URI, so those of a URI are found without a scan
+type uriIndex map[string]map[string]bool
+
+func (x uriIndex) add(uri, key string) {
+	keys, ok := x[uri]
+	if !ok {
+		keys = make(map[string]bool)
+		x[uri] = keys
+	}
+	keys[key] = true
+}
+
+func (x uriIndex) remove(uri, key string) {
+	delete(x[uri], key)
+	if len(x[uri]) == 0 {
+		delete(x, uri)
+	}
+}
+
+// keys returns a copy of the keys of the URI, so they can be removed while ranging over them
+func (x uriIndex) keys(uri string) []string {
+	var keys []string
+	for key := range x[uri] {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// purges returns if the purge matches a response to the URI with the tags
+func purges(p *sites.CachePurge, uri string, tags []string) bool {
+	for _, u := range p.Urls {
+		if uri == u {
+			return true
+		}
+	}
+
+	path := uri
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	for _, prefix := range p.Prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	for _, t := range p.Tags {
+		for _, tag := range tags {
+			if t == tag {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// apply applies the stored purges of the Site the cache has not applied yet, forgetting those that
+// are no longer stored
+func (c *cache) apply(list []*sites.CachePurge) {
+	c.mu.Lock()
+	applied := c.purges
+	c.purges = make(map[string]bool)
+	c.mu.Unlock()
+
+	for _, p := range list {
+		if !strings.EqualFold(p.Hostname, c.hostname) {
+			continue
+		}
+
+		if !applied[p.Id] {
+			c.purge(p)
+		}
+
+		c.mu.Lock()
+		c.purges[p.Id] = true
+		c.mu.Unlock()
+	}
+}
+
+// snapshot returns a copy of the stats of the cache, with its size
+func (c *cache) snapshot() *sites.CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = uint64(len(c.entries))
+	stats.Bytes = uint64(c.size)
+	if c.disk != nil {
+		stats.DiskEntries, stats.DiskBytes = c.disk.usage()
+	}
+
+	return &stats
+}
+
+// cacheLookup is a request looked up in the cache of its Site. A request that misses can be sent
+// to the Endpoint conditionally, to revalidate a stale response.
+type cacheLookup struct {
+	c       *cache
+	uri     string
+	primary string
+	key     string
+
+	// hit is the fresh response to serve, stale the response to revalidate
+	hit, stale *cachedResponse
+
+	// store if the response may be stored, invalidate if the request was unsafe and a successful
+	// response invalidates what is cached for its URI
+	store, invalidate bool
+
+	// the conditions of the client, replaced by those of the stale response while it is revalidated
+	ifNoneMatch, ifModifiedSince string
+}
+
+// lookup looks up the request, that was sent for the URI to the upstream, returning nil for requests
+// the cache does not serve
+func (c *cache) lookup(ctx *fasthttp.RequestCtx, upstream, uri string) *cacheLookup {
+	h := &ctx.Request.Header
+	l := &cacheLookup{
+		c:               c,
+		uri:             uri,
+		primary:         primaryKey(hostname(ctx.Host()), upstream, uri),
+		ifNoneMatch:     string(h.Peek("If-None-Match")),
+		ifModifiedSince: string(h.Peek("If-Modified-Since")),
+	}
+
+	// responses to requests with credentials are not shared, RFC 9111 3.5, and ranges are not cached
+	switch {
+	case !ctx.IsGet() && !ctx.IsHead():
+		l.invalidate = !ctx.IsOptions() && !ctx.IsTrace()
+		return l
+	case len(h.Peek("Authorization")) > 0 || len(h.Peek("Range")) > 0:
+		return nil
+	}
+
+	cc := parseCacheControl([]string{string(h.Peek("Cache-Control"))})
+	l.store = ctx.IsGet() && !cc.has("no-store")
+
+	c.mu.Lock()
+	l.key = variantKey(l.primary, c.vary[l.primary], ctx)
+	c.mu.Unlock()
+
+	now := time.Now()
+	r := c.get(l.key)
+	maxAge, limited := cc.seconds("max-age")
+	revalidate := cc.has("no-cache") || strings.Contains(string(h.Peek("Pragma")), "no-cache")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if r != nil && r.fresh(now) && !revalidate && (!limited || r.age(now) <= maxAge) {
+		c.stats.Hits++
+		l.hit = r
+		return l
+	}
+
+	c.stats.Misses++
+	if r != nil && (r.header("Etag") != "" || r.header("Last-Modified") != "") {
+		l.stale = r
+	}
+
+	return l
+}
+
+// conditional returns the conditional headers to revalidate the stale response with, replacing those
+// of the client, or nil if there is none. Headers with no value are removed.
+func (l *cacheLookup) conditional() [][2]string {
+	if l.stale == nil {
+		return nil
+	}
+
+	return [][2]string{
+		{"If-None-Match", l.stale.header("Etag")},
+		{"If-Modified-Since", l.stale.header("Last-Modified")},
+	}
+}
+
+// revalidated refreshes the stale response with the headers of the 304 response that confirmed it, RFC
+// 9111 4.3.4, returning it to serve
+func (l *cacheLookup) revalidated(header [][2]string) *cachedResponse {
+	now := time.Now()
+	r := *l.stale
+
+	updated := make(map[string]bool)
+	for _, kv := range header {
+		updated[strings.ToLower(kv[0])] = true
+	}
+
+	r.Header = nil
+	for _, kv := range l.stale.Header {
+		if !updated[strings.ToLower(kv[0])] {
+			r.Header = append(r.Header, kv)
+		}
+	}
+	for _, kv := range header {
+		if !uncached(kv[0], l.c.tagHeader()) {
+			r.Header = append(r.Header, kv)
+		}
+	}
+
+	l.c.mu.Lock()
+	defer l.c.mu.Unlock()
+
+	r.Received, r.InitialAge = now, initialAge(header)
+	r.TTL, _ = freshness(r.Header, time.Duration(l.c.cfg.DefaultTtl)*time.Second, now)
+	l.c.stats.Revalidated++
+	l.c.put(l.key, &r)
+
+	return &r
+}
+
+// response stores the response to the request if it can be, or invalidates what is cached for the URI
+// of an unsafe request that succeeded, RFC 9111 4.4
+func (l *cacheLookup) response(ctx *fasthttp.RequestCtx, status int, header [][2]string, body []byte) {
+	c := l.c
+	if l.invalidate {
+		if status < 400 {
+			c.invalidate(l.uri)
+		}
+		return
+	}
+
+	if !l.cacheable(status, header) || len(body) > c.maxObjectSize() {
+		return
+	}
+
+	now := time.Now()
+	vary := headerList(headerValues(header, "Vary"))
+	r := &cachedResponse{
+		URI:        l.uri,
+		Vary:       vary,
+		Status:     status,
+		Body:       append([]byte(nil), body...),
+		Received:   now,
+		InitialAge: initialAge(header),
+	}
+
+	tags := c.tagHeader()
+	for _, kv := range header {
+		if strings.EqualFold(kv[0], tags) {
+			r.Tags = append(r.Tags, strings.FieldsFunc(kv[1], func(c rune) bool { return c == ',' || c == ' ' })...)
+		}
+		if !uncached(kv[0], tags) {
+			r.Header = append(r.Header, kv)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r.TTL, _ = freshness(header, time.Duration(c.cfg.DefaultTtl)*time.Second, now)
+
+	// the key of the request moves with the headers the responses to its URL vary on
+	if !sameFold(c.vary[l.primary], vary) {
+		c.vary[l.primary] = vary
+		if len(vary) == 0 {
+			delete(c.vary, l.primary)
+		}
+	}
+
+	c.stats.Stored++
+	c.put(variantKey(l.primary, vary, ctx), r)
+}
+
+// cacheable returns if the response can be stored, RFC 9111 3
+func (l *cacheLookup) cacheable(status int, header [][2]string) bool {
+	if !l.store || !cacheableStatus[status] || len(headerValues(header, "Set-Cookie")) > 0 {
+		return false
+	}
+
+	for _, v := range headerList(headerValues(header, "Vary")) {
+		if v == "*" {
+			return false
+		}
+	}
+
+	ttl, ok := freshness(header, time.Duration(l.c.cfg.DefaultTtl)*time.Second, time.Now())
+	validated := headerValue(header, "Etag") != "" || headerValue(header, "Last-Modified") != ""
+	return ok && (ttl > 0 || validated)
+}
+
+// notModified returns if the conditions of the client match the response, RFC 9110 13.2.2
+func (l *cacheLookup) notModified(r *cachedResponse) bool {
+	if l.ifNoneMatch != "" {
+		etag := strings.TrimPrefix(r.header("Etag"), "W/")
+		for _, tag := range headerList([]string{l.ifNoneMatch}) {
+			if tag == "*" || (etag != "" && strings.TrimPrefix(tag, "W/") == etag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if l.ifModifiedSince == "" || r.header("Last-Modified") == "" {
+		return false
+	}
+
+	since, err := http.ParseTime(l.ifModifiedSince)
+	modified, err2 := http.ParseTime(r.header("Last-Modified"))
+	return err == nil && err2 == nil && !modified.After(since)
+}
+
+// initialAge returns the age of a response when it was received, from its Age header
+func initialAge(header [][2]string) time.Duration {
+	age, err := strconv.ParseInt(headerValue(header, "Age"), 10, 64)
+	if err != nil || age < 0 {
+		return 0
+	}
+
+	return time.Duration(age) * time.Second
+}
+
+// uncached returns if a response header is not cached with the response
+func uncached(name, tagHeader string) bool {
+	if strings.EqualFold(name, tagHeader) {
+		return true
+	}
+	for _, h := range uncachedHeaders {
+		if strings.EqualFold(name, h) {
+			return true
+		}
+	}
+	for _, h := range hopHeaders {
+		if strings.EqualFold(name, h) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameFold returns if the lists of header names are the same, ignoring case
+func sameFold(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CacheStats returns the stats of the cache of the Site hostname on this node, or of every cached Site
+// if hostname is empty
+func (p *Proxy) CacheStats(hostname string) []*sites.CacheStats {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var list []*sites.CacheStats
+	for _, c := range p.caches {
+		if hostname == "" || strings.EqualFold(c.hostname, hostname) {
+			list = append(list, c.snapshot())
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Hostname < list[j].Hostname })
+
+	return list
+}
+
+// cached returns the caches of the Sites of the balancers, by lowercase hostname
+func cached(balancers map[string]*balancer) map[string]*cache {
+	caches := make(map[string]*cache)
+	for _, b := range balancers {
+		for _, s := range b.hosts {
+			if s.cache != nil {
+				caches[strings.ToLower(s.Hostname)] = s.cache
+			}
+		}
+	}
+
+	return caches
+}
+
+// serve writes the cached response to ctx, or 304 if the conditions of the client match it
+func (l *cacheLookup) serve(ctx *fasthttp.RequestCtx, r *cachedResponse, state string) {
+	resp := &ctx.Response
+	resp.Reset()
+
+	// Set parses the headers fasthttp keeps apart, such as Content-Type, and Add does not
+	seen := make(map[string]bool)
+	for _, kv := range r.Header {
+		if seen[kv[0]] {
+			resp.Header.Add(kv[0], kv[1])
+			continue
+		}
+		resp.Header.Set(kv[0], kv[1])
+		seen[kv[0]] = true
+	}
+	resp.Header.Set("Age", strconv.Itoa(int(r.age(time.Now())/time.Second)))
+	resp.Header.Set(CacheHeader, state)
+
+	if l.notModified(r) {
+		resp.SetStatusCode(fasthttp.StatusNotModified)
+		return
+	}
+
+	resp.SetStatusCode(r.Status)
+	resp.SetBody(r.Body)
+}
+
+// finish stores the response the Endpoint sent to ctx, or serves the stale response it revalidated
+func (l *cacheLookup) finish(ctx *fasthttp.RequestCtx) {
+	var header [][2]string
+	ctx.Response.Header.VisitAll(func(k, v []byte) {
+		header = append(header, [2]string{string(k), string(v)})
+	})
+
+	status := ctx.Response.StatusCode()
+	if status == fasthttp.StatusNotModified && l.stale != nil {
+		l.serve(ctx, l.revalidated(header), "REVALIDATED")
+		return
+	}
+
+	l.response(ctx, status, header, ctx.Response.Body())
+	if !l.invalidate {
+		ctx.Response.Header.Del(l.c.tagHeader())
+		ctx.Response.Header.Set(CacheHeader, "MISS")
+	}
+}
+
+// serveHTTP writes the cached response to w, or 304 if the conditions of the client match it
+func (l *cacheLookup) serveHTTP(w http.ResponseWriter, r *cachedResponse, state string) {
+	h := w.Header()
+	for _, kv := range r.Header {
+		h.Add(kv[0], kv[1])
+	}
+	h.Set("Age", strconv.Itoa(int(r.age(time.Now())/time.Second)))
+	h.Set(CacheHeader, state)
+
+	if l.notModified(r) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	h.Set("Content-Length", strconv.Itoa(len(r.Body)))
+	w.WriteHeader(r.Status)
+	w.Write(r.Body)
+}
+
+// httpHeader returns the values of the net/http header h, in the form they are cached in
+func httpHeader(h http.Header) [][2]string {
+	var header [][2]string
+	for k, vv := range h {
+		for _, v := range vv {
+			header = append(header, [2]string{k, v})
+		}
+	}
+
+	return header
+}
+
+// cappedBuffer buffers what is written to it until it would hold more than max bytes, then discards
+// it, so a streamed response can be cached if it turns out small enough
+type cappedBuffer struct {
+	bytes.Buffer
+
+	max  int
+	over bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if b.over || b.Len()+len(p) > b.max {
+		b.over = true
+		b.Reset()
+		return len(p), nil
+	}
+
+	return b.Buffer.Write(p)
+}
@@ -0,0 +1,288 @@
+package proxy
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// testCache returns the cache of example.com with the config
+func testCache(cfg *sites.Cache) *cache {
+	p := &Proxy{caches: make(map[string]*cache)}
+	return p.siteCache("example.com", cfg)
+}
+
+func TestCacheControl(t *testing.T) {
+	Convey("Cache-Control directives should be read across header lines, by lowercase name", t, func() {
+		cc := parseCacheControl([]string{`Max-Age=60, no-cache="Set-Cookie"`, " private ,,s-maxage=10"})
+		So(cc, ShouldResemble, cacheControl{"max-age": "60", "no-cache": "Set-Cookie", "private": "", "s-maxage": "10"})
+	})
+
+	Convey("Only valid delta-seconds should be seconds", t, func() {
+		cc := parseCacheControl([]string{`max-age="30", s-maxage=-1, stale=abc`})
+
+		ttl, ok := cc.seconds("max-age")
+		So(ok, ShouldBeTrue)
+		So(ttl, ShouldEqual, time.Second*30)
+
+		for _, directive := range []string{"s-maxage", "stale", "missing"} {
+			_, ok := cc.seconds(directive)
+			So(ok, ShouldBeFalse)
+		}
+	})
+}
+
+func TestFreshness(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	date := now.Add(-time.Minute).Format(http.TimeFormat)
+
+	cases := []struct {
+		name   string
+		header [][2]string
+		ttl    time.Duration
+		store  bool
+	}{
+		{"no-store should not be stored",
+			[][2]string{{"Cache-Control", "max-age=60, no-store"}}, 0, false},
+		{"private should not be stored by a shared cache",
+			[][2]string{{"Cache-Control", "private, max-age=60"}}, 0, false},
+		{"no-cache should be stored stale",
+			[][2]string{{"Cache-Control", "no-cache, max-age=60"}}, 0, true},
+		{"max-age should be the ttl",
+			[][2]string{{"Cache-Control", "max-age=60"}}, time.Minute, true},
+		{"s-maxage should win over max-age",
+			[][2]string{{"Cache-Control", "max-age=60, s-maxage=5"}}, time.Second * 5, true},
+		{"an invalid max-age should fall back to the default",
+			[][2]string{{"Cache-Control", "max-age=soon"}}, time.Second * 10, true},
+		{"max-age should win over Expires",
+			[][2]string{{"Cache-Control", "max-age=60"}, {"Expires", now.Add(time.Hour).Format(http.TimeFormat)}}, time.Minute, true},
+		{"Expires should be counted from Date",
+			[][2]string{{"Date", date}, {"Expires", now.Add(time.Minute).Format(http.TimeFormat)}}, time.Minute * 2, true},
+		{"Expires without a Date should be counted from now",
+			[][2]string{{"Expires", now.Add(time.Minute).Format(http.TimeFormat)}}, time.Minute, true},
+		{"an Expires in the past should be stored stale",
+			[][2]string{{"Expires", now.Add(-time.Minute).Format(http.TimeFormat)}}, 0, true},
+		{"an invalid Expires should be stored stale",
+			[][2]string{{"Expires", "0"}}, 0, true},
+		{"a response that does not say should be fresh for the default",
+			[][2]string{{"Content-Type", "text/html"}}, time.Second * 10, true},
+	}
+
+	for _, tc := range cases {
+		Convey("For the freshness of a response, "+tc.name, t, func() {
+			ttl, store := freshness(tc.header, time.Second*10, now)
+			So(store, ShouldEqual, tc.store)
+			So(ttl, ShouldEqual, tc.ttl)
+		})
+	}
+
+	Convey("A response that does not say should not be stored without a default", t, func() {
+		_, store := freshness(nil, 0, now)
+		So(store, ShouldBeFalse)
+	})
+
+	Convey("The age of a response should start at its Age header", t, func() {
+		r := &cachedResponse{Received: now, InitialAge: initialAge([][2]string{{"Age", "50"}}), TTL: time.Minute}
+		So(r.age(now.Add(time.Second*5)), ShouldEqual, time.Second*55)
+		So(r.fresh(now.Add(time.Second*9)), ShouldBeTrue)
+		So(r.fresh(now.Add(time.Second*10)), ShouldBeFalse)
+
+		So(initialAge([][2]string{{"Age", "-1"}}), ShouldEqual, 0)
+		So(initialAge(nil), ShouldEqual, 0)
+	})
+}
+
+func TestCacheKeys(t *testing.T) {
+	Convey("The primary key should tell apart the host, upstream and URI", t, func() {
+		keys := map[string]bool{
+			primaryKey("example.com", "web", "/a"):     true,
+			primaryKey("www.example.com", "web", "/a"): true,
+			primaryKey("example.com", "canary", "/a"):  true,
+			primaryKey("example.com", "web", "/a?b"):   true,
+		}
+		So(keys, ShouldHaveLength, 4)
+	})
+
+	Convey("A variant key", t, func() {
+		primary := primaryKey("example.com", "web", "/")
+		gzip := testRequest("GET", "/", "Accept-Encoding", "gzip", "Accept-Language", "en")
+		br := testRequest("GET", "/", "Accept-Encoding", "br", "Accept-Language", "en")
+		none := testRequest("GET", "/")
+
+		Convey("should be the primary key if the responses do not vary", func() {
+			So(variantKey(primary, nil, gzip), ShouldEqual, primary)
+		})
+
+		Convey("should differ with the values of the headers the responses vary on", func() {
+			vary := []string{"Accept-Encoding"}
+			So(variantKey(primary, vary, gzip), ShouldNotEqual, variantKey(primary, vary, br))
+			So(variantKey(primary, vary, gzip), ShouldNotEqual, variantKey(primary, vary, none))
+			So(variantKey(primary, vary, gzip), ShouldNotEqual, primary)
+		})
+
+		Convey("should not differ with the headers the responses do not vary on", func() {
+			vary := []string{"Accept-Language"}
+			So(variantKey(primary, vary, gzip), ShouldEqual, variantKey(primary, vary, br))
+		})
+
+		Convey("should not differ with the case of the header names", func() {
+			So(variantKey(primary, []string{"accept-encoding"}, gzip), ShouldEqual, variantKey(primary, []string{"Accept-Encoding"}, gzip))
+		})
+	})
+}
+
+func TestCacheLookup(t *testing.T) {
+	ok := [][2]string{{"Cache-Control", "max-age=60"}, {"Vary", "Accept-Encoding"}, {"Etag", `"v1"`}}
+
+	Convey("A cached response", t, func() {
+		c := testCache(&sites.Cache{})
+		gzip := testRequest("GET", "http://example.com/a", "Accept-Encoding", "gzip")
+		l := c.lookup(gzip, "web", "/a")
+		So(l.hit, ShouldBeNil)
+		l.response(gzip, 200, append(ok, [2]string{"Age", "5"}), []byte("hello"))
+
+		Convey("should be hit by a request with the same values of the headers it varies on", func() {
+			l := c.lookup(testRequest("GET", "http://example.com/a", "Accept-Encoding", "gzip", "Accept-Language", "de"), "web", "/a")
+			So(l.hit, ShouldNotBeNil)
+			So(string(l.hit.Body), ShouldEqual, "hello")
+			So(l.hit.header("Age"), ShouldEqual, "")
+			So(l.hit.age(time.Now()), ShouldBeGreaterThanOrEqualTo, time.Second*5)
+		})
+
+		Convey("should be missed by a request with other values of the headers it varies on", func() {
+			So(c.lookup(testRequest("GET", "http://example.com/a", "Accept-Encoding", "br"), "web", "/a").hit, ShouldBeNil)
+		})
+
+		Convey("should be missed through another upstream or host", func() {
+			So(c.lookup(testRequest("GET", "http://example.com/a", "Accept-Encoding", "gzip"), "canary", "/a").hit, ShouldBeNil)
+			So(c.lookup(testRequest("GET", "http://www.example.com/a", "Accept-Encoding", "gzip"), "web", "/a").hit, ShouldBeNil)
+		})
+
+		Convey("should be revalidated for a request with no-cache", func() {
+			l := c.lookup(testRequest("GET", "http://example.com/a", "Accept-Encoding", "gzip", "Cache-Control", "no-cache"), "web", "/a")
+			So(l.hit, ShouldBeNil)
+			So(l.stale, ShouldNotBeNil)
+			So(l.conditional(), ShouldResemble, [][2]string{{"If-None-Match", `"v1"`}, {"If-Modified-Since", ""}})
+		})
+
+		Convey("should be missed by a request with a lower max-age than its age", func() {
+			l := c.lookup(testRequest("GET", "http://example.com/a", "Accept-Encoding", "gzip", "Cache-Control", "max-age=1"), "web", "/a")
+			So(l.hit, ShouldBeNil)
+		})
+
+		Convey("should not be served to requests with credentials or ranges", func() {
+			So(c.lookup(testRequest("GET", "http://example.com/a", "Authorization", "Basic eDp5"), "web", "/a"), ShouldBeNil)
+			So(c.lookup(testRequest("GET", "http://example.com/a", "Range", "bytes=0-1"), "web", "/a"), ShouldBeNil)
+		})
+
+		Convey("should be invalidated by an unsafe request to its URI that succeeds", func() {
+			post := testRequest("POST", "http://example.com/a")
+			c.lookup(post, "web", "/a").response(post, 500, nil, nil)
+			So(c.lookup(testRequest("GET", "http://example.com/a", "Accept-Encoding", "gzip"), "web", "/a").hit, ShouldNotBeNil)
+
+			c.lookup(post, "web", "/a").response(post, 204, nil, nil)
+			So(c.lookup(testRequest("GET", "http://example.com/a", "Accept-Encoding", "gzip"), "web", "/a").hit, ShouldBeNil)
+		})
+	})
+
+	Convey("A response", t, func() {
+		c := testCache(&sites.Cache{})
+		l := c.lookup(testRequest("GET", "http://example.com/a"), "web", "/a")
+
+		cases := []struct {
+			name      string
+			status    int
+			header    [][2]string
+			cacheable bool
+		}{
+			{"with a max-age should be stored", 200, [][2]string{{"Cache-Control", "max-age=60"}}, true},
+			{"that is stale but can be revalidated should be stored", 200, [][2]string{{"Cache-Control", "no-cache"}, {"Etag", `"v1"`}}, true},
+			{"that is stale and cannot be revalidated should not be stored", 200, [][2]string{{"Cache-Control", "no-cache"}}, false},
+			{"without a ttl or a default should not be stored", 200, nil, false},
+			{"of an uncacheable status should not be stored", 500, [][2]string{{"Cache-Control", "max-age=60"}}, false},
+			{"with Set-Cookie should not be stored", 200, [][2]string{{"Cache-Control", "max-age=60"}, {"Set-Cookie", "a=b"}}, false},
+			{"that varies on everything should not be stored", 200, [][2]string{{"Cache-Control", "max-age=60"}, {"Vary", "Accept, *"}}, false},
+		}
+		for _, tc := range cases {
+			Convey(tc.name, func() {
+				So(l.cacheable(tc.status, tc.header), ShouldEqual, tc.cacheable)
+			})
+		}
+
+		Convey("to a request with no-store should not be stored", func() {
+			l := c.lookup(testRequest("GET", "http://example.com/a", "Cache-Control", "no-store"), "web", "/a")
+			So(l.cacheable(200, [][2]string{{"Cache-Control", "max-age=60"}}), ShouldBeFalse)
+		})
+	})
+}
+
+func TestNotModified(t *testing.T) {
+	modified := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	r := &cachedResponse{Header: [][2]string{{"Etag", `W/"v1"`}, {"Last-Modified", modified.Format(http.TimeFormat)}}}
+
+	cases := []struct {
+		name                    string
+		ifNoneMatch, ifModSince string
+		notModified             bool
+	}{
+		{"a matching weak etag should be not modified", `"v1"`, "", true},
+		{"one of several etags matching should be not modified", `"v0", W/"v1"`, "", true},
+		{"any etag should be not modified", "*", "", true},
+		{"another etag should be modified", `"v2"`, "", false},
+		{"If-None-Match should win over If-Modified-Since", `"v2"`, modified.Format(http.TimeFormat), false},
+		{"a date since the modification should be not modified", "", modified.Format(http.TimeFormat), true},
+		{"a date before the modification should be modified", "", modified.Add(-time.Second).Format(http.TimeFormat), false},
+		{"an invalid date should be modified", "", "yesterday", false},
+		{"no conditions should be modified", "", "", false},
+	}
+
+	for _, tc := range cases {
+		Convey("For the conditions of a client, "+tc.name, t, func() {
+			l := &cacheLookup{ifNoneMatch: tc.ifNoneMatch, ifModifiedSince: tc.ifModSince}
+			So(l.notModified(r), ShouldEqual, tc.notModified)
+		})
+	}
+}
+
+func TestPurges(t *testing.T) {
+	cases := []struct {
+		name   string
+		purge  *sites.CachePurge
+		uri    string
+		tags   []string
+		purges bool
+	}{
+		{"a URL should match the exact URI", &sites.CachePurge{Urls: []string{"/a?b=1"}}, "/a?b=1", nil, true},
+		{"a URL should not match another query", &sites.CachePurge{Urls: []string{"/a?b=1"}}, "/a?b=2", nil, false},
+		{"a prefix should match the path", &sites.CachePurge{Prefixes: []string{"/static/"}}, "/static/app.js?v=2", nil, true},
+		{"a prefix should not match the query", &sites.CachePurge{Prefixes: []string{"/a?b"}}, "/a?b=1", nil, false},
+		{"a tag should match a response with it", &sites.CachePurge{Tags: []string{"product-1"}}, "/", []string{"home", "product-1"}, true},
+		{"a tag should not match a response without it", &sites.CachePurge{Tags: []string{"product-1"}}, "/", []string{"product-12"}, false},
+	}
+
+	for _, tc := range cases {
+		Convey("For a purge, "+tc.name, t, func() {
+			So(purges(tc.purge, tc.uri, tc.tags), ShouldEqual, tc.purges)
+		})
+	}
+
+	Convey("A purge of a cache should remove the responses it matches", t, func() {
+		c := testCache(&sites.Cache{DefaultTtl: 60})
+		for _, uri := range []string{"/a", "/a?b=1", "/static/app.js"} {
+			ctx := testRequest("GET", "http://example.com"+uri)
+			c.lookup(ctx, "web", uri).response(ctx, 200, [][2]string{{"Cache-Tag", "t-" + uri}}, []byte("x"))
+		}
+		So(c.snapshot().Entries, ShouldEqual, 3)
+
+		So(c.purge(&sites.CachePurge{Urls: []string{"/a"}}), ShouldEqual, 1)
+		So(c.purge(&sites.CachePurge{Tags: []string{"t-/static/app.js"}}), ShouldEqual, 1)
+		So(c.snapshot().Entries, ShouldEqual, 1)
+
+		So(c.purge(&sites.CachePurge{}), ShouldEqual, 1)
+		So(c.snapshot().Entries, ShouldEqual, 0)
+	})
+}
@@ -0,0 +1,215 @@
+package proxy
+
+import (
+	"bufio"
+	"container/list"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// diskCache is the disk tier of the cache of a Site, in a directory of the Site under the disk path.
+// Each response is a file, named for the hash of its key, of the gob encoded diskMeta of the response
+// then the response, so the responses can be indexed again when the proxy restarts without reading
+// their bodies. The disk cache is locked by the cache it is the tier of.
+type diskCache struct {
+	dir string
+
+	// entries are the elements of lru holding the diskMeta of the responses, by variant key
+	entries map[string]*list.Element
+	lru     *list.List
+	uris    uriIndex
+	size    int64
+}
+
+// diskMeta is what the disk cache indexes a response by
+type diskMeta struct {
+	Key  string
+	URI  string
+	Tags []string
+
+	file string
+	size int64
+}
+
+// openDiskCache opens the disk tier of the Site hostname under the path, indexing the responses in it
+func openDiskCache(path, hostname string) *diskCache {
+	d := &diskCache{
+		dir:     filepath.Join(path, strings.ToLower(hostname)),
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		uris:    make(uriIndex),
+	}
+
+	if err := os.MkdirAll(d.dir, 0700); err != nil {
+		log.Printf("unable to open cache of %s on disk: %s", hostname, err)
+		return d
+	}
+
+	files, err := ioutil.ReadDir(d.dir)
+	if err != nil {
+		log.Printf("unable to open cache of %s on disk: %s", hostname, err)
+		return d
+	}
+
+	for _, fi := range files {
+		file := filepath.Join(d.dir, fi.Name())
+		m, err := readDiskMeta(file)
+		if err != nil {
+			os.Remove(file)
+			continue
+		}
+
+		m.file, m.size = file, fi.Size()
+		d.entries[m.Key] = d.lru.PushBack(m)
+		d.uris.add(m.URI, m.Key)
+		d.size += m.size
+	}
+
+	return d
+}
+
+// readDiskMeta reads the diskMeta a response file starts with
+func readDiskMeta(file string) (*diskMeta, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	m := &diskMeta{}
+	if err := gob.NewDecoder(bufio.NewReader(f)).Decode(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// readCachedFile reads the response in a response file, or nil if it can not be read
+func readCachedFile(file string) *cachedResponse {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	dec := gob.NewDecoder(bufio.NewReader(f))
+	r := &cachedResponse{}
+	if err := dec.Decode(&diskMeta{}); err != nil {
+		return nil
+	}
+	if err := dec.Decode(r); err != nil {
+		return nil
+	}
+
+	return r
+}
+
+// writeCachedFile writes the response with the key to its file in the directory, returning the file
+func writeCachedFile(dir, key string, r *cachedResponse) (string, error) {
+	sum := sha256.Sum256([]byte(key))
+	file := filepath.Join(dir, hex.EncodeToString(sum[:]))
+
+	// the response is written beside its file then moved over it, so it is never read half written
+	tmp, err := ioutil.TempFile(dir, ".tmp-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := gob.NewEncoder(w)
+	err = enc.Encode(&diskMeta{Key: key, URI: r.URI, Tags: r.Tags})
+	if err == nil {
+		err = enc.Encode(r)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return file, os.Rename(tmp.Name(), file)
+}
+
+// file returns the file of the response with the key, or "" if there is none
+func (d *diskCache) file(key string) string {
+	el, ok := d.entries[key]
+	if !ok {
+		return ""
+	}
+
+	d.lru.MoveToFront(el)
+	return el.Value.(*diskMeta).file
+}
+
+// add indexes the response with the key written to the file, then removes the least recently used
+// responses until they fit the max size, returning how many it removed
+func (d *diskCache) add(key string, r *cachedResponse, file string, max int64) uint64 {
+	size := int64(0)
+	if fi, err := os.Stat(file); err == nil {
+		size = fi.Size()
+	}
+
+	// the file of a response that was already on disk has been replaced, so is not removed
+	if el, ok := d.entries[key]; ok {
+		m := el.Value.(*diskMeta)
+		d.lru.Remove(el)
+		d.uris.remove(m.URI, key)
+		d.size -= m.size
+	}
+	d.entries[key] = d.lru.PushFront(&diskMeta{Key: key, URI: r.URI, Tags: r.Tags, file: file, size: size})
+	d.uris.add(r.URI, key)
+	d.size += size
+
+	var evicted uint64
+	for d.size > max && d.lru.Len() > 0 {
+		d.remove(d.lru.Back().Value.(*diskMeta).Key)
+		evicted++
+	}
+
+	return evicted
+}
+
+// remove removes the response with the key, and its file
+func (d *diskCache) remove(key string) bool {
+	el, ok := d.entries[key]
+	if !ok {
+		return false
+	}
+
+	m := el.Value.(*diskMeta)
+	d.lru.Remove(el)
+	delete(d.entries, key)
+	d.uris.remove(m.URI, key)
+	d.size -= m.size
+	os.Remove(m.file)
+	return true
+}
+
+// purge removes the responses that match, returning how many it removed
+func (d *diskCache) purge(match func(uri string, tags []string) bool) uint64 {
+	var n uint64
+	for key, el := range d.entries {
+		m := el.Value.(*diskMeta)
+		if match(m.URI, m.Tags) && d.remove(key) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// usage returns how many responses are on disk, and their size
+func (d *diskCache) usage() (uint64, uint64) {
+	return uint64(len(d.entries)), uint64(d.size)
+}
@@ -0,0 +1,137 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/unerror/waffy/pkg/acme"
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// CertStore selects the certificate for a TLS handshake by SNI, from the Site certificates in the
+// store. Names are matched exactly, then against a wildcard for the parent domain.
+type CertStore struct {
+	acme     *acme.Manager
+	fallback func(*tls.ClientHelloInfo) (*tls.Certificate, error)
+
+	mu    sync.RWMutex
+	certs map[string]*tls.Certificate
+}
+
+// NewCertStore creates a CertStore that answers ACME challenges from the Manager, and serves the
+// fallback for names with no Site certificate
+func NewCertStore(m *acme.Manager, fallback func(*tls.ClientHelloInfo) (*tls.Certificate, error)) *CertStore {
+	return &CertStore{
+		acme:     m,
+		fallback: fallback,
+		certs:    make(map[string]*tls.Certificate),
+	}
+}
+
+// GetCertificate returns the certificate for the SNI of the handshake, for tls.Config
+func (c *CertStore) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if acme.IsChallenge(hello) {
+		return c.acme.ChallengeCertificate(hello.ServerName)
+	}
+
+	if cert := c.Lookup(hello.ServerName); cert != nil {
+		return cert, nil
+	}
+
+	return c.fallback(hello)
+}
+
+// Lookup returns the certificate for the name, or nil if there is none
+func (c *CertStore) Lookup(name string) *tls.Certificate {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if cert, ok := c.certs[name]; ok {
+		return cert
+	}
+
+	if cert, ok := c.certs[wildcard(name)]; ok {
+		return cert
+	}
+
+	return nil
+}
+
+// load indexes the Site certificates by the hostname and aliases of their Site that they are valid
+// for, so a certificate only answers for the names of its own Site. A name that is the hostname of a
+// Site is served its certificate over that of a Site it is an alias of, and a name that is an alias
+// of more than one Site the certificate of the first.
+func (c *CertStore) load(db data.Store, list []*sites.Site) error {
+	stored, err := repository.ListSiteCertificates(db)
+	if err != nil {
+		return err
+	}
+
+	byHost := make(map[string]*tls.Certificate)
+	for _, s := range stored {
+		keypair, err := crypto.KeyPair(s.Certificate, s.Key)
+		if err != nil {
+			log.Printf("unable to load certificate for %s: %s", s.Hostname, err)
+			continue
+		}
+
+		byHost[s.Hostname] = keypair
+	}
+
+	// hostnames are indexed before aliases, and no name is indexed twice
+	certs := make(map[string]*tls.Certificate)
+	index := func(s *sites.Site, names []string) {
+		keypair, ok := byHost[s.Hostname]
+		if !ok {
+			return
+		}
+
+		for _, name := range names {
+			name = strings.ToLower(name)
+			if _, ok := certs[name]; !ok && covers(keypair, name) {
+				certs[name] = keypair
+			}
+		}
+	}
+	for _, s := range list {
+		index(s, []string{s.Hostname})
+	}
+	for _, s := range list {
+		index(s, s.Alias)
+	}
+
+	c.mu.Lock()
+	c.certs = certs
+	c.mu.Unlock()
+
+	return nil
+}
+
+// covers returns if the certificate is valid for the lowercase name, which may be a wildcard
+func covers(keypair *tls.Certificate, name string) bool {
+	for _, n := range keypair.Leaf.DNSNames {
+		n = strings.ToLower(n)
+		if n == name || n == wildcard(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// wildcard returns the wildcard name that matches the name, *.example.com for www.example.com
+func wildcard(name string) string {
+	i := strings.IndexByte(name, '.')
+	if i < 0 {
+		return ""
+	}
+
+	return "*" + name[i:]
+}
@@ -0,0 +1,115 @@
+package proxy
+
+import (
+	"crypto/ecdsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// storeCert stores a self-signed certificate for the names as the certificate of the Site hostname
+func storeCert(db data.Store, hostname string, names ...string) {
+	cert := selfSigned(names...)
+	key, err := x509.MarshalECPrivateKey(cert.PrivateKey.(*ecdsa.PrivateKey))
+	So(err, ShouldBeNil)
+
+	So(repository.SaveSiteCertificate(db, &sites.SiteCertificate{
+		Hostname:    hostname,
+		Certificate: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Leaf.Raw}),
+		Key:         pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: key}),
+	}), ShouldBeNil)
+}
+
+// served returns the first DNS name of the certificate the store serves for the name, or "" if it
+// serves none
+func served(c *CertStore, name string) string {
+	cert := c.Lookup(name)
+	if cert == nil {
+		return ""
+	}
+
+	return cert.Leaf.DNSNames[0]
+}
+
+func TestCertStore(t *testing.T) {
+	Convey("With the certificates of Sites", t, func() {
+		db, cleanup := newTestConsensus(t, true)
+		defer cleanup()
+
+		storeCert(db, "example.com", "example.com", "www.example.com", "*.example.net", "example.org", "shared.example.com", "both.example.com")
+		storeCert(db, "example.org", "example.org")
+		storeCert(db, "wild.example.io", "*.example.io")
+		storeCert(db, "shared.example.com", "shared.example.com", "both.example.com")
+		storeCert(db, "unused.example.com", "unused.example.com")
+
+		list := []*sites.Site{
+			{Hostname: "example.com", Alias: []string{"WWW.example.com", "*.example.net", "new.example.com", "shared.example.com", "both.example.com"}},
+			{Hostname: "example.org"},
+			{Hostname: "wild.example.io", Alias: []string{"other.example.io"}},
+			{Hostname: "shared.example.com", Alias: []string{"both.example.com"}},
+			{Hostname: "nocert.example.com"},
+		}
+
+		c := NewCertStore(nil, func(*tls.ClientHelloInfo) (*tls.Certificate, error) { return nil, nil })
+		So(c.load(db, list), ShouldBeNil)
+
+		Convey("A hostname should be served the certificate of its Site, in any case", func() {
+			So(served(c, "example.com"), ShouldEqual, "example.com")
+			So(served(c, "Example.COM."), ShouldEqual, "example.com")
+			So(served(c, "example.org"), ShouldEqual, "example.org")
+		})
+
+		Convey("An alias should be served the certificate of its Site", func() {
+			So(served(c, "www.example.com"), ShouldEqual, "example.com")
+			So(served(c, "other.example.io"), ShouldEqual, "*.example.io")
+		})
+
+		Convey("A wildcard alias should serve the names it matches", func() {
+			So(served(c, "a.example.net"), ShouldEqual, "example.com")
+			So(served(c, "a.b.example.net"), ShouldEqual, "")
+		})
+
+		Convey("A name should not be served a certificate of a Site it is not a name of", func() {
+			So(served(c, "unused.example.com"), ShouldEqual, "")
+			So(served(c, "a.example.io"), ShouldEqual, "")
+		})
+
+		Convey("A name should not be served a certificate that is not valid for it", func() {
+			So(served(c, "new.example.com"), ShouldEqual, "")
+			So(served(c, "nocert.example.com"), ShouldEqual, "")
+		})
+
+		Convey("The hostname of a Site should take its certificate over one of a Site it is an alias of", func() {
+			So(served(c, "shared.example.com"), ShouldEqual, "shared.example.com")
+		})
+
+		Convey("An alias of more than one Site should take the certificate of the first", func() {
+			So(served(c, "both.example.com"), ShouldEqual, "example.com")
+		})
+
+		Convey("A name with no certificate should be served the fallback", func() {
+			fallback := selfSigned("fallback")
+			c.fallback = func(*tls.ClientHelloInfo) (*tls.Certificate, error) { return fallback, nil }
+
+			cert, err := c.GetCertificate(&tls.ClientHelloInfo{ServerName: "missing.example.com"})
+			So(err, ShouldBeNil)
+			So(cert, ShouldEqual, fallback)
+
+			cert, err = c.GetCertificate(&tls.ClientHelloInfo{ServerName: "example.org"})
+			So(err, ShouldBeNil)
+			So(cert.Leaf.DNSNames, ShouldResemble, []string{"example.org"})
+		})
+	})
+
+	Convey("A wildcard name should only match a single label", t, func() {
+		So(wildcard("www.example.com"), ShouldEqual, "*.example.com")
+		So(wildcard("localhost"), ShouldEqual, "")
+	})
+}
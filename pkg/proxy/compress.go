@@ -0,0 +1,351 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// DefaultCompressionMinSize is the smallest body compressed, for Compressions that do not set one
+	DefaultCompressionMinSize = 1024
+
+	// Content codings, RFC 9110 8.4.1
+	EncodingBrotli = "br"
+	EncodingZstd   = "zstd"
+	EncodingGzip   = "gzip"
+)
+
+// DefaultEncodings are the encodings responses are compressed with, preferred first, for Compressions
+// that do not set them
+var DefaultEncodings = []string{EncodingBrotli, EncodingZstd, EncodingGzip}
+
+// DefaultCompressedTypes are the content types compressed, for Compressions that do not set them
+var DefaultCompressedTypes = []string{
+	"text/*",
+	"application/javascript",
+	"application/x-javascript",
+	"application/json",
+	"application/ld+json",
+	"application/manifest+json",
+	"application/xml",
+	"application/xhtml+xml",
+	"application/rss+xml",
+	"application/atom+xml",
+	"application/wasm",
+	"image/svg+xml",
+	"image/x-icon",
+	"font/ttf",
+	"font/otf",
+	"application/vnd.ms-fontobject",
+}
+
+// precompressed are the content types that are already compressed, or framed for streaming, so are
+// never compressed even if a wildcard content type of a Compression matches them
+var precompressed = []string{
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"image/avif",
+	"video/*",
+	"audio/*",
+	"font/woff",
+	"font/woff2",
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"application/zstd",
+	"application/x-bzip2",
+	"application/x-xz",
+	"application/x-7z-compressed",
+	"application/x-rar-compressed",
+	"application/grpc",
+}
+
+// compression is the Compression of a Site, with its defaults applied
+type compression struct {
+	*sites.Compression
+
+	encodings []string
+	types     []string
+	minSize   int
+}
+
+// newCompression creates the compression for c, which may not be nil
+func newCompression(c *sites.Compression) *compression {
+	cp := &compression{
+		Compression: c,
+		encodings:   DefaultEncodings,
+		types:       DefaultCompressedTypes,
+		minSize:     DefaultCompressionMinSize,
+	}
+
+	if len(c.Encodings) > 0 {
+		cp.encodings = c.Encodings
+	}
+	if len(c.ContentTypes) > 0 {
+		cp.types = c.ContentTypes
+	}
+	if c.MinSize > 0 {
+		cp.minSize = int(c.MinSize)
+	}
+
+	return cp
+}
+
+// negotiable returns if a response with the status and header, and a body of size bytes or -1 if
+// its size is not known, is compressed for clients that accept it
+func (c *compression) negotiable(status int, header func(string) string, size int) bool {
+	switch {
+	case status < 200, status == http.StatusNoContent, status == http.StatusPartialContent,
+		status == http.StatusNotModified:
+		return false
+	case size >= 0 && size < c.minSize:
+		return false
+	case header("Content-Encoding") != "" && !strings.EqualFold(header("Content-Encoding"), "identity"):
+		return false
+	case header("Content-Range") != "":
+		return false
+	}
+
+	// a response can forbid proxies from transforming it, RFC 9111 5.2.2.6
+	if parseCacheControl([]string{header("Cache-Control")}).has("no-transform") {
+		return false
+	}
+
+	mt, _, err := mime.ParseMediaType(header("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	return matchType(c.types, mt) && !matchType(precompressed, mt)
+}
+
+// encoding returns the encoding the client accepts most, preferring that of the Compression that
+// comes first, or "" if it accepts none of them, as RFC 9110 12.5.3
+func (c *compression) encoding(accept string) string {
+	q := make(map[string]float64)
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(params[0]))
+		if name == "" {
+			continue
+		}
+		if name == "x-gzip" {
+			name = EncodingGzip
+		}
+
+		q[name] = 1
+		for _, p := range params[1:] {
+			kv := strings.SplitN(strings.TrimSpace(p), "=", 2)
+			if len(kv) == 2 && strings.EqualFold(kv[0], "q") {
+				if v, err := strconv.ParseFloat(kv[1], 64); err == nil {
+					q[name] = v
+				}
+			}
+		}
+	}
+
+	best, bestQ := "", 0.0
+	for _, enc := range c.encodings {
+		v, ok := q[enc]
+		if !ok {
+			v = q["*"]
+		}
+		if v > bestQ {
+			best, bestQ = enc, v
+		}
+	}
+
+	return best
+}
+
+// compress compresses the response of the request with the encoding the client accepts, if the
+// response is negotiable and compresses smaller than it was
+func (c *compression) compress(ctx *fasthttp.RequestCtx) {
+	resp := &ctx.Response
+	header := func(k string) string {
+		return string(resp.Header.Peek(k))
+	}
+	if !c.negotiable(resp.StatusCode(), header, len(resp.Body())) {
+		return
+	}
+
+	resp.Header.Set("Vary", varyEncoding(header("Vary")))
+	enc := c.encoding(string(ctx.Request.Header.Peek("Accept-Encoding")))
+	if enc == "" || ctx.IsHead() {
+		return
+	}
+
+	var buf bytes.Buffer
+	w := newEncoder(enc, &buf)
+	_, err := w.Write(resp.Body())
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil || buf.Len() >= len(resp.Body()) {
+		return
+	}
+
+	resp.Header.Set("Content-Encoding", enc)
+	if etag := header("Etag"); etag != "" {
+		resp.Header.Set("Etag", weakETag(etag))
+	}
+	resp.SetBody(buf.Bytes())
+}
+
+// writer returns a writer that compresses the response to req written to w, if it is negotiable once
+// its header is written. The writer must be closed once the response is written.
+func (c *compression) writer(w http.ResponseWriter, req *http.Request) *compressWriter {
+	return &compressWriter{
+		ResponseWriter: w,
+		c:              c,
+		accept:         req.Header.Get("Accept-Encoding"),
+		head:           req.Method == http.MethodHead,
+	}
+}
+
+// compressWriter is an http.ResponseWriter that compresses the response written to it, flushing what
+// it has compressed whenever it is flushed so streamed responses are not held back
+type compressWriter struct {
+	http.ResponseWriter
+
+	c      *compression
+	accept string
+	head   bool
+
+	wroteHeader bool
+	enc         encoder
+}
+
+// WriteHeader writes the header of the response, with the encoding it is compressed with if any
+func (w *compressWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	h := w.Header()
+	size := -1
+	if cl, err := strconv.Atoi(h.Get("Content-Length")); err == nil {
+		size = cl
+	}
+
+	if w.c.negotiable(status, h.Get, size) {
+		h.Set("Vary", varyEncoding(strings.Join(h["Vary"], ", ")))
+
+		if enc := w.c.encoding(w.accept); enc != "" && !w.head {
+			h.Del("Content-Length")
+			h.Set("Content-Encoding", enc)
+			if etag := h.Get("Etag"); etag != "" {
+				h.Set("Etag", weakETag(etag))
+			}
+			w.enc = newEncoder(enc, w.ResponseWriter)
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write writes b to the response, compressed if it is
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.enc != nil {
+		return w.enc.Write(b)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// FlushError writes what has been compressed so far, and flushes the response
+func (w *compressWriter) FlushError() error {
+	if w.enc != nil {
+		if err := w.enc.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Close ends the compressed response
+func (w *compressWriter) Close() error {
+	if w.enc == nil {
+		return nil
+	}
+
+	return w.enc.Close()
+}
+
+// encoder compresses what is written to it
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// newEncoder returns an encoder compressing to w with the encoding, at levels that trade a little of
+// the compression for speed, as responses are compressed as they are served
+func newEncoder(enc string, w io.Writer) encoder {
+	switch enc {
+	case EncodingBrotli:
+		return brotli.NewWriterLevel(w, 5)
+	case EncodingZstd:
+		// the options are valid, so there is no error
+		z, _ := zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+		return z
+	default:
+		g, _ := gzip.NewWriterLevel(w, gzip.DefaultCompression)
+		return g
+	}
+}
+
+// matchType returns if the media type matches any of the types, as type/subtype or type/*
+func matchType(types []string, mt string) bool {
+	for _, t := range types {
+		t = strings.ToLower(t)
+		if t == mt || (strings.HasSuffix(t, "/*") && strings.HasPrefix(mt, strings.TrimSuffix(t, "*"))) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// varyEncoding returns the Vary header with Accept-Encoding added to it, if it does not already vary by
+// it or by everything
+func varyEncoding(vary string) string {
+	for _, v := range headerList([]string{vary}) {
+		if v == "*" || strings.EqualFold(v, "Accept-Encoding") {
+			return vary
+		}
+	}
+
+	if vary == "" {
+		return "Accept-Encoding"
+	}
+
+	return vary + ", Accept-Encoding"
+}
+
+// weakETag returns the ETag as a weak ETag, as the compressed response is not byte for byte the
+// response the ETag was made for, RFC 9110 8.8.3
+func weakETag(etag string) string {
+	if strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+
+	return "W/" + etag
+}
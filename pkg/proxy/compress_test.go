@@ -0,0 +1,210 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+func TestEncoding(t *testing.T) {
+	c := newCompression(&sites.Compression{})
+
+	cases := []struct {
+		accept   string
+		encoding string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", EncodingGzip},
+		{"x-gzip", EncodingGzip},
+		{"GZIP, Deflate", EncodingGzip},
+		{"gzip, deflate, br, zstd", EncodingBrotli},
+		{"gzip, zstd", EncodingZstd},
+		{"br;q=0.5, gzip", EncodingGzip},
+		{"br;q=0.5, gzip;Q=0.8", EncodingGzip},
+		{"br;q=0, gzip;q=0", ""},
+		{"*", EncodingBrotli},
+		{"*;q=0.1, br;q=0", EncodingZstd},
+		{"gzip;q=0.5, *;q=0.1", EncodingGzip},
+		{"br;q=bad", EncodingBrotli},
+		{" , gzip ;q=0.2 ,", EncodingGzip},
+	}
+
+	for _, tc := range cases {
+		Convey("A client that accepts "+tc.accept+" should get "+tc.encoding, t, func() {
+			So(c.encoding(tc.accept), ShouldEqual, tc.encoding)
+		})
+	}
+
+	Convey("The encodings of a Compression should be preferred in its order", t, func() {
+		c := newCompression(&sites.Compression{Encodings: []string{EncodingGzip, EncodingBrotli}})
+		So(c.encoding("br, gzip"), ShouldEqual, EncodingGzip)
+		So(c.encoding("br, gzip;q=0.9"), ShouldEqual, EncodingBrotli)
+		So(c.encoding("zstd"), ShouldEqual, "")
+	})
+}
+
+func TestNegotiable(t *testing.T) {
+	c := newCompression(&sites.Compression{})
+	header := func(h ...string) func(string) string {
+		return func(name string) string {
+			for i := 0; i+1 < len(h); i += 2 {
+				if strings.EqualFold(h[i], name) {
+					return h[i+1]
+				}
+			}
+			return ""
+		}
+	}
+	html := header("Content-Type", "text/html; charset=utf-8")
+
+	cases := []struct {
+		name       string
+		status     int
+		header     func(string) string
+		size       int
+		negotiable bool
+	}{
+		{"an HTML response should be", 200, html, 2048, true},
+		{"a response of an unknown size should be", 200, html, -1, true},
+		{"a JSON error should be", 404, header("Content-Type", "application/json"), 2048, true},
+		{"a response smaller than the min size should not be", 200, html, 100, false},
+		{"a 204 should not be", 204, html, 2048, false},
+		{"a 206 should not be", 206, header("Content-Type", "text/html", "Content-Range", "bytes 0-2047/4096"), 2048, false},
+		{"a 304 should not be", 304, html, 2048, false},
+		{"an informational response should not be", 103, html, 2048, false},
+		{"an encoded response should not be", 200, header("Content-Type", "text/html", "Content-Encoding", "gzip"), 2048, false},
+		{"an identity encoded response should be", 200, header("Content-Type", "text/html", "Content-Encoding", "identity"), 2048, true},
+		{"a no-transform response should not be", 200, header("Content-Type", "text/html", "Cache-Control", "public, no-transform"), 2048, false},
+		{"a response without a content type should not be", 200, header(), 2048, false},
+		{"an image should not be", 200, header("Content-Type", "image/png"), 2048, false},
+		{"an SVG should be", 200, header("Content-Type", "image/svg+xml"), 2048, true},
+	}
+
+	for _, tc := range cases {
+		Convey("For compressing, "+tc.name+" negotiable", t, func() {
+			So(c.negotiable(tc.status, tc.header, tc.size), ShouldEqual, tc.negotiable)
+		})
+	}
+
+	Convey("A precompressed type should not be negotiable, even if a wildcard of a Compression matches it", t, func() {
+		c := newCompression(&sites.Compression{ContentTypes: []string{"video/*", "application/*"}})
+		So(c.negotiable(200, header("Content-Type", "video/mp4"), 2048), ShouldBeFalse)
+		So(c.negotiable(200, header("Content-Type", "application/zip"), 2048), ShouldBeFalse)
+		So(c.negotiable(200, header("Content-Type", "application/json"), 2048), ShouldBeTrue)
+		So(c.negotiable(200, html, 2048), ShouldBeFalse)
+	})
+}
+
+// gunzip returns the body decompressed with gzip
+func gunzip(body []byte) string {
+	r, err := gzip.NewReader(bytes.NewReader(body))
+	So(err, ShouldBeNil)
+
+	b, err := ioutil.ReadAll(r)
+	So(err, ShouldBeNil)
+	return string(b)
+}
+
+func TestCompress(t *testing.T) {
+	c := newCompression(&sites.Compression{})
+	body := strings.Repeat("<p>hello</p>", 200)
+
+	Convey("A compressed response", t, func() {
+		ctx := testRequest("GET", "/", "Accept-Encoding", "gzip")
+		ctx.Response.Header.SetContentType("text/html")
+		ctx.Response.Header.Set("Vary", "Cookie")
+		ctx.Response.Header.Set("Etag", `"v1"`)
+		ctx.Response.SetBodyString(body)
+		c.compress(ctx)
+
+		h := &ctx.Response.Header
+		Convey("should be encoded with the encoding the client accepts", func() {
+			So(string(h.Peek("Content-Encoding")), ShouldEqual, EncodingGzip)
+			So(gunzip(ctx.Response.Body()), ShouldEqual, body)
+		})
+
+		Convey("should vary on Accept-Encoding, with a weak ETag", func() {
+			So(string(h.Peek("Vary")), ShouldEqual, "Cookie, Accept-Encoding")
+			So(string(h.Peek("Etag")), ShouldEqual, `W/"v1"`)
+		})
+	})
+
+	Convey("A negotiable response to a client that accepts no encoding should only vary on Accept-Encoding", t, func() {
+		ctx := testRequest("GET", "/")
+		ctx.Response.Header.SetContentType("text/html")
+		ctx.Response.SetBodyString(body)
+		c.compress(ctx)
+
+		So(string(ctx.Response.Header.Peek("Content-Encoding")), ShouldEqual, "")
+		So(string(ctx.Response.Header.Peek("Vary")), ShouldEqual, "Accept-Encoding")
+		So(string(ctx.Response.Body()), ShouldEqual, body)
+	})
+
+	Convey("A response that would not compress smaller should be left alone", t, func() {
+		c := newCompression(&sites.Compression{MinSize: 1})
+		ctx := testRequest("GET", "/", "Accept-Encoding", "gzip")
+		ctx.Response.Header.SetContentType("text/plain")
+		ctx.Response.SetBodyString("ab")
+		c.compress(ctx)
+
+		So(string(ctx.Response.Header.Peek("Content-Encoding")), ShouldEqual, "")
+		So(string(ctx.Response.Body()), ShouldEqual, "ab")
+	})
+
+	Convey("A streamed response should be compressed as it is written and flushed", t, func() {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", "text/event-stream")
+		rec.Header().Set("Content-Length", "4096")
+
+		w := c.writer(rec, req)
+		w.Write([]byte("data: 1\n\n"))
+		So(w.FlushError(), ShouldBeNil)
+		So(rec.Body.Len(), ShouldBeGreaterThan, 0)
+
+		w.Write([]byte("data: 2\n\n"))
+		So(w.Close(), ShouldBeNil)
+
+		So(rec.Code, ShouldEqual, http.StatusOK)
+		So(rec.Header().Get("Content-Encoding"), ShouldEqual, EncodingGzip)
+		So(rec.Header().Get("Content-Length"), ShouldEqual, "")
+		So(gunzip(rec.Body.Bytes()), ShouldEqual, "data: 1\n\ndata: 2\n\n")
+	})
+
+	Convey("A streamed response to a HEAD request should not be encoded", t, func() {
+		req := httptest.NewRequest("HEAD", "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", "text/html")
+
+		w := c.writer(rec, req)
+		w.WriteHeader(http.StatusOK)
+		So(w.Close(), ShouldBeNil)
+		So(rec.Header().Get("Content-Encoding"), ShouldEqual, "")
+		So(rec.Header().Get("Vary"), ShouldEqual, "Accept-Encoding")
+	})
+}
+
+func TestVaryEncoding(t *testing.T) {
+	Convey("Accept-Encoding should be added to Vary once", t, func() {
+		So(varyEncoding(""), ShouldEqual, "Accept-Encoding")
+		So(varyEncoding("Cookie"), ShouldEqual, "Cookie, Accept-Encoding")
+		So(varyEncoding("Cookie, accept-encoding"), ShouldEqual, "Cookie, accept-encoding")
+		So(varyEncoding("*"), ShouldEqual, "*")
+	})
+
+	Convey("An ETag should be made weak once", t, func() {
+		So(weakETag(`"v1"`), ShouldEqual, `W/"v1"`)
+		So(weakETag(`W/"v1"`), ShouldEqual, `W/"v1"`)
+	})
+}
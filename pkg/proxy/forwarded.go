@@ -0,0 +1,174 @@
+package proxy
+
+import (
+	"net"
+	"strings"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+	"github.com/valyala/fasthttp"
+)
+
+// clientKey is the user value of a request holding its client IP, as resolved through trusted proxies
+const clientKey = "waffy.client"
+
+// forwardingHeaders are the headers the proxy sets on the requests it sends upstream, and that are only
+// kept from clients that are trusted proxies
+var forwardingHeaders = []string{
+	"X-Forwarded-For",
+	"X-Forwarded-Proto",
+	"X-Forwarded-Host",
+	"Forwarded",
+	"X-Real-Ip",
+	"X-Request-Id",
+}
+
+// clientIP returns the IP of the client of the request, as resolved through trusted proxies, or of the
+// connection it was sent on
+func clientIP(ctx *fasthttp.RequestCtx) net.IP {
+	if ip, ok := ctx.UserValue(clientKey).(net.IP); ok {
+		return ip
+	}
+
+	return ctx.RemoteIP()
+}
+
+// forwarded resolves the client of the request, and sets the forwarding headers sent upstream for it.
+// The forwarding headers of requests from trusted proxies are kept with the proxy appended to them, and
+// those of other clients are replaced, so they can not claim to be sent for someone else.
+func (b *balancer) forwarded(ctx *fasthttp.RequestCtx, secure bool) {
+	peer := ctx.RemoteIP()
+	trusted := b.trusted.contains(peer)
+
+	client := peer
+	if trusted {
+		client = b.forwardedClient(ctx, peer)
+		ctx.SetUserValue(clientKey, client)
+	}
+
+	proto := SchemeHTTP
+	if secure {
+		proto = SchemeHTTPS
+	}
+
+	h := &ctx.Request.Header
+	xff, fwd := joinHeader(h, "X-Forwarded-For", ", "), joinHeader(h, "Forwarded", ", ")
+	if !trusted {
+		xff, fwd = "", ""
+		h.Del("X-Forwarded-Proto")
+		h.Del("X-Forwarded-Host")
+	}
+
+	// Set only replaces the first line of a header, and these may have several
+	h.Del("X-Forwarded-For")
+	h.Del("Forwarded")
+
+	if xff != "" {
+		xff += ", "
+	}
+	h.Set("X-Forwarded-For", xff+peer.String())
+
+	if fwd != "" {
+		fwd += ", "
+	}
+	h.Set("Forwarded", fwd+forwardedElement(peer, proto, string(ctx.Host())))
+
+	if len(h.Peek("X-Forwarded-Proto")) == 0 {
+		h.Set("X-Forwarded-Proto", proto)
+	}
+	if len(h.Peek("X-Forwarded-Host")) == 0 {
+		h.Set("X-Forwarded-Host", string(ctx.Host()))
+	}
+	h.Set("X-Real-Ip", client.String())
+	setRequestID(ctx, trusted)
+}
+
+// forwardedClient returns the client of a request from a trusted proxy, walking the hops of its
+// forwarding header from the right past those of trusted proxies. The walk stops at a hop that is not
+// an IP, such as an obfuscated Forwarded node, leaving the last trusted proxy as the client.
+func (b *balancer) forwardedClient(ctx *fasthttp.RequestCtx, peer net.IP) net.IP {
+	var hops []string
+	if b.ForwardedHeader == sites.ForwardedHeader_FORWARDED {
+		hops = forwardedFor(joinHeader(&ctx.Request.Header, "Forwarded", ","))
+	} else {
+		hops = strings.Split(joinHeader(&ctx.Request.Header, "X-Forwarded-For", ","), ",")
+	}
+
+	client := peer
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := hopIP(hops[i])
+		if ip == nil {
+			break
+		}
+
+		client = ip
+		if !b.trusted.contains(ip) {
+			break
+		}
+	}
+
+	return client
+}
+
+// joinHeader returns the values of every line of the header, joined with sep
+func joinHeader(h *fasthttp.RequestHeader, key, sep string) string {
+	var values []string
+	h.VisitAll(func(k, v []byte) {
+		if strings.EqualFold(string(k), key) && len(v) > 0 {
+			values = append(values, string(v))
+		}
+	})
+
+	return strings.Join(values, sep)
+}
+
+// forwardedFor returns the for parameters of the elements of a Forwarded header, as RFC 7239 4, with
+// "" for elements without one
+func forwardedFor(header string) []string {
+	if header == "" {
+		return nil
+	}
+
+	var hops []string
+	for _, element := range strings.Split(header, ",") {
+		var node string
+		for _, pair := range strings.Split(element, ";") {
+			kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+			if len(kv) == 2 && strings.EqualFold(kv[0], "for") {
+				node = strings.Trim(kv[1], `"`)
+			}
+		}
+		hops = append(hops, node)
+	}
+
+	return hops
+}
+
+// hopIP returns the IP of a hop of a forwarding header, with or without its port, or nil if it is not
+// an IP
+func hopIP(hop string) net.IP {
+	hop = strings.TrimSpace(hop)
+	if ip := net.ParseIP(hop); ip != nil {
+		return ip
+	}
+
+	if host, _, err := net.SplitHostPort(hop); err == nil {
+		return net.ParseIP(host)
+	}
+
+	return net.ParseIP(strings.Trim(hop, "[]"))
+}
+
+// forwardedElement returns the Forwarded element for a request from the peer, as RFC 7239 4
+func forwardedElement(peer net.IP, proto, host string) string {
+	node := peer.String()
+	if peer.To4() == nil {
+		node = `"[` + node + `]"`
+	}
+
+	element := "for=" + node + ";proto=" + proto
+	if host != "" {
+		element += `;host="` + host + `"`
+	}
+
+	return element
+}
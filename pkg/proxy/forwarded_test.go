@@ -0,0 +1,138 @@
+package proxy
+
+import (
+	"net"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/valyala/fasthttp"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// forwardedRequest returns a request to example.com from the peer, with the headers as name, value
+// pairs, each added as its own line
+func forwardedRequest(peer string, headers ...string) *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Init(&fasthttp.Request{}, &net.TCPAddr{IP: net.ParseIP(peer), Port: 40000}, nil)
+	ctx.Request.SetRequestURI("http://example.com/")
+	for i := 0; i+1 < len(headers); i += 2 {
+		ctx.Request.Header.Add(headers[i], headers[i+1])
+	}
+
+	return ctx
+}
+
+// trustingBalancer returns a balancer trusting the proxies, reading the client from the header
+func trustingBalancer(t *testing.T, header sites.ForwardedHeader, proxies ...string) *balancer {
+	trusted, err := newCIDRs(proxies)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &balancer{Balancer: &sites.Balancer{ForwardedHeader: header}, trusted: trusted}
+}
+
+func TestForwardedClient(t *testing.T) {
+	xff := trustingBalancer(t, sites.ForwardedHeader_X_FORWARDED_FOR, "10.0.0.0/8", "2001:db8::/32")
+	fwd := trustingBalancer(t, sites.ForwardedHeader_FORWARDED, "10.0.0.0/8", "2001:db8::/32")
+
+	cases := []struct {
+		name   string
+		b      *balancer
+		ctx    *fasthttp.RequestCtx
+		client string
+	}{
+		{"an untrusted peer should be the client, whatever it claims",
+			xff, forwardedRequest("192.0.2.1", "X-Forwarded-For", "198.51.100.1"), "192.0.2.1"},
+		{"a trusted proxy should give the client",
+			xff, forwardedRequest("10.0.0.1", "X-Forwarded-For", "198.51.100.1"), "198.51.100.1"},
+		{"trusted proxies should be walked past from the right",
+			xff, forwardedRequest("10.0.0.1", "X-Forwarded-For", "203.0.113.9, 198.51.100.1, 10.0.0.2"), "198.51.100.1"},
+		{"hops left of the first untrusted one should not be believed",
+			xff, forwardedRequest("10.0.0.1", "X-Forwarded-For", "10.0.0.3, 198.51.100.1"), "198.51.100.1"},
+		{"hops should be read across header lines",
+			xff, forwardedRequest("10.0.0.1", "X-Forwarded-For", "198.51.100.1", "X-Forwarded-For", "10.0.0.2"), "198.51.100.1"},
+		{"hops with ports should be read",
+			xff, forwardedRequest("10.0.0.1", "X-Forwarded-For", "198.51.100.1:1234, [2001:db8::1]:443"), "198.51.100.1"},
+		{"a hop that is not an IP should stop the walk at the last trusted proxy",
+			xff, forwardedRequest("10.0.0.1", "X-Forwarded-For", "198.51.100.1, garbage, 10.0.0.2"), "10.0.0.2"},
+		{"a trusted proxy without a header should be the client",
+			xff, forwardedRequest("10.0.0.1"), "10.0.0.1"},
+		{"a trusted IPv6 proxy should give the client",
+			xff, forwardedRequest("2001:db8::1", "X-Forwarded-For", "2001:db8:1::1, 198.51.100.7"), "198.51.100.7"},
+		{"Forwarded should give the client",
+			fwd, forwardedRequest("10.0.0.1", "Forwarded", `for=198.51.100.1;proto=https, for=10.0.0.2`), "198.51.100.1"},
+		{"Forwarded should read quoted IPv6 nodes with ports",
+			fwd, forwardedRequest("10.0.0.1", "Forwarded", `for="[2001:db9::1]:4711"`), "2001:db9::1"},
+		{"Forwarded should read its parameters in any order and case",
+			fwd, forwardedRequest("10.0.0.1", "Forwarded", `proto=http;For=198.51.100.1;by=10.0.0.1`), "198.51.100.1"},
+		{"an obfuscated Forwarded node should stop the walk",
+			fwd, forwardedRequest("10.0.0.1", "Forwarded", `for=198.51.100.1, for=_hidden, for=10.0.0.2`), "10.0.0.2"},
+		{"an element without for should stop the walk",
+			fwd, forwardedRequest("10.0.0.1", "Forwarded", `for=198.51.100.1, proto=https`), "10.0.0.1"},
+		{"X-Forwarded-For should be ignored when Forwarded is trusted",
+			fwd, forwardedRequest("10.0.0.1", "X-Forwarded-For", "198.51.100.1"), "10.0.0.1"},
+	}
+
+	for _, tc := range cases {
+		Convey("For the client of a request, "+tc.name, t, func() {
+			tc.b.forwarded(tc.ctx, false)
+			So(clientIP(tc.ctx).String(), ShouldEqual, tc.client)
+			So(string(tc.ctx.Request.Header.Peek("X-Real-Ip")), ShouldEqual, tc.client)
+		})
+	}
+}
+
+func TestForwardedHeaders(t *testing.T) {
+	b := trustingBalancer(t, sites.ForwardedHeader_X_FORWARDED_FOR, "10.0.0.0/8")
+
+	Convey("The forwarding headers of an untrusted client should be replaced", t, func() {
+		ctx := forwardedRequest("192.0.2.1",
+			"X-Forwarded-For", "198.51.100.1",
+			"X-Forwarded-For", "198.51.100.2",
+			"Forwarded", "for=198.51.100.1",
+			"X-Forwarded-Proto", "https",
+			"X-Forwarded-Host", "evil.example",
+			"X-Request-Id", "spoofed",
+		)
+		b.forwarded(ctx, false)
+
+		h := &ctx.Request.Header
+		So(joinHeader(h, "X-Forwarded-For", ", "), ShouldEqual, "192.0.2.1")
+		So(joinHeader(h, "Forwarded", ", "), ShouldEqual, `for=192.0.2.1;proto=http;host="example.com"`)
+		So(string(h.Peek("X-Forwarded-Proto")), ShouldEqual, "http")
+		So(string(h.Peek("X-Forwarded-Host")), ShouldEqual, "example.com")
+		So(string(h.Peek("X-Request-Id")), ShouldNotEqual, "spoofed")
+		So(requestID(ctx), ShouldEqual, string(h.Peek("X-Request-Id")))
+	})
+
+	Convey("The forwarding headers of a trusted proxy should be kept, with the proxy appended", t, func() {
+		ctx := forwardedRequest("10.0.0.1",
+			"X-Forwarded-For", "198.51.100.1",
+			"X-Forwarded-For", "10.0.0.2",
+			"Forwarded", "for=198.51.100.1",
+			"X-Forwarded-Proto", "https",
+			"X-Forwarded-Host", "www.example.com",
+			"X-Request-Id", "abc-123",
+		)
+		b.forwarded(ctx, true)
+
+		h := &ctx.Request.Header
+		So(joinHeader(h, "X-Forwarded-For", ", "), ShouldEqual, "198.51.100.1, 10.0.0.2, 10.0.0.1")
+		So(joinHeader(h, "Forwarded", ", "), ShouldEqual, `for=198.51.100.1, for=10.0.0.1;proto=https;host="example.com"`)
+		So(string(h.Peek("X-Forwarded-Proto")), ShouldEqual, "https")
+		So(string(h.Peek("X-Forwarded-Host")), ShouldEqual, "www.example.com")
+		So(string(h.Peek("X-Request-Id")), ShouldEqual, "abc-123")
+	})
+
+	Convey("An invalid request ID of a trusted proxy should be replaced", t, func() {
+		ctx := forwardedRequest("10.0.0.1", "X-Request-Id", "has spaces")
+		b.forwarded(ctx, false)
+		So(string(ctx.Request.Header.Peek("X-Request-Id")), ShouldNotEqual, "has spaces")
+	})
+
+	Convey("The Forwarded element of an IPv6 peer should be quoted in brackets", t, func() {
+		So(forwardedElement(net.ParseIP("2001:db8::1"), "https", ""), ShouldEqual, `for="[2001:db8::1]";proto=https`)
+	})
+}
@@ -0,0 +1,492 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// statusMisdirectedRequest is returned for a request to a Site on a listener that does not serve it
+const statusMisdirectedRequest = 421 // RFC 7540, 9.1.2
+
+// handler returns the fasthttp.RequestHandler for the Balancer on the port
+func (p *Proxy) handler(port string) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		defer renderPage(ctx)
+
+		t := p.resolve(ctx, port, ctx.IsTLS())
+		if t == nil {
+			return
+		}
+
+		upgrade := upgrading(ctx)
+		var lk *cacheLookup
+		if t.site.cache != nil && !upgrade {
+			lk = t.site.cache.lookup(ctx, t.upstream.Name, t.uri)
+		}
+		if lk != nil && lk.hit != nil {
+			lk.serve(ctx, lk.hit, "HIT")
+			t.site.editResponse(&ctx.Response.Header, t.https)
+			if t.site.compression != nil {
+				t.site.compression.compress(ctx)
+			}
+			return
+		}
+
+		var sh *shadow
+		if t.route != nil && t.route.mirror != nil && !upgrade {
+			sh = t.route.mirror.sample(ctx)
+		}
+
+		// the shadow is sent the request of the client, without the conditions of a revalidation
+		if lk != nil {
+			for _, kv := range lk.conditional() {
+				ctx.Request.Header.Del(kv[0])
+				if kv[1] != "" {
+					ctx.Request.Header.Set(kv[0], kv[1])
+				}
+			}
+		}
+
+		var pinned *backend
+		if t.site.affinity != nil {
+			pinned = p.pinned(ctx, t.site, t.upstream)
+		}
+
+		start := time.Now()
+		var be *backend
+		if upgrade {
+			be = p.upgrade(ctx, t, pinned, ctx.Hijack)
+		} else {
+			be = p.forward(ctx, t.policy, t.upstream, pinned)
+		}
+		if lk != nil && be != nil {
+			lk.finish(ctx)
+		}
+		p.stick(ctx, t, be, pinned)
+
+		// responses are cached as the upstream sent them, then changed and compressed for each client
+		if !upgrade {
+			t.site.editResponse(&ctx.Response.Header, t.https)
+		}
+		if t.site.compression != nil && be != nil && !upgrade {
+			t.site.compression.compress(ctx)
+		}
+
+		// the shadow is sent once the primary response is ready, so it adds no latency to it
+		if sh != nil {
+			go t.route.mirror.send(sh, ctx.Response.StatusCode(), time.Since(start))
+		}
+	}
+}
+
+// target is where a request is sent: its Site, the route it matched if any, and the upstream and
+// policy of the route or Site
+type target struct {
+	site     *site
+	route    *route
+	upstream *upstream
+	policy   *policy
+
+	// variant is set if the client is pinned to the split upstream with the sticky cookie
+	variant bool
+
+	// uri is the path and query the client requested, before the Site and route rewrote it, for Sites
+	// with a cache
+	uri string
+
+	// https is set if the client made the request over https, to the proxy or a trusted proxy
+	https bool
+}
+
+// resolve returns the target of a request to the Balancer on the port, that was received over TLS if
+// secure is set, rewriting its path for the route it matches. It returns nil if the request has been
+// answered instead: an ACME challenge, a request for an unknown Site, over the wrong scheme, or that a
+// route denies.
+func (p *Proxy) resolve(ctx *fasthttp.RequestCtx, port string, secure bool) *target {
+	if !secure && p.acme.ServeChallenge(ctx) {
+		return nil
+	}
+
+	b := p.balancer(port)
+	if b == nil {
+		ctx.Error("no balancer on port", fasthttp.StatusNotFound)
+		return nil
+	}
+
+	host := hostname(ctx.Host())
+	site := b.site(host)
+	if site != nil {
+		ctx.SetUserValue(siteKey, site)
+	}
+
+	// a client behind a trusted proxy is held to the ACL as well as the proxy
+	b.forwarded(ctx, secure)
+	if !b.acl.permits(clientIP(ctx)) {
+		fail(ctx, "denied", fasthttp.StatusForbidden)
+		return nil
+	}
+
+	if site == nil {
+		ctx.Error("unknown site", fasthttp.StatusNotFound)
+		return nil
+	}
+
+	switch {
+	case secure && !site.Secure:
+		ctx.Error("site is not served over TLS", statusMisdirectedRequest)
+		return nil
+	case !secure && site.Secure:
+		p.redirectHTTPS(ctx, host)
+		return nil
+	}
+
+	if site.maintenance != nil && p.maintain(ctx, site.maintenance) {
+		return nil
+	}
+
+	// trusted proxies say if they were sent the request over https, and other clients have had their
+	// X-Forwarded-Proto replaced
+	https := string(ctx.Request.Header.Peek("X-Forwarded-Proto")) == SchemeHTTPS
+	if site.redirect(ctx, https) {
+		return nil
+	}
+
+	t := &target{site: site, upstream: site.primary, policy: site.policy, https: https}
+	if site.cache != nil {
+		t.uri = string(ctx.URI().RequestURI())
+	}
+
+	site.rewriteURI(ctx)
+	if site.RequestHeaders != nil {
+		editHeaders(&ctx.Request.Header, site.RequestHeaders)
+	}
+
+	r := site.route(ctx)
+	if r == nil {
+		return t
+	}
+
+	if r.Deny {
+		fail(ctx, "denied", fasthttp.StatusForbidden)
+		return nil
+	}
+
+	r.rewrite(ctx)
+	t.route, t.upstream, t.policy = r, r.upstream, r.policy
+	if len(r.split) > 0 {
+		t.upstream, t.variant = r.variant(ctx)
+	}
+
+	return t
+}
+
+// stick sets the cookies that keep the client on the split upstream of the target, and on the backend
+// that served it if the Site has affinity
+func (p *Proxy) stick(ctx *fasthttp.RequestCtx, t *target, be, pinned *backend) {
+	if t.variant {
+		t.route.pin(ctx, t.upstream)
+	}
+
+	// clients are pinned again when their backend is gone or a retry was served by another
+	if t.site.affinity != nil && be != nil && be != pinned {
+		p.pin(ctx, t.site, t.upstream, be)
+	}
+}
+
+// hopHeaders are the headers that only apply to a single connection, so are not forwarded
+var hopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// forward proxies the request to an Endpoint picked by the upstream, or first to the pinned backend
+// if it is set, retrying idempotent requests under the policy, unless the circuit breaker of the
+// upstream rejects it. It returns the backend that served the response, or nil if none did.
+func (p *Proxy) forward(ctx *fasthttp.RequestCtx, pol *policy, u *upstream, pinned *backend) *backend {
+	if wait, ok := u.breaker.allow(atomic.LoadInt64(&u.active)); !ok {
+		unavailable(ctx, "upstream is overloaded", wait)
+		return nil
+	}
+
+	atomic.AddInt64(&u.active, 1)
+	defer atomic.AddInt64(&u.active, -1)
+
+	for _, h := range hopHeaders {
+		ctx.Request.Header.Del(h)
+	}
+
+	var deadline time.Time
+	if pol.overall > 0 {
+		deadline = time.Now().Add(pol.overall)
+	}
+
+	// a retry reserved from the budget is given back once it is tried, or however the request ends
+	reserved := false
+	defer func() {
+		if reserved {
+			atomic.AddInt64(&u.retrying, -1)
+		}
+	}()
+
+	var be *backend
+	var err error
+	for attempt := 0; ; attempt++ {
+		be = pinned
+		if attempt > 0 || be == nil {
+			be = u.pool.pick(ctx)
+		}
+		if be == nil {
+			u.breaker.done(true)
+			fail(ctx, "no healthy upstream for site", fasthttp.StatusServiceUnavailable)
+			return nil
+		}
+
+		timeout, ok := pol.timeout(time.Now(), deadline)
+		if !ok {
+			err = fasthttp.ErrTimeout
+			break
+		}
+
+		// a backend with no free connections is at capacity, rather than failing
+		err = be.do(ctx, timeout)
+		if err != fasthttp.ErrNoFreeConns {
+			be.health.observe(u.OutlierDetection, err, ctx.Response.StatusCode())
+		}
+
+		// a pinned backend that can not be connected to is gone, so the request is sent on as if it
+		// were not pinned, which is safe for any method as nothing was sent
+		if attempt == 0 && be == pinned && unreachable(err) {
+			pinned = nil
+			attempt--
+			continue
+		}
+
+		if reserved {
+			atomic.AddInt64(&u.retrying, -1)
+			reserved = false
+		}
+
+		if attempt >= pol.attempts || !retryable(ctx, err, ctx.Response.StatusCode()) || !u.retry(pol) {
+			break
+		}
+		reserved = true
+
+		wait := pol.backoff(attempt + 1)
+		if !deadline.IsZero() && time.Now().Add(wait).After(deadline) {
+			break
+		}
+
+		time.Sleep(wait)
+		ctx.Response.Reset()
+	}
+
+	if err != fasthttp.ErrNoFreeConns {
+		u.breaker.done(err != nil || ctx.Response.StatusCode() >= fasthttp.StatusInternalServerError)
+	}
+
+	if err != nil {
+		log.Printf("unable to proxy to %s: %s", be.client.Addr, err)
+		ctx.Response.Reset()
+
+		switch err {
+		case fasthttp.ErrTimeout:
+			fail(ctx, "upstream timed out", fasthttp.StatusGatewayTimeout)
+		case fasthttp.ErrNoFreeConns:
+			unavailable(ctx, "upstream is overloaded", overloadRetryAfter)
+		default:
+			fail(ctx, "upstream unavailable", fasthttp.StatusBadGateway)
+		}
+		return nil
+	}
+
+	for _, h := range hopHeaders {
+		ctx.Response.Header.Del(h)
+	}
+
+	return be
+}
+
+// do sends the request to the backend, waiting up to timeout for the response if it is set
+func (b *backend) do(ctx *fasthttp.RequestCtx, timeout time.Duration) error {
+	atomic.AddInt64(&b.active, 1)
+	defer atomic.AddInt64(&b.active, -1)
+
+	return b.send(&ctx.Request, &ctx.Response, clientPeer(ctx), timeout)
+}
+
+// clientPeer returns the client of the request, and the address it connected to. Clients resolved
+// through trusted proxies have no port of their own.
+func clientPeer(ctx *fasthttp.RequestCtx) *peer {
+	if ip, ok := ctx.UserValue(clientKey).(net.IP); ok {
+		return &peer{src: &net.TCPAddr{IP: ip}, dst: ctx.LocalAddr()}
+	}
+
+	return &peer{src: ctx.RemoteAddr(), dst: ctx.LocalAddr()}
+}
+
+// send sends req from the peer to the backend, reading its response into resp, waiting up to timeout
+// for it if it is set. Requests to h2c Endpoints are sent with the transport, as fasthttp only speaks
+// HTTP/1.1, and the trailers of their responses are dropped.
+func (b *backend) send(req *fasthttp.Request, resp *fasthttp.Response, from *peer, timeout time.Duration) error {
+	if b.proxyProtocol != 0 {
+		return b.sendAlone(req, resp, from, timeout)
+	}
+
+	if b.Scheme != SchemeH2C {
+		if timeout > 0 {
+			return b.client.DoTimeout(req, resp, timeout)
+		}
+
+		return b.client.Do(req, resp)
+	}
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	out, err := http.NewRequest(string(req.Header.Method()), b.url(string(req.URI().RequestURI())), bytes.NewReader(req.Body()))
+	if err != nil {
+		return err
+	}
+	out = out.WithContext(ctx)
+	out.Host = string(req.Host())
+	req.Header.VisitAll(func(k, v []byte) {
+		switch string(k) {
+		case "Host", "Content-Length":
+		default:
+			out.Header.Add(string(k), string(v))
+		}
+	})
+
+	in, err := b.transport.RoundTrip(out)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fasthttp.ErrTimeout
+		}
+		return err
+	}
+	defer in.Body.Close()
+
+	body, err := ioutil.ReadAll(in.Body)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fasthttp.ErrTimeout
+		}
+		return err
+	}
+
+	resp.Reset()
+	resp.SetStatusCode(in.StatusCode)
+	for k, vv := range in.Header {
+		// Set parses the headers fasthttp keeps apart, such as Content-Type and Set-Cookie, and Add does not
+		for i, v := range vv {
+			if i == 0 || k == "Set-Cookie" {
+				resp.Header.Set(k, v)
+			} else {
+				resp.Header.Add(k, v)
+			}
+		}
+	}
+	resp.SetBody(body)
+
+	return nil
+}
+
+// sendAlone sends req from the peer to the backend on a connection of its own, that starts with the
+// PROXY protocol header for the peer, waiting up to timeout for the response if it is set
+func (b *backend) sendAlone(req *fasthttp.Request, resp *fasthttp.Response, from *peer, timeout time.Duration) error {
+	conn, err := b.dial(from)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if b.client.IsTLS {
+		conn = tls.Client(conn, b.client.TLSConfig)
+	}
+	if timeout > 0 {
+		conn.SetDeadline(time.Now().Add(timeout))
+	}
+
+	bw := bufio.NewWriter(conn)
+	if err := req.Write(bw); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	resp.SkipBody = req.Header.IsHead()
+	if err := resp.Read(bufio.NewReader(conn)); err != nil {
+		if timedOut(err) {
+			return fasthttp.ErrTimeout
+		}
+		return err
+	}
+
+	return nil
+}
+
+// unavailable rejects the request with a 503, asking the client to retry after wait
+func unavailable(ctx *fasthttp.RequestCtx, msg string, wait time.Duration) {
+	fail(ctx, msg, fasthttp.StatusServiceUnavailable)
+	ctx.Response.Header.Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+}
+
+// redirectHTTPS redirects the request to the TLS listener of the host
+func (p *Proxy) redirectHTTPS(ctx *fasthttp.RequestCtx, host string) {
+	port, ok := p.httpsPort(host)
+	if !ok {
+		port, ok = p.httpsPort(wildcard(host))
+	}
+	if !ok {
+		fail(ctx, "site has no TLS listener", fasthttp.StatusServiceUnavailable)
+		return
+	}
+
+	target := fmt.Sprintf("https://%s%s", host, ctx.RequestURI())
+	if port != "443" {
+		target = fmt.Sprintf("https://%s%s", net.JoinHostPort(host, port), ctx.RequestURI())
+	}
+
+	// only GET and HEAD can change method on a 301, so keep the method of anything else with a 308
+	status := fasthttp.StatusPermanentRedirect
+	if ctx.IsGet() || ctx.IsHead() {
+		status = fasthttp.StatusMovedPermanently
+	}
+
+	ctx.Redirect(target, status)
+}
+
+// hostname returns the lowercased host of a Host header, without the port
+func hostname(host []byte) string {
+	h := string(host)
+	if name, _, err := net.SplitHostPort(h); err == nil {
+		h = name
+	}
+
+	return strings.ToLower(strings.TrimSuffix(h, "."))
+}
@@ -0,0 +1,392 @@
+package proxy
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+const (
+	// CheckTick is how often the leader looks for health checks that are due
+	CheckTick = time.Second
+
+	defaultCheckInterval      = time.Second * 10
+	defaultCheckTimeout       = time.Second * 2
+	defaultHealthyThreshold   = 2
+	defaultUnhealthyThreshold = 3
+	defaultExpectedStatus     = fasthttp.StatusOK
+	defaultBaseEjection       = time.Second * 30
+	defaultMaxEjection        = time.Minute * 5
+)
+
+// health is the health of an Endpoint in an Upstream of a Site. It is kept across reloads of the
+// Balancers, so that checks and ejections are not reset by unrelated changes.
+type health struct {
+	key string
+
+	// healthy is 1 while the Endpoint passes its active checks
+	healthy int32
+
+	// ejectedUntil is when an ejection by outlier detection ends, in unix nanoseconds
+	ejectedUntil int64
+
+	mu       sync.Mutex
+	state    *sites.EndpointHealth
+	checking bool
+	next     time.Time
+
+	// passes and failures are the active checks in a row with the same result
+	passes, failures uint32
+
+	// errors5xx and connectErrors are the failed requests in a row, ejections the ejections in a row
+	errors5xx, connectErrors, ejections uint32
+}
+
+// newHealth creates the health of the Endpoint at address, healthy until it is checked
+func newHealth(hostname, upstream, address string) *health {
+	return &health{
+		key:     repository.HealthKey(hostname, upstream, address),
+		healthy: 1,
+		state: &sites.EndpointHealth{
+			Hostname: hostname,
+			Upstream: upstream,
+			Address:  address,
+			Healthy:  true,
+		},
+	}
+}
+
+// available returns if requests can be sent to the Endpoint
+func (h *health) available() bool {
+	return atomic.LoadInt32(&h.healthy) == 1 && time.Now().UnixNano() >= atomic.LoadInt64(&h.ejectedUntil)
+}
+
+// status returns the health of the Endpoint from the active checks
+func (h *health) status() sites.EndpointHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return *h.state
+}
+
+// apply sets the health of the Endpoint to the stored result of the checks of the leader
+func (h *health) apply(state *sites.EndpointHealth) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if state.Healthy != h.state.Healthy {
+		h.passes, h.failures = 0, 0
+	}
+	h.state = state
+	h.setHealthy(state.Healthy)
+}
+
+func (h *health) setHealthy(healthy bool) {
+	if healthy {
+		atomic.StoreInt32(&h.healthy, 1)
+	} else {
+		atomic.StoreInt32(&h.healthy, 0)
+	}
+}
+
+// due returns if a check of the Endpoint is due, claiming it until done is called
+func (h *health) due(now time.Time, interval time.Duration) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.checking || now.Before(h.next) {
+		return false
+	}
+
+	h.checking = true
+	h.next = now.Add(interval)
+	return true
+}
+
+// done records the result of a check, and returns the new health of the Endpoint if it changed
+func (h *health) done(c *sites.HealthCheck, err error) *sites.EndpointHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checking = false
+
+	healthy := h.state.Healthy
+	if err == nil {
+		h.passes, h.failures = h.passes+1, 0
+		healthy = healthy || h.passes >= threshold(c.HealthyThreshold, defaultHealthyThreshold)
+	} else {
+		h.passes, h.failures = 0, h.failures+1
+		healthy = healthy && h.failures < threshold(c.UnhealthyThreshold, defaultUnhealthyThreshold)
+	}
+
+	if healthy == h.state.Healthy {
+		return nil
+	}
+
+	state := &sites.EndpointHealth{
+		Hostname:  h.state.Hostname,
+		Upstream:  h.state.Upstream,
+		Address:   h.state.Address,
+		Healthy:   healthy,
+		ChangedAt: time.Now().Unix(),
+	}
+	if err != nil {
+		state.Reason = err.Error()
+	}
+
+	h.state = state
+	h.setHealthy(healthy)
+	return state
+}
+
+// observe records the result of a request to the Endpoint, ejecting it when the outlier detection
+// sees too many failed requests in a row. err is the error connecting to the Endpoint, if any.
+func (h *health) observe(o *sites.OutlierDetection, err error, status int) {
+	if o == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch {
+	case err != nil:
+		h.connectErrors++
+	case status >= fasthttp.StatusInternalServerError:
+		h.connectErrors = 0
+		h.errors5xx++
+	default:
+		h.connectErrors, h.errors5xx, h.ejections = 0, 0, 0
+		return
+	}
+
+	if (o.ConsecutiveConnectErrors == 0 || h.connectErrors < o.ConsecutiveConnectErrors) &&
+		(o.Consecutive_5Xx == 0 || h.errors5xx < o.Consecutive_5Xx) {
+		return
+	}
+
+	// each ejection in a row doubles the last, up to the max
+	ejection := seconds(o.BaseEjection, defaultBaseEjection) << h.ejections
+	if max := seconds(o.MaxEjection, defaultMaxEjection); ejection > max || ejection <= 0 {
+		ejection = max
+	}
+
+	h.connectErrors, h.errors5xx = 0, 0
+	h.ejections++
+	atomic.StoreInt64(&h.ejectedUntil, time.Now().Add(ejection).UnixNano())
+
+	log.Printf("ejected endpoint %s of %s for %s", h.state.Address, h.state.Hostname, ejection)
+}
+
+// check runs the active health checks that are due, while this node is the leader, until stop is
+// closed
+func (p *Proxy) check(stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case <-time.After(CheckTick):
+		}
+
+		if !p.db.Leader() {
+			continue
+		}
+
+		p.mu.RLock()
+		balancers := p.balancers
+		p.mu.RUnlock()
+
+		now := time.Now()
+		for _, b := range balancers {
+			p.checkUpstream(now, balancerHost(b.Port), b.endpoints)
+
+			// Sites are indexed by each alias too, and Sites served by several Balancers share their
+			// health, so due only lets the first of them check each Endpoint
+			for _, s := range b.hosts {
+				for _, up := range s.upstreams {
+					p.checkUpstream(now, s.Hostname, up)
+				}
+			}
+		}
+	}
+}
+
+// checkUpstream starts the checks of the Endpoints of the upstream of the Site hostname that are due
+func (p *Proxy) checkUpstream(now time.Time, hostname string, up *upstream) {
+	if up.HealthCheck == nil {
+		return
+	}
+
+	interval := seconds(up.HealthCheck.Interval, defaultCheckInterval)
+	for _, be := range up.backends {
+		if be.health.due(now, interval) {
+			go p.probe(hostname, up, be)
+		}
+	}
+}
+
+// probe checks the backend, and stores its health when it changes
+func (p *Proxy) probe(hostname string, up *upstream, be *backend) {
+	// the Endpoints of a Balancer, under balancerHost, serve no Site, so are asked for themselves
+	host := hostname
+	if strings.HasPrefix(hostname, ":") {
+		host = be.client.Addr
+	}
+
+	state := be.health.done(up.HealthCheck, probe(host, up.HealthCheck, be))
+	if state == nil {
+		return
+	}
+
+	if state.Healthy {
+		log.Printf("endpoint %s of %s is healthy", state.Address, hostname)
+	} else {
+		log.Printf("endpoint %s of %s is unhealthy: %s", state.Address, hostname, state.Reason)
+	}
+
+	if err := repository.SaveEndpointHealth(p.db, state); err != nil {
+		log.Printf("unable to save health of endpoint %s: %s", state.Address, err)
+	}
+}
+
+// probe runs the health check c against the backend of the Site hostname
+func probe(hostname string, c *sites.HealthCheck, be *backend) error {
+	timeout := seconds(c.Timeout, defaultCheckTimeout)
+
+	if c.Type == sites.HealthCheckType_TCP {
+		conn, err := net.DialTimeout("tcp", be.client.Addr, timeout)
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+	}
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+
+	path := c.Path
+	if path == "" {
+		path = "/"
+	}
+	req.SetRequestURI(path)
+	req.Header.SetHost(hostname)
+	req.Header.SetUserAgent("waffy-health-check")
+
+	if err := be.send(req, resp, nil, timeout); err != nil {
+		return err
+	}
+
+	expected := int(c.ExpectedStatus)
+	if expected == 0 {
+		expected = defaultExpectedStatus
+	}
+	if resp.StatusCode() != expected {
+		return fmt.Errorf("status %d, expected %d", resp.StatusCode(), expected)
+	}
+
+	if c.BodyRegex == "" {
+		return nil
+	}
+
+	re, err := regexp.Compile(c.BodyRegex)
+	if err != nil {
+		return fmt.Errorf("invalid body regex: %s", err)
+	}
+	if !re.Match(resp.Body()) {
+		return fmt.Errorf("body does not match %s", c.BodyRegex)
+	}
+
+	return nil
+}
+
+// Status returns the health of the Endpoints of the Site hostname as seen by this node, or of every
+// Site and Balancer if hostname is empty. The Endpoints of a Balancer are under its balancerHost.
+func (p *Proxy) Status(hostname string) []*sites.EndpointStatus {
+	p.mu.RLock()
+	balancers := p.balancers
+	p.mu.RUnlock()
+
+	var list []*sites.EndpointStatus
+	seen := make(map[*health]*sites.EndpointStatus)
+	add := func(be *backend) {
+		active := atomic.LoadInt64(&be.active)
+		if st, ok := seen[be.health]; ok {
+			st.ActiveRequests += active
+			return
+		}
+
+		state := be.health.status()
+		st := &sites.EndpointStatus{
+			Health:         &state,
+			ActiveRequests: active,
+		}
+		if until := atomic.LoadInt64(&be.health.ejectedUntil); until > time.Now().UnixNano() {
+			st.EjectedUntil = time.Unix(0, until).Unix()
+		}
+
+		seen[be.health] = st
+		list = append(list, st)
+	}
+
+	for _, b := range balancers {
+		if hostname == "" || hostname == balancerHost(b.Port) {
+			for _, be := range b.endpoints.backends {
+				add(be)
+			}
+		}
+
+		// aliases index the same site, which is only counted once
+		counted := make(map[*site]bool)
+		for _, s := range b.hosts {
+			if counted[s] || hostname != "" && !strings.EqualFold(s.Hostname, hostname) {
+				continue
+			}
+			counted[s] = true
+
+			for _, u := range s.Upstreams {
+				for _, be := range s.upstreams[u.Name].backends {
+					add(be)
+				}
+			}
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i].Health, list[j].Health
+		return repository.HealthKey(a.Hostname, a.Upstream, a.Address) < repository.HealthKey(b.Hostname, b.Upstream, b.Address)
+	})
+
+	return list
+}
+
+// threshold returns t, or def if it is unset
+func threshold(t, def uint32) uint32 {
+	if t == 0 {
+		return def
+	}
+
+	return t
+}
+
+// seconds returns the duration of s seconds, or def if it is unset
+func seconds(s int64, def time.Duration) time.Duration {
+	if s <= 0 {
+		return def
+	}
+
+	return time.Duration(s) * time.Second
+}
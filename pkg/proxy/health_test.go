@@ -0,0 +1,312 @@
+package proxy
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// ejectedFor returns how long the ejection of the health lasts from now, or 0 if it is not ejected
+func ejectedFor(h *health) time.Duration {
+	until := time.Unix(0, atomic.LoadInt64(&h.ejectedUntil))
+	if d := time.Until(until); d > 0 {
+		return d
+	}
+
+	return 0
+}
+
+// readmit ends the ejection of the health, as if it had run its course
+func readmit(h *health) {
+	atomic.StoreInt64(&h.ejectedUntil, time.Now().Add(-time.Second).UnixNano())
+}
+
+func TestHealthChecks(t *testing.T) {
+	errCheck := errors.New("connection refused")
+
+	Convey("With an Endpoint that is healthy until it is checked", t, func() {
+		h := newHealth("example.com", "web", "10.0.0.1:80")
+		So(h.available(), ShouldBeTrue)
+
+		Convey("It should be marked unhealthy after the unhealthy threshold of failed checks in a row", func() {
+			c := &sites.HealthCheck{}
+			So(h.done(c, errCheck), ShouldBeNil)
+			So(h.done(c, errCheck), ShouldBeNil)
+			So(h.available(), ShouldBeTrue)
+
+			state := h.done(c, errCheck)
+			So(state, ShouldNotBeNil)
+			So(state.Healthy, ShouldBeFalse)
+			So(state.Reason, ShouldEqual, "connection refused")
+			So(state.ChangedAt, ShouldBeGreaterThan, 0)
+			So(state.Address, ShouldEqual, "10.0.0.1:80")
+			So(h.available(), ShouldBeFalse)
+
+			Convey("And healthy again after the healthy threshold of passed checks in a row", func() {
+				So(h.done(c, nil), ShouldBeNil)
+				So(h.available(), ShouldBeFalse)
+
+				state := h.done(c, nil)
+				So(state, ShouldNotBeNil)
+				So(state.Healthy, ShouldBeTrue)
+				So(state.Reason, ShouldBeEmpty)
+				So(h.available(), ShouldBeTrue)
+			})
+
+			Convey("A failed check should restart the passes in a row", func() {
+				h.done(c, nil)
+				h.done(c, errCheck)
+				So(h.done(c, nil), ShouldBeNil)
+				So(h.available(), ShouldBeFalse)
+			})
+		})
+
+		Convey("A passed check should restart the failures in a row", func() {
+			c := &sites.HealthCheck{}
+			h.done(c, errCheck)
+			h.done(c, errCheck)
+			h.done(c, nil)
+			So(h.done(c, errCheck), ShouldBeNil)
+			So(h.done(c, errCheck), ShouldBeNil)
+			So(h.available(), ShouldBeTrue)
+		})
+
+		Convey("The thresholds of the check should replace the defaults", func() {
+			c := &sites.HealthCheck{UnhealthyThreshold: 1, HealthyThreshold: 3}
+			So(h.done(c, errCheck), ShouldNotBeNil)
+
+			h.done(c, nil)
+			h.done(c, nil)
+			So(h.available(), ShouldBeFalse)
+			So(h.done(c, nil), ShouldNotBeNil)
+			So(h.available(), ShouldBeTrue)
+		})
+
+		Convey("A check should only be due once at a time, and once per interval", func() {
+			now := time.Now()
+			So(h.due(now, time.Second*10), ShouldBeTrue)
+			So(h.due(now.Add(time.Second*20), time.Second*10), ShouldBeFalse)
+
+			h.done(&sites.HealthCheck{}, nil)
+			So(h.due(now.Add(time.Second*5), time.Second*10), ShouldBeFalse)
+			So(h.due(now.Add(time.Second*10), time.Second*10), ShouldBeTrue)
+		})
+
+		Convey("The health stored by the leader should replace the health of a follower", func() {
+			c := &sites.HealthCheck{}
+			h.done(c, errCheck)
+			h.done(c, errCheck)
+
+			h.apply(&sites.EndpointHealth{Address: "10.0.0.1:80", Healthy: false, Reason: "from the leader"})
+			So(h.available(), ShouldBeFalse)
+			So(h.status().Reason, ShouldEqual, "from the leader")
+
+			// the failures before the leader marked it unhealthy do not count towards passes
+			So(h.done(c, nil), ShouldBeNil)
+			So(h.done(c, nil), ShouldNotBeNil)
+		})
+	})
+}
+
+func TestProbe(t *testing.T) {
+	var host, agent atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		host.Store(req.Host)
+		agent.Store(req.UserAgent())
+
+		switch req.URL.Path {
+		case "/healthz":
+			w.Write([]byte("status: ok"))
+		case "/slow":
+			time.Sleep(time.Millisecond * 1500)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	addr := strings.TrimPrefix(srv.URL, "http://")
+	be, err := newBackend(&sites.Endpoint{Address: addr}, newHealth("example.com", "web", addr), nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	down, err := newBackend(&sites.Endpoint{Address: closed.Addr().String()}, newHealth("example.com", "web", closed.Addr().String()), nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed.Close()
+
+	Convey("An HTTP check should GET the path of the Site, expecting its status and body", t, func() {
+		So(probe("example.com", &sites.HealthCheck{Path: "/healthz", BodyRegex: "ok$"}, be), ShouldBeNil)
+		So(host.Load(), ShouldEqual, "example.com")
+		So(agent.Load(), ShouldEqual, "waffy-health-check")
+
+		So(probe("example.com", &sites.HealthCheck{Path: "/down", ExpectedStatus: 503}, be), ShouldBeNil)
+	})
+
+	Convey("An HTTP check should fail on another status, a body that does not match, or a timeout", t, func() {
+		So(probe("example.com", &sites.HealthCheck{}, be), ShouldNotBeNil)
+		So(probe("example.com", &sites.HealthCheck{Path: "/healthz", BodyRegex: "^ready"}, be), ShouldNotBeNil)
+		So(probe("example.com", &sites.HealthCheck{Path: "/healthz", ExpectedStatus: 204}, be), ShouldNotBeNil)
+		So(probe("example.com", &sites.HealthCheck{Path: "/slow", Timeout: 1}, be), ShouldNotBeNil)
+		So(probe("example.com", &sites.HealthCheck{Path: "/healthz"}, down), ShouldNotBeNil)
+	})
+
+	Convey("A TCP check should only connect", t, func() {
+		So(probe("example.com", &sites.HealthCheck{Type: sites.HealthCheckType_TCP}, be), ShouldBeNil)
+		So(probe("example.com", &sites.HealthCheck{Type: sites.HealthCheckType_TCP}, down), ShouldNotBeNil)
+	})
+
+	Convey("The leader should store the health of an Endpoint when it changes", t, func() {
+		db, cleanup := newTestConsensus(t, true)
+		defer cleanup()
+
+		p := &Proxy{db: db}
+		up := &upstream{Upstream: &sites.Upstream{Name: "web", HealthCheck: &sites.HealthCheck{Path: "/healthz", UnhealthyThreshold: 1}}}
+		p.probe("example.com", up, be)
+
+		stored, err := repository.ListEndpointHealth(db)
+		So(err, ShouldBeNil)
+		So(stored, ShouldBeEmpty)
+
+		p.probe("example.com", up, down)
+		stored, err = repository.ListEndpointHealth(db)
+		So(err, ShouldBeNil)
+		So(stored, ShouldHaveLength, 1)
+
+		state := stored[down.health.key]
+		So(state, ShouldNotBeNil)
+		So(state.Healthy, ShouldBeFalse)
+		So(state.Reason, ShouldNotBeEmpty)
+	})
+}
+
+func TestOutlierDetection(t *testing.T) {
+	Convey("With an Endpoint that is ejected after 3 5xx responses or 2 connect errors in a row", t, func() {
+		o := &sites.OutlierDetection{Consecutive_5Xx: 3, ConsecutiveConnectErrors: 2, BaseEjection: 10, MaxEjection: 35}
+		h := newHealth("example.com", "web", "10.0.0.1:80")
+		errConnect := errors.New("connection refused")
+
+		Convey("It should be ejected for the base ejection after the 5xx responses in a row", func() {
+			h.observe(o, nil, 500)
+			h.observe(o, nil, 503)
+			So(h.available(), ShouldBeTrue)
+
+			h.observe(o, nil, 502)
+			So(h.available(), ShouldBeFalse)
+			So(ejectedFor(h), ShouldAlmostEqual, time.Second*10, time.Second)
+			So(h.status().Healthy, ShouldBeTrue)
+		})
+
+		Convey("It should be ejected after the connect errors in a row", func() {
+			h.observe(o, errConnect, 0)
+			So(h.available(), ShouldBeTrue)
+
+			h.observe(o, errConnect, 0)
+			So(h.available(), ShouldBeFalse)
+		})
+
+		Convey("A successful response should restart the failures in a row", func() {
+			h.observe(o, nil, 500)
+			h.observe(o, nil, 500)
+			h.observe(o, nil, 200)
+			h.observe(o, nil, 500)
+			h.observe(o, nil, 500)
+			So(h.available(), ShouldBeTrue)
+
+			h.observe(o, errConnect, 0)
+			h.observe(o, nil, 404)
+			h.observe(o, errConnect, 0)
+			So(h.available(), ShouldBeTrue)
+		})
+
+		Convey("A 5xx response should restart the connect errors in a row", func() {
+			h.observe(o, errConnect, 0)
+			h.observe(o, nil, 500)
+			h.observe(o, errConnect, 0)
+			So(h.available(), ShouldBeTrue)
+		})
+
+		Convey("An Endpoint should be re-admitted once its ejection ends", func() {
+			h.observe(o, errConnect, 0)
+			h.observe(o, errConnect, 0)
+			So(h.available(), ShouldBeFalse)
+
+			readmit(h)
+			So(h.available(), ShouldBeTrue)
+
+			Convey("Each ejection in a row should double the last, up to the max", func() {
+				h.observe(o, errConnect, 0)
+				h.observe(o, errConnect, 0)
+				So(ejectedFor(h), ShouldAlmostEqual, time.Second*20, time.Second)
+
+				readmit(h)
+				h.observe(o, errConnect, 0)
+				h.observe(o, errConnect, 0)
+				So(ejectedFor(h), ShouldAlmostEqual, time.Second*35, time.Second)
+
+				readmit(h)
+				h.observe(o, errConnect, 0)
+				h.observe(o, errConnect, 0)
+				So(ejectedFor(h), ShouldAlmostEqual, time.Second*35, time.Second)
+			})
+
+			Convey("A successful response after it is re-admitted should restart the ejections in a row", func() {
+				h.observe(o, nil, 200)
+				h.observe(o, errConnect, 0)
+				h.observe(o, errConnect, 0)
+				So(ejectedFor(h), ShouldAlmostEqual, time.Second*10, time.Second)
+			})
+		})
+
+		Convey("The defaults should apply to an unset ejection", func() {
+			o := &sites.OutlierDetection{Consecutive_5Xx: 1}
+			h.observe(o, nil, 500)
+			So(ejectedFor(h), ShouldAlmostEqual, defaultBaseEjection, time.Second)
+
+			h.ejections = 10
+			h.observe(o, nil, 500)
+			So(ejectedFor(h), ShouldAlmostEqual, defaultMaxEjection, time.Second)
+		})
+
+		Convey("A threshold that is unset should never eject", func() {
+			o := &sites.OutlierDetection{ConsecutiveConnectErrors: 1}
+			for i := 0; i < 100; i++ {
+				h.observe(o, nil, 500)
+			}
+			So(h.available(), ShouldBeTrue)
+		})
+
+		Convey("An Upstream without outlier detection should never eject", func() {
+			for i := 0; i < 100; i++ {
+				h.observe(nil, errConnect, 0)
+			}
+			So(h.available(), ShouldBeTrue)
+		})
+
+		Convey("An ejection should be kept by the node across reloads, but not shared with other nodes", func() {
+			p := New(nil, nil, nil, nil, nil)
+			h = p.endpointHealth(h)
+			h.observe(o, errConnect, 0)
+			h.observe(o, errConnect, 0)
+
+			So(p.endpointHealth(newHealth("example.com", "web", "10.0.0.1:80")).available(), ShouldBeFalse)
+			So(New(nil, nil, nil, nil, nil).endpointHealth(newHealth("example.com", "web", "10.0.0.1:80")).available(), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,476 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"google.golang.org/grpc/codes"
+)
+
+// http2Handler returns the net/http Handler for the HTTP/2 Balancer on the port, that terminates TLS
+// if secure is set. Requests are resolved, balanced, cached and compressed like those to HTTP/1.1
+// Balancers, through a fasthttp.RequestCtx describing their headers, but their bodies and the trailers
+// of their responses are streamed as they are for gRPC. Their Routes do not mirror them.
+func (p *Proxy) http2Handler(port string, secure bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := requestCtx(req)
+		t := p.resolve(ctx, port, secure)
+		if t == nil {
+			reply(w, req, ctx)
+			return
+		}
+
+		var pinned *backend
+		if t.site.affinity != nil {
+			pinned = p.pinned(ctx, t.site, t.upstream)
+		}
+
+		if upgrading(ctx) {
+			p.upgradeHTTP(w, ctx, t, pinned)
+			return
+		}
+
+		if t.site.compression != nil {
+			cw := t.site.compression.writer(w, req)
+			defer cw.Close()
+			w = cw
+		}
+		if t.site.edits() {
+			w = &editWriter{ResponseWriter: w, site: t.site, https: t.https}
+		}
+
+		var lk *cacheLookup
+		if t.site.cache != nil {
+			lk = t.site.cache.lookup(ctx, t.upstream.Name, t.uri)
+		}
+		if lk != nil && lk.hit != nil {
+			lk.serveHTTP(w, lk.hit, "HIT")
+			return
+		}
+
+		p.stream(w, req, ctx, t, pinned, lk)
+	})
+}
+
+// requestCtx returns a fasthttp.RequestCtx with the method, URI and headers of req, so it can be
+// resolved and balanced like requests to HTTP/1.1 Balancers. Its body is left in req, to be streamed.
+func requestCtx(req *http.Request) *fasthttp.RequestCtx {
+	var r fasthttp.Request
+	r.Header.SetMethod(req.Method)
+	r.SetRequestURI(req.URL.RequestURI())
+	for k, vv := range req.Header {
+		// Set parses the headers fasthttp keeps apart, such as Content-Type and Cookie, and Add does not
+		if k == "Cookie" {
+			r.Header.Set(k, strings.Join(vv, "; "))
+			continue
+		}
+
+		r.Header.Set(k, vv[0])
+		for _, v := range vv[1:] {
+			r.Header.Add(k, v)
+		}
+	}
+	r.Header.SetHost(req.Host)
+
+	ctx := &fasthttp.RequestCtx{}
+	if req.TLS != nil && len(req.TLS.PeerCertificates) > 0 {
+		ctx.SetUserValue(peerCertificateKey, req.TLS.PeerCertificates[0])
+	}
+	addr, err := net.ResolveTCPAddr("tcp", req.RemoteAddr)
+	if err != nil {
+		ctx.Init(&r, nil, nil)
+		return ctx
+	}
+
+	ctx.Init(&r, addr, nil)
+	return ctx
+}
+
+// stream proxies the request to an Endpoint picked by the upstream of the target, or first to the
+// pinned backend if it is set, unless the circuit breaker of the upstream rejects it. The request body
+// is streamed to the backend as it arrives, so requests are only retried on another backend when the
+// one picked can not be connected to, within the attempts and budget of the policy. Responses to the cache lookup are cached once streamed, if they
+// are small enough.
+func (p *Proxy) stream(w http.ResponseWriter, req *http.Request, ctx *fasthttp.RequestCtx, t *target, pinned *backend, lk *cacheLookup) {
+	pol, u := t.policy, t.upstream
+	if wait, ok := u.breaker.allow(atomic.LoadInt64(&u.active)); !ok {
+		unavailable(ctx, "upstream is overloaded", wait)
+		reply(w, req, ctx)
+		return
+	}
+
+	atomic.AddInt64(&u.active, 1)
+	defer atomic.AddInt64(&u.active, -1)
+
+	if lk != nil {
+		for _, kv := range lk.conditional() {
+			req.Header.Del(kv[0])
+			if kv[1] != "" {
+				req.Header.Set(kv[0], kv[1])
+			}
+		}
+	}
+
+	rctx := withPeer(req.Context(), clientPeer(ctx))
+	if pol.overall > 0 {
+		var cancel context.CancelFunc
+		rctx, cancel = context.WithTimeout(rctx, pol.overall)
+		defer cancel()
+	}
+
+	// a retry reserved from the budget is given back once it is tried, or however the request ends
+	reserved := false
+	defer func() {
+		if reserved {
+			atomic.AddInt64(&u.retrying, -1)
+		}
+	}()
+
+	var be *backend
+	var resp *http.Response
+	var err error
+	for attempt := 0; ; attempt++ {
+		be = pinned
+		if attempt > 0 || be == nil {
+			be = u.pool.pick(ctx)
+		}
+		if be == nil {
+			u.breaker.done(true)
+			fail(ctx, "no healthy upstream for site", fasthttp.StatusServiceUnavailable)
+			reply(w, req, ctx)
+			return
+		}
+
+		atomic.AddInt64(&be.active, 1)
+		resp, err = be.roundTrip(outgoing(rctx, req, ctx, t.site, be), pol.perTry)
+		if err != nil {
+			atomic.AddInt64(&be.active, -1)
+			be.health.observe(u.OutlierDetection, err, 0)
+		} else {
+			be.health.observe(u.OutlierDetection, nil, resp.StatusCode)
+		}
+
+		// a pinned backend that can not be connected to is gone, so the request is sent on as if it
+		// were not pinned
+		if attempt == 0 && be == pinned && unreachable(err) {
+			pinned = nil
+			attempt--
+			continue
+		}
+
+		if reserved {
+			atomic.AddInt64(&u.retrying, -1)
+			reserved = false
+		}
+
+		if attempt >= pol.attempts || !unreachable(err) || !u.retry(pol) {
+			break
+		}
+		reserved = true
+
+		wait := pol.backoff(attempt + 1)
+		if deadline, ok := rctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			break
+		}
+
+		time.Sleep(wait)
+	}
+	u.breaker.done(err != nil || resp.StatusCode >= fasthttp.StatusInternalServerError)
+
+	if err != nil {
+		log.Printf("unable to proxy to %s: %s", be.client.Addr, err)
+		if err == fasthttp.ErrTimeout || rctx.Err() == context.DeadlineExceeded {
+			fail(ctx, "upstream timed out", fasthttp.StatusGatewayTimeout)
+		} else {
+			fail(ctx, "upstream unavailable", fasthttp.StatusBadGateway)
+		}
+		p.stick(ctx, t, nil, pinned)
+		reply(w, req, ctx)
+		return
+	}
+	defer atomic.AddInt64(&be.active, -1)
+	defer resp.Body.Close()
+
+	h := w.Header()
+	p.stick(ctx, t, be, pinned)
+	ctx.Response.Header.VisitAllCookie(func(_, v []byte) {
+		h.Add("Set-Cookie", string(v))
+	})
+
+	for _, k := range hopHeaders {
+		resp.Header.Del(k)
+	}
+
+	var body io.Reader = resp.Body
+	var cached *cappedBuffer
+	if lk != nil {
+		header := httpHeader(resp.Header)
+		if resp.StatusCode == http.StatusNotModified && lk.stale != nil {
+			lk.serveHTTP(w, lk.revalidated(header), "REVALIDATED")
+			return
+		}
+
+		if lk.cacheable(resp.StatusCode, header) {
+			cached = &cappedBuffer{max: lk.c.maxObjectSize()}
+			body = io.TeeReader(resp.Body, cached)
+		}
+		if !lk.invalidate {
+			resp.Header.Del(lk.c.tagHeader())
+			h.Set(CacheHeader, "MISS")
+		}
+	}
+
+	for k, vv := range resp.Header {
+		h[k] = vv
+	}
+
+	w.WriteHeader(resp.StatusCode)
+	if err := flushCopy(w, body); err != nil {
+		// the client went away, so there is no one to tell
+		if req.Context().Err() == context.Canceled {
+			return
+		}
+
+		log.Printf("unable to stream response of %s: %s", be.client.Addr, err)
+
+		// a gRPC call cut short has no status of its own, so it is reported as unavailable
+		if isGRPC(req) && resp.Trailer.Get("Grpc-Status") == "" {
+			h.Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(int(codes.Unavailable)))
+			h.Set(http.TrailerPrefix+"Grpc-Message", "upstream stream failed")
+			return
+		}
+	}
+
+	for k, vv := range resp.Trailer {
+		for _, v := range vv {
+			h.Add(http.TrailerPrefix+k, v)
+		}
+	}
+
+	// responses with trailers, such as those of gRPC calls, are not cached
+	switch {
+	case lk == nil:
+	case lk.invalidate:
+		lk.response(ctx, resp.StatusCode, nil, nil)
+	case cached != nil && !cached.over && len(resp.Trailer) == 0:
+		lk.response(ctx, resp.StatusCode, httpHeader(resp.Header), cached.Bytes())
+	}
+}
+
+// outgoing returns the request to send to the backend for req, with the path and query of ctx as the
+// Site and its route rewrote them, and the headers as the Site changed them. The body is not closed
+// when the request is sent, so it can be sent again if the backend can not be connected to.
+func outgoing(rctx context.Context, req *http.Request, ctx *fasthttp.RequestCtx, s *site, be *backend) *http.Request {
+	out := req.Clone(rctx)
+	out.RequestURI = ""
+	out.URL.Scheme = SchemeHTTP
+	if be.Scheme == SchemeHTTPS {
+		out.URL.Scheme = SchemeHTTPS
+	}
+	out.URL.Host = be.client.Addr
+	out.URL.Path = string(ctx.Path())
+	out.URL.RawPath = ""
+	out.URL.RawQuery = string(ctx.URI().QueryString())
+
+	if req.Body != nil && req.Body != http.NoBody {
+		out.Body = io.NopCloser(req.Body)
+	}
+
+	// the forwarding headers were set on ctx when the request was resolved
+	for _, k := range forwardingHeaders {
+		out.Header.Del(k)
+		if v := ctx.Request.Header.Peek(k); len(v) > 0 {
+			out.Header.Set(k, string(v))
+		}
+	}
+
+	if s.RequestHeaders != nil {
+		editHeaders(out.Header, s.RequestHeaders)
+	}
+
+	te := out.Header.Get("Te")
+	for _, h := range hopHeaders {
+		out.Header.Del(h)
+	}
+
+	// gRPC requires TE: trailers, the only hop header that is forwarded
+	if strings.Contains(strings.ToLower(te), "trailers") {
+		out.Header.Set("Te", "trailers")
+	}
+
+	return out
+}
+
+// roundTrip sends the request to the backend with its transport, waiting up to timeout for the
+// response headers if it is set
+func (b *backend) roundTrip(out *http.Request, timeout time.Duration) (*http.Response, error) {
+	if timeout <= 0 {
+		return b.transport.RoundTrip(out)
+	}
+
+	ctx, cancel := context.WithCancel(out.Context())
+	timer := time.AfterFunc(timeout, cancel)
+	resp, err := b.transport.RoundTrip(out.WithContext(ctx))
+	if timer.Stop() {
+		return resp, err
+	}
+
+	if err == nil {
+		resp.Body.Close()
+	}
+	return nil, fasthttp.ErrTimeout
+}
+
+// flushCopy copies the body to w, flushing after each write so streamed responses are not held back
+func flushCopy(w http.ResponseWriter, body io.Reader) error {
+	rc := http.NewResponseController(w)
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if _, err := w.Write(buf[:n]); err != nil {
+				return err
+			}
+			if err := rc.Flush(); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// upgradeHTTP tunnels an Upgrade request from an HTTP/1.1 client of an HTTP/2 Balancer, hijacking its
+// connection from net/http once the backend switches protocols
+func (p *Proxy) upgradeHTTP(w http.ResponseWriter, ctx *fasthttp.RequestCtx, t *target, pinned *backend) {
+	var join fasthttp.HijackHandler
+	be := p.upgrade(ctx, t, pinned, func(h fasthttp.HijackHandler) {
+		join = h
+	})
+	p.stick(ctx, t, be, pinned)
+
+	if join == nil {
+		reply(w, nil, ctx)
+		return
+	}
+
+	conn, brw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		log.Printf("unable to hijack upgraded connection: %s", err)
+
+		// the tunnel is joined to a closed connection, so its backend connection is closed with it
+		c, _ := net.Pipe()
+		c.Close()
+		go join(c)
+
+		ctx.Response.Reset()
+		ctx.Error("upgrades are not supported over http2", fasthttp.StatusNotImplemented)
+		reply(w, nil, ctx)
+		return
+	}
+
+	if err := ctx.Response.Header.Write(brw.Writer); err != nil || brw.Flush() != nil {
+		conn.Close()
+		return
+	}
+
+	// the hijacked reader may hold what the client sent after the handshake
+	join(&bufferedConn{Conn: conn, r: brw.Reader})
+}
+
+// bufferedConn is a net.Conn that reads what was buffered from it first
+type bufferedConn struct {
+	net.Conn
+
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// reply writes the response the proxy made in ctx, rather than a backend. gRPC requests are answered
+// with a trailers-only response, with the grpc-status for the HTTP status, and others with the error
+// page of their Site for the status if it has one.
+func reply(w http.ResponseWriter, req *http.Request, ctx *fasthttp.RequestCtx) {
+	if req == nil || !isGRPC(req) {
+		renderPage(ctx)
+	}
+
+	h := w.Header()
+	ctx.Response.Header.VisitAll(func(k, v []byte) {
+		if string(k) != "Content-Length" {
+			h.Add(string(k), string(v))
+		}
+	})
+
+	if req != nil && isGRPC(req) {
+		h.Set("Content-Type", "application/grpc")
+		h.Set("Grpc-Status", strconv.Itoa(int(grpcCode(ctx.Response.StatusCode()))))
+		h.Set("Grpc-Message", grpcMessage(string(ctx.Response.Body())))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// the length is set so that bodies too small to compress are not
+	h.Set("Content-Length", strconv.Itoa(len(ctx.Response.Body())))
+	w.WriteHeader(ctx.Response.StatusCode())
+	w.Write(ctx.Response.Body())
+}
+
+// isGRPC returns if the request is a gRPC call
+func isGRPC(req *http.Request) bool {
+	return strings.HasPrefix(req.Header.Get("Content-Type"), "application/grpc")
+}
+
+// grpcCode returns the gRPC status code for the HTTP status of a response the proxy made, following
+// https://github.com/grpc/grpc/blob/master/doc/http-grpc-status-mapping.md, except for the timeouts of
+// the proxy, which are deadlines
+func grpcCode(status int) codes.Code {
+	switch status {
+	case fasthttp.StatusBadRequest:
+		return codes.Internal
+	case fasthttp.StatusUnauthorized:
+		return codes.Unauthenticated
+	case fasthttp.StatusForbidden:
+		return codes.PermissionDenied
+	case fasthttp.StatusNotFound:
+		return codes.Unimplemented
+	case fasthttp.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case fasthttp.StatusTooManyRequests, fasthttp.StatusBadGateway, fasthttp.StatusServiceUnavailable:
+		return codes.Unavailable
+	}
+
+	return codes.Unknown
+}
+
+// grpcMessage percent-encodes a grpc-message, as the gRPC spec requires
+func grpcMessage(msg string) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c < ' ' || c > '~' || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,324 @@
+package proxy
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/valyala/fasthttp"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// selfSigned returns a self-signed certificate for the names
+func selfSigned(names ...string) *tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	So(err, ShouldBeNil)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: names[0]},
+		DNSNames:     names,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	So(err, ShouldBeNil)
+
+	leaf, err := x509.ParseCertificate(der)
+	So(err, ShouldBeNil)
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+// h2cBackend returns an h2c server that answers each call with the handler, and its Endpoint
+func h2cBackend(h http.HandlerFunc) (*httptest.Server, *sites.Endpoint) {
+	srv := httptest.NewServer(h2c.NewHandler(h, &http2.Server{}))
+	return srv, &sites.Endpoint{Address: strings.TrimPrefix(srv.URL, "http://"), Scheme: SchemeH2C}
+}
+
+// serveBalancer serves the Balancer on a free port, returning the address to dial it on and a func
+// to stop it. Its port is replaced, as the Balancer is looked up by the port it was listened on.
+func serveBalancer(b *sites.Balancer) (string, func()) {
+	_, addr, stop := serveProxy(b)
+	return addr, stop
+}
+
+// serveProxy serves the Balancer like serveBalancer, also returning the Proxy that serves it
+func serveProxy(b *sites.Balancer) (*Proxy, string, func()) {
+	cert := selfSigned("example.com")
+	p := New(nil, nil, func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		return cert, nil
+	}, nil, nil)
+
+	b.Port = "0"
+	bl, err := p.newBalancer(b, nil)
+	So(err, ShouldBeNil)
+	p.balancers[b.Port] = bl
+
+	srv, err := p.listen(b, nil)
+	So(err, ShouldBeNil)
+	go p.serve(b.Port, srv)
+
+	return p, srv.sock.Addr().String(), func() { srv.stop(b.Port, true) }
+}
+
+// h2cClient returns a client that speaks HTTP/2 without TLS to any address
+func h2cClient() *http.Client {
+	return &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+}
+
+// grpcCall returns a gRPC request of the method to the address
+func grpcCall(addr, method string) *http.Request {
+	req, err := http.NewRequest("POST", "http://"+addr+method, strings.NewReader("\x00\x00\x00\x00\x00"))
+	So(err, ShouldBeNil)
+	req.Host = "example.com"
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("Te", "trailers")
+	return req
+}
+
+func TestHTTP2Balancer(t *testing.T) {
+	backend, endpoint := h2cBackend(func(w http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "/Slow") {
+			time.Sleep(time.Millisecond * 200)
+		}
+
+		w.Header().Set("X-Proto", req.Proto)
+		if isGRPC(req) {
+			w.Header().Set("Content-Type", "application/grpc")
+			w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+			w.Write([]byte("\x00\x00\x00\x00\x00"))
+			w.Header().Set("Grpc-Status", "0")
+			w.Header().Set("Grpc-Message", "ok")
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+	defer backend.Close()
+
+	site := func(routes ...*sites.Route) *sites.Site {
+		return &sites.Site{
+			Hostname:  "example.com",
+			Upstreams: []*sites.Upstream{{Name: "web", Endpoints: []*sites.Endpoint{endpoint}}},
+			Routes:    routes,
+		}
+	}
+
+	Convey("An h2 Balancer should serve HTTP/2 to clients that offer it by ALPN, and HTTP/1.1 to others", t, func() {
+		addr, stop := serveBalancer(&sites.Balancer{Proto: ProtoH2, Sites: []*sites.Site{{Hostname: "example.com", Secure: true, Upstreams: site().Upstreams}}})
+		defer stop()
+
+		for _, h2 := range []bool{true, false} {
+			transport := &http.Transport{TLSClientConfig: &tls.Config{ServerName: "example.com", InsecureSkipVerify: true}}
+			if h2 {
+				So(http2.ConfigureTransport(transport), ShouldBeNil)
+			}
+
+			req, err := http.NewRequest("GET", "https://"+addr+"/", nil)
+			So(err, ShouldBeNil)
+			req.Host = "example.com"
+			resp, err := (&http.Client{Transport: transport}).Do(req)
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			transport.CloseIdleConnections()
+
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(resp.ProtoMajor == 2, ShouldEqual, h2)
+			So(resp.Header.Get("X-Proto"), ShouldEqual, "HTTP/2.0")
+		}
+	})
+
+	Convey("With an h2c Balancer", t, func() {
+		pol := &sites.Resilience{Timeouts: &sites.Timeouts{PerTry: 50}}
+		addr, stop := serveBalancer(&sites.Balancer{Proto: ProtoH2C, Sites: []*sites.Site{site(
+			&sites.Route{
+				Name:      "denied",
+				PathMatch: sites.PathMatch_EXACT,
+				Path:      "/pkg.Admin/Drop",
+				Headers:   []*sites.ValueMatch{{Name: "Content-Type", Value: "^application/grpc", Regex: true}},
+				Deny:      true,
+			},
+			&sites.Route{
+				Name:       "slow",
+				PathMatch:  sites.PathMatch_PREFIX,
+				Path:       "/pkg.Slow/",
+				Headers:    []*sites.ValueMatch{{Name: "Content-Type", Value: "^application/grpc", Regex: true}},
+				Upstream:   "web",
+				Resilience: pol,
+			},
+		)}})
+		defer stop()
+		client := h2cClient()
+
+		Convey("Requests should be served over HTTP/2 without TLS", func() {
+			req, err := http.NewRequest("GET", "http://"+addr+"/", nil)
+			So(err, ShouldBeNil)
+			req.Host = "example.com"
+
+			resp, err := client.Do(req)
+			So(err, ShouldBeNil)
+			body, _ := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			So(resp.ProtoMajor, ShouldEqual, 2)
+			So(string(body), ShouldEqual, "ok")
+		})
+
+		Convey("The trailers of a gRPC call should be passed through", func() {
+			resp, err := client.Do(grpcCall(addr, "/pkg.Greeter/Hello"))
+			So(err, ShouldBeNil)
+			ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			So(resp.Header.Get("X-Proto"), ShouldEqual, "HTTP/2.0")
+			So(resp.Trailer.Get("Grpc-Status"), ShouldEqual, "0")
+			So(resp.Trailer.Get("Grpc-Message"), ShouldEqual, "ok")
+		})
+
+		Convey("A gRPC call denied by its Route should be answered with trailers only", func() {
+			resp, err := client.Do(grpcCall(addr, "/pkg.Admin/Drop"))
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(resp.Header.Get("Content-Type"), ShouldEqual, "application/grpc")
+			So(resp.Header.Get("Grpc-Status"), ShouldEqual, "7")
+			So(resp.Header.Get("Grpc-Message"), ShouldEqual, "denied")
+		})
+
+		Convey("A request to the path of a gRPC Route that is not gRPC should not match it", func() {
+			req, err := http.NewRequest("GET", "http://"+addr+"/pkg.Admin/Drop", nil)
+			So(err, ShouldBeNil)
+			req.Host = "example.com"
+
+			resp, err := client.Do(req)
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(resp.Header.Get("Grpc-Status"), ShouldEqual, "")
+		})
+
+		Convey("A gRPC call that times out should be answered with DEADLINE_EXCEEDED", func() {
+			resp, err := client.Do(grpcCall(addr, "/pkg.Slow/Slow"))
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+
+			So(resp.Header.Get("Grpc-Status"), ShouldEqual, "4")
+			So(resp.Header.Get("Grpc-Message"), ShouldEqual, "upstream timed out")
+		})
+	})
+}
+
+func TestStream(t *testing.T) {
+	// refused returns a backend that can not be connected to
+	refused := func() *backend {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		So(err, ShouldBeNil)
+		addr := ln.Addr().String()
+		ln.Close()
+
+		be, err := newBackend(&sites.Endpoint{Address: addr, Scheme: SchemeH2C}, newHealth("example.com", "web", addr), nil, 0)
+		So(err, ShouldBeNil)
+		return be
+	}
+
+	// call streams a gRPC call to the upstream under the policy, returning its response
+	call := func(u *upstream, pol *policy, pinned *backend) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "http://example.com/pkg.Greeter/Hello", strings.NewReader(""))
+		req.Header.Set("Content-Type", "application/grpc")
+
+		rec := httptest.NewRecorder()
+		t := &target{site: &site{Site: &sites.Site{}}, upstream: u, policy: pol}
+		(&Proxy{}).stream(rec, req, requestCtx(req), t, pinned, nil)
+		return rec
+	}
+
+	Convey("A gRPC call rejected by the circuit breaker should be answered with UNAVAILABLE", t, func() {
+		u := &upstream{
+			Upstream: &sites.Upstream{Name: "web"},
+			pool:     &scripted{},
+			breaker:  newBreaker(&sites.CircuitBreaker{MaxPending: 1}),
+			active:   1,
+		}
+
+		rec := call(u, newPolicy(nil), nil)
+		So(rec.Code, ShouldEqual, http.StatusOK)
+		So(rec.Header().Get("Grpc-Status"), ShouldEqual, "14")
+		So(rec.Header().Get("Grpc-Message"), ShouldEqual, "upstream is overloaded")
+	})
+
+	Convey("A pinned backend that can not be connected to should be tried once, then the retries end", t, func() {
+		pinned := refused()
+		other := refused()
+		u := &upstream{
+			Upstream: &sites.Upstream{Name: "web"},
+			pool:     &scripted{backends: []*backend{pinned, other, pinned, other, pinned}},
+			breaker:  newBreaker(&sites.CircuitBreaker{ErrorPercent: 50, MinRequests: 1}),
+		}
+		pol := newPolicy(&sites.Resilience{Retry: &sites.RetryPolicy{Attempts: 2, BaseBackoff: 1, MaxBackoff: 1}})
+
+		rec := call(u, pol, pinned)
+		So(rec.Header().Get("Grpc-Status"), ShouldEqual, "14")
+		So(u.pool.(*scripted).backends, ShouldHaveLength, 2)
+		So(atomic.LoadInt64(&u.retrying), ShouldEqual, 0)
+
+		_, ok := u.breaker.allow(0)
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("A pinned backend picked again should not be retried without end", t, func() {
+		pinned := refused()
+		u := &upstream{Upstream: &sites.Upstream{Name: "web"}, pool: &scripted{backends: []*backend{pinned, pinned, pinned}}}
+		pol := newPolicy(&sites.Resilience{Retry: &sites.RetryPolicy{Attempts: 1, BaseBackoff: 1, MaxBackoff: 1}})
+
+		rec := call(u, pol, pinned)
+		So(rec.Header().Get("Grpc-Status"), ShouldEqual, "14")
+		So(u.pool.(*scripted).backends, ShouldHaveLength, 1)
+		So(atomic.LoadInt64(&u.retrying), ShouldEqual, 0)
+	})
+
+	Convey("A retry that finds no backend should give back its budget, and count against the breaker", t, func() {
+		u := &upstream{
+			Upstream: &sites.Upstream{Name: "web"},
+			pool:     &scripted{backends: []*backend{refused()}},
+			breaker:  newBreaker(&sites.CircuitBreaker{ErrorPercent: 50, MinRequests: 1}),
+		}
+		pol := newPolicy(&sites.Resilience{Retry: &sites.RetryPolicy{Attempts: 2, BaseBackoff: 1, MaxBackoff: 1}})
+
+		rec := call(u, pol, nil)
+		So(rec.Header().Get("Grpc-Status"), ShouldEqual, "14")
+		So(rec.Header().Get("Grpc-Message"), ShouldEqual, "no healthy upstream for site")
+		So(atomic.LoadInt64(&u.retrying), ShouldEqual, 0)
+
+		_, ok := u.breaker.allow(0)
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("The gRPC status of a response the proxy made should follow its HTTP status", t, func() {
+		So(grpcCode(fasthttp.StatusForbidden).String(), ShouldEqual, "PermissionDenied")
+		So(grpcCode(fasthttp.StatusGatewayTimeout).String(), ShouldEqual, "DeadlineExceeded")
+		So(grpcCode(fasthttp.StatusServiceUnavailable).String(), ShouldEqual, "Unavailable")
+		So(grpcMessage("50% off\n"), ShouldEqual, "50%25 off%0A")
+	})
+}
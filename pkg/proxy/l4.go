@@ -0,0 +1,380 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// DefaultUDPIdleTimeout closes the sessions of udp Balancers with no datagrams either way, for
+	// Balancers that do not set an idle timeout. tcp Balancers default to DefaultIdleTimeout.
+	DefaultUDPIdleTimeout = time.Minute
+
+	// SNITimeout is how long a tcp Balancer with Sites waits for the TLS ClientHello of a connection,
+	// before sending it to the default Site
+	SNITimeout = time.Second * 5
+
+	// maxDatagram is the largest UDP datagram relayed
+	maxDatagram = 64 * 1024
+
+	// TLS record framing, RFC 8446 5.1, with the largest record a ClientHello is read from
+	recordHeaderSize    = 5
+	recordTypeHandshake = 0x16
+	maxRecord           = recordHeaderSize + 1<<14 + 2048
+)
+
+var (
+	errNoEndpoint = errors.New("no healthy upstream for balancer")
+	errSNIRead    = errors.New("sni read")
+)
+
+// l4Upstream returns the upstream that connections and datagrams of a tcp or udp Balancer with the
+// SNI are sent to: the first Upstream of the Site the SNI matches, of the default Site, or the
+// Endpoints of the Balancer
+func (b *balancer) l4Upstream(sni string) *upstream {
+	if sni != "" {
+		if s := b.site(hostname([]byte(sni))); s != nil {
+			return s.primary
+		}
+	}
+
+	if s, ok := b.hosts[strings.ToLower(b.DefaultSite)]; ok {
+		return s.primary
+	}
+
+	return b.endpoints
+}
+
+// idle returns the idle timeout of the connections and sessions of a tcp or udp Balancer
+func (b *balancer) idle() time.Duration {
+	switch {
+	case b.IdleTimeout > 0:
+		return time.Duration(b.IdleTimeout) * time.Second
+	case b.Proto == ProtoUDP:
+		return DefaultUDPIdleTimeout
+	}
+
+	return DefaultIdleTimeout
+}
+
+// connCtx returns a fasthttp.RequestCtx for a connection or datagram from addr, so Endpoints can be
+// picked for it like for requests. Only the client IP is set, so header and cookie hashes pick in turn.
+func connCtx(addr net.Addr) *fasthttp.RequestCtx {
+	if u, ok := addr.(*net.UDPAddr); ok {
+		addr = &net.TCPAddr{IP: u.IP, Port: u.Port, Zone: u.Zone}
+	}
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Init(&fasthttp.Request{}, addr, nil)
+	return ctx
+}
+
+// dial connects over the network to an Endpoint picked by the upstream for the peer, picking another
+// while the one picked can not be connected to. TCP connections start with the PROXY protocol header
+// for the peer if the upstream sends it.
+func (u *upstream) dial(network string, from *peer) (*backend, net.Conn, error) {
+	ctx := connCtx(from.src)
+	err := errNoEndpoint
+	for range u.backends {
+		be := u.pool.pick(ctx)
+		if be == nil {
+			return nil, nil, errNoEndpoint
+		}
+
+		// dialing UDP only fails for an address that does not resolve, so says nothing of the health
+		// of the Endpoint. It is seen by the replies of its session instead.
+		if network == ProtoUDP {
+			conn, err := net.Dial(network, be.client.Addr)
+			if err == nil {
+				return be, conn, nil
+			}
+			be.health.observe(u.OutlierDetection, err, 0)
+			continue
+		}
+
+		conn, err := be.dial(from)
+		be.health.observe(u.OutlierDetection, err, 0)
+		if err == nil {
+			return be, conn, nil
+		}
+	}
+
+	return nil, nil, err
+}
+
+// serveTCP forwards the connections of the tcp Balancer on the port until its listener is closed
+func (p *Proxy) serveTCP(port string, ln net.Listener) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				time.Sleep(time.Millisecond * 10)
+				continue
+			}
+			return err
+		}
+
+		go p.forwardTCP(port, conn)
+	}
+}
+
+// forwardTCP joins the connection to an Endpoint of the upstream for its SNI, unless the circuit
+// breaker of the upstream rejects it. TLS is passed through as it is, the ClientHello is only read to
+// route the connection, and only by Balancers with Sites.
+func (p *Proxy) forwardTCP(port string, conn net.Conn) {
+	b := p.balancer(port)
+	if b == nil || p.isDraining() || resolveClient(conn) != nil {
+		conn.Close()
+		return
+	}
+
+	br := bufio.NewReaderSize(conn, maxRecord)
+	var sni string
+	if len(b.hosts) > 0 {
+		conn.SetReadDeadline(time.Now().Add(SNITimeout))
+		sni = clientHelloSNI(br)
+		conn.SetReadDeadline(time.Time{})
+	}
+
+	u := b.l4Upstream(sni)
+	if _, ok := u.breaker.allow(atomic.LoadInt64(&u.active)); !ok {
+		conn.Close()
+		return
+	}
+
+	atomic.AddInt64(&u.active, 1)
+	defer atomic.AddInt64(&u.active, -1)
+
+	be, bc, err := u.dial(ProtoTCP, &peer{src: conn.RemoteAddr(), dst: conn.LocalAddr()})
+	u.breaker.done(err != nil)
+	if err != nil {
+		log.Printf("unable to forward connection on port %s: %s", port, err)
+		conn.Close()
+		return
+	}
+
+	// what was read of the ClientHello is still buffered, and is sent on first
+	t := &tunnel{
+		client:  &bufferedConn{Conn: conn, r: br},
+		backend: bc,
+		br:      bufio.NewReader(bc),
+		idle:    b.idle(),
+	}
+	t.active()
+	p.join(t, be)
+}
+
+// clientHelloSNI returns the SNI of the TLS ClientHello the connection starts with, leaving it
+// buffered, or "" if it does not start with one
+func clientHelloSNI(br *bufio.Reader) string {
+	first, err := br.Peek(1)
+	if err != nil || first[0] != recordTypeHandshake {
+		return ""
+	}
+
+	header, err := br.Peek(recordHeaderSize)
+	if err != nil {
+		return ""
+	}
+
+	size := recordHeaderSize + int(binary.BigEndian.Uint16(header[3:]))
+	if size > maxRecord {
+		return ""
+	}
+
+	record, err := br.Peek(size)
+	if err != nil {
+		return ""
+	}
+
+	// the handshake is abandoned once the ClientHello is parsed
+	var sni string
+	tls.Server(&helloConn{r: bytes.NewReader(record)}, &tls.Config{
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			sni = hello.ServerName
+			return nil, errSNIRead
+		},
+	}).Handshake()
+
+	return sni
+}
+
+// helloConn is a net.Conn that reads a ClientHello for crypto/tls to parse, and discards any reply
+type helloConn struct {
+	r io.Reader
+}
+
+func (c *helloConn) Read(b []byte) (int, error)         { return c.r.Read(b) }
+func (c *helloConn) Write(b []byte) (int, error)        { return len(b), nil }
+func (c *helloConn) Close() error                       { return nil }
+func (c *helloConn) LocalAddr() net.Addr                { return nil }
+func (c *helloConn) RemoteAddr() net.Addr               { return nil }
+func (c *helloConn) SetDeadline(t time.Time) error      { return nil }
+func (c *helloConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *helloConn) SetWriteDeadline(t time.Time) error { return nil }
+
+// udpSession relays the datagrams of a client of a udp Balancer to the Endpoint picked for its first
+// datagram, and the replies of the Endpoint back, until it is idle
+type udpSession struct {
+	conn net.Conn
+	be   *backend
+	u    *upstream
+
+	idle time.Duration
+	last int64
+}
+
+// active records a datagram of the session
+func (s *udpSession) active() {
+	atomic.StoreInt64(&s.last, time.Now().UnixNano())
+}
+
+// udpRelay is the sessions of the clients of the udp Balancer on a port, by client address
+type udpRelay struct {
+	p    *Proxy
+	port string
+	pc   net.PacketConn
+
+	mu       sync.Mutex
+	sessions map[string]*udpSession
+}
+
+// serveUDP relays the datagrams of the udp Balancer on the port until its connection is closed, then
+// closes the sessions
+func (p *Proxy) serveUDP(port string, pc net.PacketConn) error {
+	r := &udpRelay{p: p, port: port, pc: pc, sessions: make(map[string]*udpSession)}
+	defer r.close()
+
+	buf := make([]byte, maxDatagram)
+	for {
+		n, addr, err := pc.ReadFrom(buf)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				continue
+			}
+			return err
+		}
+
+		b := p.balancer(port)
+		if b == nil || !b.acl.permits(addrIP(addr)) {
+			continue
+		}
+
+		s := r.session(b, addr)
+		if s == nil {
+			continue
+		}
+
+		if _, err := s.conn.Write(buf[:n]); err == nil {
+			s.active()
+		}
+	}
+}
+
+// session returns the session of the client, opening one to an Endpoint of the upstream of the
+// Balancer if it has none, or nil if there is no Endpoint to open one to. It is only called by the
+// read loop of serveUDP, so no other session of the client is opened while the Endpoint is dialed,
+// which is done without holding the lock the replies of every session take to end.
+func (r *udpRelay) session(b *balancer, addr net.Addr) *udpSession {
+	r.mu.Lock()
+	s, ok := r.sessions[addr.String()]
+	r.mu.Unlock()
+	if ok {
+		return s
+	}
+
+	u := b.l4Upstream("")
+	if _, ok := u.breaker.allow(atomic.LoadInt64(&u.active)); !ok {
+		return nil
+	}
+
+	be, conn, err := u.dial(ProtoUDP, &peer{src: addr, dst: r.pc.LocalAddr()})
+	u.breaker.done(err != nil)
+	if err != nil {
+		log.Printf("unable to forward datagram on port %s: %s", r.port, err)
+		return nil
+	}
+
+	s = &udpSession{conn: conn, be: be, u: u, idle: b.idle()}
+	s.active()
+
+	r.mu.Lock()
+	r.sessions[addr.String()] = s
+	r.mu.Unlock()
+
+	atomic.AddInt64(&u.active, 1)
+	atomic.AddInt64(&be.active, 1)
+	go r.reply(addr, s)
+
+	return s
+}
+
+// reply relays the datagrams of the Endpoint of the session to its client, until the session is idle
+// or closed
+func (r *udpRelay) reply(addr net.Addr, s *udpSession) {
+	defer func() {
+		r.mu.Lock()
+		if r.sessions[addr.String()] == s {
+			delete(r.sessions, addr.String())
+		}
+		r.mu.Unlock()
+
+		s.conn.Close()
+		atomic.AddInt64(&s.u.active, -1)
+		atomic.AddInt64(&s.be.active, -1)
+	}()
+
+	// the first reply of the Endpoint is what shows it is up, rather than dialing it
+	replied := false
+
+	buf := make([]byte, maxDatagram)
+	for {
+		s.conn.SetReadDeadline(time.Now().Add(s.idle))
+		n, err := s.conn.Read(buf)
+		if n > 0 {
+			if !replied {
+				replied = true
+				s.be.health.observe(s.u.OutlierDetection, nil, 0)
+			}
+
+			s.active()
+			r.pc.WriteTo(buf[:n], addr)
+		}
+
+		switch {
+		case err == nil:
+		case timedOut(err):
+			if time.Duration(time.Now().UnixNano()-atomic.LoadInt64(&s.last)) >= s.idle {
+				return
+			}
+		default:
+			// the Endpoint refused the datagrams, as an ICMP port unreachable
+			if !errors.Is(err, net.ErrClosed) {
+				s.be.health.observe(s.u.OutlierDetection, err, 0)
+			}
+			return
+		}
+	}
+}
+
+// close closes every session of the relay
+func (r *udpRelay) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.sessions {
+		s.conn.Close()
+	}
+}
@@ -0,0 +1,172 @@
+package proxy
+
+import (
+	"bufio"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// helloFrom returns a reader of the connection a TLS client with the SNI starts
+func helloFrom(sni string) *bufio.Reader {
+	client, server := net.Pipe()
+	go func() {
+		tls.Client(client, &tls.Config{ServerName: sni, InsecureSkipVerify: true}).Handshake()
+	}()
+
+	br := bufio.NewReaderSize(server, maxRecord)
+	br.Peek(recordHeaderSize)
+	return br
+}
+
+func TestClientHelloSNI(t *testing.T) {
+	Convey("The SNI of a ClientHello should be read, leaving it buffered", t, func() {
+		br := helloFrom("example.com")
+		So(clientHelloSNI(br), ShouldEqual, "example.com")
+
+		first, err := br.Peek(1)
+		So(err, ShouldBeNil)
+		So(first[0], ShouldEqual, recordTypeHandshake)
+	})
+
+	Convey("A ClientHello without an SNI should have none", t, func() {
+		So(clientHelloSNI(helloFrom("")), ShouldEqual, "")
+	})
+
+	Convey("A connection that does not start with a handshake should have no SNI", t, func() {
+		br := bufio.NewReader(strings.NewReader("GET / HTTP/1.1\r\n\r\n"))
+		So(clientHelloSNI(br), ShouldEqual, "")
+	})
+}
+
+func TestBalancerEndpointHealth(t *testing.T) {
+	var host string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+	}))
+	defer srv.Close()
+	addr := strings.TrimPrefix(srv.URL, "http://")
+
+	p := New(nil, nil, nil, nil, nil)
+	b, err := p.newBalancer(&sites.Balancer{
+		Proto:       ProtoTCP,
+		Port:        "9000",
+		Endpoints:   []*sites.Endpoint{{Address: addr, Weight: 1}},
+		HealthCheck: &sites.HealthCheck{Type: sites.HealthCheckType_HTTP},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.balancers = map[string]*balancer{"9000": b}
+	key := repository.HealthKey(":9000", balancerUpstream, addr)
+
+	Convey("The Endpoints of a Balancer should be checked under the hostname :port", t, func() {
+		So(checked(p.balancers), ShouldContainKey, key)
+		So(b.endpoints.HealthCheck, ShouldNotBeNil)
+	})
+
+	Convey("An http check of the Endpoints of a Balancer should ask for the Endpoint itself", t, func() {
+		p.probe(balancerHost("9000"), b.endpoints, b.endpoints.backends[0])
+		So(host, ShouldEqual, addr)
+	})
+
+	Convey("The status of the Endpoints of a Balancer should be listed for :port, and every Site", t, func() {
+		for _, hostname := range []string{"", ":9000"} {
+			list := p.Status(hostname)
+			So(list, ShouldHaveLength, 1)
+			So(list[0].Health.Hostname, ShouldEqual, ":9000")
+			So(list[0].Health.Upstream, ShouldEqual, balancerUpstream)
+		}
+
+		So(p.Status("example.com"), ShouldBeEmpty)
+	})
+}
+
+// udpEcho returns the address of a UDP server that echoes each datagram, and a func to stop it
+func udpEcho() (string, func()) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	So(err, ShouldBeNil)
+
+	go func() {
+		buf := make([]byte, maxDatagram)
+		for {
+			n, addr, err := pc.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			pc.WriteTo(buf[:n], addr)
+		}
+	}()
+
+	return pc.LocalAddr().String(), func() { pc.Close() }
+}
+
+func TestUDPSession(t *testing.T) {
+	outliers := &sites.OutlierDetection{ConsecutiveConnectErrors: 3}
+
+	Convey("With a udp Balancer", t, func() {
+		addr, stop := udpEcho()
+		defer stop()
+
+		p := New(nil, nil, nil, nil, nil)
+		b, err := p.newBalancer(&sites.Balancer{
+			Proto:            ProtoUDP,
+			Port:             "9001",
+			Endpoints:        []*sites.Endpoint{{Address: addr, Weight: 1}},
+			OutlierDetection: outliers,
+			IdleTimeout:      1,
+		}, nil)
+		So(err, ShouldBeNil)
+		be := b.endpoints.backends[0]
+
+		Convey("Dialing an Endpoint should not be taken as a success", func() {
+			be.health.connectErrors = 2
+
+			_, conn, err := b.endpoints.dial(ProtoUDP, &peer{src: &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1}})
+			So(err, ShouldBeNil)
+			conn.Close()
+			So(be.health.connectErrors, ShouldEqual, 2)
+		})
+
+		Convey("A session should be opened once per client, and relay the replies to it", func() {
+			pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+			So(err, ShouldBeNil)
+			client, err := net.ListenPacket("udp", "127.0.0.1:0")
+			So(err, ShouldBeNil)
+			r := &udpRelay{p: p, port: "9001", pc: pc, sessions: make(map[string]*udpSession)}
+			defer func() {
+				r.close()
+				pc.Close()
+				client.Close()
+			}()
+
+			be.health.connectErrors = 2
+			s := r.session(b, client.LocalAddr())
+			So(s, ShouldNotBeNil)
+			So(r.session(b, client.LocalAddr()), ShouldEqual, s)
+
+			_, err = s.conn.Write([]byte("ping"))
+			So(err, ShouldBeNil)
+
+			buf := make([]byte, maxDatagram)
+			client.SetReadDeadline(time.Now().Add(time.Second))
+			n, _, err := client.ReadFrom(buf)
+			So(err, ShouldBeNil)
+			So(string(buf[:n]), ShouldEqual, "ping")
+
+			// the reply shows the Endpoint is up
+			be.health.mu.Lock()
+			So(be.health.connectErrors, ShouldEqual, 0)
+			be.health.mu.Unlock()
+		})
+	})
+}
@@ -0,0 +1,257 @@
+package proxy
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+const (
+	// MaxMirrored is the most mirrored requests of a Route in flight at once, more are dropped
+	MaxMirrored = 100
+
+	// MirrorTimeout is the timeout of a mirrored request
+	MirrorTimeout = time.Second * 30
+
+	// MirrorHeader is set on mirrored requests, so the shadow can tell them from real requests
+	MirrorHeader = "X-Waffy-Mirror"
+
+	// mirrorAll is the Percent of a Mirror of every request. A Percent of 0 mirrors none, so a Mirror
+	// can be paused without being removed.
+	mirrorAll = -1
+)
+
+// mirror sends copies of the requests of a Route to its shadow upstream
+type mirror struct {
+	*sites.Mirror
+
+	upstream *upstream
+	stats    *mirrorStats
+}
+
+// newMirror creates the mirror m of the Route of the Site hostname to one of the upstreams
+func (p *Proxy) newMirror(hostname, route string, m *sites.Mirror, upstreams map[string]*upstream) (*mirror, error) {
+	up, ok := upstreams[m.Upstream]
+	if !ok {
+		return nil, fmt.Errorf("unknown mirror upstream %s", m.Upstream)
+	}
+
+	return &mirror{
+		Mirror:   m,
+		upstream: up,
+		stats:    p.mirrorStats(hostname, route, m.Upstream),
+	}, nil
+}
+
+// shadow is a copy of a request to send to the backend of the shadow upstream
+type shadow struct {
+	req *fasthttp.Request
+	be  *backend
+}
+
+// sample returns a copy of the request and the shadow backend to send it to if the request is sampled
+// for mirroring, or nil. The backend is picked while the request is still being served, as the
+// request can not be read once it has been.
+func (m *mirror) sample(ctx *fasthttp.RequestCtx) *shadow {
+	if m.Percent != mirrorAll && rand.Intn(100) >= int(m.Percent) {
+		return nil
+	}
+
+	be := m.upstream.pool.pick(ctx)
+	if be == nil || !m.stats.acquire() {
+		return nil
+	}
+
+	req := fasthttp.AcquireRequest()
+	ctx.Request.CopyTo(req)
+	return &shadow{req: req, be: be}
+}
+
+// send sends the sampled request to the shadow backend, recording its response against the status and
+// latency of the primary response
+func (m *mirror) send(sh *shadow, status int, latency time.Duration) {
+	defer m.stats.release()
+	defer fasthttp.ReleaseRequest(sh.req)
+
+	req, be := sh.req, sh.be
+
+	for _, h := range hopHeaders {
+		req.Header.Del(h)
+	}
+	req.Header.Set(MirrorHeader, "1")
+
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+
+	start := time.Now()
+	err := be.send(req, resp, nil, MirrorTimeout)
+	be.health.observe(m.upstream.OutlierDetection, err, resp.StatusCode())
+	if err != nil {
+		log.Printf("unable to mirror to %s: %s", be.client.Addr, err)
+		m.stats.record(status, latency, 0, 0)
+		return
+	}
+
+	m.stats.record(status, latency, resp.StatusCode(), time.Since(start))
+}
+
+// mirrorStats are the stats of a mirror, kept across reloads of the Balancers
+type mirrorStats struct {
+	mu       sync.Mutex
+	stats    sites.MirrorStats
+	inflight int
+	classes  map[string]*sites.StatusClass
+
+	primaryLatency, shadowLatency time.Duration
+}
+
+// acquire reserves a mirrored request in flight, or counts it as dropped if too many are
+func (s *mirrorStats) acquire() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.inflight >= MaxMirrored {
+		s.stats.Dropped++
+		return false
+	}
+
+	s.inflight++
+	return true
+}
+
+func (s *mirrorStats) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.inflight--
+}
+
+// record records the primary and shadow status and latency of a mirrored request. A shadow status of 0
+// is a shadow request that failed.
+func (s *mirrorStats) record(primary int, primaryLatency time.Duration, shadow int, shadowLatency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats.Mirrored++
+	s.primaryLatency += primaryLatency
+	s.class(primary).Primary++
+
+	switch {
+	case shadow == 0:
+		s.stats.Failed++
+		return
+	case shadow == primary:
+		s.stats.Matched++
+	default:
+		s.stats.Mismatched++
+	}
+
+	s.shadowLatency += shadowLatency
+	s.class(shadow).Shadow++
+}
+
+// class returns the StatusClass of the status
+func (s *mirrorStats) class(status int) *sites.StatusClass {
+	name := fmt.Sprintf("%dxx", status/100)
+	c, ok := s.classes[name]
+	if !ok {
+		c = &sites.StatusClass{Class: name}
+		s.classes[name] = c
+	}
+
+	return c
+}
+
+// snapshot returns a copy of the stats, with the mean latencies
+func (s *mirrorStats) snapshot() *sites.MirrorStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.stats
+	for _, c := range s.classes {
+		class := *c
+		stats.Statuses = append(stats.Statuses, &class)
+	}
+	sort.Slice(stats.Statuses, func(i, j int) bool { return stats.Statuses[i].Class < stats.Statuses[j].Class })
+
+	if s.stats.Mirrored > 0 {
+		stats.PrimaryLatency = int64(s.primaryLatency/time.Microsecond) / int64(s.stats.Mirrored)
+	}
+	if responses := s.stats.Matched + s.stats.Mismatched; responses > 0 {
+		stats.ShadowLatency = int64(s.shadowLatency/time.Microsecond) / int64(responses)
+	}
+
+	return &stats
+}
+
+// mirrorKey returns the key of the stats of the mirror of a Route to the shadow Upstream
+func mirrorKey(hostname, route, upstream string) string {
+	return fmt.Sprintf("%s/%s/%s", hostname, route, upstream)
+}
+
+// mirrorStats returns the stats of the mirror of a Route to the shadow Upstream, creating them if the
+// mirror is new
+func (p *Proxy) mirrorStats(hostname, route, upstream string) *mirrorStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := mirrorKey(hostname, route, upstream)
+	if s, ok := p.mirrors[key]; ok {
+		return s
+	}
+
+	s := &mirrorStats{
+		stats: sites.MirrorStats{
+			Hostname: hostname,
+			Route:    route,
+			Upstream: upstream,
+		},
+		classes: make(map[string]*sites.StatusClass),
+	}
+	p.mirrors[key] = s
+	return s
+}
+
+// MirrorStats returns the stats of the mirrors of the Site hostname on this node, or of every Site if
+// hostname is empty
+func (p *Proxy) MirrorStats(hostname string) []*sites.MirrorStats {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var list []*sites.MirrorStats
+	for _, s := range p.mirrors {
+		stats := s.snapshot()
+		if hostname == "" || strings.EqualFold(stats.Hostname, hostname) {
+			list = append(list, stats)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return mirrorKey(list[i].Hostname, list[i].Route, list[i].Upstream) < mirrorKey(list[j].Hostname, list[j].Route, list[j].Upstream)
+	})
+
+	return list
+}
+
+// mirrored returns the stats of the mirrors of the Routes of the balancers, by mirrorKey
+func mirrored(balancers map[string]*balancer) map[string]*mirrorStats {
+	mirrors := make(map[string]*mirrorStats)
+	for _, b := range balancers {
+		for _, s := range b.hosts {
+			for _, r := range s.routes {
+				if r.mirror != nil {
+					mirrors[mirrorKey(s.Hostname, r.Name, r.mirror.Upstream)] = r.mirror.stats
+				}
+			}
+		}
+	}
+
+	return mirrors
+}
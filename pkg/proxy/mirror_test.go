@@ -0,0 +1,214 @@
+package proxy
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/valyala/fasthttp"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// sampled returns how many of n requests the mirror samples, releasing each shadow it samples
+func sampled(m *mirror, n int) int {
+	count := 0
+	for i := 0; i < n; i++ {
+		if sh := m.sample(testRequest("GET", "http://example.com/")); sh != nil {
+			count++
+			fasthttp.ReleaseRequest(sh.req)
+			m.stats.release()
+		}
+	}
+
+	return count
+}
+
+// testMirror returns a mirror of the percent of the requests to the backends
+func testMirror(percent int32, backends []*backend) *mirror {
+	pool, err := newPicker(sites.Strategy_ROUND_ROBIN, nil, backends)
+	So(err, ShouldBeNil)
+
+	p := &Proxy{mirrors: make(map[string]*mirrorStats)}
+	return &mirror{
+		Mirror:   &sites.Mirror{Upstream: "shadow", Percent: percent},
+		upstream: &upstream{Upstream: &sites.Upstream{Name: "shadow"}, backends: backends, pool: pool},
+		stats:    p.mirrorStats("example.com", "all", "shadow"),
+	}
+}
+
+func TestMirrorSample(t *testing.T) {
+	Convey("With a shadow upstream", t, func() {
+		backends := testBackends(1)
+
+		Convey("A mirror of every request should sample every request", func() {
+			So(sampled(testMirror(mirrorAll, backends), 1000), ShouldEqual, 1000)
+			So(sampled(testMirror(100, backends), 1000), ShouldEqual, 1000)
+		})
+
+		Convey("A mirror of 0% should sample no request", func() {
+			So(sampled(testMirror(0, backends), 1000), ShouldEqual, 0)
+		})
+
+		Convey("A mirror of a percent should sample about that percent of the requests", func() {
+			n := sampled(testMirror(25, backends), 4000)
+			So(n, ShouldBeBetween, 800, 1200)
+		})
+
+		Convey("A sampled request should be a copy, with the backend to send it to", func() {
+			m := testMirror(mirrorAll, backends)
+			ctx := testRequest("POST", "http://example.com/orders?id=1", "X-Test", "1")
+			ctx.Request.SetBody([]byte("order"))
+
+			sh := m.sample(ctx)
+			So(sh, ShouldNotBeNil)
+			So(sh.be, ShouldEqual, backends[0])
+
+			ctx.Request.SetBody([]byte("changed"))
+			ctx.Request.Header.Set("X-Test", "2")
+			So(string(sh.req.Body()), ShouldEqual, "order")
+			So(string(sh.req.Header.Peek("X-Test")), ShouldEqual, "1")
+			So(string(sh.req.URI().RequestURI()), ShouldEqual, "/orders?id=1")
+		})
+
+		Convey("Requests over the mirrored requests in flight should be dropped", func() {
+			m := testMirror(mirrorAll, backends)
+			for i := 0; i < MaxMirrored; i++ {
+				So(m.sample(testRequest("GET", "http://example.com/")), ShouldNotBeNil)
+			}
+
+			So(m.sample(testRequest("GET", "http://example.com/")), ShouldBeNil)
+			So(m.stats.snapshot().Dropped, ShouldEqual, 1)
+
+			m.stats.release()
+			So(m.sample(testRequest("GET", "http://example.com/")), ShouldNotBeNil)
+		})
+
+		Convey("A request should not be sampled when the shadow upstream has no backend, nor reserve a request in flight", func() {
+			m := testMirror(mirrorAll, nil)
+			So(m.sample(testRequest("GET", "http://example.com/")), ShouldBeNil)
+			So(m.stats.inflight, ShouldEqual, 0)
+		})
+	})
+}
+
+func TestMirrorIsolation(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("primary"))
+	}))
+	defer primary.Close()
+
+	var shadowed, marked int64
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt64(&shadowed, 1)
+		if req.Header.Get(MirrorHeader) == "1" {
+			atomic.AddInt64(&marked, 1)
+		}
+
+		time.Sleep(time.Millisecond * 500)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer slow.Close()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unreachable := closed.Addr().String()
+	closed.Close()
+
+	// serve serves a Site that mirrors every request to the shadow, returning the Proxy and address
+	serve := func(shadow string) (*Proxy, string, func()) {
+		return serveProxy(&sites.Balancer{Proto: ProtoHTTP, Sites: []*sites.Site{{
+			Hostname: "example.com",
+			Upstreams: []*sites.Upstream{
+				{Name: "web", Endpoints: []*sites.Endpoint{{Address: strings.TrimPrefix(primary.URL, "http://")}}},
+				{Name: "shadow", Endpoints: []*sites.Endpoint{{Address: shadow}}},
+			},
+			Routes: []*sites.Route{{Name: "all", Upstream: "web", Mirror: &sites.Mirror{Upstream: "shadow", Percent: mirrorAll}}},
+		}}})
+	}
+
+	// get returns the response of the proxy to the client, and how long it took
+	get := func(addr string) (int, string, time.Duration) {
+		req, err := http.NewRequest("GET", "http://"+addr+"/", nil)
+		So(err, ShouldBeNil)
+		req.Host = "example.com"
+		req.Close = true
+
+		start := time.Now()
+		resp, err := http.DefaultClient.Do(req)
+		So(err, ShouldBeNil)
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		So(err, ShouldBeNil)
+		return resp.StatusCode, string(body), time.Since(start)
+	}
+
+	// recorded waits for the mirror of the Site to record a request, returning its stats
+	recorded := func(p *Proxy) *sites.MirrorStats {
+		for i := 0; i < 100; i++ {
+			if stats := p.MirrorStats("example.com"); len(stats) == 1 && stats[0].Mirrored > 0 {
+				return stats[0]
+			}
+			time.Sleep(time.Millisecond * 20)
+		}
+
+		return &sites.MirrorStats{}
+	}
+
+	Convey("A slow shadow should not delay or change the response to the client", t, func() {
+		p, addr, stop := serve(strings.TrimPrefix(slow.URL, "http://"))
+		defer stop()
+
+		status, body, took := get(addr)
+		So(status, ShouldEqual, http.StatusOK)
+		So(body, ShouldEqual, "primary")
+		So(took, ShouldBeLessThan, time.Millisecond*300)
+
+		stats := recorded(p)
+		So(stats.Mirrored, ShouldEqual, 1)
+		So(stats.Mismatched, ShouldEqual, 1)
+		So(stats.ShadowLatency, ShouldBeGreaterThanOrEqualTo, 500000)
+		So(atomic.LoadInt64(&shadowed), ShouldEqual, 1)
+		So(atomic.LoadInt64(&marked), ShouldEqual, 1)
+	})
+
+	Convey("A shadow that can not be reached should not fail the response to the client", t, func() {
+		p, addr, stop := serve(unreachable)
+		defer stop()
+
+		status, body, _ := get(addr)
+		So(status, ShouldEqual, http.StatusOK)
+		So(body, ShouldEqual, "primary")
+
+		stats := recorded(p)
+		So(stats.Mirrored, ShouldEqual, 1)
+		So(stats.Failed, ShouldEqual, 1)
+	})
+
+	Convey("A paused mirror should not send the shadow any request", t, func() {
+		before := atomic.LoadInt64(&shadowed)
+		addr, stop := serveBalancer(&sites.Balancer{Proto: ProtoHTTP, Sites: []*sites.Site{{
+			Hostname: "example.com",
+			Upstreams: []*sites.Upstream{
+				{Name: "web", Endpoints: []*sites.Endpoint{{Address: strings.TrimPrefix(primary.URL, "http://")}}},
+				{Name: "shadow", Endpoints: []*sites.Endpoint{{Address: strings.TrimPrefix(slow.URL, "http://")}}},
+			},
+			Routes: []*sites.Route{{Name: "all", Upstream: "web", Mirror: &sites.Mirror{Upstream: "shadow"}}},
+		}}})
+		defer stop()
+
+		status, _, _ := get(addr)
+		So(status, ShouldEqual, http.StatusOK)
+		time.Sleep(time.Millisecond * 100)
+		So(atomic.LoadInt64(&shadowed), ShouldEqual, before)
+	})
+}
@@ -0,0 +1,345 @@
+package proxy
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// DefaultPageType is the content type of the error and maintenance pages that do not set one
+	DefaultPageType = "text/html; charset=utf-8"
+
+	// failureKey is the user value of a request the proxy answered itself, holding its failure
+	failureKey = "waffy.failure"
+
+	// siteKey is the user value of a request holding the site it is for, once it is known
+	siteKey = "waffy.site"
+
+	// requestIDKey is the user value of a request holding its request ID
+	requestIDKey = "waffy.request-id"
+
+	// peerCertificateKey is the user value of a request over HTTP/2 holding the client certificate
+	// sent over TLS, as its fasthttp.RequestCtx has no connection
+	peerCertificateKey = "waffy.peer-certificate"
+
+	// maxRequestID is the longest X-Request-Id kept from trusted proxies
+	maxRequestID = 128
+)
+
+// defaultMaintenancePage is the maintenance page of Sites with neither a maintenance template nor a
+// 503 ErrorPage
+const defaultMaintenancePage = `<!DOCTYPE html>
+<html>
+<head><title>{{.StatusText}}</title></head>
+<body>
+<h1>{{.Host}} is down for maintenance</h1>
+<p>Please try again later.</p>
+<p><small>Request ID {{.RequestID}}</small></p>
+</body>
+</html>
+`
+
+// pageData is what the templates of error and maintenance pages are given
+type pageData struct {
+	RequestID  string
+	Status     int
+	StatusText string
+
+	// Message is why the proxy answered the request itself
+	Message string
+
+	Method   string
+	Host     string
+	Path     string
+	ClientIP string
+	Time     time.Time
+}
+
+// executor is a parsed text/template or html/template
+type executor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// page is the template of an error or maintenance page
+type page struct {
+	tmpl        executor
+	contentType string
+}
+
+// newPage parses the template of a page with the content type, escaping it as HTML if it is HTML
+func newPage(name, contentType, text string) (*page, error) {
+	if contentType == "" {
+		contentType = DefaultPageType
+	}
+
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid content type of %s: %s", name, err)
+	}
+
+	var tmpl executor
+	if mt == "text/html" || mt == "application/xhtml+xml" {
+		tmpl, err = htmltemplate.New(name).Parse(text)
+	} else {
+		tmpl, err = template.New(name).Parse(text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid template of %s: %s", name, err)
+	}
+
+	return &page{tmpl: tmpl, contentType: contentType}, nil
+}
+
+// newErrorPages parses the ErrorPages of a Site, by status
+func newErrorPages(eps []*sites.ErrorPage) (map[int]*page, error) {
+	pages := make(map[int]*page)
+	for _, ep := range eps {
+		pg, err := newPage(fmt.Sprintf("error page %d", ep.Status), ep.ContentType, ep.Template)
+		if err != nil {
+			return nil, err
+		}
+		pages[int(ep.Status)] = pg
+	}
+
+	return pages, nil
+}
+
+// maintenance is the Maintenance of a Site, with its page parsed and the clients that bypass it
+type maintenance struct {
+	*sites.Maintenance
+
+	page  *page
+	allow cidrs
+	users map[string]bool
+}
+
+// newMaintenance creates the maintenance for m, falling back to the 503 page of the errors, or nil if
+// m is not enabled
+func newMaintenance(m *sites.Maintenance, errors map[int]*page) (*maintenance, error) {
+	if m == nil || !m.Enabled {
+		return nil, nil
+	}
+
+	allow, err := newCIDRs(m.Allow)
+	if err != nil {
+		return nil, fmt.Errorf("invalid maintenance allow: %s", err)
+	}
+
+	mt := &maintenance{
+		Maintenance: m,
+		allow:       allow,
+		users:       make(map[string]bool),
+		page:        errors[fasthttp.StatusServiceUnavailable],
+	}
+	for _, u := range m.Users {
+		mt.users[strings.ToLower(u)] = true
+	}
+
+	switch {
+	case m.Template != "":
+		if mt.page, err = newPage("maintenance page", m.ContentType, m.Template); err != nil {
+			return nil, err
+		}
+	case mt.page == nil:
+		mt.page, _ = newPage("maintenance page", DefaultPageType, defaultMaintenancePage)
+	}
+
+	return mt, nil
+}
+
+// failure is why the proxy answered a request itself, and the page it is answered with if not the
+// error page of its Site for the status
+type failure struct {
+	msg  string
+	page *page
+}
+
+// fail answers the request with the status and message itself, rather than with a response of an
+// upstream, to be replaced by the error page of its Site for the status if it has one. Failures are
+// logged with the request ID clients are given, so they can be found.
+func fail(ctx *fasthttp.RequestCtx, msg string, status int) {
+	answer(ctx, msg, status, nil)
+}
+
+// answer answers the request with the status and message itself, and the page if it is set
+func answer(ctx *fasthttp.RequestCtx, msg string, status int, pg *page) {
+	ctx.Error(msg, status)
+	ctx.SetUserValue(failureKey, &failure{msg: msg, page: pg})
+
+	id := requestID(ctx)
+	if id != "" {
+		ctx.Response.Header.Set("X-Request-Id", id)
+	}
+	log.Printf("answered %s %s%s with %d, request %s: %s", ctx.Method(), ctx.Host(), ctx.Path(), status, id, msg)
+}
+
+// renderPage replaces the response the proxy answered the request with itself by the page of the
+// failure, or the error page of its Site for the status, if it has one
+func renderPage(ctx *fasthttp.RequestCtx) {
+	f, ok := ctx.UserValue(failureKey).(*failure)
+	if !ok {
+		return
+	}
+
+	pg := f.page
+	if s, ok := ctx.UserValue(siteKey).(*site); ok && pg == nil {
+		pg = s.errorPages[ctx.Response.StatusCode()]
+	}
+	if pg == nil {
+		return
+	}
+
+	status := ctx.Response.StatusCode()
+	var buf bytes.Buffer
+	err := pg.tmpl.Execute(&buf, &pageData{
+		RequestID:  requestID(ctx),
+		Status:     status,
+		StatusText: http.StatusText(status),
+		Message:    f.msg,
+		Method:     string(ctx.Method()),
+		Host:       string(ctx.Host()),
+		Path:       string(ctx.Path()),
+		ClientIP:   clientIP(ctx).String(),
+		Time:       time.Now().UTC(),
+	})
+	if err != nil {
+		log.Printf("unable to render %d page for %s: %s", status, ctx.Host(), err)
+		return
+	}
+
+	// pages are made for each request, so are not cached
+	ctx.Response.Header.SetContentType(pg.contentType)
+	ctx.Response.Header.Set("Cache-Control", "no-store")
+	ctx.Response.SetBody(buf.Bytes())
+}
+
+// setRequestID sets the request ID of the request, sent upstream as X-Request-Id. That of a trusted
+// proxy is kept, so the request can be followed through it.
+func setRequestID(ctx *fasthttp.RequestCtx, trusted bool) {
+	id := string(ctx.Request.Header.Peek("X-Request-Id"))
+	if !trusted || !validRequestID(id) {
+		b := make([]byte, 16)
+		rand.Read(b)
+		id = hex.EncodeToString(b)
+	}
+
+	ctx.Request.Header.Set("X-Request-Id", id)
+	ctx.SetUserValue(requestIDKey, id)
+}
+
+// requestID returns the request ID of the request, or "" if it has none yet
+func requestID(ctx *fasthttp.RequestCtx) string {
+	id, _ := ctx.UserValue(requestIDKey).(string)
+	return id
+}
+
+// validRequestID returns if the X-Request-Id of a trusted proxy is short and printable
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestID {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// maintain answers the request with the maintenance page of the Site, unless its client bypasses
+// maintenance, returning if it did
+func (p *Proxy) maintain(ctx *fasthttp.RequestCtx, m *maintenance) bool {
+	if p.bypasses(ctx, m) {
+		return false
+	}
+
+	answer(ctx, "site is down for maintenance", fasthttp.StatusServiceUnavailable, m.page)
+	if m.RetryAfter > 0 {
+		ctx.Response.Header.Set("Retry-After", strconv.FormatInt(m.RetryAfter, 10))
+	}
+
+	return true
+}
+
+// bypasses returns if the client of the request bypasses the maintenance, from an allowed CIDR or
+// with the client certificate of an allowed user, verified against the waffy CA and not revoked
+func (p *Proxy) bypasses(ctx *fasthttp.RequestCtx, m *maintenance) bool {
+	if m.allow.contains(clientIP(ctx)) {
+		return true
+	}
+
+	cert := clientCertificate(ctx)
+	if cert == nil || !m.users[strings.ToLower(cert.Subject.CommonName)] {
+		return false
+	}
+
+	if err := crypto.Verify(cert, p.roots, p.intermediates.Trusted(time.Now()), x509.ExtKeyUsageClientAuth); err != nil {
+		return false
+	}
+
+	// a revoked certificate, or a user that has been removed, no longer bypasses maintenance
+	c, err := repository.FindCertificateBySerial(p.db.Weak(), cert.SerialNumber.Bytes())
+	if err == nil && c.RevokedAt != 0 {
+		return false
+	}
+
+	_, err = repository.FindUserByEmail(p.db.Weak(), cert.Subject.CommonName)
+	return err == nil
+}
+
+// clientCertificate returns the certificate the client of the request sent over TLS, or nil if it sent
+// none
+func clientCertificate(ctx *fasthttp.RequestCtx) *x509.Certificate {
+	if cert, ok := ctx.UserValue(peerCertificateKey).(*x509.Certificate); ok {
+		return cert
+	}
+
+	if state := ctx.TLSConnectionState(); state != nil && len(state.PeerCertificates) > 0 {
+		return state.PeerCertificates[0]
+	}
+
+	return nil
+}
+
+// clientAuth returns the GetConfigForClient of the TLS listener of the Balancer on the port with the
+// config, asking the clients of Sites in maintenance that users may bypass for a certificate, so the
+// clients of other Sites are never asked for one
+func (p *Proxy) clientAuth(port string, cfg *tls.Config) func(*tls.ClientHelloInfo) (*tls.Config, error) {
+	return func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+		b := p.balancer(port)
+		if b == nil {
+			return nil, nil
+		}
+
+		s := b.site(hostname([]byte(hello.ServerName)))
+		if s == nil || s.maintenance == nil || len(s.maintenance.users) == 0 {
+			return nil, nil
+		}
+
+		// the certificate is verified when the request is, as clients without one are still served
+		c := cfg.Clone()
+		c.GetConfigForClient = nil
+		c.ClientAuth = tls.RequestClientCert
+		return c, nil
+	}
+}
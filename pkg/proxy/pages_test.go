@@ -0,0 +1,312 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/valyala/fasthttp"
+
+	"github.com/unerror/waffy/pkg/config"
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+	"github.com/unerror/waffy/pkg/services/protos/users"
+)
+
+const testBits = 1024
+
+// testConsensus is a data.Consensus of a single node on a data.Store. The proxy only reads and
+// writes the Buckets of the store, so it has no values of its own.
+type testConsensus struct {
+	data.Store
+	leader bool
+}
+
+func (c *testConsensus) List() ([]data.Node, error)        { return nil, errNoValues }
+func (c *testConsensus) Get(k []byte) ([]byte, error)      { return nil, errNoValues }
+func (c *testConsensus) Set(n data.Node) error             { return errNoValues }
+func (c *testConsensus) Delete(n data.Node) error          { return errNoValues }
+func (c *testConsensus) Seek(k []byte) ([]byte, error)     { return nil, errNoValues }
+func (c *testConsensus) GetWeak(k []byte) ([]byte, error)  { return nil, errNoValues }
+func (c *testConsensus) ListWeak() ([]data.Node, error)    { return nil, errNoValues }
+func (c *testConsensus) SeekWeak(k []byte) ([]byte, error) { return nil, errNoValues }
+func (c *testConsensus) Join(addr string) error            { return nil }
+func (c *testConsensus) Leave(addr string) error           { return nil }
+func (c *testConsensus) Leader() bool                      { return c.leader }
+func (c *testConsensus) Weak() data.Bucket                 { return c }
+
+// errNoValues is the error of reading or writing a value of a testConsensus outside of a Bucket
+var errNoValues = errors.New("no values outside of a bucket")
+
+// newTestConsensus creates a testConsensus in a temporary directory, and the func that removes it
+func newTestConsensus(t *testing.T, leader bool) (*testConsensus, func()) {
+	dir, err := ioutil.TempDir("", "waffy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := data.NewDB(filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testConsensus{Store: db, leader: leader}, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestErrorPages(t *testing.T) {
+	pages, err := newErrorPages([]*sites.ErrorPage{
+		{Status: 502, Template: "<p>{{.Status}} {{.StatusText}}: {{.Message}} for {{.Method}} {{.Host}}{{.Path}} from {{.ClientIP}}, {{.RequestID}}</p>"},
+		{Status: 504, ContentType: "text/plain", Template: "{{.Message}}"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &site{Site: &sites.Site{}, errorPages: pages}
+
+	// failed returns a request from the client to the site, that the proxy failed with the status
+	failed := func(msg string, status int) *fasthttp.RequestCtx {
+		ctx := forwardedRequest("10.0.0.1")
+		setRequestID(ctx, false)
+		ctx.SetUserValue(siteKey, s)
+		fail(ctx, msg, status)
+		renderPage(ctx)
+		return ctx
+	}
+
+	Convey("A failure should be answered with the error page of its Site for the status", t, func() {
+		ctx := failed("upstream <gone>", fasthttp.StatusBadGateway)
+		id := requestID(ctx)
+
+		So(ctx.Response.StatusCode(), ShouldEqual, fasthttp.StatusBadGateway)
+		So(string(ctx.Response.Header.ContentType()), ShouldEqual, DefaultPageType)
+		So(string(ctx.Response.Header.Peek("Cache-Control")), ShouldEqual, "no-store")
+		So(string(ctx.Response.Header.Peek("X-Request-Id")), ShouldEqual, id)
+		So(string(ctx.Response.Body()), ShouldEqual,
+			"<p>502 Bad Gateway: upstream &lt;gone&gt; for GET example.com/ from 10.0.0.1, "+id+"</p>")
+	})
+
+	Convey("An error page that is not HTML should not be escaped", t, func() {
+		ctx := failed("upstream <timed out>", fasthttp.StatusGatewayTimeout)
+		So(string(ctx.Response.Header.ContentType()), ShouldEqual, "text/plain")
+		So(string(ctx.Response.Body()), ShouldEqual, "upstream <timed out>")
+	})
+
+	Convey("A failure with no error page for its status should be left as it is", t, func() {
+		ctx := failed("denied", fasthttp.StatusForbidden)
+		So(string(ctx.Response.Body()), ShouldEqual, "denied")
+	})
+
+	Convey("A response of an upstream should not be replaced by an error page", t, func() {
+		ctx := forwardedRequest("10.0.0.1")
+		ctx.SetUserValue(siteKey, s)
+		ctx.Response.SetStatusCode(fasthttp.StatusBadGateway)
+		ctx.Response.SetBodyString("from upstream")
+		renderPage(ctx)
+		So(string(ctx.Response.Body()), ShouldEqual, "from upstream")
+	})
+
+	Convey("An error page with an invalid template or content type should be refused", t, func() {
+		_, err := newErrorPages([]*sites.ErrorPage{{Status: 502, Template: "{{.Status"}})
+		So(err, ShouldNotBeNil)
+
+		_, err = newErrorPages([]*sites.ErrorPage{{Status: 502, ContentType: "text/", Template: "down"}})
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestRequestID(t *testing.T) {
+	Convey("The request ID of a trusted proxy should be kept", t, func() {
+		ctx := forwardedRequest("10.0.0.1", "X-Request-Id", "abc-123")
+		setRequestID(ctx, true)
+		So(requestID(ctx), ShouldEqual, "abc-123")
+		So(string(ctx.Request.Header.Peek("X-Request-Id")), ShouldEqual, "abc-123")
+	})
+
+	Convey("The request ID of other clients, or an invalid one, should be replaced", t, func() {
+		for _, trusted := range []bool{false, true} {
+			for _, id := range []string{"abc-123", "a b", ""} {
+				if trusted && validRequestID(id) {
+					continue
+				}
+
+				ctx := forwardedRequest("10.0.0.1", "X-Request-Id", id)
+				setRequestID(ctx, trusted)
+				So(requestID(ctx), ShouldNotEqual, id)
+				So(requestID(ctx), ShouldHaveLength, 32)
+			}
+		}
+	})
+}
+
+func TestMaintenance(t *testing.T) {
+	root, rootKey, err := crypto.NewCertificateAuthority(testBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreign, foreignKey, err := crypto.NewCertificateAuthority(testBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// issue issues a client certificate for the email from the CA
+	issue := func(ca *x509.Certificate, caKey interface{}, email string) *x509.Certificate {
+		key, err := crypto.NewPrivateKey(testBits)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cert, err := crypto.NewCertificate(ca, caKey, key, false, email)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert
+	}
+
+	db, cleanup := newTestConsensus(t, true)
+	defer cleanup()
+
+	admin := issue(root, rootKey, "admin@example.com")
+	revoked := issue(root, rootKey, "admin@example.com")
+	removed := issue(root, rootKey, "removed@example.com")
+	other := issue(root, rootKey, "other@example.com")
+	forged := issue(foreign, foreignKey, "admin@example.com")
+
+	for _, c := range []*x509.Certificate{admin, other} {
+		err := repository.CreateUser(db, &users.User{Email: c.Subject.CommonName, Certificate: repository.NewCertificate(c)})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, c := range []*x509.Certificate{admin, revoked, other} {
+		if err := repository.CreateCertificate(db, repository.NewCertificate(c)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := repository.RevokeCertificate(db, revoked.SerialNumber.Bytes(), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+	p := &Proxy{db: db, roots: roots, intermediates: &config.IntermediatePool{}}
+
+	m, err := newMaintenance(&sites.Maintenance{
+		Enabled:    true,
+		Allow:      []string{"10.1.0.0/16"},
+		Users:      []string{"Admin@example.com", "removed@example.com"},
+		RetryAfter: 120,
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// request returns a request from the client, with the client certificate if it is set
+	request := func(client string, cert *x509.Certificate) *fasthttp.RequestCtx {
+		ctx := forwardedRequest(client)
+		if cert != nil {
+			ctx.SetUserValue(peerCertificateKey, cert)
+		}
+		return ctx
+	}
+
+	Convey("A client that does not bypass maintenance should get the maintenance page, with Retry-After", t, func() {
+		ctx := request("10.2.0.1", nil)
+		setRequestID(ctx, false)
+		So(p.maintain(ctx, m), ShouldBeTrue)
+		renderPage(ctx)
+
+		So(ctx.Response.StatusCode(), ShouldEqual, fasthttp.StatusServiceUnavailable)
+		So(string(ctx.Response.Header.Peek("Retry-After")), ShouldEqual, "120")
+		So(string(ctx.Response.Body()), ShouldContainSubstring, "<h1>example.com is down for maintenance</h1>")
+		So(string(ctx.Response.Body()), ShouldContainSubstring, "Request ID "+requestID(ctx))
+	})
+
+	Convey("A client from an allowed CIDR should bypass maintenance", t, func() {
+		So(p.maintain(request("10.1.2.3", nil), m), ShouldBeFalse)
+	})
+
+	Convey("A user with a verified certificate that is not revoked should bypass maintenance", t, func() {
+		So(p.maintain(request("10.2.0.1", admin), m), ShouldBeFalse)
+	})
+
+	Convey("A user with a revoked certificate should not bypass maintenance", t, func() {
+		So(p.maintain(request("10.2.0.1", revoked), m), ShouldBeTrue)
+	})
+
+	Convey("A certificate for an allowed user from another CA should not bypass maintenance", t, func() {
+		So(p.maintain(request("10.2.0.1", forged), m), ShouldBeTrue)
+	})
+
+	Convey("A user that is not allowed, or has been removed, should not bypass maintenance", t, func() {
+		So(p.maintain(request("10.2.0.1", other), m), ShouldBeTrue)
+		So(p.maintain(request("10.2.0.1", removed), m), ShouldBeTrue)
+	})
+
+	Convey("The maintenance page should be the template of the Maintenance, then the 503 error page", t, func() {
+		pages, err := newErrorPages([]*sites.ErrorPage{{Status: 503, ContentType: "text/plain", Template: "unavailable"}})
+		So(err, ShouldBeNil)
+
+		for tmpl, body := range map[string]string{"{{.Host}} is back soon": "example.com is back soon", "": "unavailable"} {
+			m, err := newMaintenance(&sites.Maintenance{Enabled: true, ContentType: "text/plain", Template: tmpl}, pages)
+			So(err, ShouldBeNil)
+
+			ctx := request("10.2.0.1", nil)
+			So(p.maintain(ctx, m), ShouldBeTrue)
+			renderPage(ctx)
+			So(string(ctx.Response.Body()), ShouldEqual, body)
+			So(string(ctx.Response.Header.Peek("Retry-After")), ShouldEqual, "")
+		}
+	})
+
+	Convey("A Maintenance that is not enabled should not be loaded", t, func() {
+		m, err := newMaintenance(&sites.Maintenance{Allow: []string{"10.1.0.0/16"}}, nil)
+		So(err, ShouldBeNil)
+		So(m, ShouldBeNil)
+	})
+}
+
+func TestClientAuth(t *testing.T) {
+	maintained := func(users ...string) *site {
+		m, err := newMaintenance(&sites.Maintenance{Enabled: true, Users: users}, nil)
+		So(err, ShouldBeNil)
+		return &site{Site: &sites.Site{}, maintenance: m}
+	}
+
+	Convey("Client certificates should only be asked for by Sites in maintenance that users may bypass", t, func() {
+		p := New(nil, nil, nil, nil, nil)
+		p.balancers["443"] = &balancer{hosts: map[string]*site{
+			"down.example.com":   maintained("admin@example.com"),
+			"closed.example.com": maintained(),
+			"up.example.com":     {Site: &sites.Site{}},
+		}}
+		cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+		auth := p.clientAuth("443", cfg)
+		cfg.GetConfigForClient = auth
+
+		c, err := auth(&tls.ClientHelloInfo{ServerName: "down.example.com"})
+		So(err, ShouldBeNil)
+		So(c.ClientAuth, ShouldEqual, tls.RequestClientCert)
+		So(c.GetConfigForClient, ShouldBeNil)
+		So(c.MinVersion, ShouldEqual, tls.VersionTLS12)
+
+		for _, name := range []string{"closed.example.com", "up.example.com", "unknown.example.com"} {
+			c, err := auth(&tls.ClientHelloInfo{ServerName: name})
+			So(err, ShouldBeNil)
+			So(c, ShouldBeNil)
+		}
+
+		c, err = p.clientAuth("8443", cfg)(&tls.ClientHelloInfo{ServerName: "down.example.com"})
+		So(err, ShouldBeNil)
+		So(c, ShouldBeNil)
+	})
+}
@@ -0,0 +1,673 @@
+// Package proxy serves the Sites of each Balancer stored in the consensus
+package proxy
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"github.com/unerror/waffy/pkg/acme"
+	"github.com/unerror/waffy/pkg/config"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+const (
+	// ReloadInterval is how often the Balancers are reloaded from the store
+	ReloadInterval = time.Second * 10
+
+	// ProtoHTTP is the Balancer proto that serves HTTP/1.1 without TLS
+	ProtoHTTP = "http"
+
+	// ProtoHTTPS is the Balancer proto that terminates TLS
+	ProtoHTTPS = "https"
+
+	// ProtoH2 is the Balancer proto that terminates TLS, serving HTTP/2 and HTTP/1.1
+	ProtoH2 = "h2"
+
+	// ProtoH2C is the Balancer proto that serves HTTP/2 without TLS, and HTTP/1.1
+	ProtoH2C = "h2c"
+
+	// ProtoTCP is the Balancer proto that forwards TCP connections as they are, routing TLS by SNI
+	ProtoTCP = "tcp"
+
+	// ProtoUDP is the Balancer proto that relays UDP datagrams as they are
+	ProtoUDP = "udp"
+
+	// SchemeHTTP is the Endpoint scheme of HTTP/1.1 backends, and of Endpoints with no scheme
+	SchemeHTTP = "http"
+
+	// SchemeHTTPS is the Endpoint scheme of backends served over TLS, with HTTP/2 if they offer it
+	SchemeHTTPS = "https"
+
+	// SchemeH2C is the Endpoint scheme of HTTP/2 backends served without TLS
+	SchemeH2C = "h2c"
+)
+
+// upstream is an Upstream, with the picker for its Endpoints, its circuit breaker, and the number of
+// requests and retries active on it
+type upstream struct {
+	*sites.Upstream
+
+	backends []*backend
+	pool     picker
+	breaker  *breaker
+
+	active, retrying int64
+}
+
+// newUpstream creates the upstream for u of the Site hostname, with the health of its Endpoints and
+// the Resilience r of the Site. The Endpoints of a Balancer have no Site, and are not checked.
+func (p *Proxy) newUpstream(hostname string, u *sites.Upstream, r *sites.Resilience) (*upstream, error) {
+	backends := make([]*backend, len(u.Endpoints))
+	for i, e := range u.Endpoints {
+		h := newHealth(hostname, u.Name, endpointAddr(e))
+		if hostname != "" {
+			h = p.endpointHealth(h)
+		}
+
+		b, err := newBackend(e, h, r, u.ProxyProtocol)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load endpoint %s: %s", e.Address, err)
+		}
+		backends[i] = b
+	}
+
+	pool, err := newPicker(u.Strategy, u.Hash, backends)
+	if err != nil {
+		return nil, err
+	}
+
+	return &upstream{
+		Upstream: u,
+		backends: backends,
+		pool:     pool,
+		breaker:  newBreaker(r.GetCircuitBreaker()),
+	}, nil
+}
+
+// site is a Site, with its Upstreams by name, its Routes, and the policy of requests to them
+type site struct {
+	*sites.Site
+
+	upstreams map[string]*upstream
+	routes    []*route
+	policy    *policy
+
+	// affinity is the Affinity of the Site, or of its Balancer if it has none, or nil if clients are
+	// not pinned to an Endpoint
+	affinity *sites.Affinity
+
+	websocket *websocket
+
+	// cache is the cache of the responses of the Site, or nil if they are not cached
+	cache *cache
+
+	// compression is the Compression of the Site, or nil if its responses are not compressed
+	compression *compression
+
+	redirects []*redirect
+	rewrites  []*rewrite
+
+	// errorPages replace the responses the proxy answers requests with itself, by status
+	errorPages map[int]*page
+
+	// maintenance is the Maintenance of the Site, or nil if it is not down for maintenance
+	maintenance *maintenance
+
+	// primary is the first Upstream of the Site, or the Endpoints of the Balancer if it has none
+	primary *upstream
+}
+
+// balancer is a Balancer, with its Sites indexed by hostname and alias
+type balancer struct {
+	*sites.Balancer
+
+	raw   []byte
+	hosts map[string]*site
+	acl   *acl
+
+	// proxyFrom are the load balancers whose connections start with a PROXY protocol header
+	proxyFrom cidrs
+
+	// trusted are the proxies whose forwarding headers give the client of a request
+	trusted cidrs
+
+	// endpoints are the Endpoints of the Balancer, for Sites without Upstreams
+	endpoints *upstream
+}
+
+// newBalancer creates the balancer for b, keeping the Endpoint connections and state of prev if b
+// has not changed
+func (p *Proxy) newBalancer(b *sites.Balancer, prev *balancer) (*balancer, error) {
+	raw, err := b.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	if prev != nil && bytes.Equal(prev.raw, raw) {
+		return prev, nil
+	}
+
+	a, err := newACL(b.Allow, b.Deny)
+	if err != nil {
+		return nil, err
+	}
+
+	proxyFrom, err := newCIDRs(b.ProxyProtocolFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	trusted, err := newCIDRs(b.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := p.newUpstream(balancerHost(b.Port), &sites.Upstream{
+		Name:             balancerUpstream,
+		Endpoints:        b.Endpoints,
+		Strategy:         b.Strategy,
+		Hash:             b.Hash,
+		ProxyProtocol:    b.ProxyProtocol,
+		HealthCheck:      b.HealthCheck,
+		OutlierDetection: b.OutlierDetection,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make(map[string]*site)
+	for _, s := range b.Sites {
+		st := &site{
+			Site:      s,
+			upstreams: make(map[string]*upstream),
+			policy:    newPolicy(s.Resilience),
+			primary:   endpoints,
+			affinity:  b.Affinity,
+		}
+		if s.Affinity != nil {
+			st.affinity = s.Affinity
+		}
+
+		if st.websocket, err = newWebSocket(s.Websocket); err != nil {
+			return nil, fmt.Errorf("unable to load site %s: %s", s.Hostname, err)
+		}
+		if st.redirects, err = newRedirects(s.Redirects); err != nil {
+			return nil, fmt.Errorf("unable to load site %s: %s", s.Hostname, err)
+		}
+		if st.rewrites, err = newRewrites(s.Rewrites); err != nil {
+			return nil, fmt.Errorf("unable to load site %s: %s", s.Hostname, err)
+		}
+
+		// the Sites of tcp and udp Balancers are not HTTP, so have no responses to cache or compress,
+		// and no pages
+		if s.Cache != nil && !l4Proto(b.Proto) {
+			st.cache = p.siteCache(s.Hostname, s.Cache)
+		}
+		if s.Compression != nil && !l4Proto(b.Proto) {
+			st.compression = newCompression(s.Compression)
+		}
+		if !l4Proto(b.Proto) {
+			if st.errorPages, err = newErrorPages(s.ErrorPages); err != nil {
+				return nil, fmt.Errorf("unable to load site %s: %s", s.Hostname, err)
+			}
+			if st.maintenance, err = newMaintenance(s.Maintenance, st.errorPages); err != nil {
+				return nil, fmt.Errorf("unable to load site %s: %s", s.Hostname, err)
+			}
+		}
+
+		for i, u := range s.Upstreams {
+			up, err := p.newUpstream(s.Hostname, u, s.Resilience)
+			if err != nil {
+				return nil, fmt.Errorf("unable to load upstream %s of %s: %s", u.Name, s.Hostname, err)
+			}
+
+			st.upstreams[u.Name] = up
+			if i == 0 {
+				st.primary = up
+			}
+		}
+
+		for _, r := range s.Routes {
+			rt, err := newRoute(r, st.upstreams, st.policy)
+			if err != nil {
+				return nil, fmt.Errorf("unable to load route %s of %s: %s", r.Name, s.Hostname, err)
+			}
+
+			if r.Mirror != nil && !r.Deny {
+				if rt.mirror, err = p.newMirror(s.Hostname, r.Name, r.Mirror, st.upstreams); err != nil {
+					return nil, fmt.Errorf("unable to load route %s of %s: %s", r.Name, s.Hostname, err)
+				}
+			}
+
+			st.routes = append(st.routes, rt)
+		}
+
+		for _, name := range append([]string{s.Hostname}, s.Alias...) {
+			hosts[strings.ToLower(name)] = st
+		}
+	}
+
+	return &balancer{
+		Balancer:  b,
+		raw:       raw,
+		hosts:     hosts,
+		acl:       a,
+		proxyFrom: proxyFrom,
+		trusted:   trusted,
+		endpoints: endpoints,
+	}, nil
+}
+
+// site returns the Site for the host, matching exactly then against a wildcard alias
+func (b *balancer) site(host string) *site {
+	if s, ok := b.hosts[host]; ok {
+		return s
+	}
+
+	return b.hosts[wildcard(host)]
+}
+
+// Proxy listens on the port of each Balancer, and serves its Sites
+type Proxy struct {
+	db    data.Consensus
+	acme  *acme.Manager
+	certs *CertStore
+
+	// roots and intermediates verify the client certificates of users
+	roots         *x509.CertPool
+	intermediates *config.IntermediatePool
+
+	mu        sync.RWMutex
+	servers   map[string]*server
+	balancers map[string]*balancer
+
+	// reloads asks Run to reload the Balancers before the next ReloadInterval
+	reloads chan struct{}
+
+	// httpsPorts are the ports Sites are served over TLS on, by hostname and alias
+	httpsPorts map[string]string
+
+	// health is the health of the Endpoints of each Site, by repository.HealthKey
+	health map[string]*health
+
+	// mirrors are the stats of the mirrors of each Route, by mirrorKey
+	mirrors map[string]*mirrorStats
+
+	// caches are the caches of the Sites, by lowercase hostname
+	caches map[string]*cache
+
+	// affinityKey is the key affinity cookies are signed with, shared by every node through the store
+	affinityKey []byte
+
+	// tunnels are the open tunnels of Upgrade requests, drained when the proxy is closed
+	tunnels   map[*tunnel]bool
+	tunneling sync.WaitGroup
+	draining  bool
+}
+
+// New creates a Proxy for the Balancers in the store, answering ACME challenges from the Manager.
+// TLS handshakes for names with no Site certificate are served the fallback certificate. The client
+// certificates of users are verified against the roots through the intermediates trusted at the time.
+func New(
+	db data.Consensus,
+	m *acme.Manager,
+	fallback func(*tls.ClientHelloInfo) (*tls.Certificate, error),
+	roots *x509.CertPool,
+	intermediates *config.IntermediatePool,
+) *Proxy {
+	return &Proxy{
+		db:            db,
+		acme:          m,
+		certs:         NewCertStore(m, fallback),
+		roots:         roots,
+		intermediates: intermediates,
+		servers:       make(map[string]*server),
+		balancers:     make(map[string]*balancer),
+		httpsPorts:    make(map[string]string),
+		health:        make(map[string]*health),
+		mirrors:       make(map[string]*mirrorStats),
+		caches:        make(map[string]*cache),
+		tunnels:       make(map[*tunnel]bool),
+		reloads:       make(chan struct{}, 1),
+	}
+}
+
+// Run listens for each Balancer, reloading the Balancers every ReloadInterval or when asked to, until
+// stop is closed. The Endpoints of the Sites are health checked while this node is the leader. Once
+// stop is closed, Run returns when the requests and tunnels being served have finished.
+func (p *Proxy) Run(stop <-chan struct{}) {
+	go p.check(stop)
+
+	for {
+		if err := p.reload(); err != nil {
+			log.Printf("unable to reload balancers: %s", err)
+		}
+
+		select {
+		case <-stop:
+			p.close()
+			return
+		case <-p.reloads:
+		case <-time.After(ReloadInterval):
+		}
+	}
+}
+
+// reload loads the Balancers, Site certificates, Endpoint health and affinity key, then swaps them in
+// at once, so each request is served by either the old or the new Balancers. A Balancer that can not
+// be loaded keeps serving as it was. Servers are started for Balancers that are new or whose proto
+// changed, and the servers they replace and those of removed Balancers are shut down once they have
+// served the requests they took.
+func (p *Proxy) reload() error {
+	weak := p.db.Weak()
+
+	list, err := repository.ListBalancers(weak)
+	if err != nil {
+		return err
+	}
+
+	stored, err := repository.ListEndpointHealth(weak)
+	if err != nil {
+		return fmt.Errorf("unable to load endpoint health: %s", err)
+	}
+
+	purges, err := repository.ListCachePurges(weak)
+	if err != nil {
+		return fmt.Errorf("unable to load cache purges: %s", err)
+	}
+
+	var all []*sites.Site
+	balancers := make(map[string]*balancer)
+	httpsPorts := make(map[string]string)
+	for _, b := range list {
+		prev := p.balancer(b.Port)
+		bal, err := p.newBalancer(b, prev)
+		if err != nil {
+			log.Printf("unable to load balancer on port %s: %s", b.Port, err)
+			if prev == nil {
+				continue
+			}
+			bal = prev
+		}
+		balancers[b.Port] = bal
+		b = bal.Balancer
+
+		// the Sites of tcp and udp Balancers route by SNI, and are served no certificate
+		for _, s := range b.Sites {
+			if !l4Proto(b.Proto) {
+				all = append(all, s)
+			}
+			if !secureProto(b.Proto) || !s.Secure {
+				continue
+			}
+
+			for _, name := range append([]string{s.Hostname}, s.Alias...) {
+				httpsPorts[strings.ToLower(name)] = b.Port
+			}
+		}
+	}
+
+	if err := p.certs.load(weak, all); err != nil {
+		return fmt.Errorf("unable to load site certificates: %s", err)
+	}
+
+	p.loadAffinityKey()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.balancers = balancers
+	p.httpsPorts = httpsPorts
+	p.health = checked(balancers)
+	p.mirrors = mirrored(balancers)
+	p.caches = cached(balancers)
+
+	for key, h := range p.health {
+		if state, ok := stored[key]; ok {
+			h.apply(state)
+		}
+	}
+
+	for _, c := range p.caches {
+		c.apply(purges)
+	}
+
+	for port, b := range balancers {
+		prev := p.servers[port]
+		if prev != nil && prev.proto == b.Proto {
+			continue
+		}
+
+		srv, err := p.listen(b.Balancer, prev)
+		if err != nil {
+			log.Printf("unable to listen for balancer on port %s: %s", port, err)
+			continue
+		}
+
+		// the connections waiting on the socket are left for the new server
+		if prev != nil {
+			prev.ln.Close()
+			go prev.stop(port, prev.sock != srv.sock)
+		}
+
+		p.servers[port] = srv
+		go p.serve(port, srv)
+	}
+
+	for port, srv := range p.servers {
+		if _, ok := balancers[port]; !ok {
+			delete(p.servers, port)
+			go srv.stop(port, true)
+		}
+	}
+
+	return nil
+}
+
+// listen returns a server for the Balancer, on the socket of prev if it has one so no connection to
+// the port is refused while its server is replaced. Connections are served through a listener that
+// resolves the clients of connections from trusted load balancers, closes the connections the ACL of
+// the Balancer does not permit, and terminates TLS for https and h2 Balancers.
+func (p *Proxy) listen(b *sites.Balancer, prev *server) (*server, error) {
+	if b.Proto == ProtoUDP {
+		pc, err := net.ListenPacket("udp", fmt.Sprintf(":%s", b.Port))
+		if err != nil {
+			return nil, err
+		}
+
+		return &server{
+			proto: b.Proto,
+			ln:    pc,
+			run:   func() error { return p.serveUDP(b.Port, pc) },
+		}, nil
+	}
+
+	var sock *socket
+	if prev != nil && prev.sock != nil {
+		sock = prev.sock
+	} else {
+		var err error
+		if sock, err = listenSocket(b.Port); err != nil {
+			return nil, err
+		}
+	}
+
+	var ln net.Listener = &clientListener{Listener: sock.listener(), p: p, port: b.Port}
+	if secureProto(b.Proto) {
+		protos := []string{"http/1.1", acme.ALPNProto}
+		if b.Proto == ProtoH2 {
+			protos = append([]string{"h2"}, protos...)
+		}
+
+		cfg := &tls.Config{
+			GetCertificate: p.certs.GetCertificate,
+			NextProtos:     protos,
+			MinVersion:     tls.VersionTLS12,
+		}
+		cfg.GetConfigForClient = p.clientAuth(b.Port, cfg)
+		ln = tls.NewListener(ln, cfg)
+	}
+
+	srv, err := p.newServer(b, ln)
+	if err != nil {
+		ln.Close()
+		if prev == nil || prev.sock != sock {
+			sock.Close()
+		}
+		return nil, err
+	}
+	srv.sock = sock
+
+	return srv, nil
+}
+
+// newServer returns the server for the Balancer on the listener. HTTP/2 Balancers are served with
+// net/http, as fasthttp only serves HTTP/1.1.
+func (p *Proxy) newServer(b *sites.Balancer, ln net.Listener) (*server, error) {
+	switch b.Proto {
+	case ProtoTCP:
+		return &server{
+			proto: b.Proto,
+			ln:    ln,
+			run:   func() error { return p.serveTCP(b.Port, ln) },
+		}, nil
+	case ProtoH2, ProtoH2C:
+		a := &activity{}
+		h2 := &http2.Server{IdleTimeout: KeepAliveTimeout}
+		s := &http.Server{Handler: a.track(p.http2Handler(b.Port, b.Proto == ProtoH2))}
+		if b.Proto == ProtoH2C {
+			s.Handler = h2c.NewHandler(s.Handler, h2)
+		} else if err := http2.ConfigureServer(s, h2); err != nil {
+			return nil, fmt.Errorf("unable to configure http2: %s", err)
+		}
+		return serveHTTP(b.Proto, s, ln, a), nil
+	default:
+		s := &fasthttp.Server{
+			Name:        "waffy",
+			Handler:     p.handler(b.Port),
+			IdleTimeout: KeepAliveTimeout,
+		}
+
+		return &server{
+			proto:    b.Proto,
+			ln:       ln,
+			run:      func() error { return s.Serve(ln) },
+			shutdown: func() { s.Shutdown() },
+		}, nil
+	}
+}
+
+// serve serves the Balancer on the port until its server is stopped
+func (p *Proxy) serve(port string, srv *server) {
+	log.Printf("starting %s balancer on port %s", srv.proto, port)
+
+	err := srv.run()
+	if err != nil && err != http.ErrServerClosed && !errors.Is(err, net.ErrClosed) {
+		log.Printf("balancer on port %s stopped: %s", port, err)
+	}
+}
+
+// secureProto returns if Balancers with the proto terminate TLS
+func secureProto(proto string) bool {
+	return proto == ProtoHTTPS || proto == ProtoH2
+}
+
+// balancerUpstream is the name of the Upstream the health of the Endpoints of a Balancer is kept under
+const balancerUpstream = "endpoints"
+
+// balancerHost returns the hostname the health of the Endpoints of the Balancer on the port is kept
+// under, which can not clash with the hostname of a Site
+func balancerHost(port string) string {
+	return ":" + port
+}
+
+// l4Proto returns if Balancers with the proto forward connections or datagrams rather than requests
+func l4Proto(proto string) bool {
+	return proto == ProtoTCP || proto == ProtoUDP
+}
+
+// endpointHealth returns the health of the Endpoint of h, or h if it has none yet
+func (p *Proxy) endpointHealth(h *health) *health {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if existing, ok := p.health[h.key]; ok {
+		return existing
+	}
+
+	p.health[h.key] = h
+	return h
+}
+
+// checked returns the health of the Endpoints of the Sites and of the balancers, by
+// repository.HealthKey
+func checked(balancers map[string]*balancer) map[string]*health {
+	health := make(map[string]*health)
+	for _, b := range balancers {
+		for _, be := range b.endpoints.backends {
+			health[be.health.key] = be.health
+		}
+
+		for _, s := range b.hosts {
+			for _, u := range s.upstreams {
+				for _, be := range u.backends {
+					health[be.health.key] = be.health
+				}
+			}
+		}
+	}
+
+	return health
+}
+
+// balancer returns the current Balancer listening on the port
+func (p *Proxy) balancer(port string) *balancer {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.balancers[port]
+}
+
+// httpsPort returns the port the host is served over TLS on
+func (p *Proxy) httpsPort(host string) (string, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	port, ok := p.httpsPorts[host]
+	return port, ok
+}
+
+// close stops every server, draining the requests and tunnels they are serving
+func (p *Proxy) close() {
+	p.mu.Lock()
+	servers := p.servers
+	p.servers = make(map[string]*server)
+	p.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for port, srv := range servers {
+		wg.Add(1)
+		go func(port string, srv *server) {
+			defer wg.Done()
+			srv.stop(port, true)
+		}(port, srv)
+	}
+
+	p.drain()
+	wg.Wait()
+}
@@ -0,0 +1,293 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ProxyHeaderTimeout is how long a trusted load balancer has to send the PROXY protocol header of a
+	// connection before it is closed
+	ProxyHeaderTimeout = time.Second * 5
+
+	// maxProxyV1 is the longest PROXY protocol v1 header, with its CRLF
+	maxProxyV1 = 107
+
+	// PROXY protocol v2 commands and address families, with the size of their addresses
+	proxyV2Local   = 0x20
+	proxyV2Proxy   = 0x21
+	proxyV2TCP4    = 0x11
+	proxyV2TCP6    = 0x21
+	proxyV2Unspec  = 0x00
+	proxyV2Inet4Sz = 12
+	proxyV2Inet6Sz = 36
+)
+
+// proxyV2Signature starts every PROXY protocol v2 header
+var proxyV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+var (
+	errDenied        = errors.New("client denied by balancer acl")
+	errNoProxyHeader = errors.New("connection does not start with a PROXY protocol header")
+)
+
+// peer is the address of a client, and the address it connected to, as given to Endpoints with the
+// PROXY protocol
+type peer struct {
+	src, dst net.Addr
+}
+
+type peerKey struct{}
+
+// withPeer returns ctx carrying the peer, for the transports of backends to dial with
+func withPeer(ctx context.Context, from *peer) context.Context {
+	return context.WithValue(ctx, peerKey{}, from)
+}
+
+// peerFrom returns the peer ctx carries, or nil if it carries none
+func peerFrom(ctx context.Context) *peer {
+	from, _ := ctx.Value(peerKey{}).(*peer)
+	return from
+}
+
+// proxyHeader returns the PROXY protocol header of the version for the peer. Peers that are not
+// TCP connections of one address family, such as the health checks and mirrors of the proxy, are sent
+// the header for a connection of the proxy's own.
+func proxyHeader(version uint32, from *peer) []byte {
+	var src, dst *net.TCPAddr
+	if from != nil {
+		src, _ = from.src.(*net.TCPAddr)
+		dst, _ = from.dst.(*net.TCPAddr)
+	}
+
+	v4 := src != nil && dst != nil && src.IP.To4() != nil && dst.IP.To4() != nil
+	v6 := src != nil && dst != nil && src.IP.To4() == nil && dst.IP.To4() == nil
+
+	if version == 1 {
+		switch {
+		case v4:
+			return []byte(fmt.Sprintf("PROXY TCP4 %s %s %d %d\r\n", src.IP.To4(), dst.IP.To4(), src.Port, dst.Port))
+		case v6:
+			return []byte(fmt.Sprintf("PROXY TCP6 %s %s %d %d\r\n", src.IP, dst.IP, src.Port, dst.Port))
+		}
+
+		return []byte("PROXY UNKNOWN\r\n")
+	}
+
+	h := append([]byte{}, proxyV2Signature...)
+	switch {
+	case v4:
+		h = append(h, proxyV2Proxy, proxyV2TCP4, 0, proxyV2Inet4Sz)
+		h = append(append(h, src.IP.To4()...), dst.IP.To4()...)
+	case v6:
+		h = append(h, proxyV2Proxy, proxyV2TCP6, 0, proxyV2Inet6Sz)
+		h = append(append(h, src.IP.To16()...), dst.IP.To16()...)
+	default:
+		return append(h, proxyV2Local, proxyV2Unspec, 0, 0)
+	}
+
+	ports := make([]byte, 4)
+	binary.BigEndian.PutUint16(ports, uint16(src.Port))
+	binary.BigEndian.PutUint16(ports[2:], uint16(dst.Port))
+	return append(h, ports...)
+}
+
+// readProxyHeader reads the PROXY protocol v1 or v2 header the connection starts with, returning the
+// client and the address it connected to, or nil if the header is for a connection of the load
+// balancer's own. Connections from trusted load balancers must start with a header, so one that
+// starts with anything else, or ends before its header is whole, is an error: its client could
+// otherwise be taken for the load balancer, and let through the ACL of the Balancer as it.
+func readProxyHeader(r *bufio.Reader) (*peer, error) {
+	first, err := r.Peek(1)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read PROXY protocol header: %s", err)
+	}
+
+	switch first[0] {
+	case 'P':
+		return readProxyV1(r)
+	case proxyV2Signature[0]:
+		sig, err := r.Peek(len(proxyV2Signature))
+		if err == nil && bytes.Equal(sig, proxyV2Signature) {
+			return readProxyV2(r)
+		}
+	}
+
+	return nil, errNoProxyHeader
+}
+
+// readProxyV1 reads a PROXY protocol v1 header
+func readProxyV1(r *bufio.Reader) (*peer, error) {
+	prefix, err := r.Peek(6)
+	if err != nil || string(prefix) != "PROXY " {
+		return nil, errNoProxyHeader
+	}
+
+	var line []byte
+	for len(line) < maxProxyV1 {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+	}
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, fmt.Errorf("invalid PROXY protocol v1 header")
+	}
+
+	fields := strings.Fields(string(line))
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return nil, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, fmt.Errorf("invalid PROXY protocol v1 header")
+	}
+
+	src, err := tcpAddr(fields[2], fields[4])
+	if err != nil {
+		return nil, err
+	}
+	dst, err := tcpAddr(fields[3], fields[5])
+	if err != nil {
+		return nil, err
+	}
+
+	return &peer{src: src, dst: dst}, nil
+}
+
+// tcpAddr parses the address and port of a PROXY protocol v1 header
+func tcpAddr(ip, port string) (*net.TCPAddr, error) {
+	addr := net.ParseIP(ip)
+	p, err := strconv.ParseUint(port, 10, 16)
+	if addr == nil || err != nil {
+		return nil, fmt.Errorf("invalid PROXY protocol v1 address %s:%s", ip, port)
+	}
+
+	return &net.TCPAddr{IP: addr, Port: int(p)}, nil
+}
+
+// readProxyV2 reads a PROXY protocol v2 header, skipping its TLVs
+func readProxyV2(r *bufio.Reader) (*peer, error) {
+	header := make([]byte, len(proxyV2Signature)+4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	command, family := header[12], header[13]
+	body := make([]byte, binary.BigEndian.Uint16(header[14:]))
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case command == proxyV2Local:
+		return nil, nil
+	case command != proxyV2Proxy:
+		return nil, fmt.Errorf("invalid PROXY protocol v2 command %#x", command)
+	}
+
+	// only TCP clients are given, others are served as connections of the load balancer
+	switch {
+	case family == proxyV2TCP4 && len(body) >= proxyV2Inet4Sz:
+		return &peer{
+			src: &net.TCPAddr{IP: net.IP(body[0:4]), Port: int(binary.BigEndian.Uint16(body[8:]))},
+			dst: &net.TCPAddr{IP: net.IP(body[4:8]), Port: int(binary.BigEndian.Uint16(body[10:]))},
+		}, nil
+	case family == proxyV2TCP6 && len(body) >= proxyV2Inet6Sz:
+		return &peer{
+			src: &net.TCPAddr{IP: net.IP(body[0:16]), Port: int(binary.BigEndian.Uint16(body[32:]))},
+			dst: &net.TCPAddr{IP: net.IP(body[16:32]), Port: int(binary.BigEndian.Uint16(body[34:]))},
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// proxyConn is a connection from a trusted load balancer, that reads the PROXY protocol header it
+// starts with before anything else, then gives the client it names as its remote address. Clients
+// the ACL of the Balancer does not permit are closed once the header is read.
+type proxyConn struct {
+	net.Conn
+
+	r   *bufio.Reader
+	acl *acl
+
+	resolving sync.Once
+	from      *peer
+	err       error
+}
+
+// resolve reads the PROXY protocol header, once
+func (c *proxyConn) resolve() {
+	c.resolving.Do(func() {
+		c.Conn.SetReadDeadline(time.Now().Add(ProxyHeaderTimeout))
+		c.from, c.err = readProxyHeader(c.r)
+		c.Conn.SetReadDeadline(time.Time{})
+
+		client := c.Conn.RemoteAddr()
+		if c.from != nil {
+			client = c.from.src
+		}
+		if c.err == nil && !c.acl.permits(addrIP(client)) {
+			c.err = errDenied
+		}
+		if c.err != nil {
+			c.Conn.Close()
+		}
+	})
+}
+
+// resolveClient reads the PROXY protocol header of a connection from a trusted load balancer, returning
+// an error if it is invalid or the ACL of the Balancer does not permit its client
+func resolveClient(conn net.Conn) error {
+	if c, ok := conn.(*proxyConn); ok {
+		c.resolve()
+		return c.err
+	}
+
+	return nil
+}
+
+func (c *proxyConn) Read(b []byte) (int, error) {
+	c.resolve()
+	if c.err != nil {
+		return 0, c.err
+	}
+
+	return c.r.Read(b)
+}
+
+// RemoteAddr returns the client the load balancer named, or the load balancer if it named none
+func (c *proxyConn) RemoteAddr() net.Addr {
+	c.resolve()
+	if c.from != nil {
+		return c.from.src
+	}
+
+	return c.Conn.RemoteAddr()
+}
+
+// LocalAddr returns the address the client connected to the load balancer on, or that of the load
+// balancer's connection if it named none
+func (c *proxyConn) LocalAddr() net.Addr {
+	c.resolve()
+	if c.from != nil {
+		return c.from.dst
+	}
+
+	return c.Conn.LocalAddr()
+}
@@ -0,0 +1,202 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// proxyV2 returns a PROXY protocol v2 header with the command, family and body
+func proxyV2(command, family byte, body []byte) []byte {
+	h := append([]byte{}, proxyV2Signature...)
+	h = append(h, command, family, 0, 0)
+	binary.BigEndian.PutUint16(h[14:], uint16(len(body)))
+	return append(h, body...)
+}
+
+// inet4 returns the body of a PROXY protocol v2 header for TCP over IPv4, followed by the TLVs
+func inet4(src, dst string, srcPort, dstPort uint16, tlvs ...byte) []byte {
+	body := append(append([]byte{}, net.ParseIP(src).To4()...), net.ParseIP(dst).To4()...)
+	body = append(body, 0, 0, 0, 0)
+	binary.BigEndian.PutUint16(body[8:], srcPort)
+	binary.BigEndian.PutUint16(body[10:], dstPort)
+	return append(body, tlvs...)
+}
+
+func TestReadProxyHeader(t *testing.T) {
+	v6 := make([]byte, proxyV2Inet6Sz)
+	copy(v6, net.ParseIP("2001:db8::1"))
+	copy(v6[16:], net.ParseIP("2001:db8::2"))
+	binary.BigEndian.PutUint16(v6[32:], 1234)
+	binary.BigEndian.PutUint16(v6[34:], 443)
+
+	// a PP2_TYPE_AUTHORITY TLV for example.com
+	authority := append([]byte{0x02, 0, 11}, "example.com"...)
+
+	valid := []struct {
+		name     string
+		header   []byte
+		src, dst string
+	}{
+		{"v1 TCP4", []byte("PROXY TCP4 192.0.2.1 192.0.2.2 1234 443\r\n"), "192.0.2.1:1234", "192.0.2.2:443"},
+		{"v1 TCP6", []byte("PROXY TCP6 2001:db8::1 2001:db8::2 1234 443\r\n"), "[2001:db8::1]:1234", "[2001:db8::2]:443"},
+		{"v1 UNKNOWN", []byte("PROXY UNKNOWN\r\n"), "", ""},
+		{"v2 TCP4", proxyV2(proxyV2Proxy, proxyV2TCP4, inet4("192.0.2.1", "192.0.2.2", 1234, 443)), "192.0.2.1:1234", "192.0.2.2:443"},
+		{"v2 TCP4 with TLVs", proxyV2(proxyV2Proxy, proxyV2TCP4, inet4("192.0.2.1", "192.0.2.2", 1234, 443, authority...)), "192.0.2.1:1234", "192.0.2.2:443"},
+		{"v2 TCP6", proxyV2(proxyV2Proxy, proxyV2TCP6, v6), "[2001:db8::1]:1234", "[2001:db8::2]:443"},
+		{"v2 LOCAL", proxyV2(proxyV2Local, proxyV2Unspec, nil), "", ""},
+		{"v2 LOCAL with an address", proxyV2(proxyV2Local, proxyV2TCP4, inet4("192.0.2.1", "192.0.2.2", 1234, 443)), "", ""},
+	}
+
+	for _, tc := range valid {
+		Convey("A "+tc.name+" header should be read, leaving what follows it", t, func() {
+			r := bufio.NewReader(bytes.NewReader(append(append([]byte{}, tc.header...), "GET /"...)))
+			from, err := readProxyHeader(r)
+			So(err, ShouldBeNil)
+
+			if tc.src == "" {
+				So(from, ShouldBeNil)
+			} else {
+				So(from, ShouldNotBeNil)
+				So(from.src.String(), ShouldEqual, tc.src)
+				So(from.dst.String(), ShouldEqual, tc.dst)
+			}
+
+			rest, _ := ioutil.ReadAll(r)
+			So(string(rest), ShouldEqual, "GET /")
+		})
+	}
+
+	v2 := proxyV2(proxyV2Proxy, proxyV2TCP4, inet4("192.0.2.1", "192.0.2.2", 1234, 443, authority...))
+	invalid := []struct {
+		name   string
+		header []byte
+	}{
+		{"no", []byte("GET / HTTP/1.1\r\n\r\n")},
+		{"an empty", nil},
+		{"a v1 lookalike", []byte("PROXIED\r\n")},
+		{"a v2 lookalike", []byte("\r\n\r\nGET / HTTP/1.1\r\n")},
+		{"a truncated v1", []byte("PROXY TCP4 192.0.2.1")},
+		{"a v1 without CRLF", []byte("PROXY TCP4 192.0.2.1 192.0.2.2 1234 443\n")},
+		{"an overlong v1", append([]byte("PROXY TCP4 "), bytes.Repeat([]byte("1"), maxProxyV1)...)},
+		{"a v1 with a bad address", []byte("PROXY TCP4 192.0.2.1 nope 1234 443\r\n")},
+		{"a v1 with a bad port", []byte("PROXY TCP4 192.0.2.1 192.0.2.2 1234 70000\r\n")},
+		{"a v1 with a bad protocol", []byte("PROXY UDP4 192.0.2.1 192.0.2.2 1234 443\r\n")},
+		{"a v2 with a truncated header", v2[:14]},
+		{"a v2 with a truncated address", v2[:20]},
+		{"a v2 with truncated TLVs", v2[:len(v2)-1]},
+		{"a v2 with an unknown command", proxyV2(0x22, proxyV2TCP4, inet4("192.0.2.1", "192.0.2.2", 1234, 443))},
+		{"a v2 of another version", proxyV2(0x11, proxyV2TCP4, inet4("192.0.2.1", "192.0.2.2", 1234, 443))},
+	}
+
+	for _, tc := range invalid {
+		Convey("A connection with "+tc.name+" header should be refused", t, func() {
+			_, err := readProxyHeader(bufio.NewReader(bytes.NewReader(tc.header)))
+			So(err, ShouldNotBeNil)
+		})
+	}
+
+	Convey("A connection that sends nothing before its deadline should be refused", t, func() {
+		client, server := net.Pipe()
+		defer client.Close()
+		server.SetReadDeadline(time.Now().Add(time.Millisecond * 10))
+
+		_, err := readProxyHeader(bufio.NewReader(server))
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestProxyHeader(t *testing.T) {
+	v4 := &peer{
+		src: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234},
+		dst: &net.TCPAddr{IP: net.ParseIP("192.0.2.2"), Port: 443},
+	}
+	v6 := &peer{
+		src: &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 1234},
+		dst: &net.TCPAddr{IP: net.ParseIP("2001:db8::2"), Port: 443},
+	}
+	mixed := &peer{src: v4.src, dst: v6.dst}
+	udp := &peer{src: &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 53}, dst: v4.dst}
+
+	Convey("A v1 header should name the client and the address it connected to", t, func() {
+		So(string(proxyHeader(1, v4)), ShouldEqual, "PROXY TCP4 192.0.2.1 192.0.2.2 1234 443\r\n")
+		So(string(proxyHeader(1, v6)), ShouldEqual, "PROXY TCP6 2001:db8::1 2001:db8::2 1234 443\r\n")
+	})
+
+	Convey("A v1 header for no TCP client of one family should be UNKNOWN", t, func() {
+		for _, from := range []*peer{nil, mixed, udp} {
+			So(string(proxyHeader(1, from)), ShouldEqual, "PROXY UNKNOWN\r\n")
+		}
+	})
+
+	Convey("A v2 header should name the client and the address it connected to", t, func() {
+		So(proxyHeader(2, v4), ShouldResemble, proxyV2(proxyV2Proxy, proxyV2TCP4, inet4("192.0.2.1", "192.0.2.2", 1234, 443)))
+
+		h := proxyHeader(2, v6)
+		So(h[:len(proxyV2Signature)], ShouldResemble, proxyV2Signature)
+		So(h[12:16], ShouldResemble, []byte{proxyV2Proxy, proxyV2TCP6, 0, proxyV2Inet6Sz})
+		So(len(h), ShouldEqual, 16+proxyV2Inet6Sz)
+	})
+
+	Convey("A v2 header for no TCP client of one family should be LOCAL", t, func() {
+		for _, from := range []*peer{nil, mixed, udp} {
+			So(proxyHeader(2, from), ShouldResemble, proxyV2(proxyV2Local, proxyV2Unspec, nil))
+		}
+	})
+
+	Convey("Each header that is sent should read back as the same peer", t, func() {
+		for _, version := range []uint32{1, 2} {
+			for _, from := range []*peer{v4, v6} {
+				read, err := readProxyHeader(bufio.NewReader(bytes.NewReader(proxyHeader(version, from))))
+				So(err, ShouldBeNil)
+				So(read.src.String(), ShouldEqual, from.src.String())
+				So(read.dst.String(), ShouldEqual, from.dst.String())
+			}
+		}
+	})
+}
+
+func TestProxyConn(t *testing.T) {
+	// proxied returns a proxyConn of a connection that starts with b, permitting the CIDRs
+	proxied := func(b []byte, allow ...string) *proxyConn {
+		a, err := newACL(allow, nil)
+		So(err, ShouldBeNil)
+
+		lb, conn := tcpPair()
+		go func() {
+			lb.Write(b)
+			lb.Close()
+		}()
+
+		return &proxyConn{Conn: conn, r: bufio.NewReader(conn), acl: a}
+	}
+
+	Convey("A connection with a header should be from the client it names", t, func() {
+		c := proxied([]byte("PROXY TCP4 192.0.2.1 192.0.2.2 1234 443\r\nhello"))
+		So(resolveClient(c), ShouldBeNil)
+		So(c.RemoteAddr().String(), ShouldEqual, "192.0.2.1:1234")
+		So(c.LocalAddr().String(), ShouldEqual, "192.0.2.2:443")
+
+		rest, _ := ioutil.ReadAll(c)
+		So(string(rest), ShouldEqual, "hello")
+	})
+
+	Convey("A connection without a header should be refused, rather than be from the load balancer", t, func() {
+		c := proxied([]byte("GET / HTTP/1.1\r\n\r\n"))
+		So(resolveClient(c), ShouldNotBeNil)
+
+		_, err := c.Read(make([]byte, 1))
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("A connection whose client the ACL denies should be refused", t, func() {
+		c := proxied([]byte("PROXY TCP4 192.0.2.1 192.0.2.2 1234 443\r\n"), "198.51.100.0/24")
+		So(resolveClient(c), ShouldEqual, errDenied)
+	})
+}
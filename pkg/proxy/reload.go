@@ -0,0 +1,253 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// KeepAliveTimeout is how long connections to HTTP Balancers are kept open waiting for their next
+// request, so servers that are shut down are not held open by idle clients
+const KeepAliveTimeout = time.Second * 15
+
+// server serves a Balancer on its port, until it is shut down
+type server struct {
+	proto string
+
+	// sock is the listening socket of the port, or nil for udp Balancers
+	sock *socket
+
+	// ln is what the server takes connections or datagrams from, closed to stop it taking more
+	ln io.Closer
+
+	// run serves the Balancer until ln is closed
+	run func() error
+
+	// shutdown waits for the requests the server is serving to finish once ln is closed, or is nil if
+	// its connections are left to finish on their own
+	shutdown func()
+}
+
+// serveHTTP returns the server for an HTTP/2 Balancer served by s from the listener, that waits for
+// the requests a tracks once it is shut down
+func serveHTTP(proto string, s *http.Server, ln net.Listener, a *activity) *server {
+	s.IdleTimeout = KeepAliveTimeout
+	s.ConnState = a.connState
+
+	return &server{
+		proto: proto,
+		ln:    ln,
+		run:   func() error { return s.Serve(ln) },
+		shutdown: func() {
+			// net/http closes the connections that have not yet sent a request once it is shut down,
+			// even those it accepted before, so they are given until KeepAliveTimeout to send one
+			deadline := time.Now().Add(KeepAliveTimeout)
+			for a.waiting() && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond * 100)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), DrainTimeout)
+			defer cancel()
+
+			if err := s.Shutdown(ctx); err != nil {
+				s.Close()
+			}
+
+			// net/http does not wait for the connections h2c hijacks
+			a.wait()
+		},
+	}
+}
+
+// activity tracks the requests a net/http server is serving, and its connections that have not yet
+// sent a request
+type activity struct {
+	requests int64
+	fresh    sync.Map
+}
+
+// track returns h, counting the requests it is serving
+func (a *activity) track(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt64(&a.requests, 1)
+		defer atomic.AddInt64(&a.requests, -1)
+
+		h.ServeHTTP(w, req)
+	})
+}
+
+// connState records if the connection has yet to send a request
+func (a *activity) connState(conn net.Conn, state http.ConnState) {
+	if state == http.StateNew {
+		a.fresh.Store(conn, true)
+	} else {
+		a.fresh.Delete(conn)
+	}
+}
+
+// waiting returns if any connection has yet to send a request
+func (a *activity) waiting() bool {
+	waiting := false
+	a.fresh.Range(func(_, _ interface{}) bool {
+		waiting = true
+		return false
+	})
+
+	return waiting
+}
+
+// wait waits until no requests are being served
+func (a *activity) wait() {
+	for atomic.LoadInt64(&a.requests) > 0 {
+		time.Sleep(time.Millisecond * 100)
+	}
+}
+
+// stop stops the server for the Balancer on the port taking connections, then waits up to
+// DrainTimeout for what it is serving to finish. The socket of the port is closed first if release is
+// set, otherwise it is left open for the server replacing this one.
+func (s *server) stop(port string, release bool) {
+	if release && s.sock != nil {
+		s.sock.Close()
+	}
+	s.ln.Close()
+	if s.shutdown == nil {
+		log.Printf("stopped %s balancer on port %s", s.proto, port)
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.shutdown()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Printf("stopped %s balancer on port %s", s.proto, port)
+	case <-time.After(DrainTimeout):
+		log.Printf("%s balancer on port %s still serving after %s", s.proto, port, DrainTimeout)
+	}
+}
+
+// socket is the listening socket of a port, kept open while the Balancer on the port is replaced so
+// no connection to it is refused. The connections it accepts are handed to the listener of the server
+// serving the port.
+type socket struct {
+	net.Listener
+
+	conns  chan net.Conn
+	closed chan struct{}
+	once   sync.Once
+}
+
+// listenSocket opens the socket of the port, and starts accepting connections on it
+func listenSocket(port string) (*socket, error) {
+	ln, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &socket{
+		Listener: ln,
+		conns:    make(chan net.Conn),
+		closed:   make(chan struct{}),
+	}
+	go s.accept()
+
+	return s, nil
+}
+
+// accept accepts the connections of the socket until it is closed, handing each to a server
+func (s *socket) accept() {
+	for {
+		conn, err := s.Listener.Accept()
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				time.Sleep(time.Millisecond * 10)
+				continue
+			}
+
+			s.Close()
+			return
+		}
+
+		select {
+		case s.conns <- conn:
+		case <-s.closed:
+			conn.Close()
+			return
+		}
+	}
+}
+
+// Close closes the socket, and the listeners of every server on it
+func (s *socket) Close() error {
+	var err error
+	s.once.Do(func() {
+		close(s.closed)
+		err = s.Listener.Close()
+	})
+
+	return err
+}
+
+// listener returns a listener of the connections of the socket for a server, that can be closed
+// without closing the socket
+func (s *socket) listener() net.Listener {
+	return &handoff{s: s, done: make(chan struct{})}
+}
+
+// handoff is the listener of a server for the connections of a socket
+type handoff struct {
+	s *socket
+
+	done chan struct{}
+	once sync.Once
+}
+
+// Accept returns the next connection of the socket, or net.ErrClosed once the listener or socket is
+// closed
+func (h *handoff) Accept() (net.Conn, error) {
+	// a listener that is closed takes no more connections, even if one is waiting
+	select {
+	case <-h.done:
+		return nil, net.ErrClosed
+	case <-h.s.closed:
+		return nil, net.ErrClosed
+	default:
+	}
+
+	select {
+	case conn := <-h.s.conns:
+		return conn, nil
+	case <-h.done:
+		return nil, net.ErrClosed
+	case <-h.s.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close stops the listener taking connections, leaving the socket open
+func (h *handoff) Close() error {
+	h.once.Do(func() { close(h.done) })
+	return nil
+}
+
+// Addr returns the address of the socket
+func (h *handoff) Addr() net.Addr {
+	return h.s.Addr()
+}
+
+// Reload reloads the Balancers from the store now, rather than at the next ReloadInterval
+func (p *Proxy) Reload() {
+	select {
+	case p.reloads <- struct{}{}:
+	default:
+	}
+}
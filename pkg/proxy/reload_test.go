@@ -0,0 +1,208 @@
+package proxy
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// openSocket returns a socket on a free port, and the address to dial it on
+func openSocket() (*socket, string) {
+	sock, err := listenSocket("0")
+	So(err, ShouldBeNil)
+
+	return sock, sock.Addr().String()
+}
+
+// accepted returns the next connection the listener accepts, or the error it accepts with, waiting
+// up to a second for one
+func accepted(ln net.Listener) (net.Conn, error) {
+	type result struct {
+		conn net.Conn
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		conn, err := ln.Accept()
+		done <- result{conn, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.conn, r.err
+	case <-time.After(time.Second):
+		return nil, nil
+	}
+}
+
+// get sends a GET request on the connection, returning the status of the response
+func get(conn net.Conn) (int, error) {
+	if _, err := conn.Write([]byte("GET / HTTP/1.1\r\nHost: example.com\r\nConnection: close\r\n\r\n")); err != nil {
+		return 0, err
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+func TestSocket(t *testing.T) {
+	Convey("Connections to a socket should be handed to the listener of its server", t, func() {
+		sock, addr := openSocket()
+		defer sock.Close()
+		ln := sock.listener()
+
+		client, err := net.Dial("tcp", addr)
+		So(err, ShouldBeNil)
+		defer client.Close()
+
+		conn, err := accepted(ln)
+		So(err, ShouldBeNil)
+		So(conn, ShouldNotBeNil)
+		So(conn.RemoteAddr().String(), ShouldEqual, client.LocalAddr().String())
+		So(ln.Addr().String(), ShouldEqual, addr)
+	})
+
+	Convey("A connection made while a server is replaced should be taken by the new server", t, func() {
+		sock, addr := openSocket()
+		defer sock.Close()
+		old := sock.listener()
+		So(old.Close(), ShouldBeNil)
+
+		client, err := net.Dial("tcp", addr)
+		So(err, ShouldBeNil)
+		defer client.Close()
+
+		conn, err := accepted(old)
+		So(errors.Is(err, net.ErrClosed), ShouldBeTrue)
+		So(conn, ShouldBeNil)
+
+		conn, err = accepted(sock.listener())
+		So(err, ShouldBeNil)
+		So(conn, ShouldNotBeNil)
+		defer conn.Close()
+
+		client.Write([]byte("ping"))
+		b := make([]byte, 4)
+		_, err = conn.Read(b)
+		So(err, ShouldBeNil)
+		So(string(b), ShouldEqual, "ping")
+	})
+
+	Convey("Closing a socket should close the listeners of its servers, and refuse connections", t, func() {
+		sock, addr := openSocket()
+		ln := sock.listener()
+		So(sock.Close(), ShouldBeNil)
+		So(sock.Close(), ShouldBeNil)
+
+		_, err := accepted(ln)
+		So(errors.Is(err, net.ErrClosed), ShouldBeTrue)
+
+		_, err = net.Dial("tcp", addr)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestServerStop(t *testing.T) {
+	// serve returns a server on the socket whose handler waits for release, and the activity it tracks
+	serve := func(sock *socket, release chan struct{}) (*server, *activity) {
+		a := &activity{}
+		s := &http.Server{Handler: a.track(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			<-release
+		}))}
+		srv := serveHTTP(ProtoH2C, s, sock.listener(), a)
+		srv.sock = sock
+		go srv.run()
+
+		return srv, a
+	}
+
+	Convey("Stopping a server should wait for the requests it is serving, leaving the socket open", t, func() {
+		sock, addr := openSocket()
+		defer sock.Close()
+		release := make(chan struct{})
+		srv, a := serve(sock, release)
+
+		client, err := net.Dial("tcp", addr)
+		So(err, ShouldBeNil)
+		defer client.Close()
+
+		status := make(chan int, 1)
+		go func() {
+			code, _ := get(client)
+			status <- code
+		}()
+		for i := 0; atomic.LoadInt64(&a.requests) == 0 && i < 100; i++ {
+			time.Sleep(time.Millisecond * 10)
+		}
+
+		stopped := make(chan struct{})
+		go func() {
+			srv.stop("0", false)
+			close(stopped)
+		}()
+
+		early := false
+		select {
+		case <-stopped:
+			early = true
+		case <-time.After(time.Millisecond * 100):
+		}
+		So(early, ShouldBeFalse)
+
+		close(release)
+		So(<-status, ShouldEqual, http.StatusOK)
+		<-stopped
+
+		next, err := net.Dial("tcp", addr)
+		So(err, ShouldBeNil)
+		next.Close()
+	})
+
+	Convey("A connection accepted before its server is stopped should still be served", t, func() {
+		sock, addr := openSocket()
+		defer sock.Close()
+		release := make(chan struct{})
+		close(release)
+		srv, a := serve(sock, release)
+
+		client, err := net.Dial("tcp", addr)
+		So(err, ShouldBeNil)
+		defer client.Close()
+		for i := 0; !a.waiting() && i < 100; i++ {
+			time.Sleep(time.Millisecond * 10)
+		}
+		So(a.waiting(), ShouldBeTrue)
+
+		stopped := make(chan struct{})
+		go func() {
+			srv.stop("0", false)
+			close(stopped)
+		}()
+		time.Sleep(time.Millisecond * 50)
+
+		code, err := get(client)
+		So(err, ShouldBeNil)
+		So(code, ShouldEqual, http.StatusOK)
+		<-stopped
+	})
+
+	Convey("Stopping a server and releasing its socket should refuse connections", t, func() {
+		sock, addr := openSocket()
+		srv, _ := serve(sock, make(chan struct{}))
+		srv.stop("0", true)
+
+		_, err := net.Dial("tcp", addr)
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,224 @@
+package proxy
+
+import (
+	"math/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+const (
+	defaultConnectTimeout = time.Second * 3
+	defaultRetryBudget    = 20
+	defaultMinRetries     = 3
+	defaultBaseBackoff    = time.Millisecond * 25
+	defaultMaxBackoff     = time.Millisecond * 250
+	defaultMinRequests    = 20
+	defaultBreakerWindow  = time.Second * 10
+	defaultBreakerOpen    = time.Second * 30
+
+	// overloadRetryAfter is the Retry-After of a request rejected because an Upstream is at capacity
+	overloadRetryAfter = time.Second
+)
+
+// policy is the timeouts and retries of requests to a Site, with defaults applied
+type policy struct {
+	// perTry limits each try as a whole, as the timeout of fasthttp.HostClient.DoTimeout: waiting for
+	// a connection, connecting, writing the request and reading the response. overall limits every
+	// try and backoff of a request.
+	perTry, overall time.Duration
+
+	attempts                int
+	budget, minRetries      int64
+	baseBackoff, maxBackoff time.Duration
+}
+
+// newPolicy creates the policy of r, which may be nil
+func newPolicy(r *sites.Resilience) *policy {
+	p := &policy{}
+	if r == nil {
+		return p
+	}
+
+	if t := r.Timeouts; t != nil {
+		p.perTry = millis(t.PerTry, 0)
+		p.overall = millis(t.Overall, 0)
+	}
+
+	if rt := r.Retry; rt != nil {
+		p.attempts = int(rt.Attempts)
+		p.budget = int64(threshold(rt.BudgetPercent, defaultRetryBudget))
+		p.minRetries = int64(threshold(rt.MinRetries, defaultMinRetries))
+		p.baseBackoff = millis(rt.BaseBackoff, defaultBaseBackoff)
+		p.maxBackoff = millis(rt.MaxBackoff, defaultMaxBackoff)
+	}
+
+	return p
+}
+
+// timeout returns the timeout of a try started at now, or false if the overall deadline has passed.
+// A zero timeout is unlimited.
+func (p *policy) timeout(now, deadline time.Time) (time.Duration, bool) {
+	if deadline.IsZero() {
+		return p.perTry, true
+	}
+
+	left := deadline.Sub(now)
+	if left <= 0 {
+		return 0, false
+	}
+	if p.perTry > 0 && p.perTry < left {
+		return p.perTry, true
+	}
+
+	return left, true
+}
+
+// backoff returns the jittered wait before the retry after attempt tries, so that retries of many
+// requests spread out rather than arriving together
+func (p *policy) backoff(attempt int) time.Duration {
+	wait := p.baseBackoff << uint(attempt-1)
+	if wait > p.maxBackoff || wait <= 0 {
+		wait = p.maxBackoff
+	}
+
+	return time.Duration(rand.Int63n(int64(wait)) + 1)
+}
+
+// retryable returns if the request can be retried after a try that got err or the status
+func retryable(ctx *fasthttp.RequestCtx, err error, status int) bool {
+	if !idempotent(ctx) {
+		return false
+	}
+
+	switch {
+	case err != nil:
+		return true
+	case status == fasthttp.StatusBadGateway,
+		status == fasthttp.StatusServiceUnavailable,
+		status == fasthttp.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// unreachable returns if err is a failure to connect to a backend, so the request was not sent
+func unreachable(err error) bool {
+	if err == fasthttp.ErrDialTimeout {
+		return true
+	}
+
+	op, ok := err.(*net.OpError)
+	return ok && op.Op == "dial"
+}
+
+// idempotent returns if sending the request more than once has the same effect as sending it once
+func idempotent(ctx *fasthttp.RequestCtx) bool {
+	switch string(ctx.Method()) {
+	case "GET", "HEAD", "OPTIONS", "TRACE", "PUT", "DELETE":
+		return true
+	}
+
+	return false
+}
+
+// breaker is the circuit breaker of an Upstream, that opens when too many of the requests in a window
+// fail
+type breaker struct {
+	*sites.CircuitBreaker
+
+	mu        sync.Mutex
+	start     time.Time
+	requests  uint32
+	errors    uint32
+	openUntil time.Time
+}
+
+// newBreaker creates the breaker for c, or nil if c is nil
+func newBreaker(c *sites.CircuitBreaker) *breaker {
+	if c == nil {
+		return nil
+	}
+
+	return &breaker{CircuitBreaker: c}
+}
+
+// allow returns if a request can be sent to the Upstream with pending requests, or how long to wait
+// before retrying it
+func (b *breaker) allow(pending int64) (time.Duration, bool) {
+	if b == nil {
+		return 0, true
+	}
+
+	if b.MaxPending != 0 && pending >= int64(b.MaxPending) {
+		return overloadRetryAfter, false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if wait := time.Until(b.openUntil); wait > 0 {
+		return wait, false
+	}
+
+	return 0, true
+}
+
+// done records the outcome of a request, opening the breaker if the errors in the window reach the
+// error percent
+func (b *breaker) done(failed bool) {
+	if b == nil || b.ErrorPercent == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(b.start) > seconds(b.Window, defaultBreakerWindow) {
+		b.start, b.requests, b.errors = now, 0, 0
+	}
+
+	b.requests++
+	if failed {
+		b.errors++
+	}
+
+	if b.requests < threshold(b.MinRequests, defaultMinRequests) || b.errors*100 < b.ErrorPercent*b.requests {
+		return
+	}
+
+	b.openUntil = now.Add(seconds(b.Open, defaultBreakerOpen))
+	b.start, b.requests, b.errors = now, 0, 0
+}
+
+// retry reserves a retry of a request to the upstream within the budget of the policy, returning
+// false if the budget is spent. A reserved retry is released with atomic.AddInt64(&u.retrying, -1).
+func (u *upstream) retry(p *policy) bool {
+	budget := atomic.LoadInt64(&u.active) * p.budget / 100
+	if budget < p.minRetries {
+		budget = p.minRetries
+	}
+
+	if atomic.AddInt64(&u.retrying, 1) > budget {
+		atomic.AddInt64(&u.retrying, -1)
+		return false
+	}
+
+	return true
+}
+
+// millis returns the duration of ms milliseconds, or def if it is unset
+func millis(ms int64, def time.Duration) time.Duration {
+	if ms <= 0 {
+		return def
+	}
+
+	return time.Duration(ms) * time.Millisecond
+}
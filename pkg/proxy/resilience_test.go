@@ -0,0 +1,273 @@
+package proxy
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/valyala/fasthttp"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+func TestPolicy(t *testing.T) {
+	Convey("A nil Resilience should be unlimited, without retries", t, func() {
+		p := newPolicy(nil)
+		So(p.attempts, ShouldEqual, 0)
+
+		timeout, ok := p.timeout(time.Now(), time.Time{})
+		So(ok, ShouldBeTrue)
+		So(timeout, ShouldEqual, 0)
+	})
+
+	Convey("The timeout of a try", t, func() {
+		p := newPolicy(&sites.Resilience{Timeouts: &sites.Timeouts{PerTry: 100}})
+		now := time.Now()
+
+		cases := []struct {
+			name     string
+			deadline time.Time
+			timeout  time.Duration
+			ok       bool
+		}{
+			{"without an overall deadline should be the per try timeout", time.Time{}, time.Millisecond * 100, true},
+			{"well before the deadline should be the per try timeout", now.Add(time.Second), time.Millisecond * 100, true},
+			{"close to the deadline should be what is left of it", now.Add(time.Millisecond * 30), time.Millisecond * 30, true},
+			{"past the deadline should not be tried", now.Add(-time.Millisecond), 0, false},
+		}
+		for _, tc := range cases {
+			Convey(tc.name, func() {
+				timeout, ok := p.timeout(now, tc.deadline)
+				So(ok, ShouldEqual, tc.ok)
+				So(timeout, ShouldEqual, tc.timeout)
+			})
+		}
+
+		Convey("without a per try timeout should be what is left of the deadline", func() {
+			timeout, ok := newPolicy(&sites.Resilience{}).timeout(now, now.Add(time.Second))
+			So(ok, ShouldBeTrue)
+			So(timeout, ShouldEqual, time.Second)
+		})
+	})
+
+	Convey("Backoffs should be jittered up to their doubling base, capped at the max", t, func() {
+		p := newPolicy(&sites.Resilience{Retry: &sites.RetryPolicy{Attempts: 5, BaseBackoff: 10, MaxBackoff: 50}})
+		for i := 0; i < 100; i++ {
+			for attempt, max := range map[int]time.Duration{
+				1:  time.Millisecond * 10,
+				2:  time.Millisecond * 20,
+				3:  time.Millisecond * 40,
+				4:  time.Millisecond * 50,
+				40: time.Millisecond * 50,
+			} {
+				wait := p.backoff(attempt)
+				So(wait, ShouldBeGreaterThan, 0)
+				So(wait, ShouldBeLessThanOrEqualTo, max)
+			}
+		}
+	})
+}
+
+func TestRetryable(t *testing.T) {
+	get, post := &fasthttp.RequestCtx{}, &fasthttp.RequestCtx{}
+	get.Request.Header.SetMethod("GET")
+	post.Request.Header.SetMethod("POST")
+
+	Convey("Idempotent requests should be retried after an error, 502, 503 or 504", t, func() {
+		So(retryable(get, errors.New("reset"), 0), ShouldBeTrue)
+		for _, status := range []int{502, 503, 504} {
+			So(retryable(get, nil, status), ShouldBeTrue)
+		}
+		for _, status := range []int{200, 404, 500} {
+			So(retryable(get, nil, status), ShouldBeFalse)
+		}
+	})
+
+	Convey("Requests that are not idempotent should never be retried", t, func() {
+		So(retryable(post, errors.New("reset"), 0), ShouldBeFalse)
+		So(retryable(post, nil, 503), ShouldBeFalse)
+	})
+
+	Convey("Only failures to connect should be unreachable", t, func() {
+		So(unreachable(fasthttp.ErrDialTimeout), ShouldBeTrue)
+		So(unreachable(&net.OpError{Op: "dial", Err: errors.New("refused")}), ShouldBeTrue)
+		So(unreachable(&net.OpError{Op: "read", Err: errors.New("reset")}), ShouldBeFalse)
+		So(unreachable(fasthttp.ErrTimeout), ShouldBeFalse)
+	})
+}
+
+func TestBreaker(t *testing.T) {
+	Convey("A nil breaker should allow everything", t, func() {
+		var b *breaker
+		_, ok := b.allow(1000)
+		So(ok, ShouldBeTrue)
+		b.done(true)
+	})
+
+	Convey("A breaker should reject requests over its max pending", t, func() {
+		b := newBreaker(&sites.CircuitBreaker{MaxPending: 2})
+		_, ok := b.allow(1)
+		So(ok, ShouldBeTrue)
+
+		wait, ok := b.allow(2)
+		So(ok, ShouldBeFalse)
+		So(wait, ShouldEqual, overloadRetryAfter)
+	})
+
+	Convey("A breaker", t, func() {
+		b := newBreaker(&sites.CircuitBreaker{ErrorPercent: 50, MinRequests: 4, Open: 30})
+
+		Convey("should stay closed below its min requests, even if every one fails", func() {
+			for i := 0; i < 3; i++ {
+				b.done(true)
+			}
+			_, ok := b.allow(0)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("should stay closed below its error percent", func() {
+			for _, failed := range []bool{true, false, false, false, false} {
+				b.done(failed)
+			}
+			_, ok := b.allow(0)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("should open at its error percent, for its open duration", func() {
+			for _, failed := range []bool{true, false, true, false} {
+				b.done(failed)
+			}
+			wait, ok := b.allow(0)
+			So(ok, ShouldBeFalse)
+			So(wait, ShouldBeGreaterThan, time.Second*29)
+			So(wait, ShouldBeLessThanOrEqualTo, time.Second*30)
+		})
+
+		Convey("should close again once it has been open for its open duration", func() {
+			for i := 0; i < 4; i++ {
+				b.done(true)
+			}
+			b.openUntil = time.Now().Add(-time.Millisecond)
+
+			_, ok := b.allow(0)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("should start a new window once its window has passed", func() {
+			for i := 0; i < 3; i++ {
+				b.done(true)
+			}
+			b.start = time.Now().Add(-defaultBreakerWindow - time.Second)
+			b.done(true)
+
+			_, ok := b.allow(0)
+			So(ok, ShouldBeTrue)
+			So(b.requests, ShouldEqual, 1)
+		})
+	})
+}
+
+func TestRetryBudget(t *testing.T) {
+	Convey("Retries should be reserved up to the min retries of an idle upstream", t, func() {
+		u := &upstream{}
+		p := newPolicy(&sites.Resilience{Retry: &sites.RetryPolicy{Attempts: 3, BudgetPercent: 10, MinRetries: 2}})
+
+		So(u.retry(p), ShouldBeTrue)
+		So(u.retry(p), ShouldBeTrue)
+		So(u.retry(p), ShouldBeFalse)
+		So(atomic.LoadInt64(&u.retrying), ShouldEqual, 2)
+
+		atomic.AddInt64(&u.retrying, -1)
+		So(u.retry(p), ShouldBeTrue)
+	})
+
+	Convey("Retries should be reserved up to the budget percent of the active requests", t, func() {
+		u := &upstream{active: 100}
+		p := newPolicy(&sites.Resilience{Retry: &sites.RetryPolicy{Attempts: 3, BudgetPercent: 10, MinRetries: 2}})
+
+		for i := 0; i < 10; i++ {
+			So(u.retry(p), ShouldBeTrue)
+		}
+		So(u.retry(p), ShouldBeFalse)
+	})
+}
+
+// scripted is a picker that picks its backends in turn, then nothing
+type scripted struct {
+	backends []*backend
+}
+
+func (s *scripted) pick(*fasthttp.RequestCtx) *backend {
+	if len(s.backends) == 0 {
+		return nil
+	}
+
+	b := s.backends[0]
+	s.backends = s.backends[1:]
+	return b
+}
+
+func TestForwardRetries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/slow" {
+			time.Sleep(time.Millisecond * 200)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	addr := strings.TrimPrefix(srv.URL, "http://")
+
+	// failing returns an upstream that picks a backend answering 503 once, then nothing
+	failing := func() *upstream {
+		be, err := newBackend(&sites.Endpoint{Address: addr}, newHealth("example.com", "web", addr), nil, 0)
+		So(err, ShouldBeNil)
+
+		return &upstream{
+			Upstream: &sites.Upstream{Name: "web"},
+			pool:     &scripted{backends: []*backend{be}},
+			breaker:  newBreaker(&sites.CircuitBreaker{ErrorPercent: 50, MinRequests: 1}),
+		}
+	}
+
+	Convey("A retry that finds no backend should give back its budget, and count against the breaker", t, func() {
+		u := failing()
+		pol := newPolicy(&sites.Resilience{Retry: &sites.RetryPolicy{Attempts: 2, BaseBackoff: 1, MaxBackoff: 1}})
+
+		ctx := testRequest("GET", "http://example.com/")
+		So((&Proxy{}).forward(ctx, pol, u, nil), ShouldBeNil)
+		So(ctx.Response.StatusCode(), ShouldEqual, fasthttp.StatusServiceUnavailable)
+		So(atomic.LoadInt64(&u.retrying), ShouldEqual, 0)
+
+		_, ok := u.breaker.allow(0)
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("A retry that runs out of time should give back its budget", t, func() {
+		u := failing()
+		pol := newPolicy(&sites.Resilience{
+			Timeouts: &sites.Timeouts{Overall: 50},
+			Retry:    &sites.RetryPolicy{Attempts: 2, BaseBackoff: 1, MaxBackoff: 1},
+		})
+
+		ctx := testRequest("GET", "http://example.com/slow")
+		So((&Proxy{}).forward(ctx, pol, u, nil), ShouldBeNil)
+		So(ctx.Response.StatusCode(), ShouldEqual, fasthttp.StatusGatewayTimeout)
+		So(atomic.LoadInt64(&u.retrying), ShouldEqual, 0)
+	})
+
+	Convey("Retries that keep leaving early should not spend the budget of later requests", t, func() {
+		pol := newPolicy(&sites.Resilience{Retry: &sites.RetryPolicy{Attempts: 2, BaseBackoff: 1, MaxBackoff: 1, MinRetries: 1}})
+		u := failing()
+		for i := 0; i < 3; i++ {
+			u.pool = failing().pool
+			(&Proxy{}).forward(testRequest("GET", "http://example.com/"), pol, u, nil)
+		}
+		So(u.retry(pol), ShouldBeTrue)
+	})
+}
@@ -0,0 +1,265 @@
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+	"github.com/valyala/fasthttp"
+)
+
+// redirect is a Redirect of a Site, with its regex compiled
+type redirect struct {
+	*sites.Redirect
+
+	regex *regexp.Regexp
+}
+
+// rewrite is a Rewrite of a Site, with its regex compiled
+type rewrite struct {
+	*sites.Rewrite
+
+	regex *regexp.Regexp
+}
+
+// newRedirects creates the redirects for the Redirects of a Site
+func newRedirects(rs []*sites.Redirect) ([]*redirect, error) {
+	var redirects []*redirect
+	for _, r := range rs {
+		rd := &redirect{Redirect: r}
+		if r.Regex != "" {
+			re, err := regexp.Compile(r.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regex of redirect %s: %s", r.Name, err)
+			}
+			rd.regex = re
+		}
+		redirects = append(redirects, rd)
+	}
+
+	return redirects, nil
+}
+
+// newRewrites creates the rewrites for the Rewrites of a Site
+func newRewrites(rs []*sites.Rewrite) ([]*rewrite, error) {
+	var rewrites []*rewrite
+	for _, r := range rs {
+		re, err := regexp.Compile(r.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex of rewrite %s: %s", r.Name, err)
+		}
+		rewrites = append(rewrites, &rewrite{Rewrite: r, regex: re})
+	}
+
+	return rewrites, nil
+}
+
+// requestURI returns the path and query of the request, as the regexes of redirects and rewrites
+// match them
+func requestURI(ctx *fasthttp.RequestCtx) string {
+	uri := string(ctx.Path())
+	if q := ctx.URI().QueryString(); len(q) > 0 {
+		uri += "?" + string(q)
+	}
+
+	return uri
+}
+
+// redirect answers the request with the first redirect of the Site that matches it and would send it
+// elsewhere, returning if one did. The request was made over https if https is set.
+func (s *site) redirect(ctx *fasthttp.RequestCtx, https bool) bool {
+	scheme := SchemeHTTP
+	if https {
+		scheme = SchemeHTTPS
+	}
+	host, uri := string(ctx.Host()), requestURI(ctx)
+	current := scheme + "://" + host + uri
+
+	for _, r := range s.redirects {
+		if r.regex != nil && !r.regex.MatchString(uri) {
+			continue
+		}
+
+		location := r.location(scheme, host, uri)
+		if location == current {
+			continue
+		}
+
+		status := int(r.Status)
+		if status == 0 {
+			status = fasthttp.StatusMovedPermanently
+		}
+		ctx.Redirect(location, status)
+		return true
+	}
+
+	return false
+}
+
+// location returns the URL the redirect sends a request for the uri on the host over the scheme to
+func (r *redirect) location(scheme, host, uri string) string {
+	if r.Replacement != "" {
+		if r.regex != nil {
+			uri = r.regex.ReplaceAllString(uri, r.Replacement)
+		} else {
+			uri = r.Replacement
+		}
+
+		if strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://") {
+			return uri
+		}
+		if !strings.HasPrefix(uri, "/") {
+			uri = "/" + uri
+		}
+	}
+
+	// the port of the request is that of the Balancer, not of the https listener clients are sent to
+	if r.Https && scheme != SchemeHTTPS {
+		scheme = SchemeHTTPS
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+	}
+	if r.Host != "" {
+		host = r.Host
+	}
+
+	return scheme + "://" + host + uri
+}
+
+// rewriteURI rewrites the path and query of the request with the rewrites of the Site, in order
+func (s *site) rewriteURI(ctx *fasthttp.RequestCtx) {
+	if len(s.rewrites) == 0 {
+		return
+	}
+
+	uri := requestURI(ctx)
+	for _, r := range s.rewrites {
+		uri = r.regex.ReplaceAllString(uri, r.Replacement)
+	}
+
+	path, query := uri, ""
+	if i := strings.IndexByte(uri, '?'); i >= 0 {
+		path, query = uri[:i], uri[i+1:]
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	ctx.URI().SetPath(path)
+	ctx.URI().SetQueryString(query)
+}
+
+// headerEditor is a header that HeaderRules can change, such as those of fasthttp requests and
+// responses and http.Header
+type headerEditor interface {
+	Del(key string)
+	Set(key, value string)
+	Add(key, value string)
+}
+
+// editHeaders changes the header with the rules, removing, then setting, then adding headers
+func editHeaders(h headerEditor, rules *sites.HeaderRules) {
+	for _, k := range rules.Remove {
+		h.Del(k)
+	}
+
+	// fasthttp only replaces the first line of a header when it is set
+	for _, kv := range rules.Set {
+		h.Del(kv.Name)
+		h.Set(kv.Name, kv.Value)
+	}
+
+	for _, kv := range rules.Add {
+		h.Add(kv.Name, kv.Value)
+	}
+}
+
+// securityHeaders returns the security headers of the Site, for a response over https if https is set
+func (s *site) securityHeaders(https bool) []*sites.Header {
+	sh := s.SecurityHeaders
+	var headers []*sites.Header
+	set := func(name, value string) {
+		if value != "" {
+			headers = append(headers, &sites.Header{Name: name, Value: value})
+		}
+	}
+
+	// browsers ignore Strict-Transport-Security over http, RFC 6797 8.1
+	if sh.HstsMaxAge > 0 && https {
+		hsts := "max-age=" + strconv.FormatInt(sh.HstsMaxAge, 10)
+		if sh.HstsIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+		if sh.HstsPreload {
+			hsts += "; preload"
+		}
+		set("Strict-Transport-Security", hsts)
+	}
+
+	set("Content-Security-Policy", sh.ContentSecurityPolicy)
+	set("X-Frame-Options", sh.FrameOptions)
+	if sh.Nosniff {
+		set("X-Content-Type-Options", "nosniff")
+	}
+	set("Referrer-Policy", sh.ReferrerPolicy)
+	set("Permissions-Policy", sh.PermissionsPolicy)
+
+	return headers
+}
+
+// editResponse changes the headers of a response of the Site with its response header rules, then
+// sets its security headers, for a response over https if https is set
+func (s *site) editResponse(h headerEditor, https bool) {
+	if s.ResponseHeaders != nil {
+		editHeaders(h, s.ResponseHeaders)
+	}
+
+	if s.SecurityHeaders != nil {
+		editHeaders(h, &sites.HeaderRules{Set: s.securityHeaders(https)})
+	}
+}
+
+// edits returns if the Site changes the headers of its responses
+func (s *site) edits() bool {
+	return s.ResponseHeaders != nil || s.SecurityHeaders != nil
+}
+
+// editWriter is an http.ResponseWriter that changes the headers of the responses of a Site as their
+// header is written
+type editWriter struct {
+	http.ResponseWriter
+
+	site  *site
+	https bool
+
+	wroteHeader bool
+}
+
+// WriteHeader writes the header of the response, changed by the rules of the Site
+func (w *editWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.site.editResponse(w.Header(), w.https)
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write writes b to the response
+func (w *editWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// FlushError flushes the response
+func (w *editWriter) FlushError() error {
+	return http.NewResponseController(w.ResponseWriter).Flush()
+}
@@ -0,0 +1,180 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/valyala/fasthttp"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// redirectSite returns a site with the redirects
+func redirectSite(rs ...*sites.Redirect) *site {
+	redirects, err := newRedirects(rs)
+	So(err, ShouldBeNil)
+
+	return &site{Site: &sites.Site{}, redirects: redirects}
+}
+
+func TestRedirect(t *testing.T) {
+	toHTTPS := &sites.Redirect{Name: "https", Https: true}
+	www := &sites.Redirect{Name: "www", Host: "www.example.com", Status: 308}
+	moved := &sites.Redirect{Name: "moved", Regex: `^/old/(.*)$`, Replacement: "/new/$1", Status: 302}
+	away := &sites.Redirect{Name: "away", Regex: `^/docs(/.*)?$`, Replacement: "https://docs.example.com$1"}
+	fixed := &sites.Redirect{Name: "fixed", Regex: `^/$`, Replacement: "home"}
+
+	cases := []struct {
+		name      string
+		redirects []*sites.Redirect
+		uri       string
+		https     bool
+		location  string
+		status    int
+	}{
+		{"a request over http should be sent to https, permanently by default",
+			[]*sites.Redirect{toHTTPS}, "http://example.com/a?b=1", false, "https://example.com/a?b=1", 301},
+		{"a request to https should drop the port of the Balancer",
+			[]*sites.Redirect{toHTTPS}, "http://example.com:8080/a", false, "https://example.com/a", 301},
+		{"a request over https should not be sent to https again",
+			[]*sites.Redirect{toHTTPS}, "https://example.com/a", true, "", 0},
+		{"a request should be sent to the host of the redirect, with its status",
+			[]*sites.Redirect{www}, "http://example.com/a", false, "http://www.example.com/a", 308},
+		{"a request to the host of the redirect should not be sent to it again",
+			[]*sites.Redirect{www}, "http://www.example.com/a", false, "", 0},
+		{"a regex should replace the path and query it matches",
+			[]*sites.Redirect{moved}, "http://example.com/old/a?b=1", false, "http://example.com/new/a?b=1", 302},
+		{"a regex should not redirect what it does not match",
+			[]*sites.Redirect{moved}, "http://example.com/older/a", false, "", 0},
+		{"a replacement with a scheme should be the whole location",
+			[]*sites.Redirect{away}, "http://example.com/docs/intro", false, "https://docs.example.com/intro", 301},
+		{"a replacement without a leading slash should be given one",
+			[]*sites.Redirect{fixed}, "http://example.com/", false, "http://example.com/home", 301},
+		{"the first redirect that sends a request elsewhere should win",
+			[]*sites.Redirect{toHTTPS, moved, www}, "https://example.com/old/a", true, "https://example.com/new/a", 302},
+		{"redirects that are combined in one should apply together",
+			[]*sites.Redirect{{Name: "canonical", Https: true, Host: "www.example.com"}}, "http://example.com:8080/", false, "https://www.example.com/", 301},
+	}
+
+	for _, tc := range cases {
+		Convey("For redirecting, "+tc.name, t, func() {
+			s := redirectSite(tc.redirects...)
+			ctx := testRequest("GET", tc.uri)
+			So(s.redirect(ctx, tc.https), ShouldEqual, tc.location != "")
+			if tc.location != "" {
+				So(string(ctx.Response.Header.Peek("Location")), ShouldEqual, tc.location)
+				So(ctx.Response.StatusCode(), ShouldEqual, tc.status)
+			}
+		})
+	}
+
+	Convey("A redirect with an invalid regex should be refused", t, func() {
+		_, err := newRedirects([]*sites.Redirect{{Name: "bad", Regex: "("}})
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestRewriteURI(t *testing.T) {
+	cases := []struct {
+		name     string
+		rewrites []*sites.Rewrite
+		uri      string
+		path     string
+		query    string
+	}{
+		{"a prefix should be stripped, keeping the query",
+			[]*sites.Rewrite{{Regex: `^/api/`, Replacement: "/"}}, "/api/users?page=2", "/users", "page=2"},
+		{"a path should be moved into the query",
+			[]*sites.Rewrite{{Regex: `^/user/(\d+)$`, Replacement: "/user?id=$1"}}, "/user/42", "/user", "id=42"},
+		{"the query should be rewritten with the path",
+			[]*sites.Rewrite{{Regex: `\?lang=en$`, Replacement: ""}}, "/a?lang=en", "/a", ""},
+		{"rewrites should apply in order, each to the result of the last",
+			[]*sites.Rewrite{{Regex: `^/v1/`, Replacement: "/v2/"}, {Regex: `^/v2/`, Replacement: "/api/v2/"}}, "/v1/a", "/api/v2/a", ""},
+		{"a path without a leading slash should be given one",
+			[]*sites.Rewrite{{Regex: `^/app`, Replacement: ""}}, "/app", "/", ""},
+		{"a rewrite that does not match should change nothing",
+			[]*sites.Rewrite{{Regex: `^/api/`, Replacement: "/"}}, "/static/a.js?v=1", "/static/a.js", "v=1"},
+	}
+
+	for _, tc := range cases {
+		Convey("For rewriting, "+tc.name, t, func() {
+			rewrites, err := newRewrites(tc.rewrites)
+			So(err, ShouldBeNil)
+
+			ctx := testRequest("GET", "http://example.com"+tc.uri)
+			(&site{rewrites: rewrites}).rewriteURI(ctx)
+			So(string(ctx.URI().Path()), ShouldEqual, tc.path)
+			So(string(ctx.URI().QueryString()), ShouldEqual, tc.query)
+		})
+	}
+
+	Convey("A rewrite with an invalid regex should be refused", t, func() {
+		_, err := newRewrites([]*sites.Rewrite{{Name: "bad", Regex: "("}})
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestEditHeaders(t *testing.T) {
+	Convey("Header rules should remove, then set, then add headers", t, func() {
+		h := &fasthttp.RequestHeader{}
+		h.Add("Server", "nginx")
+		h.Add("X-Powered-By", "php")
+		h.Add("Cache-Control", "no-cache")
+		h.Add("Cache-Control", "private")
+
+		editHeaders(h, &sites.HeaderRules{
+			Remove: []string{"X-Powered-By", "Server"},
+			Set:    []*sites.Header{{Name: "Cache-Control", Value: "public"}, {Name: "Server", Value: "waffy"}},
+			Add:    []*sites.Header{{Name: "Link", Value: "</a.css>; rel=preload"}, {Name: "Link", Value: "</b.js>; rel=preload"}},
+		})
+
+		So(string(h.Peek("X-Powered-By")), ShouldEqual, "")
+		So(string(h.Peek("Server")), ShouldEqual, "waffy")
+		So(joinHeader(h, "Cache-Control", ", "), ShouldEqual, "public")
+		So(joinHeader(h, "Link", ", "), ShouldEqual, "</a.css>; rel=preload, </b.js>; rel=preload")
+	})
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	s := &site{Site: &sites.Site{SecurityHeaders: &sites.SecurityHeaders{
+		HstsMaxAge:            31536000,
+		HstsIncludeSubdomains: true,
+		HstsPreload:           true,
+		FrameOptions:          "DENY",
+		Nosniff:               true,
+		ReferrerPolicy:        "no-referrer",
+	}}}
+
+	Convey("Security headers should be set over https, with HSTS", t, func() {
+		So(s.securityHeaders(true), ShouldResemble, []*sites.Header{
+			{Name: "Strict-Transport-Security", Value: "max-age=31536000; includeSubDomains; preload"},
+			{Name: "X-Frame-Options", Value: "DENY"},
+			{Name: "X-Content-Type-Options", Value: "nosniff"},
+			{Name: "Referrer-Policy", Value: "no-referrer"},
+		})
+	})
+
+	Convey("HSTS should not be set over http", t, func() {
+		for _, h := range s.securityHeaders(false) {
+			So(h.Name, ShouldNotEqual, "Strict-Transport-Security")
+		}
+	})
+
+	Convey("Security headers should replace those of the Endpoint, after the response header rules", t, func() {
+		s := &site{Site: &sites.Site{
+			ResponseHeaders: &sites.HeaderRules{Set: []*sites.Header{{Name: "X-Frame-Options", Value: "SAMEORIGIN"}}},
+			SecurityHeaders: &sites.SecurityHeaders{FrameOptions: "DENY"},
+		}}
+		So(s.edits(), ShouldBeTrue)
+
+		rec := httptest.NewRecorder()
+		rec.Header().Set("X-Frame-Options", "ALLOWALL")
+		w := &editWriter{ResponseWriter: rec, site: s, https: true}
+		w.Write([]byte("ok"))
+
+		So(rec.Code, ShouldEqual, http.StatusOK)
+		So(rec.Header()["X-Frame-Options"], ShouldResemble, []string{"DENY"})
+	})
+}
@@ -0,0 +1,275 @@
+package proxy
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// splitScale is the number of points a sticky key is hashed to across the weights of a split
+const splitScale = 10000
+
+// route is a Route, with its patterns compiled, and the upstream and policy of the requests it matches
+type route struct {
+	*sites.Route
+
+	path     *regexp.Regexp
+	headers  []valueMatch
+	query    []valueMatch
+	upstream *upstream
+	policy   *policy
+
+	// split are the upstreams of the split in order, with their weights when the split is not ramping
+	split   []*upstream
+	weights []float64
+
+	mirror *mirror
+}
+
+// newRoute creates the route for r, sending requests to one of the upstreams under the policy of the
+// Route, or def if it has none
+func newRoute(r *sites.Route, upstreams map[string]*upstream, def *policy) (*route, error) {
+	rt := &route{
+		Route:    r,
+		upstream: upstreams[r.Upstream],
+		policy:   def,
+		weights:  repository.SplitWeights(r, time.Unix(r.Ramp.GetEnd(), 0)),
+	}
+
+	if r.PathMatch == sites.PathMatch_REGEX {
+		re, err := regexp.Compile(r.Path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path regex: %s", err)
+		}
+		rt.path = re
+	}
+
+	var err error
+	if rt.headers, err = newValueMatches(r.Headers); err != nil {
+		return nil, err
+	}
+	if rt.query, err = newValueMatches(r.Query); err != nil {
+		return nil, err
+	}
+
+	// requests a route denies are not sent anywhere
+	if r.Deny {
+		return rt, nil
+	}
+
+	for _, w := range r.Split {
+		up, ok := upstreams[w.Upstream]
+		if !ok {
+			return nil, fmt.Errorf("unknown upstream %s in split", w.Upstream)
+		}
+		rt.split = append(rt.split, up)
+	}
+
+	if rt.upstream == nil && len(rt.split) == 0 {
+		return nil, fmt.Errorf("unknown upstream %s", r.Upstream)
+	}
+
+	if r.Resilience != nil {
+		rt.policy = newPolicy(r.Resilience)
+	}
+
+	return rt, nil
+}
+
+// match returns if the request matches the path, method, headers and query parameters of the route
+func (r *route) match(ctx *fasthttp.RequestCtx) bool {
+	path := ctx.Path()
+	switch {
+	case r.path != nil:
+		if !r.path.Match(path) {
+			return false
+		}
+	case r.PathMatch == sites.PathMatch_EXACT:
+		if string(path) != r.Path {
+			return false
+		}
+	default:
+		if !bytes.HasPrefix(path, []byte(r.Path)) {
+			return false
+		}
+	}
+
+	if len(r.Methods) > 0 && !matchMethod(r.Methods, string(ctx.Method())) {
+		return false
+	}
+
+	for _, m := range r.headers {
+		v := ctx.Request.Header.Peek(m.Name)
+		if !m.match(v, len(v) > 0) {
+			return false
+		}
+	}
+
+	// query parameters can be present with no value, as in ?debug
+	args := ctx.QueryArgs()
+	for _, m := range r.query {
+		if !m.match(args.Peek(m.Name), args.Has(m.Name)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// rewrite rewrites the path of a request the route matched, stripping or replacing the matched prefix,
+// replacing the exact path, or replacing the regex match
+func (r *route) rewrite(ctx *fasthttp.RequestCtx) {
+	if !r.StripPrefix && r.Rewrite == "" {
+		return
+	}
+
+	path := string(ctx.Path())
+	switch {
+	case r.path != nil:
+		path = r.path.ReplaceAllString(path, r.Rewrite)
+	case r.PathMatch == sites.PathMatch_EXACT:
+		path = r.Rewrite
+	default:
+		rest := strings.TrimPrefix(path, r.Path)
+		if r.StripPrefix {
+			path = rest
+		} else {
+			path = strings.TrimSuffix(r.Rewrite, "/") + "/" + strings.TrimPrefix(rest, "/")
+		}
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	ctx.URI().SetPath(path)
+}
+
+// variant returns the upstream of the split for the request, and if the request should be pinned to
+// it with the sticky cookie. Requests stick to the upstream in their cookie while it has weight, and
+// requests with the same header or client IP hash to the same point across the weights, so they only
+// move when the weights around that point change.
+func (r *route) variant(ctx *fasthttp.RequestCtx) (*upstream, bool) {
+	weights := r.weights
+	if r.Ramp != nil && time.Now().Unix() < r.Ramp.End {
+		weights = repository.SplitWeights(r.Route, time.Now())
+	}
+
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	if total <= 0 {
+		return r.split[0], false
+	}
+
+	point := rand.Float64() * total
+	pin := false
+	switch {
+	case r.Sticky == nil:
+	case r.Sticky.Source == sites.HashSource_COOKIE:
+		if name := ctx.Request.Header.Cookie(r.Sticky.Name); len(name) > 0 {
+			for i, up := range r.split {
+				if up.Name == string(name) && weights[i] > 0 {
+					return up, false
+				}
+			}
+		}
+		pin = true
+	case r.Sticky.Source == sites.HashSource_HEADER:
+		if key := ctx.Request.Header.Peek(r.Sticky.Name); len(key) > 0 {
+			point = float64(hashKey(key)%splitScale) / splitScale * total
+		}
+	default:
+		point = float64(hashKey([]byte(clientIP(ctx).String()))%splitScale) / splitScale * total
+	}
+
+	for i, w := range weights {
+		if point < w {
+			return r.split[i], pin
+		}
+		point -= w
+	}
+
+	return r.split[len(r.split)-1], pin
+}
+
+// pin sets the sticky cookie of the route to the upstream on the response
+func (r *route) pin(ctx *fasthttp.RequestCtx, up *upstream) {
+	c := fasthttp.AcquireCookie()
+	defer fasthttp.ReleaseCookie(c)
+
+	c.SetKey(r.Sticky.Name)
+	c.SetValue(up.Name)
+	c.SetPath("/")
+	c.SetHTTPOnly(true)
+	ctx.Response.Header.SetCookie(c)
+}
+
+// route returns the first route of the site that matches the request, or nil if none do
+func (s *site) route(ctx *fasthttp.RequestCtx) *route {
+	for _, r := range s.routes {
+		if r.match(ctx) {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// valueMatch is a ValueMatch, with its regex compiled
+type valueMatch struct {
+	*sites.ValueMatch
+
+	re *regexp.Regexp
+}
+
+func newValueMatches(matches []*sites.ValueMatch) ([]valueMatch, error) {
+	var list []valueMatch
+	for _, m := range matches {
+		vm := valueMatch{ValueMatch: m}
+		if m.Regex {
+			re, err := regexp.Compile(m.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regex for %s: %s", m.Name, err)
+			}
+			vm.re = re
+		}
+		list = append(list, vm)
+	}
+
+	return list, nil
+}
+
+// match returns if the value v of the header or query parameter matches, or if it is present when the
+// match has no value
+func (m valueMatch) match(v []byte, present bool) bool {
+	switch {
+	case !present:
+		return false
+	case m.re != nil:
+		return m.re.Match(v)
+	case m.Value == "":
+		return true
+	}
+
+	return string(v) == m.Value
+}
+
+// matchMethod returns if the method is one of the methods
+func matchMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+
+	return false
+}
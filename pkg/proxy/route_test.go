@@ -0,0 +1,257 @@
+package proxy
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/valyala/fasthttp"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// testRequest returns a request for the method and URI, with the headers as name, value pairs
+func testRequest(method, uri string, headers ...string) *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod(method)
+	ctx.Request.SetRequestURI(uri)
+	for i := 0; i+1 < len(headers); i += 2 {
+		ctx.Request.Header.Set(headers[i], headers[i+1])
+	}
+
+	return ctx
+}
+
+// testRoute returns the route for r, sending requests to an upstream named web
+func testRoute(r *sites.Route) *route {
+	if r.Upstream == "" && len(r.Split) == 0 && !r.Deny {
+		r.Upstream = "web"
+	}
+
+	rt, err := newRoute(r, map[string]*upstream{"web": {Upstream: &sites.Upstream{Name: "web"}}}, nil)
+	So(err, ShouldBeNil)
+	return rt
+}
+
+func TestRouteMatch(t *testing.T) {
+	cases := []struct {
+		name  string
+		route *sites.Route
+		ctx   *fasthttp.RequestCtx
+		match bool
+	}{
+		{"any path", &sites.Route{}, testRequest("GET", "/anything"), true},
+		{"a prefix", &sites.Route{Path: "/api"}, testRequest("GET", "/api/users"), true},
+		{"another prefix", &sites.Route{Path: "/api"}, testRequest("GET", "/static/app.js"), false},
+		{"an exact path", &sites.Route{PathMatch: sites.PathMatch_EXACT, Path: "/health"}, testRequest("GET", "/health"), true},
+		{"a longer path than exact", &sites.Route{PathMatch: sites.PathMatch_EXACT, Path: "/health"}, testRequest("GET", "/health/live"), false},
+		{"a regex", &sites.Route{PathMatch: sites.PathMatch_REGEX, Path: `^/users/\d+$`}, testRequest("GET", "/users/42"), true},
+		{"a path the regex does not match", &sites.Route{PathMatch: sites.PathMatch_REGEX, Path: `^/users/\d+$`}, testRequest("GET", "/users/me"), false},
+		{"a method in any case", &sites.Route{Methods: []string{"get", "HEAD"}}, testRequest("GET", "/"), true},
+		{"another method", &sites.Route{Methods: []string{"GET"}}, testRequest("POST", "/"), false},
+		{"a header value", &sites.Route{Headers: []*sites.ValueMatch{{Name: "X-Canary", Value: "1"}}}, testRequest("GET", "/", "X-Canary", "1"), true},
+		{"another header value", &sites.Route{Headers: []*sites.ValueMatch{{Name: "X-Canary", Value: "1"}}}, testRequest("GET", "/", "X-Canary", "0"), false},
+		{"a present header", &sites.Route{Headers: []*sites.ValueMatch{{Name: "X-Canary"}}}, testRequest("GET", "/", "X-Canary", "yes"), true},
+		{"a missing header", &sites.Route{Headers: []*sites.ValueMatch{{Name: "X-Canary"}}}, testRequest("GET", "/"), false},
+		{"a header regex", &sites.Route{Headers: []*sites.ValueMatch{{Name: "User-Agent", Value: "(?i)mobile", Regex: true}}}, testRequest("GET", "/", "User-Agent", "Some Mobile Browser"), true},
+		{"a query value", &sites.Route{Query: []*sites.ValueMatch{{Name: "v", Value: "2"}}}, testRequest("GET", "/?v=2"), true},
+		{"another query value", &sites.Route{Query: []*sites.ValueMatch{{Name: "v", Value: "2"}}}, testRequest("GET", "/?v=1"), false},
+		{"a query parameter with no value", &sites.Route{Query: []*sites.ValueMatch{{Name: "debug"}}}, testRequest("GET", "/?debug"), true},
+		{"every match at once", &sites.Route{
+			Path:    "/api",
+			Methods: []string{"POST"},
+			Headers: []*sites.ValueMatch{{Name: "X-Canary", Value: "1"}},
+			Query:   []*sites.ValueMatch{{Name: "v", Value: "2"}},
+		}, testRequest("POST", "/api/users?v=2", "X-Canary", "1"), true},
+		{"all but one match", &sites.Route{
+			Path:    "/api",
+			Methods: []string{"POST"},
+			Headers: []*sites.ValueMatch{{Name: "X-Canary", Value: "1"}},
+			Query:   []*sites.ValueMatch{{Name: "v", Value: "2"}},
+		}, testRequest("POST", "/api/users?v=1", "X-Canary", "1"), false},
+	}
+
+	for _, tc := range cases {
+		Convey("A route for "+tc.name+" should match as expected", t, func() {
+			So(testRoute(tc.route).match(tc.ctx), ShouldEqual, tc.match)
+		})
+	}
+
+	Convey("A site should send a request to the first route that matches it", t, func() {
+		s := &site{routes: []*route{
+			testRoute(&sites.Route{Name: "exact", PathMatch: sites.PathMatch_EXACT, Path: "/api/health"}),
+			testRoute(&sites.Route{Name: "api", Path: "/api"}),
+			testRoute(&sites.Route{Name: "all"}),
+		}}
+
+		So(s.route(testRequest("GET", "/api/health")).Name, ShouldEqual, "exact")
+		So(s.route(testRequest("GET", "/api/users")).Name, ShouldEqual, "api")
+		So(s.route(testRequest("GET", "/")).Name, ShouldEqual, "all")
+		So((&site{}).route(testRequest("GET", "/")), ShouldBeNil)
+	})
+
+	Convey("A route that can not be loaded should error", t, func() {
+		web := map[string]*upstream{"web": {Upstream: &sites.Upstream{Name: "web"}}}
+		for _, r := range []*sites.Route{
+			{Upstream: "api"},
+			{PathMatch: sites.PathMatch_REGEX, Path: "(", Upstream: "web"},
+			{Headers: []*sites.ValueMatch{{Name: "X", Value: "(", Regex: true}}, Upstream: "web"},
+			{Split: []*sites.WeightedUpstream{{Upstream: "api", Weight: 1}}},
+		} {
+			_, err := newRoute(r, web, nil)
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("A route that denies should need no upstream", t, func() {
+		_, err := newRoute(&sites.Route{Deny: true}, nil, nil)
+		So(err, ShouldBeNil)
+	})
+}
+
+func TestRouteRewrite(t *testing.T) {
+	cases := []struct {
+		name  string
+		route *sites.Route
+		uri   string
+		path  string
+	}{
+		{"no rewrite should leave the path", &sites.Route{Path: "/api"}, "/api/users", "/api/users"},
+		{"strip prefix should remove the prefix", &sites.Route{Path: "/api", StripPrefix: true}, "/api/users", "/users"},
+		{"strip prefix of the whole path should leave /", &sites.Route{Path: "/api", StripPrefix: true}, "/api", "/"},
+		{"a prefix rewrite should replace the prefix", &sites.Route{Path: "/api", Rewrite: "/v2/"}, "/api/users", "/v2/users"},
+		{"an exact rewrite should replace the path", &sites.Route{PathMatch: sites.PathMatch_EXACT, Path: "/old", Rewrite: "/new"}, "/old", "/new"},
+		{"a regex rewrite should expand its groups", &sites.Route{PathMatch: sites.PathMatch_REGEX, Path: `^/u/(\d+)$`, Rewrite: "/users/$1"}, "/u/42", "/users/42"},
+		{"a rewrite without a leading slash should get one", &sites.Route{PathMatch: sites.PathMatch_EXACT, Path: "/old", Rewrite: "new"}, "/old", "/new"},
+	}
+
+	for _, tc := range cases {
+		Convey("A route with "+tc.name, t, func() {
+			ctx := testRequest("GET", tc.uri+"?q=1")
+			testRoute(tc.route).rewrite(ctx)
+			So(string(ctx.Path()), ShouldEqual, tc.path)
+			So(string(ctx.QueryArgs().Peek("q")), ShouldEqual, "1")
+		})
+	}
+}
+
+func TestRouteVariant(t *testing.T) {
+	// splitRoute returns a route splitting requests between the stable and canary upstreams by weight
+	splitRoute := func(stable, canary uint32, sticky *sites.HashPolicy) *route {
+		upstreams := map[string]*upstream{
+			"stable": {Upstream: &sites.Upstream{Name: "stable"}},
+			"canary": {Upstream: &sites.Upstream{Name: "canary"}},
+		}
+		rt, err := newRoute(&sites.Route{
+			Split:  []*sites.WeightedUpstream{{Upstream: "stable", Weight: stable}, {Upstream: "canary", Weight: canary}},
+			Sticky: sticky,
+		}, upstreams, nil)
+		So(err, ShouldBeNil)
+		return rt
+	}
+
+	// variants counts the upstreams the requests are sent to
+	variants := func(rt *route, n int, request func(i int) *fasthttp.RequestCtx) map[string]int {
+		counts := make(map[string]int)
+		for i := 0; i < n; i++ {
+			up, _ := rt.variant(request(i))
+			counts[up.Name]++
+		}
+		return counts
+	}
+
+	Convey("Requests should be split by weight", t, func() {
+		counts := variants(splitRoute(3, 1, nil), 4000, func(int) *fasthttp.RequestCtx { return testRequest("GET", "/") })
+		So(counts["stable"], ShouldBeBetween, 2800, 3200)
+		So(counts["canary"], ShouldBeBetween, 800, 1200)
+	})
+
+	Convey("A split with no weight should send every request to its first upstream", t, func() {
+		counts := variants(splitRoute(0, 0, nil), 10, func(int) *fasthttp.RequestCtx { return testRequest("GET", "/") })
+		So(counts, ShouldResemble, map[string]int{"stable": 10})
+	})
+
+	Convey("With a sticky header", t, func() {
+		sticky := &sites.HashPolicy{Source: sites.HashSource_HEADER, Name: "X-User"}
+
+		Convey("requests with the same key should stay on the same upstream, and keys should split by weight", func() {
+			rt := splitRoute(1, 1, sticky)
+			counts := make(map[string]int)
+			for i := 0; i < 2000; i++ {
+				user := fmt.Sprintf("user-%d", i)
+				up, pin := rt.variant(testRequest("GET", "/", "X-User", user))
+				So(pin, ShouldBeFalse)
+				counts[up.Name]++
+
+				again, _ := rt.variant(testRequest("GET", "/", "X-User", user))
+				So(again, ShouldEqual, up)
+			}
+			So(counts["stable"], ShouldBeBetween, 850, 1150)
+		})
+
+		Convey("only keys should move to the canary as its weight grows", func() {
+			before, after := splitRoute(90, 10, sticky), splitRoute(50, 50, sticky)
+			for i := 0; i < 500; i++ {
+				ctx := testRequest("GET", "/", "X-User", fmt.Sprintf("user-%d", i))
+				was, _ := before.variant(ctx)
+				now, _ := after.variant(ctx)
+				if was.Name == "canary" {
+					So(now.Name, ShouldEqual, "canary")
+				}
+			}
+		})
+	})
+
+	Convey("With a sticky cookie", t, func() {
+		sticky := &sites.HashPolicy{Source: sites.HashSource_COOKIE, Name: "variant"}
+
+		Convey("a request with the cookie should stay on its upstream, without pinning it again", func() {
+			rt := splitRoute(99, 1, sticky)
+			for i := 0; i < 20; i++ {
+				up, pin := rt.variant(testRequest("GET", "/", "Cookie", "variant=canary"))
+				So(up.Name, ShouldEqual, "canary")
+				So(pin, ShouldBeFalse)
+			}
+		})
+
+		Convey("a request without the cookie, or naming an upstream with no weight, should be pinned", func() {
+			rt := splitRoute(1, 0, sticky)
+			for _, ctx := range []*fasthttp.RequestCtx{
+				testRequest("GET", "/"),
+				testRequest("GET", "/", "Cookie", "variant=canary"),
+				testRequest("GET", "/", "Cookie", "variant=gone"),
+			} {
+				up, pin := rt.variant(ctx)
+				So(up.Name, ShouldEqual, "stable")
+				So(pin, ShouldBeTrue)
+			}
+		})
+
+		Convey("pinning should set the cookie to the upstream", func() {
+			rt := splitRoute(1, 1, sticky)
+			ctx := testRequest("GET", "/")
+			rt.pin(ctx, rt.split[1])
+
+			c := fasthttp.AcquireCookie()
+			defer fasthttp.ReleaseCookie(c)
+			c.SetKey("variant")
+			So(ctx.Response.Header.Cookie(c), ShouldBeTrue)
+			So(string(c.Value()), ShouldEqual, "canary")
+			So(c.HTTPOnly(), ShouldBeTrue)
+		})
+	})
+
+	Convey("A ramping split should move requests along the ramp", t, func() {
+		rt := splitRoute(0, 1, nil)
+		rt.Ramp = &sites.SplitRamp{
+			From:  []*sites.WeightedUpstream{{Upstream: "stable", Weight: 1}},
+			Start: time.Now().Add(-time.Hour).Unix(),
+			End:   time.Now().Add(time.Hour).Unix(),
+		}
+
+		counts := variants(rt, 4000, func(int) *fasthttp.RequestCtx { return testRequest("GET", "/") })
+		So(counts["stable"], ShouldBeBetween, 1800, 2200)
+		So(counts["canary"], ShouldBeBetween, 1800, 2200)
+	})
+}
@@ -0,0 +1,502 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// DefaultIdleTimeout closes tunnels with no traffic either way, for Sites that do not set one
+	DefaultIdleTimeout = time.Minute * 5
+
+	// HandshakeTimeout is the timeout of an Upgrade handshake for Sites with no read or overall timeout
+	HandshakeTimeout = time.Second * 30
+
+	// DrainTimeout is how long the open tunnels have to close once the proxy is closed
+	DrainTimeout = time.Second * 30
+)
+
+// upgrading returns if the request asks to switch the connection to another protocol
+func upgrading(ctx *fasthttp.RequestCtx) bool {
+	if len(ctx.Request.Header.Peek("Upgrade")) == 0 {
+		return false
+	}
+
+	for _, token := range bytes.Split(ctx.Request.Header.Peek("Connection"), []byte(",")) {
+		if strings.EqualFold(string(bytes.TrimSpace(token)), "upgrade") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// upgrade tunnels an Upgrade request to an Endpoint picked by the upstream of the target, or the pinned
+// backend if it is set. The handshake is sent to the Endpoint on a connection of its own, and when the
+// Endpoint switches protocols the client connection is taken over with hijack, once the response has
+// been written, and joined to it. The handshake is served like any other request until then. It returns
+// the backend that answered the handshake, or nil if none did.
+func (p *Proxy) upgrade(ctx *fasthttp.RequestCtx, t *target, pinned *backend, hijack func(fasthttp.HijackHandler)) *backend {
+	s, pol, u := t.site, t.policy, t.upstream
+	if s.websocket.Disabled {
+		fail(ctx, "upgrades are disabled for site", fasthttp.StatusForbidden)
+		return nil
+	}
+
+	if p.isDraining() {
+		unavailable(ctx, "proxy is shutting down", overloadRetryAfter)
+		return nil
+	}
+
+	if wait, ok := u.breaker.allow(atomic.LoadInt64(&u.active)); !ok {
+		unavailable(ctx, "upstream is overloaded", wait)
+		return nil
+	}
+
+	atomic.AddInt64(&u.active, 1)
+	defer atomic.AddInt64(&u.active, -1)
+
+	be := pinned
+	if be == nil {
+		be = u.pool.pick(ctx)
+	}
+	if be == nil {
+		fail(ctx, "no healthy upstream for site", fasthttp.StatusServiceUnavailable)
+		return nil
+	}
+
+	conn, br, err := be.handshake(ctx, s.websocket, pol)
+
+	// a pinned backend that can not be connected to is gone, so the handshake goes to another
+	if be == pinned && unreachable(err) {
+		be.health.observe(u.OutlierDetection, err, 0)
+		if be = u.pool.pick(ctx); be == nil {
+			fail(ctx, "no healthy upstream for site", fasthttp.StatusServiceUnavailable)
+			return nil
+		}
+		conn, br, err = be.handshake(ctx, s.websocket, pol)
+	}
+
+	be.health.observe(u.OutlierDetection, err, ctx.Response.StatusCode())
+	u.breaker.done(err != nil || ctx.Response.StatusCode() >= fasthttp.StatusInternalServerError)
+	if err != nil {
+		log.Printf("unable to upgrade to %s: %s", be.client.Addr, err)
+		ctx.Response.Reset()
+
+		if timedOut(err) {
+			fail(ctx, "upstream timed out", fasthttp.StatusGatewayTimeout)
+		} else {
+			fail(ctx, "upstream unavailable", fasthttp.StatusBadGateway)
+		}
+		return nil
+	}
+
+	if ctx.Response.StatusCode() != fasthttp.StatusSwitchingProtocols {
+		conn.Close()
+		for _, h := range hopHeaders {
+			ctx.Response.Header.Del(h)
+		}
+		return be
+	}
+
+	tn := &tunnel{
+		backend: conn,
+		br:      br,
+		idle:    seconds(s.websocket.IdleTimeout, DefaultIdleTimeout),
+		last:    time.Now().UnixNano(),
+	}
+	if strings.EqualFold(string(ctx.Request.Header.Peek("Upgrade")), "websocket") {
+		tn.ws = s.websocket
+	}
+
+	// fasthttp only hands over connections that are kept alive after the response
+	ctx.Response.Header.ResetConnectionClose()
+	hijack(func(c net.Conn) {
+		tn.client = c
+		p.join(tn, be)
+	})
+
+	return be
+}
+
+// handshake sends the Upgrade request to the backend on a connection of its own, reading the response
+// into ctx. It returns the connection and its reader, that holds anything the backend sent after its
+// response, to join to the client if the backend switched protocols.
+func (b *backend) handshake(ctx *fasthttp.RequestCtx, ws *websocket, pol *policy) (net.Conn, *bufio.Reader, error) {
+	conn, err := b.dial(clientPeer(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+	if b.client.IsTLS {
+		conn = tls.Client(conn, b.client.TLSConfig)
+	}
+
+	timeout := pol.perTry
+	if pol.overall > 0 && (timeout == 0 || pol.overall < timeout) {
+		timeout = pol.overall
+	}
+	if timeout == 0 {
+		timeout = HandshakeTimeout
+	}
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	for _, h := range hopHeaders {
+		if h != "Connection" && h != "Upgrade" {
+			ctx.Request.Header.Del(h)
+		}
+	}
+	ctx.Request.Header.Set("Connection", "Upgrade")
+
+	// compressed frames can not be matched against the deny rules, so clients can not ask for them
+	if len(ws.deny) > 0 {
+		ctx.Request.Header.Del("Sec-WebSocket-Extensions")
+	}
+
+	bw := bufio.NewWriter(conn)
+	if err := ctx.Request.Write(bw); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if err := bw.Flush(); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	br := bufio.NewReader(conn)
+	if err := ctx.Response.Read(br); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	conn.SetDeadline(time.Time{})
+	return conn, br, nil
+}
+
+// tunnel joins a hijacked client connection to the backend connection that switched protocols. The
+// frames of WebSockets are relayed one at a time, so the frames of clients can be inspected and
+// clients can be asked to go away between frames. Other protocols are relayed as they are.
+type tunnel struct {
+	client, backend net.Conn
+
+	// br reads from the backend, holding what it sent after its response
+	br *bufio.Reader
+
+	// ws is the websocket of the Site, or nil if the tunnel is not a WebSocket
+	ws *websocket
+
+	idle time.Duration
+	last int64
+
+	// mu serializes writes to the client, which is gone once it has been sent a close frame
+	mu   sync.Mutex
+	gone bool
+
+	closing sync.Once
+}
+
+// join relays between the client and backend of the tunnel until either closes it, or it is idle
+func (p *Proxy) join(t *tunnel, be *backend) {
+	if !p.track(t) {
+		t.close()
+		return
+	}
+	defer p.untrack(t)
+
+	atomic.AddInt64(&be.active, 1)
+	defer atomic.AddInt64(&be.active, -1)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer t.close()
+		t.toClient()
+	}()
+
+	t.fromClient()
+	t.close()
+	wg.Wait()
+}
+
+// fromClient relays from the client to the backend, checking the frames of WebSockets against the
+// limits of the Site, and their text messages against its deny rules
+func (t *tunnel) fromClient() error {
+	r := bufio.NewReader(&idleReader{r: t.client, conn: t.client, t: t})
+	w := &idleWriter{conn: t.backend, t: t}
+
+	if t.ws == nil || !t.ws.inspects() {
+		_, err := io.Copy(w, r)
+		return err
+	}
+
+	var size int64
+	text := false
+
+	// the frames of a text message are held until its last frame, so the whole message is matched
+	// against the deny rules rather than the fragments a client splits it into
+	var held bytes.Buffer
+	var message []byte
+	for {
+		f, err := readFrame(r)
+		if err != nil {
+			return err
+		}
+
+		if !f.control() {
+			if f.opcode != opContinuation {
+				size, text = 0, f.opcode == opText
+				held.Reset()
+				message = message[:0]
+			}
+			size += f.length
+		}
+
+		if code, reason := t.ws.limit(f, size); code != 0 {
+			return t.refuse(code, reason)
+		}
+
+		if !text || f.control() || len(t.ws.deny) == 0 {
+			if _, err := w.Write(f.raw); err != nil {
+				return err
+			}
+			if _, err := io.CopyN(w, r, f.length); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if size > t.ws.inspected() {
+			return t.refuse(closeTooBig, "message too large to inspect")
+		}
+
+		payload := make([]byte, f.length)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return err
+		}
+		held.Write(f.raw)
+		held.Write(payload)
+		message = append(message, f.unmask(payload)...)
+		if !f.fin {
+			continue
+		}
+
+		if t.ws.denied(message) {
+			return t.refuse(closePolicyViolated, "denied")
+		}
+		if _, err := held.WriteTo(w); err != nil {
+			return err
+		}
+	}
+}
+
+// toClient relays from the backend to the client, a frame at a time for WebSockets
+func (t *tunnel) toClient() error {
+	r := bufio.NewReader(&idleReader{r: t.br, conn: t.backend, t: t})
+	w := &idleWriter{conn: t.client, t: t}
+
+	if t.ws == nil {
+		_, err := io.Copy(w, r)
+		return err
+	}
+
+	for {
+		f, err := readFrame(r)
+		if err != nil {
+			return err
+		}
+
+		if err := t.relay(f, r, w); err != nil {
+			return err
+		}
+	}
+}
+
+// relay writes the frame and its payload from r to the client, or discards them if the client has
+// been sent a close frame
+func (t *tunnel) relay(f *frame, r io.Reader, w io.Writer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.gone {
+		_, err := io.CopyN(ioutil.Discard, r, f.length)
+		return err
+	}
+
+	if _, err := w.Write(f.raw); err != nil {
+		return err
+	}
+
+	_, err := io.CopyN(w, r, f.length)
+	return err
+}
+
+// refuse closes a WebSocket whose client broke the limits or deny rules of the Site, sending the close
+// code and reason to both the client and the backend
+func (t *tunnel) refuse(code uint16, reason string) error {
+	log.Printf("closing websocket from %s to %s: %s", t.client.RemoteAddr(), t.backend.RemoteAddr(), reason)
+
+	t.goAway(code, reason)
+	(&idleWriter{conn: t.backend, t: t}).Write(closeFrame(code, reason, true))
+
+	return fmt.Errorf("websocket closed: %s", reason)
+}
+
+// goAway sends the client of a WebSocket a close frame with the code and reason, between the frames
+// relayed to it
+func (t *tunnel) goAway(code uint16, reason string) {
+	if t.ws == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.gone {
+		return
+	}
+	t.gone = true
+
+	(&idleWriter{conn: t.client, t: t}).Write(closeFrame(code, reason, false))
+}
+
+// close closes both connections of the tunnel
+func (t *tunnel) close() {
+	t.closing.Do(func() {
+		t.client.Close()
+		t.backend.Close()
+	})
+}
+
+// active records traffic on the tunnel
+func (t *tunnel) active() {
+	atomic.StoreInt64(&t.last, time.Now().UnixNano())
+}
+
+// idleFor returns how long it has been since the last traffic on the tunnel
+func (t *tunnel) idleFor() time.Duration {
+	return time.Duration(time.Now().UnixNano() - atomic.LoadInt64(&t.last))
+}
+
+// idleReader reads from r, only timing out once the tunnel has had no traffic either way for its idle
+// timeout, so that a tunnel that only carries traffic one way stays open
+type idleReader struct {
+	r    io.Reader
+	conn net.Conn
+	t    *tunnel
+}
+
+func (r *idleReader) Read(b []byte) (int, error) {
+	for {
+		r.conn.SetReadDeadline(time.Now().Add(r.t.idle))
+		n, err := r.r.Read(b)
+		if n > 0 {
+			r.t.active()
+			if timedOut(err) {
+				err = nil
+			}
+			return n, err
+		}
+
+		if timedOut(err) && r.t.idleFor() < r.t.idle {
+			continue
+		}
+
+		return n, err
+	}
+}
+
+// idleWriter writes to conn, timing out if the peer does not read for the idle timeout of the tunnel
+type idleWriter struct {
+	conn net.Conn
+	t    *tunnel
+}
+
+func (w *idleWriter) Write(b []byte) (int, error) {
+	w.conn.SetWriteDeadline(time.Now().Add(w.t.idle))
+	n, err := w.conn.Write(b)
+	if n > 0 {
+		w.t.active()
+	}
+
+	return n, err
+}
+
+// timedOut returns if err is a timeout
+func timedOut(err error) bool {
+	ne, ok := err.(net.Error)
+	return ok && ne.Timeout()
+}
+
+// track adds the tunnel to those drained when the proxy is closed, or returns false if it is closing
+func (p *Proxy) track(t *tunnel) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.draining {
+		return false
+	}
+
+	p.tunnels[t] = true
+	p.tunneling.Add(1)
+	return true
+}
+
+func (p *Proxy) untrack(t *tunnel) {
+	p.mu.Lock()
+	delete(p.tunnels, t)
+	p.mu.Unlock()
+
+	p.tunneling.Done()
+}
+
+// isDraining returns if the proxy is closing, and so takes no new tunnels
+func (p *Proxy) isDraining() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.draining
+}
+
+// drain asks the clients of every WebSocket to go away, and waits for the tunnels to close, closing
+// any still open after DrainTimeout
+func (p *Proxy) drain() {
+	p.mu.Lock()
+	p.draining = true
+	var tunnels []*tunnel
+	for t := range p.tunnels {
+		tunnels = append(tunnels, t)
+	}
+	p.mu.Unlock()
+
+	for _, t := range tunnels {
+		t.goAway(closeGoingAway, "proxy is shutting down")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		p.tunneling.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(DrainTimeout):
+		log.Printf("closing tunnels still open after %s", DrainTimeout)
+		for _, t := range tunnels {
+			t.close()
+		}
+		<-done
+	}
+}
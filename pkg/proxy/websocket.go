@@ -0,0 +1,188 @@
+package proxy
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+const (
+	// MaxInspectedMessage is the largest client text message matched against the deny rules of a Site
+	// without a max_message_size, larger text messages close the connection when a Site has deny rules
+	MaxInspectedMessage = 1 << 20
+
+	// WebSocket opcodes, RFC 6455 5.2
+	opContinuation = 0x0
+	opText         = 0x1
+	opClose        = 0x8
+
+	// WebSocket close codes, RFC 6455 7.4.1
+	closeGoingAway      = 1001
+	closeProtocolError  = 1002
+	closePolicyViolated = 1008
+	closeTooBig         = 1009
+)
+
+// websocket is the WebSocket of a Site, with its deny rules compiled
+type websocket struct {
+	*sites.WebSocket
+
+	deny []*regexp.Regexp
+}
+
+// newWebSocket creates the websocket for w, which may be nil
+func newWebSocket(w *sites.WebSocket) (*websocket, error) {
+	ws := &websocket{WebSocket: w}
+	if w == nil {
+		ws.WebSocket = &sites.WebSocket{}
+		return ws, nil
+	}
+
+	for _, d := range w.Deny {
+		re, err := regexp.Compile(d)
+		if err != nil {
+			return nil, fmt.Errorf("invalid websocket deny regex: %s", err)
+		}
+		ws.deny = append(ws.deny, re)
+	}
+
+	return ws, nil
+}
+
+// inspects returns if the frames of clients are checked against the limits and deny rules
+func (w *websocket) inspects() bool {
+	return w.MaxFrameSize > 0 || w.MaxMessageSize > 0 || len(w.deny) > 0
+}
+
+// frame is the header of a WebSocket frame, as read from the connection
+type frame struct {
+	raw []byte
+
+	fin    bool
+	opcode byte
+	masked bool
+	mask   [4]byte
+	length int64
+}
+
+// control returns if the frame is a close, ping or pong frame, which can come between the frames of
+// a message
+func (f *frame) control() bool {
+	return f.opcode&0x8 != 0
+}
+
+// readFrame reads the header of the next frame, leaving its payload to be read
+func readFrame(r *bufio.Reader) (*frame, error) {
+	f := &frame{raw: make([]byte, 2, 14)}
+	if _, err := io.ReadFull(r, f.raw); err != nil {
+		return nil, err
+	}
+
+	f.fin = f.raw[0]&0x80 != 0
+	f.opcode = f.raw[0] & 0x0f
+	f.masked = f.raw[1]&0x80 != 0
+	f.length = int64(f.raw[1] & 0x7f)
+
+	var ext int
+	switch f.length {
+	case 126:
+		ext = 2
+	case 127:
+		ext = 8
+	}
+	if f.masked {
+		ext += 4
+	}
+
+	rest := f.raw[2 : 2+ext]
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return nil, err
+	}
+	f.raw = f.raw[:2+ext]
+
+	switch f.length {
+	case 126:
+		f.length = int64(binary.BigEndian.Uint16(rest))
+		rest = rest[2:]
+	case 127:
+		f.length = int64(binary.BigEndian.Uint64(rest) &^ (1 << 63))
+		rest = rest[8:]
+	}
+	if f.masked {
+		copy(f.mask[:], rest)
+	}
+
+	return f, nil
+}
+
+// unmask returns the payload of a masked frame, unmasked
+func (f *frame) unmask(payload []byte) []byte {
+	plain := make([]byte, len(payload))
+	for i, b := range payload {
+		plain[i] = b ^ f.mask[i%4]
+	}
+
+	return plain
+}
+
+// closeFrame returns a close frame with the code and reason, masked as a client frame if masked is
+// set
+func closeFrame(code uint16, reason string, masked bool) []byte {
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, code)
+	payload = append(payload, reason...)
+	if len(payload) > 125 {
+		payload = payload[:125]
+	}
+
+	if !masked {
+		return append([]byte{0x80 | opClose, byte(len(payload))}, payload...)
+	}
+
+	f := &frame{}
+	rand.Read(f.mask[:])
+	out := append([]byte{0x80 | opClose, 0x80 | byte(len(payload))}, f.mask[:]...)
+	return append(out, f.unmask(payload)...)
+}
+
+// limit returns the close code and reason to close the connection with if a client frame breaks the
+// size limits of the Site, with size the size of its message so far
+func (w *websocket) limit(f *frame, size int64) (uint16, string) {
+	switch {
+	case !f.masked:
+		return closeProtocolError, "client frames must be masked"
+	case w.MaxFrameSize > 0 && f.length > w.MaxFrameSize:
+		return closeTooBig, "frame too large"
+	case w.MaxMessageSize > 0 && size > w.MaxMessageSize:
+		return closeTooBig, "message too large"
+	}
+
+	return 0, ""
+}
+
+// inspected returns the largest client text message matched against the deny rules of the Site, its
+// max_message_size or MaxInspectedMessage
+func (w *websocket) inspected() int64 {
+	if w.MaxMessageSize > 0 {
+		return w.MaxMessageSize
+	}
+
+	return MaxInspectedMessage
+}
+
+// denied returns if the unmasked payload of a whole client text message, across its frames, matches a
+// deny rule of the Site
+func (w *websocket) denied(text []byte) bool {
+	for _, re := range w.deny {
+		if re.Match(text) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,236 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// clientFrame returns a masked client frame with the opcode and payload, the last of its message if
+// fin is set
+func clientFrame(fin bool, opcode byte, payload []byte) []byte {
+	b0 := opcode
+	if fin {
+		b0 |= 0x80
+	}
+
+	out := []byte{b0}
+	switch {
+	case len(payload) < 126:
+		out = append(out, 0x80|byte(len(payload)))
+	case len(payload) <= 0xffff:
+		out = append(out, 0x80|126, 0, 0)
+		binary.BigEndian.PutUint16(out[2:], uint16(len(payload)))
+	default:
+		out = append(out, 0x80|127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(out[2:], uint64(len(payload)))
+	}
+
+	f := &frame{}
+	rand.Read(f.mask[:])
+	out = append(out, f.mask[:]...)
+	return append(out, f.unmask(payload)...)
+}
+
+// relayed sends the frames from a client through fromClient with the WebSocket, returning what the
+// backend and client were sent, and the error it stopped with
+func relayed(w *sites.WebSocket, frames ...[]byte) ([]byte, []byte, error) {
+	ws, err := newWebSocket(w)
+	So(err, ShouldBeNil)
+
+	client, clientPeer := tcpPair()
+	backend, backendPeer := tcpPair()
+	t := &tunnel{client: client, backend: backend, ws: ws, idle: time.Second}
+
+	toBackend, toClient := make(chan []byte), make(chan []byte)
+	go func() {
+		b, _ := ioutil.ReadAll(backendPeer)
+		toBackend <- b
+	}()
+	go func() {
+		b, _ := ioutil.ReadAll(clientPeer)
+		toClient <- b
+	}()
+	go func() {
+		for _, f := range frames {
+			if _, err := clientPeer.Write(f); err != nil {
+				return
+			}
+		}
+		// the client stops sending, but still reads what it is sent back
+		clientPeer.(*net.TCPConn).CloseWrite()
+	}()
+
+	err = t.fromClient()
+	t.close()
+	return <-toBackend, <-toClient, err
+}
+
+// tcpPair returns both ends of a loopback TCP connection
+func tcpPair() (net.Conn, net.Conn) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	So(err, ShouldBeNil)
+	defer ln.Close()
+
+	dialed, err := net.Dial("tcp", ln.Addr().String())
+	So(err, ShouldBeNil)
+	accepted, err := ln.Accept()
+	So(err, ShouldBeNil)
+
+	return accepted, dialed
+}
+
+func TestReadFrame(t *testing.T) {
+	Convey("Reading a frame should decode its header and leave its payload", t, func() {
+		for _, n := range []int{0, 5, 125, 126, 300, 0xffff, 0x10000} {
+			payload := bytes.Repeat([]byte("a"), n)
+			raw := clientFrame(true, opText, payload)
+
+			r := bufio.NewReader(bytes.NewReader(raw))
+			f, err := readFrame(r)
+			So(err, ShouldBeNil)
+			So(f.fin, ShouldBeTrue)
+			So(f.opcode, ShouldEqual, opText)
+			So(f.masked, ShouldBeTrue)
+			So(f.length, ShouldEqual, n)
+			So(f.raw, ShouldResemble, raw[:len(raw)-n])
+
+			rest, _ := ioutil.ReadAll(r)
+			So(f.unmask(rest), ShouldResemble, payload)
+		}
+	})
+
+	Convey("A continuation frame that is not the last of its message should not be fin", t, func() {
+		f, err := readFrame(bufio.NewReader(bytes.NewReader(clientFrame(false, opContinuation, []byte("x")))))
+		So(err, ShouldBeNil)
+		So(f.fin, ShouldBeFalse)
+		So(f.opcode, ShouldEqual, opContinuation)
+		So(f.control(), ShouldBeFalse)
+	})
+
+	Convey("An unmasked server frame should have no mask", t, func() {
+		f, err := readFrame(bufio.NewReader(bytes.NewReader(closeFrame(closeGoingAway, "bye", false))))
+		So(err, ShouldBeNil)
+		So(f.masked, ShouldBeFalse)
+		So(f.control(), ShouldBeTrue)
+		So(f.length, ShouldEqual, 5)
+	})
+
+	Convey("A truncated header should error", t, func() {
+		raw := clientFrame(true, opText, bytes.Repeat([]byte("a"), 300))
+		for _, n := range []int{1, 3, 7} {
+			_, err := readFrame(bufio.NewReader(bytes.NewReader(raw[:n])))
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+func TestUnmask(t *testing.T) {
+	Convey("Unmasking should XOR the payload with the mask, and undo itself", t, func() {
+		f := &frame{mask: [4]byte{1, 2, 3, 4}}
+		So(f.unmask([]byte{1, 2, 3, 4, 5}), ShouldResemble, []byte{0, 0, 0, 0, 4})
+		So(f.unmask(f.unmask([]byte("hello"))), ShouldResemble, []byte("hello"))
+	})
+
+	Convey("A masked close frame should unmask to its code and reason", t, func() {
+		r := bufio.NewReader(bytes.NewReader(closeFrame(closePolicyViolated, "denied", true)))
+		f, err := readFrame(r)
+		So(err, ShouldBeNil)
+		So(f.masked, ShouldBeTrue)
+
+		payload, _ := ioutil.ReadAll(r)
+		plain := f.unmask(payload)
+		So(binary.BigEndian.Uint16(plain), ShouldEqual, closePolicyViolated)
+		So(string(plain[2:]), ShouldEqual, "denied")
+	})
+}
+
+func TestWebSocketDeny(t *testing.T) {
+	deny := &sites.WebSocket{Deny: []string{"evil"}}
+
+	Convey("A text message matching a deny rule should be closed with 1008", t, func() {
+		backend, client, err := relayed(deny, clientFrame(true, opText, []byte("so evil")))
+		So(err, ShouldNotBeNil)
+		So(bytes.Contains(backend, []byte("so evil")), ShouldBeFalse)
+		So(closeCode(backend, true), ShouldEqual, closePolicyViolated)
+		So(closeCode(client, false), ShouldEqual, closePolicyViolated)
+	})
+
+	Convey("A text message split across frames should be matched whole", t, func() {
+		backend, client, err := relayed(deny,
+			clientFrame(false, opText, []byte("e")),
+			clientFrame(false, opContinuation, []byte("v")),
+			clientFrame(true, opContinuation, []byte("il")),
+		)
+		So(err, ShouldNotBeNil)
+		So(closeCode(backend, true), ShouldEqual, closePolicyViolated)
+		So(closeCode(client, false), ShouldEqual, closePolicyViolated)
+	})
+
+	Convey("Control frames between the frames of a message should be relayed before it", t, func() {
+		ping := clientFrame(true, 0x9, []byte("p"))
+		first := clientFrame(false, opText, []byte("go"))
+		last := clientFrame(true, opContinuation, []byte("od"))
+
+		backend, _, err := relayed(deny, first, ping, last)
+		So(err, ShouldEqual, io.EOF)
+		So(backend, ShouldResemble, append(append(append([]byte{}, ping...), first...), last...))
+	})
+
+	Convey("A binary message should not be matched", t, func() {
+		bin := clientFrame(true, 0x2, []byte("evil"))
+		backend, _, err := relayed(deny, bin)
+		So(err, ShouldEqual, io.EOF)
+		So(backend, ShouldResemble, bin)
+	})
+
+	Convey("A text message larger than the max message size should be closed with 1009", t, func() {
+		w := &sites.WebSocket{Deny: []string{"evil"}, MaxMessageSize: 4}
+		backend, _, err := relayed(w,
+			clientFrame(false, opText, []byte("abc")),
+			clientFrame(true, opContinuation, []byte("de")),
+		)
+		So(err, ShouldNotBeNil)
+		So(closeCode(backend, true), ShouldEqual, closeTooBig)
+	})
+
+	Convey("An unmasked client frame should be closed with 1002", t, func() {
+		_, client, err := relayed(deny, []byte{0x80 | opText, 2, 'h', 'i'})
+		So(err, ShouldNotBeNil)
+		So(closeCode(client, false), ShouldEqual, closeProtocolError)
+	})
+}
+
+// closeCode returns the code of the close frame at the end of b, or 0 if there is none
+func closeCode(b []byte, masked bool) uint16 {
+	r := bufio.NewReader(bytes.NewReader(b))
+	for {
+		f, err := readFrame(r)
+		if err != nil {
+			return 0
+		}
+
+		payload := make([]byte, f.length)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return 0
+		}
+		if f.opcode != opClose || f.masked != masked {
+			continue
+		}
+
+		if f.masked {
+			payload = f.unmask(payload)
+		}
+		return binary.BigEndian.Uint16(payload)
+	}
+}
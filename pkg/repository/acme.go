@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+const (
+	// AcmeAccountBucket is the Bucket Store that ACME accounts are stored in, keyed by directory URL
+	AcmeAccountBucket = "acme-accounts"
+
+	// AcmeChallengeBucket is the Bucket Store that pending ACME challenge responses are stored in, so
+	// that any node can answer them
+	AcmeChallengeBucket = "acme-challenges"
+)
+
+// SaveAcmeAccount creates or replaces the ACME account for its directory in the data store
+func SaveAcmeAccount(d data.Store, a *sites.AcmeAccount) error {
+	b, err := d.Bucket(AcmeAccountBucket)
+	if err != nil {
+		return err
+	}
+
+	return Save(b, []byte(a.Directory), a)
+}
+
+// FindAcmeAccount returns the ACME account for the directory URL
+func FindAcmeAccount(d data.Store, directory string) (*sites.AcmeAccount, error) {
+	b, err := d.Bucket(AcmeAccountBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	mBytes, err := b.Get([]byte(directory))
+	if err != nil {
+		return nil, err
+	}
+
+	a := sites.AcmeAccount{}
+	if err := a.Unmarshal(mBytes); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// SetAcmeChallenge stores the response to a pending ACME challenge
+func SetAcmeChallenge(d data.Store, key string, response []byte) error {
+	b, err := d.Bucket(AcmeChallengeBucket)
+	if err != nil {
+		return err
+	}
+
+	return b.Set(data.Node{
+		Key:   []byte(key),
+		Value: response,
+	})
+}
+
+// FindAcmeChallenge returns the response to a pending ACME challenge
+func FindAcmeChallenge(d data.Store, key string) ([]byte, error) {
+	b, err := d.Bucket(AcmeChallengeBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Get([]byte(key))
+}
+
+// DeleteAcmeChallenge removes the response to a finished ACME challenge
+func DeleteAcmeChallenge(d data.Store, key string) error {
+	b, err := d.Bucket(AcmeChallengeBucket)
+	if err != nil {
+		return err
+	}
+
+	return b.Delete(data.Node{Key: []byte(key)})
+}
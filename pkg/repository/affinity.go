@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/unerror/waffy/pkg/data"
+)
+
+// AffinityBucket is the Bucket Store that the key affinity cookies are signed with is stored in, so
+// that every node can validate the cookies set by the others
+const AffinityBucket = "affinity"
+
+// affinityKey is the key of the HMAC key in the AffinityBucket
+var affinityKey = []byte("hmac")
+
+// CreateAffinityKey stores the HMAC key affinity cookies are signed with, unless one already exists
+func CreateAffinityKey(d data.Store, key []byte) error {
+	b, err := d.Bucket(AffinityBucket)
+	if err != nil {
+		return err
+	}
+
+	if _, err := b.Get(affinityKey); err == nil {
+		return fmt.Errorf("affinity key already exists")
+	}
+
+	return b.Set(data.Node{
+		Key:   affinityKey,
+		Value: key,
+	})
+}
+
+// FindAffinityKey returns the HMAC key affinity cookies are signed with
+func FindAffinityKey(d data.Store) ([]byte, error) {
+	b, err := d.Bucket(AffinityBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Get(affinityKey)
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+const (
+	// CachePurgeBucket is the Bucket Store that cache purges are stored in, keyed by their id, so that
+	// every node removes the same responses from its cache
+	CachePurgeBucket = "cache-purges"
+
+	// CachePurgeRetention is how long cache purges are stored for nodes to apply
+	CachePurgeRetention = time.Hour
+)
+
+// SaveCachePurge stores a cache purge in the data store
+func SaveCachePurge(d data.Store, p *sites.CachePurge) error {
+	b, err := d.Bucket(CachePurgeBucket)
+	if err != nil {
+		return err
+	}
+
+	return Save(b, []byte(p.Id), p)
+}
+
+// ListCachePurges returns every cache purge in the data store
+func ListCachePurges(d data.Store) ([]*sites.CachePurge, error) {
+	b, err := d.Bucket(CachePurgeBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := b.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var purges []*sites.CachePurge
+	for _, n := range nodes {
+		if n.Bucket {
+			continue
+		}
+
+		p := sites.CachePurge{}
+		if err := p.Unmarshal(n.Value); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal cache purge %s: %s", n.Key, err)
+		}
+		purges = append(purges, &p)
+	}
+
+	return purges, nil
+}
+
+// DeleteCachePurge removes a cache purge from the data store
+func DeleteCachePurge(d data.Store, id string) error {
+	b, err := d.Bucket(CachePurgeBucket)
+	if err != nil {
+		return err
+	}
+
+	return b.Delete(data.Node{Key: []byte(id)})
+}
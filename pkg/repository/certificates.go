@@ -1,6 +1,11 @@
 package repository
 
 import (
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/unerror/waffy/pkg/crypto"
 	"github.com/unerror/waffy/pkg/data"
 	"github.com/unerror/waffy/pkg/services/protos/certificates"
 )
@@ -10,6 +15,22 @@ const (
 	CertificateBucket = "certificates"
 )
 
+// NewCertificate creates the Certificate record for a x509 Certificate, with its full subject
+func NewCertificate(cert *x509.Certificate) *certificates.Certificate {
+	return &certificates.Certificate{
+		Certificate:  crypto.EncodePEM(cert),
+		SerialNumber: cert.SerialNumber.Bytes(),
+		Subject: &certificates.Subject{
+			CommonName:   cert.Subject.CommonName,
+			Organization: cert.Subject.Organization,
+			Country:      cert.Subject.Country,
+			Province:     cert.Subject.Province,
+			Locality:     cert.Subject.Locality,
+			SerialNumer:  []byte(cert.Subject.SerialNumber),
+		},
+	}
+}
+
 // CreateCertificate creates a Certificate in the data store
 func CreateCertificate(d data.Store, c *certificates.Certificate) error {
 	b, err := d.Bucket(CertificateBucket)
@@ -19,3 +40,74 @@ func CreateCertificate(d data.Store, c *certificates.Certificate) error {
 
 	return Create(b, []byte(c.SerialNumber), c)
 }
+
+// FindCertificateBySerial returns the Certificate stored with the given serial number
+func FindCertificateBySerial(d data.Store, serial []byte) (*certificates.Certificate, error) {
+	b, err := d.Bucket(CertificateBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	c := certificates.Certificate{}
+	mBytes, err := b.Get(serial)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Unmarshal(mBytes); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// RevokeCertificate marks the Certificate with the given serial number as revoked, for the RFC 5280
+// CRLReason code reason
+func RevokeCertificate(d data.Store, serial []byte, reason int32) (*certificates.Certificate, error) {
+	c, err := FindCertificateBySerial(d, serial)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.RevokedAt != 0 {
+		return nil, fmt.Errorf("certificate %x is already revoked", serial)
+	}
+
+	c.RevokedAt = time.Now().Unix()
+	c.RevocationReason = reason
+
+	b, err := d.Bucket(CertificateBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, Update(b, serial, c)
+}
+
+// ListCertificates returns every Certificate in the data store
+func ListCertificates(d data.Store) ([]*certificates.Certificate, error) {
+	b, err := d.Bucket(CertificateBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := b.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []*certificates.Certificate
+	for _, n := range nodes {
+		if n.Bucket {
+			continue
+		}
+
+		c := certificates.Certificate{}
+		if err := c.Unmarshal(n.Value); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal certificate %x: %s", n.Key, err)
+		}
+		certs = append(certs, &c)
+	}
+
+	return certs, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// EndpointHealthBucket is the Bucket Store that the results of the active health checks of Endpoints
+// are stored in, keyed by HealthKey, so that every node routes around the same unhealthy Endpoints
+const EndpointHealthBucket = "endpoint-health"
+
+// HealthKey returns the key of the health of the Endpoint at address in an Upstream of a Site
+func HealthKey(hostname, upstream, address string) string {
+	return fmt.Sprintf("%s/%s/%s", hostname, upstream, address)
+}
+
+// SaveEndpointHealth creates or replaces the health of an Endpoint in the data store
+func SaveEndpointHealth(d data.Store, h *sites.EndpointHealth) error {
+	b, err := d.Bucket(EndpointHealthBucket)
+	if err != nil {
+		return err
+	}
+
+	return Save(b, []byte(HealthKey(h.Hostname, h.Upstream, h.Address)), h)
+}
+
+// ListEndpointHealth returns the health of every checked Endpoint in the data store, by HealthKey
+func ListEndpointHealth(d data.Store) (map[string]*sites.EndpointHealth, error) {
+	b, err := d.Bucket(EndpointHealthBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := b.List()
+	if err != nil {
+		return nil, err
+	}
+
+	health := make(map[string]*sites.EndpointHealth)
+	for _, n := range nodes {
+		if n.Bucket {
+			continue
+		}
+
+		h := sites.EndpointHealth{}
+		if err := h.Unmarshal(n.Value); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal endpoint health %s: %s", n.Key, err)
+		}
+		health[string(n.Key)] = &h
+	}
+
+	return health, nil
+}
@@ -24,6 +24,36 @@ func Create(b data.Bucket, k []byte, m proto.Marshaler) error {
 	})
 }
 
+// Update will update the existing Marshable message m with key k in data.Bucket b
+func Update(b data.Bucket, k []byte, m proto.Marshaler) error {
+	if _, err := b.Get(k); err != nil {
+		return fmt.Errorf("%s does not exist", k)
+	}
+
+	mBytes, err := m.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return b.Set(data.Node{
+		Key:   k,
+		Value: mBytes,
+	})
+}
+
+// Save will create or replace the Marshable message m with key k in data.Bucket b
+func Save(b data.ValueSetter, k []byte, m proto.Marshaler) error {
+	mBytes, err := m.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return b.Set(data.Node{
+		Key:   k,
+		Value: mBytes,
+	})
+}
+
 // Seek finds the Unmarshable message with the key k in the data.Bucket b
 func Seek(b data.ValueFinder, k []byte, u proto.Unmarshaler) error {
 	mBytes, err := b.Seek(k)
@@ -0,0 +1,313 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+const (
+	// BalancerBucket is the Bucket Store that Balancers, and the Sites they serve, are stored in
+	BalancerBucket = "balancers"
+
+	// SiteCertificateBucket is the Bucket Store that Site TLS certificates are stored in
+	SiteCertificateBucket = "site-certificates"
+)
+
+// CreateBalancer creates a Balancer, keyed by its port, in the data store
+func CreateBalancer(d data.Store, b *sites.Balancer) error {
+	bucket, err := d.Bucket(BalancerBucket)
+	if err != nil {
+		return err
+	}
+
+	return Create(bucket, []byte(b.Port), b)
+}
+
+// SaveBalancer creates or replaces a Balancer in the data store
+func SaveBalancer(d data.Store, b *sites.Balancer) error {
+	bucket, err := d.Bucket(BalancerBucket)
+	if err != nil {
+		return err
+	}
+
+	return Save(bucket, []byte(b.Port), b)
+}
+
+// ListBalancers returns every Balancer in the data store
+func ListBalancers(d data.Store) ([]*sites.Balancer, error) {
+	b, err := d.Bucket(BalancerBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := b.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var balancers []*sites.Balancer
+	for _, n := range nodes {
+		if n.Bucket {
+			continue
+		}
+
+		balancer := sites.Balancer{}
+		if err := balancer.Unmarshal(n.Value); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal balancer %s: %s", n.Key, err)
+		}
+		balancers = append(balancers, &balancer)
+	}
+
+	return balancers, nil
+}
+
+// ListSites returns every Site served by a Balancer in the data store, once per hostname
+func ListSites(d data.Store) ([]*sites.Site, error) {
+	balancers, err := ListBalancers(d)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var list []*sites.Site
+	for _, b := range balancers {
+		for _, s := range b.Sites {
+			if seen[s.Hostname] {
+				continue
+			}
+
+			seen[s.Hostname] = true
+			list = append(list, s)
+		}
+	}
+
+	return list, nil
+}
+
+// SaveSiteCertificate creates or replaces the TLS certificate for a Site in the data store
+func SaveSiteCertificate(d data.Store, c *sites.SiteCertificate) error {
+	b, err := d.Bucket(SiteCertificateBucket)
+	if err != nil {
+		return err
+	}
+
+	return Save(b, []byte(c.Hostname), c)
+}
+
+// FindSiteCertificate returns the TLS certificate stored for the Site hostname
+func FindSiteCertificate(d data.Store, hostname string) (*sites.SiteCertificate, error) {
+	b, err := d.Bucket(SiteCertificateBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	mBytes, err := b.Get([]byte(hostname))
+	if err != nil {
+		return nil, err
+	}
+
+	c := sites.SiteCertificate{}
+	if err := c.Unmarshal(mBytes); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// ListSiteCertificates returns every Site TLS certificate in the data store
+func ListSiteCertificates(d data.Store) ([]*sites.SiteCertificate, error) {
+	b, err := d.Bucket(SiteCertificateBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := b.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []*sites.SiteCertificate
+	for _, n := range nodes {
+		if n.Bucket {
+			continue
+		}
+
+		c := sites.SiteCertificate{}
+		if err := c.Unmarshal(n.Value); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal site certificate %s: %s", n.Key, err)
+		}
+		certs = append(certs, &c)
+	}
+
+	return certs, nil
+}
+
+// DeleteSiteCertificate removes the TLS certificate for the Site hostname from the data store
+func DeleteSiteCertificate(d data.Store, hostname string) error {
+	b, err := d.Bucket(SiteCertificateBucket)
+	if err != nil {
+		return err
+	}
+
+	return b.Delete(data.Node{Key: []byte(hostname)})
+}
+
+// FindSite returns the Site with the hostname, from any Balancer that serves it
+func FindSite(d data.Store, hostname string) (*sites.Site, error) {
+	list, err := ListSites(d)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range list {
+		if s.Hostname == hostname {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("site %s does not exist", hostname)
+}
+
+// FindBalancer returns the Balancer listening on the port
+func FindBalancer(d data.Store, port string) (*sites.Balancer, error) {
+	balancers, err := ListBalancers(d)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range balancers {
+		if b.Port == port {
+			return b, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no balancer on port %s", port)
+}
+
+// SitePorts returns the ports of the Balancers that serve the Site hostname
+func SitePorts(d data.Store, hostname string) ([]string, error) {
+	balancers, err := ListBalancers(d)
+	if err != nil {
+		return nil, err
+	}
+
+	var ports []string
+	for _, b := range balancers {
+		for _, s := range b.Sites {
+			if s.Hostname == hostname {
+				ports = append(ports, b.Port)
+				break
+			}
+		}
+	}
+
+	return ports, nil
+}
+
+// CreateSite adds the Site to the Balancer on the port
+func CreateSite(d data.Store, port string, s *sites.Site) error {
+	b, err := FindBalancer(d, port)
+	if err != nil {
+		return err
+	}
+
+	for _, existing := range b.Sites {
+		if existing.Hostname == s.Hostname {
+			return fmt.Errorf("site %s already exists on port %s", s.Hostname, port)
+		}
+	}
+
+	b.Sites = append(b.Sites, s)
+	return SaveBalancer(d, b)
+}
+
+// UpdateSite replaces the Site with the same hostname, on every Balancer that serves it
+func UpdateSite(d data.Store, s *sites.Site) error {
+	balancers, err := ListBalancers(d)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, b := range balancers {
+		for i, existing := range b.Sites {
+			if existing.Hostname != s.Hostname {
+				continue
+			}
+
+			found = true
+			b.Sites[i] = s
+			if err := SaveBalancer(d, b); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("site %s does not exist", s.Hostname)
+	}
+
+	return nil
+}
+
+// DeleteSite removes the Site hostname from the Balancer on the port, or from every Balancer if port
+// is empty
+func DeleteSite(d data.Store, hostname, port string) error {
+	balancers, err := ListBalancers(d)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, b := range balancers {
+		if port != "" && b.Port != port {
+			continue
+		}
+
+		for i, s := range b.Sites {
+			if s.Hostname != hostname {
+				continue
+			}
+
+			found = true
+			b.Sites = append(b.Sites[:i], b.Sites[i+1:]...)
+			if err := SaveBalancer(d, b); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("site %s does not exist", hostname)
+	}
+
+	return nil
+}
+
+// SplitWeights returns the weights of the split of a Route at now, part way along its ramp if it is
+// ramping
+func SplitWeights(r *sites.Route, now time.Time) []float64 {
+	progress := 1.0
+	from := make(map[string]float64)
+	if ramp := r.Ramp; ramp != nil && now.Unix() < ramp.End && ramp.End > ramp.Start {
+		progress = (float64(now.UnixNano())/float64(time.Second) - float64(ramp.Start)) / float64(ramp.End-ramp.Start)
+		if progress < 0 {
+			progress = 0
+		}
+
+		for _, w := range ramp.From {
+			from[w.Upstream] = float64(w.Weight)
+		}
+	}
+
+	weights := make([]float64, len(r.Split))
+	for i, w := range r.Split {
+		weights[i] = from[w.Upstream] + (float64(w.Weight)-from[w.Upstream])*progress
+	}
+
+	return weights
+}
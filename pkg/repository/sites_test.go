@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+func TestSplitWeights(t *testing.T) {
+	start := time.Unix(1000, 0)
+	end := start.Add(time.Second * 100)
+
+	route := &sites.Route{
+		Split: []*sites.WeightedUpstream{{Upstream: "stable", Weight: 10}, {Upstream: "canary", Weight: 90}},
+		Ramp: &sites.SplitRamp{
+			From:  []*sites.WeightedUpstream{{Upstream: "stable", Weight: 100}},
+			Start: start.Unix(),
+			End:   end.Unix(),
+		},
+	}
+
+	cases := []struct {
+		name    string
+		now     time.Time
+		weights []float64
+	}{
+		{"before the ramp starts should be the earlier weights", start.Add(-time.Second), []float64{100, 0}},
+		{"when the ramp starts should be the earlier weights", start, []float64{100, 0}},
+		{"a quarter of the way should be a quarter of the way", start.Add(time.Second * 25), []float64{77.5, 22.5}},
+		{"half way should be half way", start.Add(time.Second * 50), []float64{55, 45}},
+		{"once the ramp ends should be the weights of the split", end, []float64{10, 90}},
+		{"after the ramp should be the weights of the split", end.Add(time.Hour), []float64{10, 90}},
+	}
+
+	for _, tc := range cases {
+		Convey("The weights of a ramping split "+tc.name, t, func() {
+			weights := SplitWeights(route, tc.now)
+			So(weights, ShouldHaveLength, len(tc.weights))
+			for i, w := range tc.weights {
+				So(weights[i], ShouldAlmostEqual, w, 0.001)
+			}
+		})
+	}
+
+	Convey("The weights of a split without a ramp should be its own", t, func() {
+		r := &sites.Route{Split: route.Split}
+		So(SplitWeights(r, start), ShouldResemble, []float64{10, 90})
+	})
+
+	Convey("A ramp that ends before it starts should not ramp", t, func() {
+		r := &sites.Route{Split: route.Split, Ramp: &sites.SplitRamp{From: route.Ramp.From, Start: end.Unix(), End: start.Unix()}}
+		So(SplitWeights(r, start.Add(-time.Second)), ShouldResemble, []float64{10, 90})
+	})
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"fmt"
+
 	"github.com/unerror/waffy/pkg/data"
 	"github.com/unerror/waffy/pkg/services/protos/users"
 )
@@ -33,5 +35,10 @@ func FindUserByEmail(d data.Store, email string) (*users.User, error) {
 		return nil, err
 	}
 
+	// Seek finds the nearest key, so make sure it is the user we asked for
+	if u.Email != email {
+		return nil, fmt.Errorf("user %s not found", email)
+	}
+
 	return &u, nil
 }
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"bytes"
+	"crypto/x509"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/users"
+)
+
+// peerCertificate returns the verified client certificate of the RPC caller
+func peerCertificate(ctx context.Context) (*x509.Certificate, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "no peer found")
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "no client certificate found")
+	}
+
+	return info.State.PeerCertificates[0], nil
+}
+
+// requireAdmin ensures the RPC caller is a user with the ADMIN role
+func requireAdmin(ctx context.Context, db data.Store) (*users.User, error) {
+	cert, err := peerCertificate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := repository.FindUserByEmail(db, cert.Subject.CommonName)
+	if err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not a user", cert.Subject.CommonName)
+	}
+
+	if u.Role != users.Role_ADMIN {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not an admin", u.Email)
+	}
+
+	return u, nil
+}
+
+// currentCertificate returns if cert is the certificate the user was last issued, and is not revoked
+func currentCertificate(db data.Store, u *users.User, cert *x509.Certificate) bool {
+	if u.Certificate == nil || !bytes.Equal(u.Certificate.SerialNumber, cert.SerialNumber.Bytes()) {
+		return false
+	}
+
+	return !revoked(db, cert)
+}
+
+// revoked returns if cert is revoked in the certificates bucket. Certificates that are not in it, such
+// as those issued before it was kept, are not revoked.
+func revoked(db data.Store, cert *x509.Certificate) bool {
+	c, err := repository.FindCertificateBySerial(db, cert.SerialNumber.Bytes())
+	return err == nil && c.RevokedAt != 0
+}
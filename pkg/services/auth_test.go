@@ -0,0 +1,125 @@
+package services
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/users"
+)
+
+const testBits = 1024
+
+// testCA is a root and intermediate that test certificates are issued by
+type testCA struct {
+	root, intermediate *x509.Certificate
+	intermediateKey    interface{}
+}
+
+// newTestCA creates a testCA
+func newTestCA(t *testing.T) *testCA {
+	root, rootKey, err := crypto.NewCertificateAuthority(testBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	intermediate, intermediateKey, err := crypto.NewIntermediateCA(root, rootKey, testBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCA{root: root, intermediate: intermediate, intermediateKey: intermediateKey}
+}
+
+// issue issues a client certificate for the email
+func (ca *testCA) issue(t *testing.T, email string) *x509.Certificate {
+	key, err := crypto.NewPrivateKey(testBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := crypto.NewCertificate(ca.intermediate, ca.intermediateKey, key, false, email)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert
+}
+
+// newTestDB creates a data.Store in a temporary directory, and the func that removes it
+func newTestDB(t *testing.T) (data.Store, func()) {
+	dir, err := ioutil.TempDir("", "waffy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := data.NewDB(filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// createUser stores a user with the role and certificate
+func createUser(t *testing.T, db data.Store, cert *x509.Certificate, role users.Role) {
+	err := repository.CreateUser(db, &users.User{
+		Email:       cert.Subject.CommonName,
+		Role:        role,
+		Certificate: repository.NewCertificate(cert),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// peerContext returns the context of an RPC from a client with the certificate
+func peerContext(cert *x509.Certificate) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}},
+	})
+}
+
+func TestRevoked(t *testing.T) {
+	ca := newTestCA(t)
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+
+	good := ca.issue(t, "good@example.com")
+	bad := ca.issue(t, "bad@example.com")
+	unstored := ca.issue(t, "unstored@example.com")
+	for _, c := range []*x509.Certificate{good, bad} {
+		if err := repository.CreateCertificate(db, repository.NewCertificate(c)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := repository.RevokeCertificate(db, bad.SerialNumber.Bytes(), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("A revoked certificate should be revoked", t, func() {
+		So(revoked(db, bad), ShouldBeTrue)
+	})
+
+	Convey("A stored certificate that is not revoked should not be revoked", t, func() {
+		So(revoked(db, good), ShouldBeFalse)
+	})
+
+	Convey("A certificate that is not stored should not be revoked", t, func() {
+		So(revoked(db, unstored), ShouldBeFalse)
+	})
+}
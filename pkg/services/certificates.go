@@ -1 +1,207 @@
 package services
+
+import (
+	"bytes"
+	"crypto/x509"
+	"sort"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/unerror/waffy/pkg/config"
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/certificates"
+)
+
+// CertificatesService serves the Certificates stored in the certificates bucket to admins
+type CertificatesService struct {
+	db data.Store
+}
+
+// NewCertificatesService creates a CertificatesService backed by the data.Store db
+func NewCertificatesService(db data.Store) *CertificatesService {
+	return &CertificatesService{db: db}
+}
+
+// List lists the stored Certificates ordered by expiry, optionally only those expiring soon
+func (s *CertificatesService) List(ctx context.Context, req *certificates.ListRequest) (*certificates.ListResponse, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	certs, err := repository.ListCertificates(s.db)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to list certificates: %s", err)
+	}
+
+	var expiry int64
+	if req.ExpiringWithin > 0 {
+		expiry = time.Now().Unix() + req.ExpiringWithin
+	}
+
+	resp := &certificates.ListResponse{}
+	for _, c := range certs {
+		info, err := certificateInfo(c)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%s", err)
+		}
+
+		if expiry > 0 && info.NotAfter > expiry {
+			continue
+		}
+		resp.Certificates = append(resp.Certificates, info)
+	}
+
+	sort.Slice(resp.Certificates, func(i, j int) bool {
+		return resp.Certificates[i].NotAfter < resp.Certificates[j].NotAfter
+	})
+
+	return resp, nil
+}
+
+// Get returns a Certificate by serial number or subject
+func (s *CertificatesService) Get(ctx context.Context, req *certificates.GetRequest) (*certificates.CertificateInfo, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	c, err := s.find(req)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := certificateInfo(c)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", err)
+	}
+
+	return info, nil
+}
+
+// Export returns a PEM encoded Certificate, with the CA chain that issued it
+func (s *CertificatesService) Export(ctx context.Context, req *certificates.GetRequest) (*certificates.ExportResponse, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	c, err := s.find(req)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := crypto.DecodeCertificatePEM(c.Certificate)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to decode certificate: %s", err)
+	}
+
+	chain, err := issuerChain(cert)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to load CA chain: %s", err)
+	}
+
+	return &certificates.ExportResponse{
+		Certificate: c.Certificate,
+		Chain:       chain,
+	}, nil
+}
+
+// find finds the Certificate by serial number, or the most recently issued Certificate for the subject
+func (s *CertificatesService) find(req *certificates.GetRequest) (*certificates.Certificate, error) {
+	if len(req.SerialNumber) > 0 {
+		c, err := repository.FindCertificateBySerial(s.db, req.SerialNumber)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "certificate %x not found", req.SerialNumber)
+		}
+
+		return c, nil
+	}
+
+	if req.Subject == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "serial_number or subject is required")
+	}
+
+	certs, err := repository.ListCertificates(s.db)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to list certificates: %s", err)
+	}
+
+	var (
+		found    *certificates.Certificate
+		issuedAt time.Time
+	)
+	for _, c := range certs {
+		if c.Subject == nil || (c.Subject.CommonName != req.Subject && c.Subject.Email != req.Subject) {
+			continue
+		}
+
+		cert, err := crypto.DecodeCertificatePEM(c.Certificate)
+		if err != nil {
+			continue
+		}
+
+		if found == nil || cert.NotBefore.After(issuedAt) {
+			found, issuedAt = c, cert.NotBefore
+		}
+	}
+
+	if found == nil {
+		return nil, status.Errorf(codes.NotFound, "no certificate found for subject %s", req.Subject)
+	}
+
+	return found, nil
+}
+
+// certificateInfo parses the stored Certificate c into a CertificateInfo
+func certificateInfo(c *certificates.Certificate) (*certificates.CertificateInfo, error) {
+	cert, err := crypto.DecodeCertificatePEM(c.Certificate)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to decode certificate %x: %s", c.SerialNumber, err)
+	}
+
+	info := &certificates.CertificateInfo{
+		Certificate:    c,
+		NotBefore:      cert.NotBefore.Unix(),
+		NotAfter:       cert.NotAfter.Unix(),
+		DnsNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+		KeyType:        crypto.KeyType(cert),
+		Issuer:         cert.Issuer.CommonName,
+	}
+
+	for _, ip := range cert.IPAddresses {
+		info.IpAddresses = append(info.IpAddresses, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		info.Uris = append(info.Uris, uri.String())
+	}
+
+	switch {
+	case c.Subject != nil && c.Subject.Email != "":
+		info.OwnerType = certificates.OwnerType_USER
+		info.Owner = c.Subject.Email
+	case c.Subject != nil && c.Subject.CommonName != "":
+		info.OwnerType = certificates.OwnerType_NODE
+		info.Owner = c.Subject.CommonName
+	}
+
+	return info, nil
+}
+
+// issuerChain returns the PEM encoded chain for cert, from the intermediate that issued it to the root
+func issuerChain(cert *x509.Certificate) ([]byte, error) {
+	chain, err := config.LoadChain(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	for _, c := range chain {
+		b.Write(crypto.EncodePEM(c))
+	}
+
+	return b.Bytes(), nil
+}
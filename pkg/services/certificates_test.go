@@ -0,0 +1,60 @@
+package services
+
+import (
+	"crypto/x509"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/certificates"
+	"github.com/unerror/waffy/pkg/services/protos/users"
+)
+
+func TestCertificatesService(t *testing.T) {
+	ca := newTestCA(t)
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+
+	admin := ca.issue(t, "admin@example.com")
+	createUser(t, db, admin, users.Role_ADMIN)
+	user := ca.issue(t, "user@example.com")
+	createUser(t, db, user, users.Role_USER)
+	stranger := ca.issue(t, "stranger@example.com")
+
+	for _, c := range []*certificates.Certificate{repository.NewCertificate(admin), repository.NewCertificate(user)} {
+		if err := repository.CreateCertificate(db, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := NewCertificatesService(db)
+	get := &certificates.GetRequest{SerialNumber: user.SerialNumber.Bytes()}
+
+	Convey("An admin should list and get the certificates", t, func() {
+		resp, err := s.List(peerContext(admin), &certificates.ListRequest{})
+		So(err, ShouldBeNil)
+		So(resp.Certificates, ShouldHaveLength, 2)
+
+		info, err := s.Get(peerContext(admin), get)
+		So(err, ShouldBeNil)
+		So(info.Certificate.SerialNumber, ShouldResemble, user.SerialNumber.Bytes())
+	})
+
+	Convey("Users that are not admins should be denied every certificate RPC", t, func() {
+		for _, c := range []*x509.Certificate{user, stranger} {
+			ctx := peerContext(c)
+
+			_, err := s.List(ctx, &certificates.ListRequest{})
+			So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+
+			_, err = s.Get(ctx, get)
+			So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+
+			_, err = s.Export(ctx, get)
+			So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+		}
+	})
+}
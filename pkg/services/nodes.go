@@ -1 +1,104 @@
 package services
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/unerror/waffy/pkg/config"
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/nodes"
+)
+
+// JoinService joins and enrolls load balancer nodes
+type JoinService struct {
+	db data.Consensus
+}
+
+// NewJoinService creates a JoinService for the data.Consensus db
+func NewJoinService(db data.Consensus) *JoinService {
+	return &JoinService{db: db}
+}
+
+// Join joins the node at the Raft address to the consensus
+func (s *JoinService) Join(ctx context.Context, req *nodes.JoinRequest) (*nodes.JoinResponse, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Join(req.Url); err != nil {
+		return &nodes.JoinResponse{Error: err.Error()}, nil
+	}
+
+	return &nodes.JoinResponse{}, nil
+}
+
+// Enroll signs the node's certificate request with the intermediate CA, for the requested subject and
+// subject alternative names, and stores the Certificate
+func (s *JoinService) Enroll(ctx context.Context, req *nodes.EnrollRequest) (*nodes.EnrollResponse, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	if req.Hostname == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "hostname is required")
+	}
+
+	block, _ := pem.Decode(req.Csr)
+	if block == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "no PEM encoded certificate request found")
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to parse certificate request: %s", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid certificate request signature: %s", err)
+	}
+
+	subject := crypto.Subject{Name: pkix.Name{CommonName: req.Hostname}}
+	if req.Subject != nil {
+		subject.Organization = req.Subject.Organization
+		subject.Country = req.Subject.Country
+		subject.Province = req.Subject.Province
+		subject.Locality = req.Subject.Locality
+	}
+	if err := subject.AddAltNames(req.DnsNames, req.IpAddresses, req.Uris); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	ca, caKey, err := config.LoadIntermediate()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to load intermediate CA: %s", err)
+	}
+
+	cert, err := crypto.NewSubjectCertificate(ca, caKey, csr.PublicKey, true, subject)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to sign certificate: %s", err)
+	}
+
+	c := repository.NewCertificate(cert)
+	if err := repository.CreateCertificate(s.db, c); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to store certificate: %s", err)
+	}
+
+	chain, err := issuerChain(cert)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to load CA chain: %s", err)
+	}
+
+	return &nodes.EnrollResponse{
+		Node: &nodes.Node{
+			Hostname:    req.Hostname,
+			Certificate: c,
+		},
+		Chain: chain,
+	}, nil
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/unerror/waffy/pkg/config"
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/certificates"
+	"github.com/unerror/waffy/pkg/services/protos/nodes"
+	"github.com/unerror/waffy/pkg/services/protos/users"
+)
+
+// testConsensus is a data.Consensus of a single node on a data.Store. The services only read and
+// write the Buckets of the store, so it has no values of its own.
+type testConsensus struct {
+	data.Store
+}
+
+func (c *testConsensus) List() ([]data.Node, error)        { return nil, errNoValues }
+func (c *testConsensus) Get(k []byte) ([]byte, error)      { return nil, errNoValues }
+func (c *testConsensus) Set(n data.Node) error             { return errNoValues }
+func (c *testConsensus) Delete(n data.Node) error          { return errNoValues }
+func (c *testConsensus) Seek(k []byte) ([]byte, error)     { return nil, errNoValues }
+func (c *testConsensus) GetWeak(k []byte) ([]byte, error)  { return nil, errNoValues }
+func (c *testConsensus) ListWeak() ([]data.Node, error)    { return nil, errNoValues }
+func (c *testConsensus) SeekWeak(k []byte) ([]byte, error) { return nil, errNoValues }
+func (c *testConsensus) Join(addr string) error            { return nil }
+func (c *testConsensus) Leave(addr string) error           { return nil }
+func (c *testConsensus) Leader() bool                      { return true }
+func (c *testConsensus) Weak() data.Bucket                 { return c }
+
+// errNoValues is the error of reading or writing a value of a testConsensus outside of a Bucket
+var errNoValues = errors.New("no values outside of a bucket")
+
+// csr returns a PEM encoded certificate request signed by a new key
+func csr(t *testing.T) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, testBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "ignored"}}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+}
+
+func TestEnroll(t *testing.T) {
+	cfg, _ := config.Load()
+	dir, err := ioutil.TempDir("", "waffy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	certPath := cfg.CertPath
+	cfg.CertPath = dir
+	defer func() { cfg.CertPath = certPath }()
+
+	root, rootKey, err := crypto.NewCertificateAuthority(testBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	intermediate, intermediateKey, err := crypto.NewIntermediateCA(root, rootKey, testBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveCA(root, rootKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveIntermediate(intermediate, intermediateKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveIntermediates([]config.Intermediate{{Certificate: intermediate}}); err != nil {
+		t.Fatal(err)
+	}
+
+	ca := newTestCA(t)
+	store, cleanup := newTestDB(t)
+	defer cleanup()
+
+	admin := ca.issue(t, "admin@example.com")
+	createUser(t, store, admin, users.Role_ADMIN)
+	user := ca.issue(t, "user@example.com")
+	createUser(t, store, user, users.Role_USER)
+
+	s := NewJoinService(&testConsensus{Store: store})
+	ctx := peerContext(admin)
+
+	Convey("An enrolled node should be issued a certificate for its subject and alternative names", t, func() {
+		resp, err := s.Enroll(ctx, &nodes.EnrollRequest{
+			Hostname:    "node1.waffy.local",
+			Subject:     &certificates.Subject{Organization: []string{"waffy"}, Country: []string{"NZ"}},
+			DnsNames:    []string{"node1", "*.node1.waffy.local"},
+			IpAddresses: []string{"10.0.0.1", "::1"},
+			Uris:        []string{"spiffe://waffy/node1"},
+			Csr:         csr(t),
+		})
+		So(err, ShouldBeNil)
+		So(resp.Node.Hostname, ShouldEqual, "node1.waffy.local")
+
+		cert, err := crypto.DecodeCertificatePEM(resp.Node.Certificate.Certificate)
+		So(err, ShouldBeNil)
+		So(cert.Subject.CommonName, ShouldEqual, "node1.waffy.local")
+		So(cert.Subject.Organization, ShouldResemble, []string{"waffy"})
+		So(cert.Subject.Country, ShouldResemble, []string{"NZ"})
+		So(cert.DNSNames, ShouldResemble, []string{"node1.waffy.local", "node1", "*.node1.waffy.local"})
+		So(cert.IPAddresses, ShouldHaveLength, 2)
+		So(cert.IPAddresses[0].String(), ShouldEqual, "10.0.0.1")
+		So(cert.IPAddresses[1].String(), ShouldEqual, "::1")
+		So(cert.URIs, ShouldHaveLength, 1)
+		So(cert.URIs[0].String(), ShouldEqual, "spiffe://waffy/node1")
+		So(cert.CheckSignatureFrom(intermediate), ShouldBeNil)
+
+		stored, err := repository.FindCertificateBySerial(store, cert.SerialNumber.Bytes())
+		So(err, ShouldBeNil)
+		So(stored.Certificate, ShouldResemble, resp.Node.Certificate.Certificate)
+	})
+
+	Convey("The hostname should not be named twice when it is also requested as a DNS name", t, func() {
+		resp, err := s.Enroll(ctx, &nodes.EnrollRequest{Hostname: "node2.waffy.local", DnsNames: []string{"node2.waffy.local"}, Csr: csr(t)})
+		So(err, ShouldBeNil)
+
+		cert, err := crypto.DecodeCertificatePEM(resp.Node.Certificate.Certificate)
+		So(err, ShouldBeNil)
+		So(cert.DNSNames, ShouldResemble, []string{"node2.waffy.local"})
+	})
+
+	Convey("Invalid or duplicate alternative names should be refused", t, func() {
+		for _, req := range []*nodes.EnrollRequest{
+			{DnsNames: []string{"node 3"}},
+			{DnsNames: []string{"node3..waffy.local"}},
+			{DnsNames: []string{"node3", "NODE3"}},
+			{IpAddresses: []string{"10.0.0"}},
+			{IpAddresses: []string{"10.0.0.3", "10.0.0.3"}},
+			{Uris: []string{"node3"}},
+			{Uris: []string{"spiffe://waffy/node3", "spiffe://waffy/node3"}},
+		} {
+			req.Hostname = "node3.waffy.local"
+			req.Csr = csr(t)
+
+			_, err := s.Enroll(ctx, req)
+			So(status.Code(err), ShouldEqual, codes.InvalidArgument)
+		}
+	})
+
+	Convey("A request with no hostname or certificate request should be refused", t, func() {
+		_, err := s.Enroll(ctx, &nodes.EnrollRequest{Csr: csr(t)})
+		So(status.Code(err), ShouldEqual, codes.InvalidArgument)
+
+		_, err = s.Enroll(ctx, &nodes.EnrollRequest{Hostname: "node3.waffy.local", Csr: []byte("not a request")})
+		So(status.Code(err), ShouldEqual, codes.InvalidArgument)
+	})
+
+	Convey("Users that are not admins should be denied enrolling nodes", t, func() {
+		_, err := s.Enroll(peerContext(user), &nodes.EnrollRequest{Hostname: "node3.waffy.local", Csr: csr(t)})
+		So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+	})
+}
@@ -10,6 +10,11 @@
 	It has these top-level messages:
 		Subject
 		Certificate
+		CertificateInfo
+		ListRequest
+		ListResponse
+		GetRequest
+		ExportResponse
 */
 package certificates
 
@@ -17,6 +22,11 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
 import io "io"
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -30,6 +40,31 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// OwnerType is the kind of identity a Certificate belongs to
+type OwnerType int32
+
+const (
+	OwnerType_UNKNOWN OwnerType = 0
+	OwnerType_USER    OwnerType = 1
+	OwnerType_NODE    OwnerType = 2
+)
+
+var OwnerType_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "USER",
+	2: "NODE",
+}
+var OwnerType_value = map[string]int32{
+	"UNKNOWN": 0,
+	"USER":    1,
+	"NODE":    2,
+}
+
+func (x OwnerType) String() string {
+	return proto.EnumName(OwnerType_name, int32(x))
+}
+func (OwnerType) EnumDescriptor() ([]byte, []int) { return fileDescriptorCertificates, []int{0} }
+
 // Subject is the identity information for the certificate
 type Subject struct {
 	CommonName   string   `protobuf:"bytes,1,opt,name=common_name,json=commonName,proto3" json:"common_name,omitempty"`
@@ -97,9 +132,11 @@ func (m *Subject) GetSerialNumer() []byte {
 
 // Certificate represents some PKI certificate
 type Certificate struct {
-	Subject      *Subject `protobuf:"bytes,1,opt,name=subject" json:"subject,omitempty"`
-	Certificate  []byte   `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate,omitempty"`
-	SerialNumber []byte   `protobuf:"bytes,3,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Subject          *Subject `protobuf:"bytes,1,opt,name=subject" json:"subject,omitempty"`
+	Certificate      []byte   `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	SerialNumber     []byte   `protobuf:"bytes,3,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	RevokedAt        int64    `protobuf:"varint,4,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	RevocationReason int32    `protobuf:"varint,5,opt,name=revocation_reason,json=revocationReason,proto3" json:"revocation_reason,omitempty"`
 }
 
 func (m *Certificate) Reset()                    { *m = Certificate{} }
@@ -128,10 +165,352 @@ func (m *Certificate) GetSerialNumber() []byte {
 	return nil
 }
 
+func (m *Certificate) GetRevokedAt() int64 {
+	if m != nil {
+		return m.RevokedAt
+	}
+	return 0
+}
+
+func (m *Certificate) GetRevocationReason() int32 {
+	if m != nil {
+		return m.RevocationReason
+	}
+	return 0
+}
+
+// CertificateInfo is a stored Certificate along with its parsed x509 details
+type CertificateInfo struct {
+	Certificate    *Certificate `protobuf:"bytes,1,opt,name=certificate" json:"certificate,omitempty"`
+	NotBefore      int64        `protobuf:"varint,2,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	NotAfter       int64        `protobuf:"varint,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	DnsNames       []string     `protobuf:"bytes,4,rep,name=dns_names,json=dnsNames" json:"dns_names,omitempty"`
+	IpAddresses    []string     `protobuf:"bytes,5,rep,name=ip_addresses,json=ipAddresses" json:"ip_addresses,omitempty"`
+	EmailAddresses []string     `protobuf:"bytes,6,rep,name=email_addresses,json=emailAddresses" json:"email_addresses,omitempty"`
+	Uris           []string     `protobuf:"bytes,7,rep,name=uris" json:"uris,omitempty"`
+	KeyType        string       `protobuf:"bytes,8,opt,name=key_type,json=keyType,proto3" json:"key_type,omitempty"`
+	Issuer         string       `protobuf:"bytes,9,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	OwnerType      OwnerType    `protobuf:"varint,10,opt,name=owner_type,json=ownerType,proto3,enum=certificates.OwnerType" json:"owner_type,omitempty"`
+	Owner          string       `protobuf:"bytes,11,opt,name=owner,proto3" json:"owner,omitempty"`
+}
+
+func (m *CertificateInfo) Reset()                    { *m = CertificateInfo{} }
+func (m *CertificateInfo) String() string            { return proto.CompactTextString(m) }
+func (*CertificateInfo) ProtoMessage()               {}
+func (*CertificateInfo) Descriptor() ([]byte, []int) { return fileDescriptorCertificates, []int{2} }
+
+func (m *CertificateInfo) GetCertificate() *Certificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *CertificateInfo) GetNotBefore() int64 {
+	if m != nil {
+		return m.NotBefore
+	}
+	return 0
+}
+
+func (m *CertificateInfo) GetNotAfter() int64 {
+	if m != nil {
+		return m.NotAfter
+	}
+	return 0
+}
+
+func (m *CertificateInfo) GetDnsNames() []string {
+	if m != nil {
+		return m.DnsNames
+	}
+	return nil
+}
+
+func (m *CertificateInfo) GetIpAddresses() []string {
+	if m != nil {
+		return m.IpAddresses
+	}
+	return nil
+}
+
+func (m *CertificateInfo) GetEmailAddresses() []string {
+	if m != nil {
+		return m.EmailAddresses
+	}
+	return nil
+}
+
+func (m *CertificateInfo) GetUris() []string {
+	if m != nil {
+		return m.Uris
+	}
+	return nil
+}
+
+func (m *CertificateInfo) GetKeyType() string {
+	if m != nil {
+		return m.KeyType
+	}
+	return ""
+}
+
+func (m *CertificateInfo) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
+}
+
+func (m *CertificateInfo) GetOwnerType() OwnerType {
+	if m != nil {
+		return m.OwnerType
+	}
+	return OwnerType_UNKNOWN
+}
+
+func (m *CertificateInfo) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+type ListRequest struct {
+	ExpiringWithin int64 `protobuf:"varint,1,opt,name=expiring_within,json=expiringWithin,proto3" json:"expiring_within,omitempty"`
+}
+
+func (m *ListRequest) Reset()                    { *m = ListRequest{} }
+func (m *ListRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()               {}
+func (*ListRequest) Descriptor() ([]byte, []int) { return fileDescriptorCertificates, []int{3} }
+
+func (m *ListRequest) GetExpiringWithin() int64 {
+	if m != nil {
+		return m.ExpiringWithin
+	}
+	return 0
+}
+
+type ListResponse struct {
+	Certificates []*CertificateInfo `protobuf:"bytes,1,rep,name=certificates" json:"certificates,omitempty"`
+}
+
+func (m *ListResponse) Reset()                    { *m = ListResponse{} }
+func (m *ListResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListResponse) ProtoMessage()               {}
+func (*ListResponse) Descriptor() ([]byte, []int) { return fileDescriptorCertificates, []int{4} }
+
+func (m *ListResponse) GetCertificates() []*CertificateInfo {
+	if m != nil {
+		return m.Certificates
+	}
+	return nil
+}
+
+type GetRequest struct {
+	SerialNumber []byte `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Subject      string `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+}
+
+func (m *GetRequest) Reset()                    { *m = GetRequest{} }
+func (m *GetRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetRequest) ProtoMessage()               {}
+func (*GetRequest) Descriptor() ([]byte, []int) { return fileDescriptorCertificates, []int{5} }
+
+func (m *GetRequest) GetSerialNumber() []byte {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return nil
+}
+
+func (m *GetRequest) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+type ExportResponse struct {
+	Certificate []byte `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Chain       []byte `protobuf:"bytes,2,opt,name=chain,proto3" json:"chain,omitempty"`
+}
+
+func (m *ExportResponse) Reset()                    { *m = ExportResponse{} }
+func (m *ExportResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExportResponse) ProtoMessage()               {}
+func (*ExportResponse) Descriptor() ([]byte, []int) { return fileDescriptorCertificates, []int{6} }
+
+func (m *ExportResponse) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *ExportResponse) GetChain() []byte {
+	if m != nil {
+		return m.Chain
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Subject)(nil), "certificates.Subject")
 	proto.RegisterType((*Certificate)(nil), "certificates.Certificate")
+	proto.RegisterType((*CertificateInfo)(nil), "certificates.CertificateInfo")
+	proto.RegisterType((*ListRequest)(nil), "certificates.ListRequest")
+	proto.RegisterType((*ListResponse)(nil), "certificates.ListResponse")
+	proto.RegisterType((*GetRequest)(nil), "certificates.GetRequest")
+	proto.RegisterType((*ExportResponse)(nil), "certificates.ExportResponse")
+	proto.RegisterEnum("certificates.OwnerType", OwnerType_name, OwnerType_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for CertificatesService service
+
+type CertificatesServiceClient interface {
+	// List the stored certificates
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Get a certificate by serial number or subject
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*CertificateInfo, error)
+	// Export a certificate, with the CA chain that issued it
+	Export(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*ExportResponse, error)
+}
+
+type certificatesServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCertificatesServiceClient(cc *grpc.ClientConn) CertificatesServiceClient {
+	return &certificatesServiceClient{cc}
+}
+
+func (c *certificatesServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := grpc.Invoke(ctx, "/certificates.CertificatesService/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certificatesServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*CertificateInfo, error) {
+	out := new(CertificateInfo)
+	err := grpc.Invoke(ctx, "/certificates.CertificatesService/Get", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certificatesServiceClient) Export(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*ExportResponse, error) {
+	out := new(ExportResponse)
+	err := grpc.Invoke(ctx, "/certificates.CertificatesService/Export", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for CertificatesService service
+
+type CertificatesServiceServer interface {
+	// List the stored certificates
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Get a certificate by serial number or subject
+	Get(context.Context, *GetRequest) (*CertificateInfo, error)
+	// Export a certificate, with the CA chain that issued it
+	Export(context.Context, *GetRequest) (*ExportResponse, error)
+}
+
+func RegisterCertificatesServiceServer(s *grpc.Server, srv CertificatesServiceServer) {
+	s.RegisterService(&_CertificatesService_serviceDesc, srv)
+}
+
+func _CertificatesService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertificatesServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/certificates.CertificatesService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertificatesServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertificatesService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertificatesServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/certificates.CertificatesService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertificatesServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertificatesService_Export_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertificatesServiceServer).Export(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/certificates.CertificatesService/Export",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertificatesServiceServer).Export(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CertificatesService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "certificates.CertificatesService",
+	HandlerType: (*CertificatesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _CertificatesService_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _CertificatesService_Get_Handler,
+		},
+		{
+			MethodName: "Export",
+			Handler:    _CertificatesService_Export_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/services/protos/certificates/certificates.proto",
 }
+
 func (m *Subject) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -265,63 +644,307 @@ func (m *Certificate) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintCertificates(dAtA, i, uint64(len(m.SerialNumber)))
 		i += copy(dAtA[i:], m.SerialNumber)
 	}
+	if m.RevokedAt != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintCertificates(dAtA, i, uint64(m.RevokedAt))
+	}
+	if m.RevocationReason != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintCertificates(dAtA, i, uint64(m.RevocationReason))
+	}
 	return i, nil
 }
 
-func encodeFixed64Certificates(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32Certificates(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
-}
-func encodeVarintCertificates(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *CertificateInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *Subject) Size() (n int) {
+
+func (m *CertificateInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.CommonName)
-	if l > 0 {
-		n += 1 + l + sovCertificates(uint64(l))
+	if m.Certificate != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCertificates(dAtA, i, uint64(m.Certificate.Size()))
+		n2, err := m.Certificate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
 	}
-	l = len(m.Email)
-	if l > 0 {
-		n += 1 + l + sovCertificates(uint64(l))
+	if m.NotBefore != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCertificates(dAtA, i, uint64(m.NotBefore))
 	}
-	if len(m.Organization) > 0 {
-		for _, s := range m.Organization {
-			l = len(s)
-			n += 1 + l + sovCertificates(uint64(l))
-		}
+	if m.NotAfter != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintCertificates(dAtA, i, uint64(m.NotAfter))
 	}
-	if len(m.Country) > 0 {
-		for _, s := range m.Country {
+	if len(m.DnsNames) > 0 {
+		for _, s := range m.DnsNames {
+			dAtA[i] = 0x22
+			i++
 			l = len(s)
-			n += 1 + l + sovCertificates(uint64(l))
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
 	}
-	if len(m.Province) > 0 {
-		for _, s := range m.Province {
+	if len(m.IpAddresses) > 0 {
+		for _, s := range m.IpAddresses {
+			dAtA[i] = 0x2a
+			i++
 			l = len(s)
-			n += 1 + l + sovCertificates(uint64(l))
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.EmailAddresses) > 0 {
+		for _, s := range m.EmailAddresses {
+			dAtA[i] = 0x32
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Uris) > 0 {
+		for _, s := range m.Uris {
+			dAtA[i] = 0x3a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.KeyType) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintCertificates(dAtA, i, uint64(len(m.KeyType)))
+		i += copy(dAtA[i:], m.KeyType)
+	}
+	if len(m.Issuer) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintCertificates(dAtA, i, uint64(len(m.Issuer)))
+		i += copy(dAtA[i:], m.Issuer)
+	}
+	if m.OwnerType != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintCertificates(dAtA, i, uint64(m.OwnerType))
+	}
+	if len(m.Owner) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintCertificates(dAtA, i, uint64(len(m.Owner)))
+		i += copy(dAtA[i:], m.Owner)
+	}
+	return i, nil
+}
+
+func (m *ListRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ExpiringWithin != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintCertificates(dAtA, i, uint64(m.ExpiringWithin))
+	}
+	return i, nil
+}
+
+func (m *ListResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Certificates) > 0 {
+		for _, msg := range m.Certificates {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintCertificates(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *GetRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.SerialNumber) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCertificates(dAtA, i, uint64(len(m.SerialNumber)))
+		i += copy(dAtA[i:], m.SerialNumber)
+	}
+	if len(m.Subject) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCertificates(dAtA, i, uint64(len(m.Subject)))
+		i += copy(dAtA[i:], m.Subject)
+	}
+	return i, nil
+}
+
+func (m *ExportResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Certificate) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCertificates(dAtA, i, uint64(len(m.Certificate)))
+		i += copy(dAtA[i:], m.Certificate)
+	}
+	if len(m.Chain) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCertificates(dAtA, i, uint64(len(m.Chain)))
+		i += copy(dAtA[i:], m.Chain)
+	}
+	return i, nil
+}
+
+func encodeFixed64Certificates(dAtA []byte, offset int, v uint64) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	dAtA[offset+4] = uint8(v >> 32)
+	dAtA[offset+5] = uint8(v >> 40)
+	dAtA[offset+6] = uint8(v >> 48)
+	dAtA[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32Certificates(dAtA []byte, offset int, v uint32) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	return offset + 4
+}
+func encodeVarintCertificates(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Subject) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.CommonName)
+	if l > 0 {
+		n += 1 + l + sovCertificates(uint64(l))
+	}
+	l = len(m.Email)
+	if l > 0 {
+		n += 1 + l + sovCertificates(uint64(l))
+	}
+	if len(m.Organization) > 0 {
+		for _, s := range m.Organization {
+			l = len(s)
+			n += 1 + l + sovCertificates(uint64(l))
+		}
+	}
+	if len(m.Country) > 0 {
+		for _, s := range m.Country {
+			l = len(s)
+			n += 1 + l + sovCertificates(uint64(l))
+		}
+	}
+	if len(m.Province) > 0 {
+		for _, s := range m.Province {
+			l = len(s)
+			n += 1 + l + sovCertificates(uint64(l))
 		}
 	}
 	if len(m.Locality) > 0 {
@@ -352,6 +975,116 @@ func (m *Certificate) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovCertificates(uint64(l))
 	}
+	if m.RevokedAt != 0 {
+		n += 1 + sovCertificates(uint64(m.RevokedAt))
+	}
+	if m.RevocationReason != 0 {
+		n += 1 + sovCertificates(uint64(m.RevocationReason))
+	}
+	return n
+}
+
+func (m *CertificateInfo) Size() (n int) {
+	var l int
+	_ = l
+	if m.Certificate != nil {
+		l = m.Certificate.Size()
+		n += 1 + l + sovCertificates(uint64(l))
+	}
+	if m.NotBefore != 0 {
+		n += 1 + sovCertificates(uint64(m.NotBefore))
+	}
+	if m.NotAfter != 0 {
+		n += 1 + sovCertificates(uint64(m.NotAfter))
+	}
+	if len(m.DnsNames) > 0 {
+		for _, s := range m.DnsNames {
+			l = len(s)
+			n += 1 + l + sovCertificates(uint64(l))
+		}
+	}
+	if len(m.IpAddresses) > 0 {
+		for _, s := range m.IpAddresses {
+			l = len(s)
+			n += 1 + l + sovCertificates(uint64(l))
+		}
+	}
+	if len(m.EmailAddresses) > 0 {
+		for _, s := range m.EmailAddresses {
+			l = len(s)
+			n += 1 + l + sovCertificates(uint64(l))
+		}
+	}
+	if len(m.Uris) > 0 {
+		for _, s := range m.Uris {
+			l = len(s)
+			n += 1 + l + sovCertificates(uint64(l))
+		}
+	}
+	l = len(m.KeyType)
+	if l > 0 {
+		n += 1 + l + sovCertificates(uint64(l))
+	}
+	l = len(m.Issuer)
+	if l > 0 {
+		n += 1 + l + sovCertificates(uint64(l))
+	}
+	if m.OwnerType != 0 {
+		n += 1 + sovCertificates(uint64(m.OwnerType))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovCertificates(uint64(l))
+	}
+	return n
+}
+
+func (m *ListRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.ExpiringWithin != 0 {
+		n += 1 + sovCertificates(uint64(m.ExpiringWithin))
+	}
+	return n
+}
+
+func (m *ListResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Certificates) > 0 {
+		for _, e := range m.Certificates {
+			l = e.Size()
+			n += 1 + l + sovCertificates(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.SerialNumber)
+	if l > 0 {
+		n += 1 + l + sovCertificates(uint64(l))
+	}
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovCertificates(uint64(l))
+	}
+	return n
+}
+
+func (m *ExportResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Certificate)
+	if l > 0 {
+		n += 1 + l + sovCertificates(uint64(l))
+	}
+	l = len(m.Chain)
+	if l > 0 {
+		n += 1 + l + sovCertificates(uint64(l))
+	}
 	return n
 }
 
@@ -399,7 +1132,574 @@ func (m *Subject) Unmarshal(dAtA []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CommonName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CommonName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CommonName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Email = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Organization", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Organization = append(m.Organization, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Country", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Country = append(m.Country, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Province", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Province = append(m.Province, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Locality", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Locality = append(m.Locality, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SerialNumer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SerialNumer = append(m.SerialNumer[:0], dAtA[iNdEx:postIndex]...)
+			if m.SerialNumer == nil {
+				m.SerialNumer = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCertificates(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Certificate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCertificates
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Certificate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Certificate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Subject == nil {
+				m.Subject = &Subject{}
+			}
+			if err := m.Subject.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Certificate = append(m.Certificate[:0], dAtA[iNdEx:postIndex]...)
+			if m.Certificate == nil {
+				m.Certificate = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SerialNumber", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SerialNumber = append(m.SerialNumber[:0], dAtA[iNdEx:postIndex]...)
+			if m.SerialNumber == nil {
+				m.SerialNumber = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RevokedAt", wireType)
+			}
+			m.RevokedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RevokedAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RevocationReason", wireType)
+			}
+			m.RevocationReason = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RevocationReason |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCertificates(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CertificateInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCertificates
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CertificateInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CertificateInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Certificate == nil {
+				m.Certificate = &Certificate{}
+			}
+			if err := m.Certificate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotBefore", wireType)
+			}
+			m.NotBefore = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NotBefore |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotAfter", wireType)
+			}
+			m.NotAfter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NotAfter |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DnsNames", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DnsNames = append(m.DnsNames, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IpAddresses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IpAddresses = append(m.IpAddresses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EmailAddresses", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -424,11 +1724,11 @@ func (m *Subject) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CommonName = string(dAtA[iNdEx:postIndex])
+			m.EmailAddresses = append(m.EmailAddresses, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 2:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Uris", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -453,11 +1753,11 @@ func (m *Subject) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Email = string(dAtA[iNdEx:postIndex])
+			m.Uris = append(m.Uris, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 3:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Organization", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyType", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -482,11 +1782,11 @@ func (m *Subject) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Organization = append(m.Organization, string(dAtA[iNdEx:postIndex]))
+			m.KeyType = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Country", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Issuer", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -511,11 +1811,30 @@ func (m *Subject) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Country = append(m.Country, string(dAtA[iNdEx:postIndex]))
+			m.Issuer = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OwnerType", wireType)
+			}
+			m.OwnerType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OwnerType |= (OwnerType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Province", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -540,13 +1859,63 @@ func (m *Subject) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Province = append(m.Province, string(dAtA[iNdEx:postIndex]))
+			m.Owner = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Locality", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCertificates(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var stringLen uint64
+			if skippy < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCertificates
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiringWithin", wireType)
+			}
+			m.ExpiringWithin = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCertificates
@@ -556,26 +1925,66 @@ func (m *Subject) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.ExpiringWithin |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCertificates(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthCertificates
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Locality = append(m.Locality, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 7:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCertificates
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SerialNumer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Certificates", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCertificates
@@ -585,21 +1994,21 @@ func (m *Subject) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthCertificates
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SerialNumer = append(m.SerialNumer[:0], dAtA[iNdEx:postIndex]...)
-			if m.SerialNumer == nil {
-				m.SerialNumer = []byte{}
+			m.Certificates = append(m.Certificates, &CertificateInfo{})
+			if err := m.Certificates[len(m.Certificates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -623,7 +2032,7 @@ func (m *Subject) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Certificate) Unmarshal(dAtA []byte) error {
+func (m *GetRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -646,17 +2055,17 @@ func (m *Certificate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Certificate: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Certificate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SerialNumber", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCertificates
@@ -666,26 +2075,103 @@ func (m *Certificate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthCertificates
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Subject == nil {
-				m.Subject = &Subject{}
-			}
-			if err := m.Subject.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.SerialNumber = append(m.SerialNumber[:0], dAtA[iNdEx:postIndex]...)
+			if m.SerialNumber == nil {
+				m.SerialNumber = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCertificates
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCertificates(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCertificates
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExportResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCertificates
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
 			}
@@ -716,9 +2202,9 @@ func (m *Certificate) Unmarshal(dAtA []byte) error {
 				m.Certificate = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SerialNumber", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Chain", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -742,9 +2228,9 @@ func (m *Certificate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SerialNumber = append(m.SerialNumber[:0], dAtA[iNdEx:postIndex]...)
-			if m.SerialNumber == nil {
-				m.SerialNumber = []byte{}
+			m.Chain = append(m.Chain[:0], dAtA[iNdEx:postIndex]...)
+			if m.Chain == nil {
+				m.Chain = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -878,24 +2364,51 @@ func init() {
 }
 
 var fileDescriptorCertificates = []byte{
-	// 296 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x91, 0xb1, 0x4e, 0xf3, 0x30,
-	0x14, 0x85, 0x7f, 0xff, 0xa5, 0x0d, 0xbd, 0x31, 0x12, 0xb2, 0x40, 0xb2, 0x18, 0x42, 0x28, 0x4b,
-	0xa7, 0x46, 0xa2, 0x6f, 0x00, 0x7b, 0x87, 0xf0, 0x00, 0x95, 0x63, 0x5d, 0x2a, 0x43, 0x6c, 0x57,
-	0x8e, 0x5b, 0xa9, 0xec, 0xbc, 0x03, 0x8f, 0xc4, 0xc8, 0xca, 0x86, 0xc2, 0x8b, 0xa0, 0x3a, 0xa4,
-	0x4d, 0xc6, 0xf3, 0x7d, 0x67, 0xf0, 0xb9, 0x86, 0xf9, 0xfa, 0x65, 0x95, 0x55, 0xe8, 0xb6, 0x4a,
-	0x62, 0x95, 0xad, 0x9d, 0xf5, 0xb6, 0xca, 0x24, 0x3a, 0xaf, 0x9e, 0x94, 0x14, 0x1e, 0xfb, 0x61,
-	0x16, 0x0a, 0x8c, 0x76, 0xd9, 0xe4, 0x8b, 0x40, 0xf4, 0xb8, 0x29, 0x9e, 0x51, 0x7a, 0x76, 0x0d,
-	0xb1, 0xb4, 0x5a, 0x5b, 0xb3, 0x34, 0x42, 0x23, 0x27, 0x29, 0x99, 0x8e, 0x73, 0x68, 0xd0, 0x42,
-	0x68, 0x64, 0x17, 0x30, 0x44, 0x2d, 0x54, 0xc9, 0xff, 0x07, 0xd5, 0x04, 0x36, 0x01, 0x6a, 0xdd,
-	0x4a, 0x18, 0xf5, 0x2a, 0xbc, 0xb2, 0x86, 0x0f, 0xd2, 0xc1, 0x74, 0x9c, 0xf7, 0x18, 0xe3, 0x10,
-	0x49, 0xbb, 0x31, 0xde, 0xed, 0xf8, 0x49, 0xd0, 0x6d, 0x64, 0x57, 0x70, 0xba, 0x76, 0x76, 0xab,
-	0x8c, 0x44, 0x3e, 0x0c, 0xea, 0x90, 0xf7, 0xae, 0xb4, 0x52, 0x94, 0xca, 0xef, 0xf8, 0xa8, 0x71,
-	0x6d, 0x66, 0x37, 0x40, 0x2b, 0x74, 0x4a, 0x94, 0x4b, 0xb3, 0xd1, 0xe8, 0x78, 0x94, 0x92, 0x29,
-	0xcd, 0xe3, 0x86, 0x2d, 0xf6, 0x68, 0xf2, 0x46, 0x20, 0x7e, 0x38, 0x8e, 0x65, 0x19, 0x44, 0x55,
-	0x33, 0x35, 0x6c, 0x8b, 0xef, 0x2e, 0x67, 0xbd, 0xfb, 0xfc, 0xdd, 0x21, 0x6f, 0x5b, 0x2c, 0x85,
-	0xb8, 0x53, 0x08, 0xab, 0x69, 0xde, 0x45, 0xec, 0x16, 0xce, 0x8e, 0xaf, 0x28, 0xd0, 0xf1, 0x41,
-	0xe8, 0xd0, 0xc3, 0x33, 0x0a, 0x74, 0xf7, 0xe7, 0x1f, 0x75, 0x42, 0x3e, 0xeb, 0x84, 0x7c, 0xd7,
-	0x09, 0x79, 0xff, 0x49, 0xfe, 0x15, 0xa3, 0xf0, 0x15, 0xf3, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x81, 0xf9, 0x52, 0xf9, 0xc1, 0x01, 0x00, 0x00,
+	// 729 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0x5d, 0x6f, 0xd3, 0x48,
+	0x14, 0xad, 0xeb, 0x7c, 0x5e, 0x67, 0xd3, 0xec, 0x6c, 0x77, 0x77, 0x9a, 0xdd, 0x66, 0xbd, 0xd9,
+	0x87, 0x8d, 0x00, 0x35, 0x52, 0x2a, 0xf5, 0x05, 0x81, 0x48, 0xa1, 0x2a, 0xa8, 0x28, 0x15, 0x2e,
+	0x55, 0x1f, 0x2d, 0xc7, 0x99, 0xb4, 0x43, 0x92, 0x19, 0x33, 0x33, 0x69, 0x1b, 0x7e, 0x01, 0x3f,
+	0x81, 0x9f, 0xc4, 0x23, 0xbc, 0x20, 0xf1, 0x86, 0xca, 0x1f, 0x41, 0x9e, 0x71, 0x12, 0xbb, 0x2d,
+	0x7d, 0xf3, 0x39, 0xe7, 0xda, 0xba, 0xe7, 0xdc, 0x7b, 0x0d, 0xdb, 0xd1, 0xe8, 0xb4, 0x2d, 0x89,
+	0x38, 0xa7, 0x21, 0x91, 0xed, 0x48, 0x70, 0xc5, 0x65, 0x3b, 0x24, 0x42, 0xd1, 0x21, 0x0d, 0x03,
+	0x45, 0xb2, 0x60, 0x4b, 0x17, 0xa0, 0x4a, 0x9a, 0x6b, 0x7e, 0xb5, 0xa0, 0x78, 0x34, 0xed, 0xbf,
+	0x21, 0xa1, 0x42, 0xff, 0x80, 0x13, 0xf2, 0xc9, 0x84, 0x33, 0x9f, 0x05, 0x13, 0x82, 0x2d, 0xd7,
+	0x6a, 0x95, 0x3d, 0x30, 0x54, 0x2f, 0x98, 0x10, 0xb4, 0x0e, 0x79, 0x32, 0x09, 0xe8, 0x18, 0xaf,
+	0x6a, 0xc9, 0x00, 0xd4, 0x84, 0x0a, 0x17, 0xa7, 0x01, 0xa3, 0xef, 0x02, 0x45, 0x39, 0xc3, 0xb6,
+	0x6b, 0xb7, 0xca, 0x5e, 0x86, 0x43, 0x18, 0x8a, 0x21, 0x9f, 0x32, 0x25, 0x66, 0x38, 0xa7, 0xe5,
+	0x39, 0x44, 0x75, 0x28, 0x45, 0x82, 0x9f, 0x53, 0x16, 0x12, 0x9c, 0xd7, 0xd2, 0x02, 0xc7, 0xda,
+	0x98, 0x87, 0xc1, 0x98, 0xaa, 0x19, 0x2e, 0x18, 0x6d, 0x8e, 0xd1, 0xbf, 0x50, 0x91, 0x44, 0xd0,
+	0x60, 0xec, 0xb3, 0xe9, 0x84, 0x08, 0x5c, 0x74, 0xad, 0x56, 0xc5, 0x73, 0x0c, 0xd7, 0x8b, 0xa9,
+	0xe6, 0x67, 0x0b, 0x9c, 0xa7, 0x4b, 0xb3, 0xa8, 0x0d, 0x45, 0x69, 0xac, 0x6a, 0x6f, 0x4e, 0xe7,
+	0xf7, 0xad, 0x4c, 0x3e, 0x49, 0x0e, 0xde, 0xbc, 0x0a, 0xb9, 0xe0, 0xa4, 0x0a, 0xb4, 0xeb, 0x8a,
+	0x97, 0xa6, 0xd0, 0x7f, 0xf0, 0xcb, 0xb2, 0x8b, 0x3e, 0x11, 0xd8, 0xd6, 0x35, 0x95, 0x45, 0x1b,
+	0x7d, 0x22, 0xd0, 0x26, 0x80, 0x20, 0xe7, 0x7c, 0x44, 0x06, 0x7e, 0xa0, 0x70, 0xce, 0xb5, 0x5a,
+	0xb6, 0x57, 0x4e, 0x98, 0xae, 0x42, 0xf7, 0xe1, 0xd7, 0x18, 0x84, 0x3a, 0x29, 0x5f, 0x90, 0x40,
+	0x72, 0x86, 0xf3, 0xae, 0xd5, 0xca, 0x7b, 0xb5, 0xa5, 0xe0, 0x69, 0xbe, 0xf9, 0xde, 0x86, 0xb5,
+	0x94, 0xa7, 0x17, 0x6c, 0xc8, 0xd1, 0xc3, 0x6c, 0x9b, 0xc6, 0xdb, 0x46, 0xd6, 0x5b, 0xea, 0x9d,
+	0xac, 0x83, 0x4d, 0x00, 0xc6, 0x95, 0xdf, 0x27, 0x43, 0x2e, 0x8c, 0x45, 0xdb, 0x2b, 0x33, 0xae,
+	0x76, 0x35, 0x81, 0xfe, 0x82, 0x18, 0xf8, 0xc1, 0x50, 0x25, 0xe6, 0x6c, 0xaf, 0xc4, 0xb8, 0xea,
+	0xc6, 0x38, 0x16, 0x07, 0x4c, 0xea, 0x6d, 0x91, 0xc9, 0x5c, 0x4b, 0x03, 0x26, 0xe3, 0x5d, 0x91,
+	0xf1, 0x80, 0x68, 0xe4, 0x07, 0x83, 0x81, 0x20, 0x52, 0x12, 0x99, 0x0c, 0xd7, 0xa1, 0x51, 0x77,
+	0x4e, 0xa1, 0xff, 0x61, 0x4d, 0xaf, 0x50, 0xaa, 0xca, 0x8c, 0xb9, 0xaa, 0xe9, 0x65, 0x21, 0x82,
+	0xdc, 0x54, 0x50, 0x89, 0x8b, 0x5a, 0xd5, 0xcf, 0x68, 0x03, 0x4a, 0x23, 0x32, 0xf3, 0xd5, 0x2c,
+	0x22, 0xb8, 0xa4, 0xf7, 0xb1, 0x38, 0x22, 0xb3, 0xd7, 0xb3, 0x88, 0xa0, 0x3f, 0xa0, 0x40, 0xa5,
+	0x9c, 0x12, 0x81, 0xcb, 0x5a, 0x48, 0x10, 0xda, 0x01, 0xe0, 0x17, 0x8c, 0x08, 0xf3, 0x12, 0xb8,
+	0x56, 0xab, 0xda, 0xf9, 0x33, 0x9b, 0xd3, 0x61, 0xac, 0xc7, 0x1f, 0xf1, 0xca, 0x7c, 0xfe, 0x18,
+	0xef, 0xbd, 0x06, 0xd8, 0x31, 0x7b, 0xaf, 0x41, 0x73, 0x07, 0x9c, 0x97, 0x54, 0x2a, 0x8f, 0xbc,
+	0x9d, 0x12, 0xa9, 0xb4, 0x99, 0xcb, 0x88, 0x0a, 0xca, 0x4e, 0xfd, 0x0b, 0xaa, 0xce, 0x28, 0xd3,
+	0x93, 0xb0, 0xbd, 0xea, 0x9c, 0x3e, 0xd1, 0x6c, 0xf3, 0x15, 0x54, 0xcc, 0x7b, 0x32, 0xe2, 0x4c,
+	0x12, 0xd4, 0x85, 0xcc, 0x49, 0x62, 0xcb, 0xb5, 0x5b, 0x4e, 0x67, 0xf3, 0xa7, 0xf3, 0x8b, 0x67,
+	0xee, 0x65, 0xaf, 0xf8, 0x00, 0x60, 0x9f, 0x2c, 0x3a, 0xb9, 0xb1, 0x94, 0xd6, 0x2d, 0x4b, 0x89,
+	0x97, 0xc7, 0x60, 0xae, 0x79, 0x0e, 0x9b, 0xcf, 0xa1, 0xba, 0x77, 0x19, 0x71, 0xb1, 0xec, 0xd0,
+	0xbd, 0xb9, 0x60, 0xd7, 0xee, 0x60, 0x1d, 0xf2, 0xe1, 0x59, 0x40, 0x59, 0x72, 0x23, 0x06, 0xdc,
+	0x7b, 0x00, 0xe5, 0x45, 0x9e, 0xc8, 0x81, 0xe2, 0x71, 0xef, 0xa0, 0x77, 0x78, 0xd2, 0xab, 0xad,
+	0xa0, 0x12, 0xe4, 0x8e, 0x8f, 0xf6, 0xbc, 0x9a, 0x15, 0x3f, 0xf5, 0x0e, 0x9f, 0xed, 0xd5, 0x56,
+	0x3b, 0x5f, 0x2c, 0xf8, 0x2d, 0x65, 0x53, 0x1e, 0x99, 0x1f, 0x1b, 0x7a, 0x04, 0xb9, 0x38, 0x2f,
+	0x74, 0x6d, 0xa3, 0x53, 0xd9, 0xd7, 0xeb, 0xb7, 0x49, 0x49, 0xf3, 0x8f, 0xc1, 0xde, 0x27, 0x0a,
+	0xe1, 0x6c, 0xc9, 0x32, 0xae, 0xfa, 0xdd, 0x49, 0xa3, 0x27, 0x50, 0x30, 0x71, 0xdc, 0xf1, 0x89,
+	0xbf, 0xb3, 0x4a, 0x36, 0xbe, 0xdd, 0xda, 0xc7, 0xab, 0x86, 0xf5, 0xe9, 0xaa, 0x61, 0x7d, 0xbb,
+	0x6a, 0x58, 0x1f, 0xbe, 0x37, 0x56, 0xfa, 0x05, 0xfd, 0x2b, 0xde, 0xfe, 0x11, 0x00, 0x00, 0xff,
+	0xff, 0xfa, 0x41, 0x49, 0xc2, 0xc1, 0x05, 0x00, 0x00,
 }
@@ -11,6 +11,8 @@
 		Node
 		JoinRequest
 		JoinResponse
+		EnrollRequest
+		EnrollResponse
 */
 package nodes
 
@@ -94,10 +96,92 @@ func (m *JoinResponse) GetError() string {
 	return ""
 }
 
+type EnrollRequest struct {
+	Hostname    string                `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Subject     *certificates.Subject `protobuf:"bytes,2,opt,name=subject" json:"subject,omitempty"`
+	DnsNames    []string              `protobuf:"bytes,3,rep,name=dns_names,json=dnsNames" json:"dns_names,omitempty"`
+	IpAddresses []string              `protobuf:"bytes,4,rep,name=ip_addresses,json=ipAddresses" json:"ip_addresses,omitempty"`
+	Uris        []string              `protobuf:"bytes,5,rep,name=uris" json:"uris,omitempty"`
+	Csr         []byte                `protobuf:"bytes,6,opt,name=csr,proto3" json:"csr,omitempty"`
+}
+
+func (m *EnrollRequest) Reset()                    { *m = EnrollRequest{} }
+func (m *EnrollRequest) String() string            { return proto.CompactTextString(m) }
+func (*EnrollRequest) ProtoMessage()               {}
+func (*EnrollRequest) Descriptor() ([]byte, []int) { return fileDescriptorNodes, []int{3} }
+
+func (m *EnrollRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *EnrollRequest) GetSubject() *certificates.Subject {
+	if m != nil {
+		return m.Subject
+	}
+	return nil
+}
+
+func (m *EnrollRequest) GetDnsNames() []string {
+	if m != nil {
+		return m.DnsNames
+	}
+	return nil
+}
+
+func (m *EnrollRequest) GetIpAddresses() []string {
+	if m != nil {
+		return m.IpAddresses
+	}
+	return nil
+}
+
+func (m *EnrollRequest) GetUris() []string {
+	if m != nil {
+		return m.Uris
+	}
+	return nil
+}
+
+func (m *EnrollRequest) GetCsr() []byte {
+	if m != nil {
+		return m.Csr
+	}
+	return nil
+}
+
+type EnrollResponse struct {
+	Node  *Node  `protobuf:"bytes,1,opt,name=node" json:"node,omitempty"`
+	Chain []byte `protobuf:"bytes,2,opt,name=chain,proto3" json:"chain,omitempty"`
+}
+
+func (m *EnrollResponse) Reset()                    { *m = EnrollResponse{} }
+func (m *EnrollResponse) String() string            { return proto.CompactTextString(m) }
+func (*EnrollResponse) ProtoMessage()               {}
+func (*EnrollResponse) Descriptor() ([]byte, []int) { return fileDescriptorNodes, []int{4} }
+
+func (m *EnrollResponse) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *EnrollResponse) GetChain() []byte {
+	if m != nil {
+		return m.Chain
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Node)(nil), "nodes.Node")
 	proto.RegisterType((*JoinRequest)(nil), "nodes.JoinRequest")
 	proto.RegisterType((*JoinResponse)(nil), "nodes.JoinResponse")
+	proto.RegisterType((*EnrollRequest)(nil), "nodes.EnrollRequest")
+	proto.RegisterType((*EnrollResponse)(nil), "nodes.EnrollResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -113,6 +197,8 @@ const _ = grpc.SupportPackageIsVersion4
 type JoinServiceClient interface {
 	// Join a node to the consensus
 	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
+	// Enroll a node, signing its certificate request with the CA
+	Enroll(ctx context.Context, in *EnrollRequest, opts ...grpc.CallOption) (*EnrollResponse, error)
 }
 
 type joinServiceClient struct {
@@ -132,11 +218,22 @@ func (c *joinServiceClient) Join(ctx context.Context, in *JoinRequest, opts ...g
 	return out, nil
 }
 
+func (c *joinServiceClient) Enroll(ctx context.Context, in *EnrollRequest, opts ...grpc.CallOption) (*EnrollResponse, error) {
+	out := new(EnrollResponse)
+	err := grpc.Invoke(ctx, "/nodes.JoinService/Enroll", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for JoinService service
 
 type JoinServiceServer interface {
 	// Join a node to the consensus
 	Join(context.Context, *JoinRequest) (*JoinResponse, error)
+	// Enroll a node, signing its certificate request with the CA
+	Enroll(context.Context, *EnrollRequest) (*EnrollResponse, error)
 }
 
 func RegisterJoinServiceServer(s *grpc.Server, srv JoinServiceServer) {
@@ -161,6 +258,24 @@ func _JoinService_Join_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JoinService_Enroll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JoinServiceServer).Enroll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodes.JoinService/Enroll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JoinServiceServer).Enroll(ctx, req.(*EnrollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _JoinService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "nodes.JoinService",
 	HandlerType: (*JoinServiceServer)(nil),
@@ -169,6 +284,10 @@ var _JoinService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Join",
 			Handler:    _JoinService_Join_Handler,
 		},
+		{
+			MethodName: "Enroll",
+			Handler:    _JoinService_Enroll_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/services/protos/nodes/nodes.proto",
@@ -256,6 +375,125 @@ func (m *JoinResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *EnrollRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EnrollRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintNodes(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if m.Subject != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintNodes(dAtA, i, uint64(m.Subject.Size()))
+		n2, err := m.Subject.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if len(m.DnsNames) > 0 {
+		for _, s := range m.DnsNames {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.IpAddresses) > 0 {
+		for _, s := range m.IpAddresses {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Uris) > 0 {
+		for _, s := range m.Uris {
+			dAtA[i] = 0x2a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Csr) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintNodes(dAtA, i, uint64(len(m.Csr)))
+		i += copy(dAtA[i:], m.Csr)
+	}
+	return i, nil
+}
+
+func (m *EnrollResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EnrollResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Node != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintNodes(dAtA, i, uint64(m.Node.Size()))
+		n3, err := m.Node.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if len(m.Chain) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintNodes(dAtA, i, uint64(len(m.Chain)))
+		i += copy(dAtA[i:], m.Chain)
+	}
+	return i, nil
+}
+
 func encodeFixed64Nodes(dAtA []byte, offset int, v uint64) int {
 	dAtA[offset] = uint8(v)
 	dAtA[offset+1] = uint8(v >> 8)
@@ -317,6 +555,56 @@ func (m *JoinResponse) Size() (n int) {
 	return n
 }
 
+func (m *EnrollRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovNodes(uint64(l))
+	}
+	if m.Subject != nil {
+		l = m.Subject.Size()
+		n += 1 + l + sovNodes(uint64(l))
+	}
+	if len(m.DnsNames) > 0 {
+		for _, s := range m.DnsNames {
+			l = len(s)
+			n += 1 + l + sovNodes(uint64(l))
+		}
+	}
+	if len(m.IpAddresses) > 0 {
+		for _, s := range m.IpAddresses {
+			l = len(s)
+			n += 1 + l + sovNodes(uint64(l))
+		}
+	}
+	if len(m.Uris) > 0 {
+		for _, s := range m.Uris {
+			l = len(s)
+			n += 1 + l + sovNodes(uint64(l))
+		}
+	}
+	l = len(m.Csr)
+	if l > 0 {
+		n += 1 + l + sovNodes(uint64(l))
+	}
+	return n
+}
+
+func (m *EnrollResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Node != nil {
+		l = m.Node.Size()
+		n += 1 + l + sovNodes(uint64(l))
+	}
+	l = len(m.Chain)
+	if l > 0 {
+		n += 1 + l + sovNodes(uint64(l))
+	}
+	return n
+}
+
 func sovNodes(x uint64) (n int) {
 	for {
 		n++
@@ -600,6 +888,350 @@ func (m *JoinResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *EnrollRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNodes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EnrollRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EnrollRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNodes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNodes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNodes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNodes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Subject == nil {
+				m.Subject = &certificates.Subject{}
+			}
+			if err := m.Subject.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DnsNames", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNodes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNodes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DnsNames = append(m.DnsNames, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IpAddresses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNodes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNodes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IpAddresses = append(m.IpAddresses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uris", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNodes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNodes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Uris = append(m.Uris, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Csr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNodes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNodes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Csr = append(m.Csr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Csr == nil {
+				m.Csr = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNodes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNodes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EnrollResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNodes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EnrollResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EnrollResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNodes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNodes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Node == nil {
+				m.Node = &Node{}
+			}
+			if err := m.Node.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chain", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNodes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNodes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Chain = append(m.Chain[:0], dAtA[iNdEx:postIndex]...)
+			if m.Chain == nil {
+				m.Chain = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNodes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNodes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipNodes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -708,21 +1340,31 @@ var (
 func init() { proto.RegisterFile("pkg/services/protos/nodes/nodes.proto", fileDescriptorNodes) }
 
 var fileDescriptorNodes = []byte{
-	// 254 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x2d, 0xc8, 0x4e, 0xd7,
-	0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0x2d, 0xd6, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0x2f, 0xd6,
-	0xcf, 0xcb, 0x4f, 0x49, 0x85, 0x92, 0x7a, 0x60, 0x21, 0x21, 0x56, 0x30, 0x47, 0xca, 0x27, 0x3d,
-	0xb3, 0x24, 0xa3, 0x34, 0x49, 0x2f, 0x39, 0x3f, 0x57, 0xbf, 0x34, 0x2f, 0xb5, 0xa8, 0x28, 0xbf,
-	0x48, 0xbf, 0x3c, 0x31, 0x2d, 0xad, 0x52, 0x1f, 0x9b, 0x31, 0xc9, 0xa9, 0x45, 0x25, 0x99, 0x69,
-	0x99, 0xc9, 0x89, 0x25, 0xa9, 0xa8, 0x1c, 0x88, 0xa1, 0x4a, 0xf1, 0x5c, 0x2c, 0x7e, 0xf9, 0x29,
-	0xa9, 0x42, 0x52, 0x5c, 0x1c, 0x19, 0xf9, 0xc5, 0x25, 0x79, 0x89, 0xb9, 0xa9, 0x12, 0x8c, 0x0a,
-	0x8c, 0x1a, 0x9c, 0x41, 0x70, 0xbe, 0x90, 0x35, 0x17, 0x37, 0x92, 0x4e, 0x09, 0x26, 0x05, 0x46,
-	0x0d, 0x6e, 0x23, 0x49, 0x3d, 0x14, 0xd3, 0x9c, 0x11, 0x9c, 0x20, 0x64, 0xd5, 0x4a, 0xf2, 0x5c,
-	0xdc, 0x5e, 0xf9, 0x99, 0x79, 0x41, 0xa9, 0x85, 0xa5, 0xa9, 0xc5, 0x25, 0x42, 0x02, 0x5c, 0xcc,
-	0xa5, 0x45, 0x39, 0x50, 0x2b, 0x40, 0x4c, 0x25, 0x15, 0x2e, 0x1e, 0x88, 0x82, 0xe2, 0x82, 0xfc,
-	0xbc, 0xe2, 0x54, 0x21, 0x11, 0x2e, 0x56, 0xb0, 0xa7, 0xa0, 0x6a, 0x20, 0x1c, 0x23, 0x3b, 0x88,
-	0x31, 0xc1, 0x10, 0xef, 0x09, 0xe9, 0x73, 0xb1, 0x80, 0xb8, 0x42, 0x42, 0x7a, 0x90, 0x10, 0x42,
-	0xb2, 0x42, 0x4a, 0x18, 0x45, 0x0c, 0x62, 0xaa, 0x93, 0xc0, 0x89, 0x47, 0x72, 0x8c, 0x17, 0x1e,
-	0xc9, 0x31, 0x3e, 0x78, 0x24, 0xc7, 0x38, 0xe3, 0xb1, 0x1c, 0x43, 0x12, 0x1b, 0x38, 0x00, 0x8c,
-	0x01, 0x01, 0x00, 0x00, 0xff, 0xff, 0x5d, 0xf6, 0x28, 0x3b, 0x7e, 0x01, 0x00, 0x00,
+	// 405 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x52, 0xdd, 0xaa, 0xd3, 0x40,
+	0x10, 0x76, 0x6d, 0x5a, 0x3d, 0x93, 0x28, 0x87, 0xf5, 0x1c, 0x88, 0x11, 0x72, 0x62, 0x50, 0xe8,
+	0x55, 0x16, 0x2a, 0x5e, 0x79, 0xa5, 0x22, 0x82, 0xc8, 0xb9, 0x48, 0x1f, 0xa0, 0xa4, 0xc9, 0xb6,
+	0x5d, 0x6d, 0x77, 0xe3, 0x4e, 0xa2, 0xf8, 0x26, 0x3e, 0x8f, 0x57, 0x5e, 0xfa, 0x08, 0x52, 0x5f,
+	0x44, 0xf6, 0x27, 0xda, 0x88, 0x78, 0x13, 0xe6, 0x9b, 0x6f, 0xe6, 0xdb, 0xf9, 0x66, 0x02, 0x8f,
+	0xdb, 0xf7, 0x5b, 0x86, 0x5c, 0x7f, 0x14, 0x35, 0x47, 0xd6, 0x6a, 0xd5, 0x29, 0x64, 0x52, 0x35,
+	0xdc, 0x7f, 0x0b, 0x9b, 0xa2, 0x53, 0x0b, 0x92, 0xb7, 0x5b, 0xd1, 0xed, 0xfa, 0x75, 0x51, 0xab,
+	0x03, 0xeb, 0x25, 0xd7, 0x5a, 0x69, 0xf6, 0xa9, 0xda, 0x6c, 0x3e, 0xb3, 0x7f, 0xc9, 0xd4, 0x5c,
+	0x77, 0x62, 0x23, 0xea, 0xaa, 0xe3, 0x63, 0xe0, 0x44, 0xf3, 0x15, 0x04, 0xd7, 0xaa, 0xe1, 0x34,
+	0x81, 0xdb, 0x3b, 0x85, 0x9d, 0xac, 0x0e, 0x3c, 0x26, 0x19, 0x99, 0x9f, 0x95, 0xbf, 0x31, 0x7d,
+	0x06, 0xe1, 0x49, 0x67, 0x7c, 0x33, 0x23, 0xf3, 0x70, 0x71, 0xbf, 0x18, 0xa9, 0xbd, 0xfc, 0x03,
+	0xca, 0xd3, 0xea, 0xfc, 0x0a, 0xc2, 0x37, 0x4a, 0xc8, 0x92, 0x7f, 0xe8, 0x39, 0x76, 0xf4, 0x1c,
+	0x26, 0xbd, 0xde, 0xfb, 0x27, 0x4c, 0x98, 0x3f, 0x82, 0xc8, 0x15, 0x60, 0xab, 0x24, 0x72, 0x7a,
+	0x01, 0x53, 0x6b, 0xca, 0xd7, 0x38, 0x90, 0x7f, 0x25, 0x70, 0xe7, 0x95, 0xd4, 0x6a, 0xbf, 0x1f,
+	0x94, 0xfe, 0x37, 0x31, 0x83, 0x5b, 0xd8, 0xaf, 0xdf, 0xf1, 0xba, 0xf3, 0xd3, 0x5e, 0x8e, 0xa7,
+	0x5d, 0x3a, 0xb2, 0x1c, 0xaa, 0xe8, 0x03, 0x38, 0x6b, 0x24, 0xae, 0x4c, 0x33, 0xc6, 0x93, 0x6c,
+	0x62, 0xd4, 0x1a, 0x89, 0xd7, 0x06, 0xd3, 0x87, 0x10, 0x89, 0x76, 0x55, 0x35, 0x8d, 0xe6, 0x88,
+	0x1c, 0xe3, 0xc0, 0xf2, 0xa1, 0x68, 0x9f, 0x0f, 0x29, 0x4a, 0x21, 0xe8, 0xb5, 0xc0, 0x78, 0x6a,
+	0x29, 0x1b, 0x1b, 0xab, 0x35, 0xea, 0x78, 0x96, 0x91, 0x79, 0x54, 0x9a, 0x30, 0x7f, 0x0d, 0x77,
+	0x07, 0x0f, 0xde, 0xec, 0x15, 0x04, 0xe6, 0xaa, 0xd6, 0x40, 0xb8, 0x08, 0x0b, 0x77, 0x6f, 0x73,
+	0x91, 0xd2, 0x12, 0x66, 0x1b, 0xf5, 0xae, 0x12, 0xd2, 0xfa, 0x88, 0x4a, 0x07, 0x16, 0xbd, 0x5b,
+	0xea, 0xd2, 0x1d, 0x9b, 0x32, 0x08, 0x0c, 0xa4, 0xd4, 0xf7, 0x9f, 0x2c, 0x3c, 0xb9, 0x37, 0xca,
+	0xf9, 0x67, 0x9f, 0xc2, 0xcc, 0x0d, 0x42, 0x2f, 0x3c, 0x3d, 0xda, 0x6d, 0x72, 0xf9, 0x57, 0xd6,
+	0xb5, 0xbd, 0x38, 0xff, 0x76, 0x4c, 0xc9, 0xf7, 0x63, 0x4a, 0x7e, 0x1c, 0x53, 0xf2, 0xe5, 0x67,
+	0x7a, 0x63, 0x3d, 0xb3, 0x7f, 0xd1, 0x93, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x67, 0xba, 0x07,
+	0x3b, 0xc3, 0x02, 0x00, 0x00,
 }
@@ -9,7 +9,65 @@
 
 	It has these top-level messages:
 		Site
+		ErrorPage
+		Maintenance
+		Redirect
+		Rewrite
+		HeaderRules
+		Header
+		SecurityHeaders
+		Compression
+		Cache
+		CachePurge
+		CacheStats
+		WebSocket
+		Affinity
+		ValueMatch
+		Route
+		Mirror
+		StatusClass
+		MirrorStats
+		WeightedUpstream
+		SplitRamp
+		Timeouts
+		RetryPolicy
+		CircuitBreaker
+		Resilience
+		HashPolicy
+		EndpointTLS
+		Endpoint
+		HealthCheck
+		OutlierDetection
+		Upstream
+		EndpointHealth
+		EndpointStatus
 		Balancer
+		SiteCertificate
+		AcmeAccount
+		UploadCertificateRequest
+		UploadCertificateResponse
+		DeleteCertificateRequest
+		DeleteCertificateResponse
+		CreateSiteRequest
+		GetSiteRequest
+		ListSitesRequest
+		SiteInfo
+		ListSitesResponse
+		UpdateSiteRequest
+		DeleteSiteRequest
+		DeleteSiteResponse
+		PutUpstreamRequest
+		DeleteUpstreamRequest
+		StatusRequest
+		StatusResponse
+		SetRoutesRequest
+		SetSplitRequest
+		MirrorStatsRequest
+		MirrorStatsResponse
+		PurgeCacheRequest
+		PurgeCacheResponse
+		CacheStatsRequest
+		CacheStatsResponse
 */
 package sites
 
@@ -18,6 +76,11 @@ import fmt "fmt"
 import math "math"
 import nodes "github.com/unerror/waffy/pkg/services/protos/nodes"
 
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
 import io "io"
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -31,12 +94,151 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// PathMatch is how a Route matches the request path
+type PathMatch int32
+
+const (
+	PathMatch_PREFIX PathMatch = 0
+	PathMatch_EXACT  PathMatch = 1
+	PathMatch_REGEX  PathMatch = 2
+)
+
+var PathMatch_name = map[int32]string{
+	0: "PREFIX",
+	1: "EXACT",
+	2: "REGEX",
+}
+var PathMatch_value = map[string]int32{
+	"PREFIX": 0,
+	"EXACT":  1,
+	"REGEX":  2,
+}
+
+func (x PathMatch) String() string {
+	return proto.EnumName(PathMatch_name, int32(x))
+}
+func (PathMatch) EnumDescriptor() ([]byte, []int) { return fileDescriptorSites, []int{0} }
+
+// Strategy is the algorithm that picks the Endpoint for a request
+type Strategy int32
+
+const (
+	Strategy_ROUND_ROBIN          Strategy = 0
+	Strategy_WEIGHTED_ROUND_ROBIN Strategy = 1
+	Strategy_LEAST_CONNECTIONS    Strategy = 2
+	Strategy_POWER_OF_TWO         Strategy = 3
+	Strategy_CONSISTENT_HASH      Strategy = 4
+)
+
+var Strategy_name = map[int32]string{
+	0: "ROUND_ROBIN",
+	1: "WEIGHTED_ROUND_ROBIN",
+	2: "LEAST_CONNECTIONS",
+	3: "POWER_OF_TWO",
+	4: "CONSISTENT_HASH",
+}
+var Strategy_value = map[string]int32{
+	"ROUND_ROBIN":          0,
+	"WEIGHTED_ROUND_ROBIN": 1,
+	"LEAST_CONNECTIONS":    2,
+	"POWER_OF_TWO":         3,
+	"CONSISTENT_HASH":      4,
+}
+
+func (x Strategy) String() string {
+	return proto.EnumName(Strategy_name, int32(x))
+}
+func (Strategy) EnumDescriptor() ([]byte, []int) { return fileDescriptorSites, []int{1} }
+
+// HashSource is the part of a request that is hashed for CONSISTENT_HASH
+type HashSource int32
+
+const (
+	HashSource_CLIENT_IP HashSource = 0
+	HashSource_HEADER    HashSource = 1
+	HashSource_COOKIE    HashSource = 2
+)
+
+var HashSource_name = map[int32]string{
+	0: "CLIENT_IP",
+	1: "HEADER",
+	2: "COOKIE",
+}
+var HashSource_value = map[string]int32{
+	"CLIENT_IP": 0,
+	"HEADER":    1,
+	"COOKIE":    2,
+}
+
+func (x HashSource) String() string {
+	return proto.EnumName(HashSource_name, int32(x))
+}
+func (HashSource) EnumDescriptor() ([]byte, []int) { return fileDescriptorSites, []int{2} }
+
+// HealthCheckType is how an Endpoint is actively checked
+type HealthCheckType int32
+
+const (
+	HealthCheckType_HTTP HealthCheckType = 0
+	HealthCheckType_TCP  HealthCheckType = 1
+)
+
+var HealthCheckType_name = map[int32]string{
+	0: "HTTP",
+	1: "TCP",
+}
+var HealthCheckType_value = map[string]int32{
+	"HTTP": 0,
+	"TCP":  1,
+}
+
+func (x HealthCheckType) String() string {
+	return proto.EnumName(HealthCheckType_name, int32(x))
+}
+func (HealthCheckType) EnumDescriptor() ([]byte, []int) { return fileDescriptorSites, []int{3} }
+
+// ForwardedHeader is the header the client address is resolved from, through trusted proxies
+type ForwardedHeader int32
+
+const (
+	ForwardedHeader_X_FORWARDED_FOR ForwardedHeader = 0
+	ForwardedHeader_FORWARDED       ForwardedHeader = 1
+)
+
+var ForwardedHeader_name = map[int32]string{
+	0: "X_FORWARDED_FOR",
+	1: "FORWARDED",
+}
+var ForwardedHeader_value = map[string]int32{
+	"X_FORWARDED_FOR": 0,
+	"FORWARDED":       1,
+}
+
+func (x ForwardedHeader) String() string {
+	return proto.EnumName(ForwardedHeader_name, int32(x))
+}
+func (ForwardedHeader) EnumDescriptor() ([]byte, []int) { return fileDescriptorSites, []int{4} }
+
 // Site represents a Site that should be load balanced, and have Rules applied to it
 type Site struct {
-	Hostname    string   `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	Alias       []string `protobuf:"bytes,2,rep,name=alias" json:"alias,omitempty"`
-	Secure      bool     `protobuf:"varint,5,opt,name=secure,proto3" json:"secure,omitempty"`
-	Autoencrypt bool     `protobuf:"varint,6,opt,name=autoencrypt,proto3" json:"autoencrypt,omitempty"`
+	Hostname        string           `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Alias           []string         `protobuf:"bytes,2,rep,name=alias" json:"alias,omitempty"`
+	Secure          bool             `protobuf:"varint,5,opt,name=secure,proto3" json:"secure,omitempty"`
+	Autoencrypt     bool             `protobuf:"varint,6,opt,name=autoencrypt,proto3" json:"autoencrypt,omitempty"`
+	Upstreams       []*Upstream      `protobuf:"bytes,7,rep,name=upstreams" json:"upstreams,omitempty"`
+	Resilience      *Resilience      `protobuf:"bytes,8,opt,name=resilience" json:"resilience,omitempty"`
+	Routes          []*Route         `protobuf:"bytes,9,rep,name=routes" json:"routes,omitempty"`
+	Affinity        *Affinity        `protobuf:"bytes,10,opt,name=affinity" json:"affinity,omitempty"`
+	Websocket       *WebSocket       `protobuf:"bytes,11,opt,name=websocket" json:"websocket,omitempty"`
+	Cache           *Cache           `protobuf:"bytes,12,opt,name=cache" json:"cache,omitempty"`
+	Compression     *Compression     `protobuf:"bytes,13,opt,name=compression" json:"compression,omitempty"`
+	Redirects       []*Redirect      `protobuf:"bytes,14,rep,name=redirects" json:"redirects,omitempty"`
+	Rewrites        []*Rewrite       `protobuf:"bytes,15,rep,name=rewrites" json:"rewrites,omitempty"`
+	RequestHeaders  *HeaderRules     `protobuf:"bytes,16,opt,name=request_headers,json=requestHeaders" json:"request_headers,omitempty"`
+	ResponseHeaders *HeaderRules     `protobuf:"bytes,17,opt,name=response_headers,json=responseHeaders" json:"response_headers,omitempty"`
+	SecurityHeaders *SecurityHeaders `protobuf:"bytes,18,opt,name=security_headers,json=securityHeaders" json:"security_headers,omitempty"`
+	ErrorPages      []*ErrorPage     `protobuf:"bytes,19,rep,name=error_pages,json=errorPages" json:"error_pages,omitempty"`
+	Maintenance     *Maintenance     `protobuf:"bytes,20,opt,name=maintenance" json:"maintenance,omitempty"`
 }
 
 func (m *Site) Reset()                    { *m = Site{} }
@@ -72,253 +274,16402 @@ func (m *Site) GetAutoencrypt() bool {
 	return false
 }
 
-// Balancer represents a Site load balancer
-type Balancer struct {
-	Proto string        `protobuf:"bytes,3,opt,name=proto,proto3" json:"proto,omitempty"`
-	Port  string        `protobuf:"bytes,4,opt,name=port,proto3" json:"port,omitempty"`
-	Sites []*Site       `protobuf:"bytes,1,rep,name=sites" json:"sites,omitempty"`
-	Notes []*nodes.Node `protobuf:"bytes,2,rep,name=notes" json:"notes,omitempty"`
+func (m *Site) GetUpstreams() []*Upstream {
+	if m != nil {
+		return m.Upstreams
+	}
+	return nil
 }
 
-func (m *Balancer) Reset()                    { *m = Balancer{} }
-func (m *Balancer) String() string            { return proto.CompactTextString(m) }
-func (*Balancer) ProtoMessage()               {}
-func (*Balancer) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{1} }
-
-func (m *Balancer) GetProto() string {
+func (m *Site) GetResilience() *Resilience {
 	if m != nil {
-		return m.Proto
+		return m.Resilience
 	}
-	return ""
+	return nil
 }
 
-func (m *Balancer) GetPort() string {
+func (m *Site) GetRoutes() []*Route {
 	if m != nil {
-		return m.Port
+		return m.Routes
 	}
-	return ""
+	return nil
 }
 
-func (m *Balancer) GetSites() []*Site {
+func (m *Site) GetAffinity() *Affinity {
 	if m != nil {
-		return m.Sites
+		return m.Affinity
 	}
 	return nil
 }
 
-func (m *Balancer) GetNotes() []*nodes.Node {
+func (m *Site) GetWebsocket() *WebSocket {
 	if m != nil {
-		return m.Notes
+		return m.Websocket
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Site)(nil), "sites.Site")
-	proto.RegisterType((*Balancer)(nil), "sites.Balancer")
-}
-func (m *Site) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Site) GetCache() *Cache {
+	if m != nil {
+		return m.Cache
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Site) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Hostname) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
-		i += copy(dAtA[i:], m.Hostname)
-	}
-	if len(m.Alias) > 0 {
-		for _, s := range m.Alias {
-			dAtA[i] = 0x12
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+func (m *Site) GetCompression() *Compression {
+	if m != nil {
+		return m.Compression
 	}
-	if m.Secure {
-		dAtA[i] = 0x28
-		i++
-		if m.Secure {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+	return nil
+}
+
+func (m *Site) GetRedirects() []*Redirect {
+	if m != nil {
+		return m.Redirects
 	}
-	if m.Autoencrypt {
-		dAtA[i] = 0x30
-		i++
-		if m.Autoencrypt {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+	return nil
+}
+
+func (m *Site) GetRewrites() []*Rewrite {
+	if m != nil {
+		return m.Rewrites
 	}
-	return i, nil
+	return nil
 }
 
-func (m *Balancer) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Site) GetRequestHeaders() *HeaderRules {
+	if m != nil {
+		return m.RequestHeaders
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Balancer) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Sites) > 0 {
-		for _, msg := range m.Sites {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+func (m *Site) GetResponseHeaders() *HeaderRules {
+	if m != nil {
+		return m.ResponseHeaders
 	}
-	if len(m.Notes) > 0 {
-		for _, msg := range m.Notes {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	return nil
+}
+
+func (m *Site) GetSecurityHeaders() *SecurityHeaders {
+	if m != nil {
+		return m.SecurityHeaders
 	}
-	if len(m.Proto) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintSites(dAtA, i, uint64(len(m.Proto)))
-		i += copy(dAtA[i:], m.Proto)
+	return nil
+}
+
+func (m *Site) GetErrorPages() []*ErrorPage {
+	if m != nil {
+		return m.ErrorPages
 	}
-	if len(m.Port) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintSites(dAtA, i, uint64(len(m.Port)))
-		i += copy(dAtA[i:], m.Port)
+	return nil
+}
+
+func (m *Site) GetMaintenance() *Maintenance {
+	if m != nil {
+		return m.Maintenance
 	}
-	return i, nil
+	return nil
 }
 
-func encodeFixed64Sites(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
+// ErrorPage is a template rendered for the responses the proxy answers requests to a Site with itself,
+// rather than those of the upstreams: 403 when a Balancer ACL or Route denies a request or the Site
+// refuses Upgrades, 429 when a request is rate limited, and 502, 503 or 504 when the upstreams fail,
+// are overloaded or have no healthy Endpoints. Templates
+// are Go templates, given the RequestID, Status, StatusText, Message, Method, Host, Path, ClientIP and
+// Time of the request, escaped as HTML when the page is HTML.
+type ErrorPage struct {
+	Status      uint32 `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Template    string `protobuf:"bytes,3,opt,name=template,proto3" json:"template,omitempty"`
 }
-func encodeFixed32Sites(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
+
+func (m *ErrorPage) Reset()                    { *m = ErrorPage{} }
+func (m *ErrorPage) String() string            { return proto.CompactTextString(m) }
+func (*ErrorPage) ProtoMessage()               {}
+func (*ErrorPage) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{1} }
+
+func (m *ErrorPage) GetStatus() uint32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
 }
-func encodeVarintSites(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+
+func (m *ErrorPage) GetContentType() string {
+	if m != nil {
+		return m.ContentType
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return ""
 }
-func (m *Site) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Hostname)
-	if l > 0 {
-		n += 1 + l + sovSites(uint64(l))
+
+func (m *ErrorPage) GetTemplate() string {
+	if m != nil {
+		return m.Template
 	}
-	if len(m.Alias) > 0 {
-		for _, s := range m.Alias {
-			l = len(s)
-			n += 1 + l + sovSites(uint64(l))
-		}
+	return ""
+}
+
+// Maintenance takes a Site down for maintenance, answering requests with a 503 page except from the
+// clients allowed to bypass it, who are served as usual
+type Maintenance struct {
+	Enabled     bool     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Allow       []string `protobuf:"bytes,2,rep,name=allow" json:"allow,omitempty"`
+	Users       []string `protobuf:"bytes,3,rep,name=users" json:"users,omitempty"`
+	Template    string   `protobuf:"bytes,4,opt,name=template,proto3" json:"template,omitempty"`
+	ContentType string   `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	RetryAfter  int64    `protobuf:"varint,6,opt,name=retry_after,json=retryAfter,proto3" json:"retry_after,omitempty"`
+}
+
+func (m *Maintenance) Reset()                    { *m = Maintenance{} }
+func (m *Maintenance) String() string            { return proto.CompactTextString(m) }
+func (*Maintenance) ProtoMessage()               {}
+func (*Maintenance) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{2} }
+
+func (m *Maintenance) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
 	}
-	if m.Secure {
-		n += 2
+	return false
+}
+
+func (m *Maintenance) GetAllow() []string {
+	if m != nil {
+		return m.Allow
 	}
-	if m.Autoencrypt {
-		n += 2
+	return nil
+}
+
+func (m *Maintenance) GetUsers() []string {
+	if m != nil {
+		return m.Users
 	}
-	return n
+	return nil
 }
 
-func (m *Balancer) Size() (n int) {
+func (m *Maintenance) GetTemplate() string {
+	if m != nil {
+		return m.Template
+	}
+	return ""
+}
+
+func (m *Maintenance) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *Maintenance) GetRetryAfter() int64 {
+	if m != nil {
+		return m.RetryAfter
+	}
+	return 0
+}
+
+// Redirect answers the requests whose path and query match it with a redirect to a URL made of its
+// replacement, host and scheme, or those of the request. Requests are only redirected when the URL
+// differs from theirs, so a Redirect to a host, such as to canonicalize www, only redirects requests
+// to other hosts, and one to https only redirects requests that were not made over https.
+type Redirect struct {
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Regex       string `protobuf:"bytes,2,opt,name=regex,proto3" json:"regex,omitempty"`
+	Replacement string `protobuf:"bytes,3,opt,name=replacement,proto3" json:"replacement,omitempty"`
+	Host        string `protobuf:"bytes,4,opt,name=host,proto3" json:"host,omitempty"`
+	Https       bool   `protobuf:"varint,5,opt,name=https,proto3" json:"https,omitempty"`
+	Status      uint32 `protobuf:"varint,6,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (m *Redirect) Reset()                    { *m = Redirect{} }
+func (m *Redirect) String() string            { return proto.CompactTextString(m) }
+func (*Redirect) ProtoMessage()               {}
+func (*Redirect) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{3} }
+
+func (m *Redirect) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Redirect) GetRegex() string {
+	if m != nil {
+		return m.Regex
+	}
+	return ""
+}
+
+func (m *Redirect) GetReplacement() string {
+	if m != nil {
+		return m.Replacement
+	}
+	return ""
+}
+
+func (m *Redirect) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *Redirect) GetHttps() bool {
+	if m != nil {
+		return m.Https
+	}
+	return false
+}
+
+func (m *Redirect) GetStatus() uint32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+// Rewrite replaces the regex match in the path and query of requests
+type Rewrite struct {
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Regex       string `protobuf:"bytes,2,opt,name=regex,proto3" json:"regex,omitempty"`
+	Replacement string `protobuf:"bytes,3,opt,name=replacement,proto3" json:"replacement,omitempty"`
+}
+
+func (m *Rewrite) Reset()                    { *m = Rewrite{} }
+func (m *Rewrite) String() string            { return proto.CompactTextString(m) }
+func (*Rewrite) ProtoMessage()               {}
+func (*Rewrite) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{4} }
+
+func (m *Rewrite) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Rewrite) GetRegex() string {
+	if m != nil {
+		return m.Regex
+	}
+	return ""
+}
+
+func (m *Rewrite) GetReplacement() string {
+	if m != nil {
+		return m.Replacement
+	}
+	return ""
+}
+
+// HeaderRules change the headers of requests or responses, removing, then setting, then adding them
+type HeaderRules struct {
+	Remove []string  `protobuf:"bytes,1,rep,name=remove" json:"remove,omitempty"`
+	Set    []*Header `protobuf:"bytes,2,rep,name=set" json:"set,omitempty"`
+	Add    []*Header `protobuf:"bytes,3,rep,name=add" json:"add,omitempty"`
+}
+
+func (m *HeaderRules) Reset()                    { *m = HeaderRules{} }
+func (m *HeaderRules) String() string            { return proto.CompactTextString(m) }
+func (*HeaderRules) ProtoMessage()               {}
+func (*HeaderRules) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{5} }
+
+func (m *HeaderRules) GetRemove() []string {
+	if m != nil {
+		return m.Remove
+	}
+	return nil
+}
+
+func (m *HeaderRules) GetSet() []*Header {
+	if m != nil {
+		return m.Set
+	}
+	return nil
+}
+
+func (m *HeaderRules) GetAdd() []*Header {
+	if m != nil {
+		return m.Add
+	}
+	return nil
+}
+
+// Header is a line of a header
+type Header struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *Header) Reset()                    { *m = Header{} }
+func (m *Header) String() string            { return proto.CompactTextString(m) }
+func (*Header) ProtoMessage()               {}
+func (*Header) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{6} }
+
+func (m *Header) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Header) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// SecurityHeaders are the security headers set on the responses of a Site, replacing any set by the
+// upstreams
+type SecurityHeaders struct {
+	HstsMaxAge            int64  `protobuf:"varint,1,opt,name=hsts_max_age,json=hstsMaxAge,proto3" json:"hsts_max_age,omitempty"`
+	HstsIncludeSubdomains bool   `protobuf:"varint,2,opt,name=hsts_include_subdomains,json=hstsIncludeSubdomains,proto3" json:"hsts_include_subdomains,omitempty"`
+	HstsPreload           bool   `protobuf:"varint,3,opt,name=hsts_preload,json=hstsPreload,proto3" json:"hsts_preload,omitempty"`
+	ContentSecurityPolicy string `protobuf:"bytes,4,opt,name=content_security_policy,json=contentSecurityPolicy,proto3" json:"content_security_policy,omitempty"`
+	FrameOptions          string `protobuf:"bytes,5,opt,name=frame_options,json=frameOptions,proto3" json:"frame_options,omitempty"`
+	Nosniff               bool   `protobuf:"varint,6,opt,name=nosniff,proto3" json:"nosniff,omitempty"`
+	ReferrerPolicy        string `protobuf:"bytes,7,opt,name=referrer_policy,json=referrerPolicy,proto3" json:"referrer_policy,omitempty"`
+	PermissionsPolicy     string `protobuf:"bytes,8,opt,name=permissions_policy,json=permissionsPolicy,proto3" json:"permissions_policy,omitempty"`
+}
+
+func (m *SecurityHeaders) Reset()                    { *m = SecurityHeaders{} }
+func (m *SecurityHeaders) String() string            { return proto.CompactTextString(m) }
+func (*SecurityHeaders) ProtoMessage()               {}
+func (*SecurityHeaders) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{7} }
+
+func (m *SecurityHeaders) GetHstsMaxAge() int64 {
+	if m != nil {
+		return m.HstsMaxAge
+	}
+	return 0
+}
+
+func (m *SecurityHeaders) GetHstsIncludeSubdomains() bool {
+	if m != nil {
+		return m.HstsIncludeSubdomains
+	}
+	return false
+}
+
+func (m *SecurityHeaders) GetHstsPreload() bool {
+	if m != nil {
+		return m.HstsPreload
+	}
+	return false
+}
+
+func (m *SecurityHeaders) GetContentSecurityPolicy() string {
+	if m != nil {
+		return m.ContentSecurityPolicy
+	}
+	return ""
+}
+
+func (m *SecurityHeaders) GetFrameOptions() string {
+	if m != nil {
+		return m.FrameOptions
+	}
+	return ""
+}
+
+func (m *SecurityHeaders) GetNosniff() bool {
+	if m != nil {
+		return m.Nosniff
+	}
+	return false
+}
+
+func (m *SecurityHeaders) GetReferrerPolicy() string {
+	if m != nil {
+		return m.ReferrerPolicy
+	}
+	return ""
+}
+
+func (m *SecurityHeaders) GetPermissionsPolicy() string {
+	if m != nil {
+		return m.PermissionsPolicy
+	}
+	return ""
+}
+
+// Compression is how the proxy compresses the responses of a Site for clients whose Accept-Encoding
+// accepts it. Responses that are already encoded, or of types that are already compressed, such as
+// images and archives, are never compressed.
+type Compression struct {
+	Encodings    []string `protobuf:"bytes,1,rep,name=encodings" json:"encodings,omitempty"`
+	ContentTypes []string `protobuf:"bytes,2,rep,name=content_types,json=contentTypes" json:"content_types,omitempty"`
+	MinSize      int64    `protobuf:"varint,3,opt,name=min_size,json=minSize,proto3" json:"min_size,omitempty"`
+}
+
+func (m *Compression) Reset()                    { *m = Compression{} }
+func (m *Compression) String() string            { return proto.CompactTextString(m) }
+func (*Compression) ProtoMessage()               {}
+func (*Compression) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{8} }
+
+func (m *Compression) GetEncodings() []string {
+	if m != nil {
+		return m.Encodings
+	}
+	return nil
+}
+
+func (m *Compression) GetContentTypes() []string {
+	if m != nil {
+		return m.ContentTypes
+	}
+	return nil
+}
+
+func (m *Compression) GetMinSize() int64 {
+	if m != nil {
+		return m.MinSize
+	}
+	return 0
+}
+
+// Cache is how the proxy caches the responses of a Site, as a shared cache honoring Cache-Control,
+// Expires and Vary, and revalidating stale responses with their ETag or Last-Modified
+type Cache struct {
+	MaxSize       int64  `protobuf:"varint,1,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	MaxObjectSize int64  `protobuf:"varint,2,opt,name=max_object_size,json=maxObjectSize,proto3" json:"max_object_size,omitempty"`
+	DefaultTtl    int64  `protobuf:"varint,3,opt,name=default_ttl,json=defaultTtl,proto3" json:"default_ttl,omitempty"`
+	DiskPath      string `protobuf:"bytes,4,opt,name=disk_path,json=diskPath,proto3" json:"disk_path,omitempty"`
+	DiskMaxSize   int64  `protobuf:"varint,5,opt,name=disk_max_size,json=diskMaxSize,proto3" json:"disk_max_size,omitempty"`
+	TagHeader     string `protobuf:"bytes,6,opt,name=tag_header,json=tagHeader,proto3" json:"tag_header,omitempty"`
+}
+
+func (m *Cache) Reset()                    { *m = Cache{} }
+func (m *Cache) String() string            { return proto.CompactTextString(m) }
+func (*Cache) ProtoMessage()               {}
+func (*Cache) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{9} }
+
+func (m *Cache) GetMaxSize() int64 {
+	if m != nil {
+		return m.MaxSize
+	}
+	return 0
+}
+
+func (m *Cache) GetMaxObjectSize() int64 {
+	if m != nil {
+		return m.MaxObjectSize
+	}
+	return 0
+}
+
+func (m *Cache) GetDefaultTtl() int64 {
+	if m != nil {
+		return m.DefaultTtl
+	}
+	return 0
+}
+
+func (m *Cache) GetDiskPath() string {
+	if m != nil {
+		return m.DiskPath
+	}
+	return ""
+}
+
+func (m *Cache) GetDiskMaxSize() int64 {
+	if m != nil {
+		return m.DiskMaxSize
+	}
+	return 0
+}
+
+func (m *Cache) GetTagHeader() string {
+	if m != nil {
+		return m.TagHeader
+	}
+	return ""
+}
+
+// CachePurge removes the cached responses of a Site matching any of its URLs, prefixes or tags, or
+// every response if it has none. Purges are stored so every node applies them.
+type CachePurge struct {
+	Id        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hostname  string   `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Urls      []string `protobuf:"bytes,3,rep,name=urls" json:"urls,omitempty"`
+	Prefixes  []string `protobuf:"bytes,4,rep,name=prefixes" json:"prefixes,omitempty"`
+	Tags      []string `protobuf:"bytes,5,rep,name=tags" json:"tags,omitempty"`
+	CreatedAt int64    `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (m *CachePurge) Reset()                    { *m = CachePurge{} }
+func (m *CachePurge) String() string            { return proto.CompactTextString(m) }
+func (*CachePurge) ProtoMessage()               {}
+func (*CachePurge) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{10} }
+
+func (m *CachePurge) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CachePurge) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *CachePurge) GetUrls() []string {
+	if m != nil {
+		return m.Urls
+	}
+	return nil
+}
+
+func (m *CachePurge) GetPrefixes() []string {
+	if m != nil {
+		return m.Prefixes
+	}
+	return nil
+}
+
+func (m *CachePurge) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *CachePurge) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+// CacheStats are the stats of the cache of a Site, on a node
+type CacheStats struct {
+	Hostname    string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Hits        uint64 `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	Misses      uint64 `protobuf:"varint,3,opt,name=misses,proto3" json:"misses,omitempty"`
+	Revalidated uint64 `protobuf:"varint,4,opt,name=revalidated,proto3" json:"revalidated,omitempty"`
+	Stored      uint64 `protobuf:"varint,5,opt,name=stored,proto3" json:"stored,omitempty"`
+	Evicted     uint64 `protobuf:"varint,6,opt,name=evicted,proto3" json:"evicted,omitempty"`
+	Purged      uint64 `protobuf:"varint,7,opt,name=purged,proto3" json:"purged,omitempty"`
+	Entries     uint64 `protobuf:"varint,8,opt,name=entries,proto3" json:"entries,omitempty"`
+	Bytes       uint64 `protobuf:"varint,9,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	DiskEntries uint64 `protobuf:"varint,10,opt,name=disk_entries,json=diskEntries,proto3" json:"disk_entries,omitempty"`
+	DiskBytes   uint64 `protobuf:"varint,11,opt,name=disk_bytes,json=diskBytes,proto3" json:"disk_bytes,omitempty"`
+}
+
+func (m *CacheStats) Reset()                    { *m = CacheStats{} }
+func (m *CacheStats) String() string            { return proto.CompactTextString(m) }
+func (*CacheStats) ProtoMessage()               {}
+func (*CacheStats) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{11} }
+
+func (m *CacheStats) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *CacheStats) GetHits() uint64 {
+	if m != nil {
+		return m.Hits
+	}
+	return 0
+}
+
+func (m *CacheStats) GetMisses() uint64 {
+	if m != nil {
+		return m.Misses
+	}
+	return 0
+}
+
+func (m *CacheStats) GetRevalidated() uint64 {
+	if m != nil {
+		return m.Revalidated
+	}
+	return 0
+}
+
+func (m *CacheStats) GetStored() uint64 {
+	if m != nil {
+		return m.Stored
+	}
+	return 0
+}
+
+func (m *CacheStats) GetEvicted() uint64 {
+	if m != nil {
+		return m.Evicted
+	}
+	return 0
+}
+
+func (m *CacheStats) GetPurged() uint64 {
+	if m != nil {
+		return m.Purged
+	}
+	return 0
+}
+
+func (m *CacheStats) GetEntries() uint64 {
+	if m != nil {
+		return m.Entries
+	}
+	return 0
+}
+
+func (m *CacheStats) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *CacheStats) GetDiskEntries() uint64 {
+	if m != nil {
+		return m.DiskEntries
+	}
+	return 0
+}
+
+func (m *CacheStats) GetDiskBytes() uint64 {
+	if m != nil {
+		return m.DiskBytes
+	}
+	return 0
+}
+
+// WebSocket is how Upgrade requests, such as WebSocket handshakes, are tunneled to an Endpoint, and
+// the limits on the frames clients send through the tunnel
+type WebSocket struct {
+	Disabled       bool     `protobuf:"varint,1,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	IdleTimeout    int64    `protobuf:"varint,2,opt,name=idle_timeout,json=idleTimeout,proto3" json:"idle_timeout,omitempty"`
+	MaxFrameSize   int64    `protobuf:"varint,3,opt,name=max_frame_size,json=maxFrameSize,proto3" json:"max_frame_size,omitempty"`
+	MaxMessageSize int64    `protobuf:"varint,4,opt,name=max_message_size,json=maxMessageSize,proto3" json:"max_message_size,omitempty"`
+	Deny           []string `protobuf:"bytes,5,rep,name=deny" json:"deny,omitempty"`
+}
+
+func (m *WebSocket) Reset()                    { *m = WebSocket{} }
+func (m *WebSocket) String() string            { return proto.CompactTextString(m) }
+func (*WebSocket) ProtoMessage()               {}
+func (*WebSocket) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{12} }
+
+func (m *WebSocket) GetDisabled() bool {
+	if m != nil {
+		return m.Disabled
+	}
+	return false
+}
+
+func (m *WebSocket) GetIdleTimeout() int64 {
+	if m != nil {
+		return m.IdleTimeout
+	}
+	return 0
+}
+
+func (m *WebSocket) GetMaxFrameSize() int64 {
+	if m != nil {
+		return m.MaxFrameSize
+	}
+	return 0
+}
+
+func (m *WebSocket) GetMaxMessageSize() int64 {
+	if m != nil {
+		return m.MaxMessageSize
+	}
+	return 0
+}
+
+func (m *WebSocket) GetDeny() []string {
+	if m != nil {
+		return m.Deny
+	}
+	return nil
+}
+
+// Affinity pins a client to the Endpoint that served it with a signed cookie, until the Endpoint is
+// unavailable
+type Affinity struct {
+	Cookie string `protobuf:"bytes,1,opt,name=cookie,proto3" json:"cookie,omitempty"`
+	Ttl    int64  `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+}
+
+func (m *Affinity) Reset()                    { *m = Affinity{} }
+func (m *Affinity) String() string            { return proto.CompactTextString(m) }
+func (*Affinity) ProtoMessage()               {}
+func (*Affinity) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{13} }
+
+func (m *Affinity) GetCookie() string {
+	if m != nil {
+		return m.Cookie
+	}
+	return ""
+}
+
+func (m *Affinity) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+// ValueMatch matches a request header or query parameter
+type ValueMatch struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Regex bool   `protobuf:"varint,3,opt,name=regex,proto3" json:"regex,omitempty"`
+}
+
+func (m *ValueMatch) Reset()                    { *m = ValueMatch{} }
+func (m *ValueMatch) String() string            { return proto.CompactTextString(m) }
+func (*ValueMatch) ProtoMessage()               {}
+func (*ValueMatch) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{14} }
+
+func (m *ValueMatch) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ValueMatch) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *ValueMatch) GetRegex() bool {
+	if m != nil {
+		return m.Regex
+	}
+	return false
+}
+
+// Route sends the requests of a Site that match it to an Upstream
+type Route struct {
+	Name        string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	PathMatch   PathMatch           `protobuf:"varint,2,opt,name=path_match,json=pathMatch,proto3,enum=sites.PathMatch" json:"path_match,omitempty"`
+	Path        string              `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Methods     []string            `protobuf:"bytes,4,rep,name=methods" json:"methods,omitempty"`
+	Headers     []*ValueMatch       `protobuf:"bytes,5,rep,name=headers" json:"headers,omitempty"`
+	Query       []*ValueMatch       `protobuf:"bytes,6,rep,name=query" json:"query,omitempty"`
+	Upstream    string              `protobuf:"bytes,7,opt,name=upstream,proto3" json:"upstream,omitempty"`
+	StripPrefix bool                `protobuf:"varint,8,opt,name=strip_prefix,json=stripPrefix,proto3" json:"strip_prefix,omitempty"`
+	Rewrite     string              `protobuf:"bytes,9,opt,name=rewrite,proto3" json:"rewrite,omitempty"`
+	Resilience  *Resilience         `protobuf:"bytes,10,opt,name=resilience" json:"resilience,omitempty"`
+	Split       []*WeightedUpstream `protobuf:"bytes,11,rep,name=split" json:"split,omitempty"`
+	Sticky      *HashPolicy         `protobuf:"bytes,12,opt,name=sticky" json:"sticky,omitempty"`
+	Ramp        *SplitRamp          `protobuf:"bytes,13,opt,name=ramp" json:"ramp,omitempty"`
+	Mirror      *Mirror             `protobuf:"bytes,14,opt,name=mirror" json:"mirror,omitempty"`
+	Deny        bool                `protobuf:"varint,15,opt,name=deny,proto3" json:"deny,omitempty"`
+}
+
+func (m *Route) Reset()                    { *m = Route{} }
+func (m *Route) String() string            { return proto.CompactTextString(m) }
+func (*Route) ProtoMessage()               {}
+func (*Route) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{15} }
+
+func (m *Route) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Route) GetPathMatch() PathMatch {
+	if m != nil {
+		return m.PathMatch
+	}
+	return PathMatch_PREFIX
+}
+
+func (m *Route) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Route) GetMethods() []string {
+	if m != nil {
+		return m.Methods
+	}
+	return nil
+}
+
+func (m *Route) GetHeaders() []*ValueMatch {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *Route) GetQuery() []*ValueMatch {
+	if m != nil {
+		return m.Query
+	}
+	return nil
+}
+
+func (m *Route) GetUpstream() string {
+	if m != nil {
+		return m.Upstream
+	}
+	return ""
+}
+
+func (m *Route) GetStripPrefix() bool {
+	if m != nil {
+		return m.StripPrefix
+	}
+	return false
+}
+
+func (m *Route) GetRewrite() string {
+	if m != nil {
+		return m.Rewrite
+	}
+	return ""
+}
+
+func (m *Route) GetResilience() *Resilience {
+	if m != nil {
+		return m.Resilience
+	}
+	return nil
+}
+
+func (m *Route) GetSplit() []*WeightedUpstream {
+	if m != nil {
+		return m.Split
+	}
+	return nil
+}
+
+func (m *Route) GetSticky() *HashPolicy {
+	if m != nil {
+		return m.Sticky
+	}
+	return nil
+}
+
+func (m *Route) GetRamp() *SplitRamp {
+	if m != nil {
+		return m.Ramp
+	}
+	return nil
+}
+
+func (m *Route) GetMirror() *Mirror {
+	if m != nil {
+		return m.Mirror
+	}
+	return nil
+}
+
+func (m *Route) GetDeny() bool {
+	if m != nil {
+		return m.Deny
+	}
+	return false
+}
+
+// Mirror sends copies of a sample of the requests of a Route to a shadow Upstream, after the primary
+// response, discarding the shadow responses
+type Mirror struct {
+	Upstream string `protobuf:"bytes,1,opt,name=upstream,proto3" json:"upstream,omitempty"`
+	Percent  int32  `protobuf:"varint,2,opt,name=percent,proto3" json:"percent,omitempty"`
+}
+
+func (m *Mirror) Reset()                    { *m = Mirror{} }
+func (m *Mirror) String() string            { return proto.CompactTextString(m) }
+func (*Mirror) ProtoMessage()               {}
+func (*Mirror) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{16} }
+
+func (m *Mirror) GetUpstream() string {
+	if m != nil {
+		return m.Upstream
+	}
+	return ""
+}
+
+func (m *Mirror) GetPercent() int32 {
+	if m != nil {
+		return m.Percent
+	}
+	return 0
+}
+
+// StatusClass counts the responses of a class of status codes, such as 2xx
+type StatusClass struct {
+	Class   string `protobuf:"bytes,1,opt,name=class,proto3" json:"class,omitempty"`
+	Primary uint64 `protobuf:"varint,2,opt,name=primary,proto3" json:"primary,omitempty"`
+	Shadow  uint64 `protobuf:"varint,3,opt,name=shadow,proto3" json:"shadow,omitempty"`
+}
+
+func (m *StatusClass) Reset()                    { *m = StatusClass{} }
+func (m *StatusClass) String() string            { return proto.CompactTextString(m) }
+func (*StatusClass) ProtoMessage()               {}
+func (*StatusClass) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{17} }
+
+func (m *StatusClass) GetClass() string {
+	if m != nil {
+		return m.Class
+	}
+	return ""
+}
+
+func (m *StatusClass) GetPrimary() uint64 {
+	if m != nil {
+		return m.Primary
+	}
+	return 0
+}
+
+func (m *StatusClass) GetShadow() uint64 {
+	if m != nil {
+		return m.Shadow
+	}
+	return 0
+}
+
+// MirrorStats compare the primary and shadow responses of the mirrored requests of a Route, on a node
+type MirrorStats struct {
+	Hostname       string         `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Route          string         `protobuf:"bytes,2,opt,name=route,proto3" json:"route,omitempty"`
+	Upstream       string         `protobuf:"bytes,3,opt,name=upstream,proto3" json:"upstream,omitempty"`
+	Mirrored       uint64         `protobuf:"varint,4,opt,name=mirrored,proto3" json:"mirrored,omitempty"`
+	Dropped        uint64         `protobuf:"varint,5,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	Failed         uint64         `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	Matched        uint64         `protobuf:"varint,7,opt,name=matched,proto3" json:"matched,omitempty"`
+	Mismatched     uint64         `protobuf:"varint,8,opt,name=mismatched,proto3" json:"mismatched,omitempty"`
+	Statuses       []*StatusClass `protobuf:"bytes,9,rep,name=statuses" json:"statuses,omitempty"`
+	PrimaryLatency int64          `protobuf:"varint,10,opt,name=primary_latency,json=primaryLatency,proto3" json:"primary_latency,omitempty"`
+	ShadowLatency  int64          `protobuf:"varint,11,opt,name=shadow_latency,json=shadowLatency,proto3" json:"shadow_latency,omitempty"`
+}
+
+func (m *MirrorStats) Reset()                    { *m = MirrorStats{} }
+func (m *MirrorStats) String() string            { return proto.CompactTextString(m) }
+func (*MirrorStats) ProtoMessage()               {}
+func (*MirrorStats) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{18} }
+
+func (m *MirrorStats) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *MirrorStats) GetRoute() string {
+	if m != nil {
+		return m.Route
+	}
+	return ""
+}
+
+func (m *MirrorStats) GetUpstream() string {
+	if m != nil {
+		return m.Upstream
+	}
+	return ""
+}
+
+func (m *MirrorStats) GetMirrored() uint64 {
+	if m != nil {
+		return m.Mirrored
+	}
+	return 0
+}
+
+func (m *MirrorStats) GetDropped() uint64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+func (m *MirrorStats) GetFailed() uint64 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+func (m *MirrorStats) GetMatched() uint64 {
+	if m != nil {
+		return m.Matched
+	}
+	return 0
+}
+
+func (m *MirrorStats) GetMismatched() uint64 {
+	if m != nil {
+		return m.Mismatched
+	}
+	return 0
+}
+
+func (m *MirrorStats) GetStatuses() []*StatusClass {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+func (m *MirrorStats) GetPrimaryLatency() int64 {
+	if m != nil {
+		return m.PrimaryLatency
+	}
+	return 0
+}
+
+func (m *MirrorStats) GetShadowLatency() int64 {
+	if m != nil {
+		return m.ShadowLatency
+	}
+	return 0
+}
+
+// WeightedUpstream is an Upstream that a Route sends a share of its requests to
+type WeightedUpstream struct {
+	Upstream string `protobuf:"bytes,1,opt,name=upstream,proto3" json:"upstream,omitempty"`
+	Weight   uint32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (m *WeightedUpstream) Reset()                    { *m = WeightedUpstream{} }
+func (m *WeightedUpstream) String() string            { return proto.CompactTextString(m) }
+func (*WeightedUpstream) ProtoMessage()               {}
+func (*WeightedUpstream) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{19} }
+
+func (m *WeightedUpstream) GetUpstream() string {
+	if m != nil {
+		return m.Upstream
+	}
+	return ""
+}
+
+func (m *WeightedUpstream) GetWeight() uint32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+// SplitRamp moves the weights of a split linearly from earlier weights to those of the split
+type SplitRamp struct {
+	From  []*WeightedUpstream `protobuf:"bytes,1,rep,name=from" json:"from,omitempty"`
+	Start int64               `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End   int64               `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (m *SplitRamp) Reset()                    { *m = SplitRamp{} }
+func (m *SplitRamp) String() string            { return proto.CompactTextString(m) }
+func (*SplitRamp) ProtoMessage()               {}
+func (*SplitRamp) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{20} }
+
+func (m *SplitRamp) GetFrom() []*WeightedUpstream {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *SplitRamp) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *SplitRamp) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+// Timeouts bound the time spent proxying a request, in milliseconds
+type Timeouts struct {
+	Connect int64 `protobuf:"varint,1,opt,name=connect,proto3" json:"connect,omitempty"`
+	PerTry  int64 `protobuf:"varint,2,opt,name=per_try,json=perTry,proto3" json:"per_try,omitempty"`
+	Overall int64 `protobuf:"varint,3,opt,name=overall,proto3" json:"overall,omitempty"`
+}
+
+func (m *Timeouts) Reset()                    { *m = Timeouts{} }
+func (m *Timeouts) String() string            { return proto.CompactTextString(m) }
+func (*Timeouts) ProtoMessage()               {}
+func (*Timeouts) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{21} }
+
+func (m *Timeouts) GetConnect() int64 {
+	if m != nil {
+		return m.Connect
+	}
+	return 0
+}
+
+func (m *Timeouts) GetPerTry() int64 {
+	if m != nil {
+		return m.PerTry
+	}
+	return 0
+}
+
+func (m *Timeouts) GetOverall() int64 {
+	if m != nil {
+		return m.Overall
+	}
+	return 0
+}
+
+// RetryPolicy retries idempotent requests that fail to connect, time out, or get a 502, 503 or 504
+type RetryPolicy struct {
+	Attempts      uint32 `protobuf:"varint,1,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	BudgetPercent uint32 `protobuf:"varint,2,opt,name=budget_percent,json=budgetPercent,proto3" json:"budget_percent,omitempty"`
+	MinRetries    uint32 `protobuf:"varint,3,opt,name=min_retries,json=minRetries,proto3" json:"min_retries,omitempty"`
+	BaseBackoff   int64  `protobuf:"varint,4,opt,name=base_backoff,json=baseBackoff,proto3" json:"base_backoff,omitempty"`
+	MaxBackoff    int64  `protobuf:"varint,5,opt,name=max_backoff,json=maxBackoff,proto3" json:"max_backoff,omitempty"`
+}
+
+func (m *RetryPolicy) Reset()                    { *m = RetryPolicy{} }
+func (m *RetryPolicy) String() string            { return proto.CompactTextString(m) }
+func (*RetryPolicy) ProtoMessage()               {}
+func (*RetryPolicy) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{22} }
+
+func (m *RetryPolicy) GetAttempts() uint32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetBudgetPercent() uint32 {
+	if m != nil {
+		return m.BudgetPercent
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetMinRetries() uint32 {
+	if m != nil {
+		return m.MinRetries
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetBaseBackoff() int64 {
+	if m != nil {
+		return m.BaseBackoff
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetMaxBackoff() int64 {
+	if m != nil {
+		return m.MaxBackoff
+	}
+	return 0
+}
+
+// CircuitBreaker rejects requests to an Upstream with a 503 while it is overloaded or failing
+type CircuitBreaker struct {
+	MaxPending     uint32 `protobuf:"varint,1,opt,name=max_pending,json=maxPending,proto3" json:"max_pending,omitempty"`
+	MaxConnections uint32 `protobuf:"varint,2,opt,name=max_connections,json=maxConnections,proto3" json:"max_connections,omitempty"`
+	ErrorPercent   uint32 `protobuf:"varint,3,opt,name=error_percent,json=errorPercent,proto3" json:"error_percent,omitempty"`
+	MinRequests    uint32 `protobuf:"varint,4,opt,name=min_requests,json=minRequests,proto3" json:"min_requests,omitempty"`
+	Window         int64  `protobuf:"varint,5,opt,name=window,proto3" json:"window,omitempty"`
+	Open           int64  `protobuf:"varint,6,opt,name=open,proto3" json:"open,omitempty"`
+}
+
+func (m *CircuitBreaker) Reset()                    { *m = CircuitBreaker{} }
+func (m *CircuitBreaker) String() string            { return proto.CompactTextString(m) }
+func (*CircuitBreaker) ProtoMessage()               {}
+func (*CircuitBreaker) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{23} }
+
+func (m *CircuitBreaker) GetMaxPending() uint32 {
+	if m != nil {
+		return m.MaxPending
+	}
+	return 0
+}
+
+func (m *CircuitBreaker) GetMaxConnections() uint32 {
+	if m != nil {
+		return m.MaxConnections
+	}
+	return 0
+}
+
+func (m *CircuitBreaker) GetErrorPercent() uint32 {
+	if m != nil {
+		return m.ErrorPercent
+	}
+	return 0
+}
+
+func (m *CircuitBreaker) GetMinRequests() uint32 {
+	if m != nil {
+		return m.MinRequests
+	}
+	return 0
+}
+
+func (m *CircuitBreaker) GetWindow() int64 {
+	if m != nil {
+		return m.Window
+	}
+	return 0
+}
+
+func (m *CircuitBreaker) GetOpen() int64 {
+	if m != nil {
+		return m.Open
+	}
+	return 0
+}
+
+// Resilience are the timeouts, retries and circuit breaking of requests to the Upstreams of a Site
+type Resilience struct {
+	Timeouts       *Timeouts       `protobuf:"bytes,1,opt,name=timeouts" json:"timeouts,omitempty"`
+	Retry          *RetryPolicy    `protobuf:"bytes,2,opt,name=retry" json:"retry,omitempty"`
+	CircuitBreaker *CircuitBreaker `protobuf:"bytes,3,opt,name=circuit_breaker,json=circuitBreaker" json:"circuit_breaker,omitempty"`
+}
+
+func (m *Resilience) Reset()                    { *m = Resilience{} }
+func (m *Resilience) String() string            { return proto.CompactTextString(m) }
+func (*Resilience) ProtoMessage()               {}
+func (*Resilience) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{24} }
+
+func (m *Resilience) GetTimeouts() *Timeouts {
+	if m != nil {
+		return m.Timeouts
+	}
+	return nil
+}
+
+func (m *Resilience) GetRetry() *RetryPolicy {
+	if m != nil {
+		return m.Retry
+	}
+	return nil
+}
+
+func (m *Resilience) GetCircuitBreaker() *CircuitBreaker {
+	if m != nil {
+		return m.CircuitBreaker
+	}
+	return nil
+}
+
+// HashPolicy is the key requests are hashed on for CONSISTENT_HASH
+type HashPolicy struct {
+	Source HashSource `protobuf:"varint,1,opt,name=source,proto3,enum=sites.HashSource" json:"source,omitempty"`
+	Name   string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (m *HashPolicy) Reset()                    { *m = HashPolicy{} }
+func (m *HashPolicy) String() string            { return proto.CompactTextString(m) }
+func (*HashPolicy) ProtoMessage()               {}
+func (*HashPolicy) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{25} }
+
+func (m *HashPolicy) GetSource() HashSource {
+	if m != nil {
+		return m.Source
+	}
+	return HashSource_CLIENT_IP
+}
+
+func (m *HashPolicy) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// EndpointTLS is how the proxy connects to an Endpoint over TLS
+type EndpointTLS struct {
+	ServerName         string `protobuf:"bytes,1,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`
+	Ca                 []byte `protobuf:"bytes,2,opt,name=ca,proto3" json:"ca,omitempty"`
+	InsecureSkipVerify bool   `protobuf:"varint,3,opt,name=insecure_skip_verify,json=insecureSkipVerify,proto3" json:"insecure_skip_verify,omitempty"`
+}
+
+func (m *EndpointTLS) Reset()                    { *m = EndpointTLS{} }
+func (m *EndpointTLS) String() string            { return proto.CompactTextString(m) }
+func (*EndpointTLS) ProtoMessage()               {}
+func (*EndpointTLS) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{26} }
+
+func (m *EndpointTLS) GetServerName() string {
+	if m != nil {
+		return m.ServerName
+	}
+	return ""
+}
+
+func (m *EndpointTLS) GetCa() []byte {
+	if m != nil {
+		return m.Ca
+	}
+	return nil
+}
+
+func (m *EndpointTLS) GetInsecureSkipVerify() bool {
+	if m != nil {
+		return m.InsecureSkipVerify
+	}
+	return false
+}
+
+// Endpoint is a backend server requests are balanced across
+type Endpoint struct {
+	Address        string            `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Weight         uint32            `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	Port           uint32            `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Scheme         string            `protobuf:"bytes,4,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	Tls            *EndpointTLS      `protobuf:"bytes,5,opt,name=tls" json:"tls,omitempty"`
+	MaxConnections uint32            `protobuf:"varint,6,opt,name=max_connections,json=maxConnections,proto3" json:"max_connections,omitempty"`
+	Labels         map[string]string `protobuf:"bytes,7,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *Endpoint) Reset()                    { *m = Endpoint{} }
+func (m *Endpoint) String() string            { return proto.CompactTextString(m) }
+func (*Endpoint) ProtoMessage()               {}
+func (*Endpoint) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{27} }
+
+func (m *Endpoint) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Endpoint) GetWeight() uint32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *Endpoint) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *Endpoint) GetScheme() string {
+	if m != nil {
+		return m.Scheme
+	}
+	return ""
+}
+
+func (m *Endpoint) GetTls() *EndpointTLS {
+	if m != nil {
+		return m.Tls
+	}
+	return nil
+}
+
+func (m *Endpoint) GetMaxConnections() uint32 {
+	if m != nil {
+		return m.MaxConnections
+	}
+	return 0
+}
+
+func (m *Endpoint) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+// HealthCheck actively checks the Endpoints of an Upstream, from the leader
+type HealthCheck struct {
+	Type               HealthCheckType `protobuf:"varint,1,opt,name=type,proto3,enum=sites.HealthCheckType" json:"type,omitempty"`
+	Path               string          `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	ExpectedStatus     uint32          `protobuf:"varint,3,opt,name=expected_status,json=expectedStatus,proto3" json:"expected_status,omitempty"`
+	BodyRegex          string          `protobuf:"bytes,4,opt,name=body_regex,json=bodyRegex,proto3" json:"body_regex,omitempty"`
+	Interval           int64           `protobuf:"varint,5,opt,name=interval,proto3" json:"interval,omitempty"`
+	Timeout            int64           `protobuf:"varint,6,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	HealthyThreshold   uint32          `protobuf:"varint,7,opt,name=healthy_threshold,json=healthyThreshold,proto3" json:"healthy_threshold,omitempty"`
+	UnhealthyThreshold uint32          `protobuf:"varint,8,opt,name=unhealthy_threshold,json=unhealthyThreshold,proto3" json:"unhealthy_threshold,omitempty"`
+}
+
+func (m *HealthCheck) Reset()                    { *m = HealthCheck{} }
+func (m *HealthCheck) String() string            { return proto.CompactTextString(m) }
+func (*HealthCheck) ProtoMessage()               {}
+func (*HealthCheck) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{28} }
+
+func (m *HealthCheck) GetType() HealthCheckType {
+	if m != nil {
+		return m.Type
+	}
+	return HealthCheckType_HTTP
+}
+
+func (m *HealthCheck) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *HealthCheck) GetExpectedStatus() uint32 {
+	if m != nil {
+		return m.ExpectedStatus
+	}
+	return 0
+}
+
+func (m *HealthCheck) GetBodyRegex() string {
+	if m != nil {
+		return m.BodyRegex
+	}
+	return ""
+}
+
+func (m *HealthCheck) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *HealthCheck) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *HealthCheck) GetHealthyThreshold() uint32 {
+	if m != nil {
+		return m.HealthyThreshold
+	}
+	return 0
+}
+
+func (m *HealthCheck) GetUnhealthyThreshold() uint32 {
+	if m != nil {
+		return m.UnhealthyThreshold
+	}
+	return 0
+}
+
+// OutlierDetection ejects Endpoints that fail requests. Each node ejects on the requests it sent: an
+// ejection is neither shared with the other nodes nor stored, so they keep sending requests to the
+// Endpoint, and it ends if the node restarts. Every Endpoint of an Upstream can be ejected at once,
+// leaving none to send requests to until the first ejection ends.
+type OutlierDetection struct {
+	Consecutive_5Xx          uint32 `protobuf:"varint,1,opt,name=consecutive_5xx,json=consecutive5xx,proto3" json:"consecutive_5xx,omitempty"`
+	ConsecutiveConnectErrors uint32 `protobuf:"varint,2,opt,name=consecutive_connect_errors,json=consecutiveConnectErrors,proto3" json:"consecutive_connect_errors,omitempty"`
+	BaseEjection             int64  `protobuf:"varint,3,opt,name=base_ejection,json=baseEjection,proto3" json:"base_ejection,omitempty"`
+	MaxEjection              int64  `protobuf:"varint,4,opt,name=max_ejection,json=maxEjection,proto3" json:"max_ejection,omitempty"`
+}
+
+func (m *OutlierDetection) Reset()                    { *m = OutlierDetection{} }
+func (m *OutlierDetection) String() string            { return proto.CompactTextString(m) }
+func (*OutlierDetection) ProtoMessage()               {}
+func (*OutlierDetection) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{29} }
+
+func (m *OutlierDetection) GetConsecutive_5Xx() uint32 {
+	if m != nil {
+		return m.Consecutive_5Xx
+	}
+	return 0
+}
+
+func (m *OutlierDetection) GetConsecutiveConnectErrors() uint32 {
+	if m != nil {
+		return m.ConsecutiveConnectErrors
+	}
+	return 0
+}
+
+func (m *OutlierDetection) GetBaseEjection() int64 {
+	if m != nil {
+		return m.BaseEjection
+	}
+	return 0
+}
+
+func (m *OutlierDetection) GetMaxEjection() int64 {
+	if m != nil {
+		return m.MaxEjection
+	}
+	return 0
+}
+
+// Upstream is a named pool of Endpoints behind a Site
+type Upstream struct {
+	Name             string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Endpoints        []*Endpoint       `protobuf:"bytes,2,rep,name=endpoints" json:"endpoints,omitempty"`
+	Strategy         Strategy          `protobuf:"varint,3,opt,name=strategy,proto3,enum=sites.Strategy" json:"strategy,omitempty"`
+	Hash             *HashPolicy       `protobuf:"bytes,4,opt,name=hash" json:"hash,omitempty"`
+	HealthCheck      *HealthCheck      `protobuf:"bytes,5,opt,name=health_check,json=healthCheck" json:"health_check,omitempty"`
+	OutlierDetection *OutlierDetection `protobuf:"bytes,6,opt,name=outlier_detection,json=outlierDetection" json:"outlier_detection,omitempty"`
+	ProxyProtocol    uint32            `protobuf:"varint,7,opt,name=proxy_protocol,json=proxyProtocol,proto3" json:"proxy_protocol,omitempty"`
+}
+
+func (m *Upstream) Reset()                    { *m = Upstream{} }
+func (m *Upstream) String() string            { return proto.CompactTextString(m) }
+func (*Upstream) ProtoMessage()               {}
+func (*Upstream) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{30} }
+
+func (m *Upstream) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Upstream) GetEndpoints() []*Endpoint {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+func (m *Upstream) GetStrategy() Strategy {
+	if m != nil {
+		return m.Strategy
+	}
+	return Strategy_ROUND_ROBIN
+}
+
+func (m *Upstream) GetHash() *HashPolicy {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *Upstream) GetHealthCheck() *HealthCheck {
+	if m != nil {
+		return m.HealthCheck
+	}
+	return nil
+}
+
+func (m *Upstream) GetOutlierDetection() *OutlierDetection {
+	if m != nil {
+		return m.OutlierDetection
+	}
+	return nil
+}
+
+func (m *Upstream) GetProxyProtocol() uint32 {
+	if m != nil {
+		return m.ProxyProtocol
+	}
+	return 0
+}
+
+// EndpointHealth is the result of the active health checks of an Endpoint, shared by every node
+type EndpointHealth struct {
+	Hostname  string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Upstream  string `protobuf:"bytes,2,opt,name=upstream,proto3" json:"upstream,omitempty"`
+	Address   string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Healthy   bool   `protobuf:"varint,4,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	ChangedAt int64  `protobuf:"varint,5,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	Reason    string `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *EndpointHealth) Reset()                    { *m = EndpointHealth{} }
+func (m *EndpointHealth) String() string            { return proto.CompactTextString(m) }
+func (*EndpointHealth) ProtoMessage()               {}
+func (*EndpointHealth) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{31} }
+
+func (m *EndpointHealth) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *EndpointHealth) GetUpstream() string {
+	if m != nil {
+		return m.Upstream
+	}
+	return ""
+}
+
+func (m *EndpointHealth) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *EndpointHealth) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *EndpointHealth) GetChangedAt() int64 {
+	if m != nil {
+		return m.ChangedAt
+	}
+	return 0
+}
+
+func (m *EndpointHealth) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// EndpointStatus is the health of an Endpoint as seen by a node
+type EndpointStatus struct {
+	Health         *EndpointHealth `protobuf:"bytes,1,opt,name=health" json:"health,omitempty"`
+	EjectedUntil   int64           `protobuf:"varint,2,opt,name=ejected_until,json=ejectedUntil,proto3" json:"ejected_until,omitempty"`
+	ActiveRequests int64           `protobuf:"varint,3,opt,name=active_requests,json=activeRequests,proto3" json:"active_requests,omitempty"`
+}
+
+func (m *EndpointStatus) Reset()                    { *m = EndpointStatus{} }
+func (m *EndpointStatus) String() string            { return proto.CompactTextString(m) }
+func (*EndpointStatus) ProtoMessage()               {}
+func (*EndpointStatus) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{32} }
+
+func (m *EndpointStatus) GetHealth() *EndpointHealth {
+	if m != nil {
+		return m.Health
+	}
+	return nil
+}
+
+func (m *EndpointStatus) GetEjectedUntil() int64 {
+	if m != nil {
+		return m.EjectedUntil
+	}
+	return 0
+}
+
+func (m *EndpointStatus) GetActiveRequests() int64 {
+	if m != nil {
+		return m.ActiveRequests
+	}
+	return 0
+}
+
+// Balancer represents a Site load balancer
+type Balancer struct {
+	Proto             string            `protobuf:"bytes,3,opt,name=proto,proto3" json:"proto,omitempty"`
+	Port              string            `protobuf:"bytes,4,opt,name=port,proto3" json:"port,omitempty"`
+	Sites             []*Site           `protobuf:"bytes,1,rep,name=sites" json:"sites,omitempty"`
+	Notes             []*nodes.Node     `protobuf:"bytes,2,rep,name=notes" json:"notes,omitempty"`
+	Strategy          Strategy          `protobuf:"varint,5,opt,name=strategy,proto3,enum=sites.Strategy" json:"strategy,omitempty"`
+	Hash              *HashPolicy       `protobuf:"bytes,6,opt,name=hash" json:"hash,omitempty"`
+	Endpoints         []*Endpoint       `protobuf:"bytes,7,rep,name=endpoints" json:"endpoints,omitempty"`
+	Affinity          *Affinity         `protobuf:"bytes,8,opt,name=affinity" json:"affinity,omitempty"`
+	Allow             []string          `protobuf:"bytes,9,rep,name=allow" json:"allow,omitempty"`
+	Deny              []string          `protobuf:"bytes,10,rep,name=deny" json:"deny,omitempty"`
+	IdleTimeout       int64             `protobuf:"varint,11,opt,name=idle_timeout,json=idleTimeout,proto3" json:"idle_timeout,omitempty"`
+	DefaultSite       string            `protobuf:"bytes,12,opt,name=default_site,json=defaultSite,proto3" json:"default_site,omitempty"`
+	ProxyProtocolFrom []string          `protobuf:"bytes,13,rep,name=proxy_protocol_from,json=proxyProtocolFrom" json:"proxy_protocol_from,omitempty"`
+	ProxyProtocol     uint32            `protobuf:"varint,14,opt,name=proxy_protocol,json=proxyProtocol,proto3" json:"proxy_protocol,omitempty"`
+	TrustedProxies    []string          `protobuf:"bytes,15,rep,name=trusted_proxies,json=trustedProxies" json:"trusted_proxies,omitempty"`
+	ForwardedHeader   ForwardedHeader   `protobuf:"varint,16,opt,name=forwarded_header,json=forwardedHeader,proto3,enum=sites.ForwardedHeader" json:"forwarded_header,omitempty"`
+	HealthCheck       *HealthCheck      `protobuf:"bytes,17,opt,name=health_check,json=healthCheck" json:"health_check,omitempty"`
+	OutlierDetection  *OutlierDetection `protobuf:"bytes,18,opt,name=outlier_detection,json=outlierDetection" json:"outlier_detection,omitempty"`
+}
+
+func (m *Balancer) Reset()                    { *m = Balancer{} }
+func (m *Balancer) String() string            { return proto.CompactTextString(m) }
+func (*Balancer) ProtoMessage()               {}
+func (*Balancer) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{33} }
+
+func (m *Balancer) GetProto() string {
+	if m != nil {
+		return m.Proto
+	}
+	return ""
+}
+
+func (m *Balancer) GetPort() string {
+	if m != nil {
+		return m.Port
+	}
+	return ""
+}
+
+func (m *Balancer) GetSites() []*Site {
+	if m != nil {
+		return m.Sites
+	}
+	return nil
+}
+
+func (m *Balancer) GetNotes() []*nodes.Node {
+	if m != nil {
+		return m.Notes
+	}
+	return nil
+}
+
+func (m *Balancer) GetStrategy() Strategy {
+	if m != nil {
+		return m.Strategy
+	}
+	return Strategy_ROUND_ROBIN
+}
+
+func (m *Balancer) GetHash() *HashPolicy {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *Balancer) GetEndpoints() []*Endpoint {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+func (m *Balancer) GetAffinity() *Affinity {
+	if m != nil {
+		return m.Affinity
+	}
+	return nil
+}
+
+func (m *Balancer) GetAllow() []string {
+	if m != nil {
+		return m.Allow
+	}
+	return nil
+}
+
+func (m *Balancer) GetDeny() []string {
+	if m != nil {
+		return m.Deny
+	}
+	return nil
+}
+
+func (m *Balancer) GetIdleTimeout() int64 {
+	if m != nil {
+		return m.IdleTimeout
+	}
+	return 0
+}
+
+func (m *Balancer) GetDefaultSite() string {
+	if m != nil {
+		return m.DefaultSite
+	}
+	return ""
+}
+
+func (m *Balancer) GetProxyProtocolFrom() []string {
+	if m != nil {
+		return m.ProxyProtocolFrom
+	}
+	return nil
+}
+
+func (m *Balancer) GetProxyProtocol() uint32 {
+	if m != nil {
+		return m.ProxyProtocol
+	}
+	return 0
+}
+
+func (m *Balancer) GetTrustedProxies() []string {
+	if m != nil {
+		return m.TrustedProxies
+	}
+	return nil
+}
+
+func (m *Balancer) GetForwardedHeader() ForwardedHeader {
+	if m != nil {
+		return m.ForwardedHeader
+	}
+	return ForwardedHeader_X_FORWARDED_FOR
+}
+
+func (m *Balancer) GetHealthCheck() *HealthCheck {
+	if m != nil {
+		return m.HealthCheck
+	}
+	return nil
+}
+
+func (m *Balancer) GetOutlierDetection() *OutlierDetection {
+	if m != nil {
+		return m.OutlierDetection
+	}
+	return nil
+}
+
+// SiteCertificate is a TLS certificate and key served for a Site
+type SiteCertificate struct {
+	Hostname    string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Certificate []byte `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Key         []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	NotAfter    int64  `protobuf:"varint,4,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	Acme        bool   `protobuf:"varint,5,opt,name=acme,proto3" json:"acme,omitempty"`
+}
+
+func (m *SiteCertificate) Reset()                    { *m = SiteCertificate{} }
+func (m *SiteCertificate) String() string            { return proto.CompactTextString(m) }
+func (*SiteCertificate) ProtoMessage()               {}
+func (*SiteCertificate) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{34} }
+
+func (m *SiteCertificate) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *SiteCertificate) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *SiteCertificate) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *SiteCertificate) GetNotAfter() int64 {
+	if m != nil {
+		return m.NotAfter
+	}
+	return 0
+}
+
+func (m *SiteCertificate) GetAcme() bool {
+	if m != nil {
+		return m.Acme
+	}
+	return false
+}
+
+// AcmeAccount is the ACME account that certificates are issued with
+type AcmeAccount struct {
+	Directory string `protobuf:"bytes,1,opt,name=directory,proto3" json:"directory,omitempty"`
+	Uri       string `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+	Key       []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (m *AcmeAccount) Reset()                    { *m = AcmeAccount{} }
+func (m *AcmeAccount) String() string            { return proto.CompactTextString(m) }
+func (*AcmeAccount) ProtoMessage()               {}
+func (*AcmeAccount) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{35} }
+
+func (m *AcmeAccount) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *AcmeAccount) GetUri() string {
+	if m != nil {
+		return m.Uri
+	}
+	return ""
+}
+
+func (m *AcmeAccount) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+// UploadCertificateRequest is a custom certificate and key for a Site
+type UploadCertificateRequest struct {
+	Hostname    string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Certificate []byte `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Key         []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (m *UploadCertificateRequest) Reset()                    { *m = UploadCertificateRequest{} }
+func (m *UploadCertificateRequest) String() string            { return proto.CompactTextString(m) }
+func (*UploadCertificateRequest) ProtoMessage()               {}
+func (*UploadCertificateRequest) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{36} }
+
+func (m *UploadCertificateRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *UploadCertificateRequest) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *UploadCertificateRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+// UploadCertificateResponse describes the stored certificate
+type UploadCertificateResponse struct {
+	Hostname string   `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	DnsNames []string `protobuf:"bytes,2,rep,name=dns_names,json=dnsNames" json:"dns_names,omitempty"`
+	NotAfter int64    `protobuf:"varint,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+}
+
+func (m *UploadCertificateResponse) Reset()                    { *m = UploadCertificateResponse{} }
+func (m *UploadCertificateResponse) String() string            { return proto.CompactTextString(m) }
+func (*UploadCertificateResponse) ProtoMessage()               {}
+func (*UploadCertificateResponse) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{37} }
+
+func (m *UploadCertificateResponse) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *UploadCertificateResponse) GetDnsNames() []string {
+	if m != nil {
+		return m.DnsNames
+	}
+	return nil
+}
+
+func (m *UploadCertificateResponse) GetNotAfter() int64 {
+	if m != nil {
+		return m.NotAfter
+	}
+	return 0
+}
+
+// DeleteCertificateRequest removes the certificate of a Site
+type DeleteCertificateRequest struct {
+	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+}
+
+func (m *DeleteCertificateRequest) Reset()                    { *m = DeleteCertificateRequest{} }
+func (m *DeleteCertificateRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteCertificateRequest) ProtoMessage()               {}
+func (*DeleteCertificateRequest) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{38} }
+
+func (m *DeleteCertificateRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+// DeleteCertificateResponse is the response to removing the certificate of a Site
+type DeleteCertificateResponse struct {
+}
+
+func (m *DeleteCertificateResponse) Reset()                    { *m = DeleteCertificateResponse{} }
+func (m *DeleteCertificateResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteCertificateResponse) ProtoMessage()               {}
+func (*DeleteCertificateResponse) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{39} }
+
+// CreateSiteRequest adds a Site to the Balancer on a port
+type CreateSiteRequest struct {
+	Port string `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
+	Site *Site  `protobuf:"bytes,2,opt,name=site" json:"site,omitempty"`
+}
+
+func (m *CreateSiteRequest) Reset()                    { *m = CreateSiteRequest{} }
+func (m *CreateSiteRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateSiteRequest) ProtoMessage()               {}
+func (*CreateSiteRequest) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{40} }
+
+func (m *CreateSiteRequest) GetPort() string {
+	if m != nil {
+		return m.Port
+	}
+	return ""
+}
+
+func (m *CreateSiteRequest) GetSite() *Site {
+	if m != nil {
+		return m.Site
+	}
+	return nil
+}
+
+// GetSiteRequest finds a Site by hostname
+type GetSiteRequest struct {
+	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+}
+
+func (m *GetSiteRequest) Reset()                    { *m = GetSiteRequest{} }
+func (m *GetSiteRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetSiteRequest) ProtoMessage()               {}
+func (*GetSiteRequest) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{41} }
+
+func (m *GetSiteRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+// ListSitesRequest lists the Sites
+type ListSitesRequest struct {
+}
+
+func (m *ListSitesRequest) Reset()                    { *m = ListSitesRequest{} }
+func (m *ListSitesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListSitesRequest) ProtoMessage()               {}
+func (*ListSitesRequest) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{42} }
+
+// SiteInfo is a Site, with the ports of the Balancers that serve it
+type SiteInfo struct {
+	Site  *Site    `protobuf:"bytes,1,opt,name=site" json:"site,omitempty"`
+	Ports []string `protobuf:"bytes,2,rep,name=ports" json:"ports,omitempty"`
+}
+
+func (m *SiteInfo) Reset()                    { *m = SiteInfo{} }
+func (m *SiteInfo) String() string            { return proto.CompactTextString(m) }
+func (*SiteInfo) ProtoMessage()               {}
+func (*SiteInfo) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{43} }
+
+func (m *SiteInfo) GetSite() *Site {
+	if m != nil {
+		return m.Site
+	}
+	return nil
+}
+
+func (m *SiteInfo) GetPorts() []string {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+// ListSitesResponse is the list of Sites
+type ListSitesResponse struct {
+	Sites []*SiteInfo `protobuf:"bytes,1,rep,name=sites" json:"sites,omitempty"`
+}
+
+func (m *ListSitesResponse) Reset()                    { *m = ListSitesResponse{} }
+func (m *ListSitesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListSitesResponse) ProtoMessage()               {}
+func (*ListSitesResponse) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{44} }
+
+func (m *ListSitesResponse) GetSites() []*SiteInfo {
+	if m != nil {
+		return m.Sites
+	}
+	return nil
+}
+
+// UpdateSiteRequest replaces a Site, on every Balancer that serves it
+type UpdateSiteRequest struct {
+	Site *Site `protobuf:"bytes,1,opt,name=site" json:"site,omitempty"`
+}
+
+func (m *UpdateSiteRequest) Reset()                    { *m = UpdateSiteRequest{} }
+func (m *UpdateSiteRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateSiteRequest) ProtoMessage()               {}
+func (*UpdateSiteRequest) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{45} }
+
+func (m *UpdateSiteRequest) GetSite() *Site {
+	if m != nil {
+		return m.Site
+	}
+	return nil
+}
+
+// DeleteSiteRequest removes a Site
+type DeleteSiteRequest struct {
+	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Port     string `protobuf:"bytes,2,opt,name=port,proto3" json:"port,omitempty"`
+}
+
+func (m *DeleteSiteRequest) Reset()                    { *m = DeleteSiteRequest{} }
+func (m *DeleteSiteRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteSiteRequest) ProtoMessage()               {}
+func (*DeleteSiteRequest) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{46} }
+
+func (m *DeleteSiteRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *DeleteSiteRequest) GetPort() string {
+	if m != nil {
+		return m.Port
+	}
+	return ""
+}
+
+// DeleteSiteResponse is the response to removing a Site
+type DeleteSiteResponse struct {
+}
+
+func (m *DeleteSiteResponse) Reset()                    { *m = DeleteSiteResponse{} }
+func (m *DeleteSiteResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteSiteResponse) ProtoMessage()               {}
+func (*DeleteSiteResponse) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{47} }
+
+// PutUpstreamRequest creates or replaces an Upstream of a Site by name
+type PutUpstreamRequest struct {
+	Hostname string    `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Upstream *Upstream `protobuf:"bytes,2,opt,name=upstream" json:"upstream,omitempty"`
+}
+
+func (m *PutUpstreamRequest) Reset()                    { *m = PutUpstreamRequest{} }
+func (m *PutUpstreamRequest) String() string            { return proto.CompactTextString(m) }
+func (*PutUpstreamRequest) ProtoMessage()               {}
+func (*PutUpstreamRequest) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{48} }
+
+func (m *PutUpstreamRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *PutUpstreamRequest) GetUpstream() *Upstream {
+	if m != nil {
+		return m.Upstream
+	}
+	return nil
+}
+
+// DeleteUpstreamRequest removes an Upstream of a Site
+type DeleteUpstreamRequest struct {
+	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (m *DeleteUpstreamRequest) Reset()                    { *m = DeleteUpstreamRequest{} }
+func (m *DeleteUpstreamRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteUpstreamRequest) ProtoMessage()               {}
+func (*DeleteUpstreamRequest) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{49} }
+
+func (m *DeleteUpstreamRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *DeleteUpstreamRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// StatusRequest requests the health of the Endpoints of a Site
+type StatusRequest struct {
+	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+}
+
+func (m *StatusRequest) Reset()                    { *m = StatusRequest{} }
+func (m *StatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*StatusRequest) ProtoMessage()               {}
+func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{50} }
+
+func (m *StatusRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+// StatusResponse is the health of the Endpoints as seen by the node answering
+type StatusResponse struct {
+	Endpoints []*EndpointStatus `protobuf:"bytes,1,rep,name=endpoints" json:"endpoints,omitempty"`
+	Node      string            `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+}
+
+func (m *StatusResponse) Reset()                    { *m = StatusResponse{} }
+func (m *StatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*StatusResponse) ProtoMessage()               {}
+func (*StatusResponse) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{51} }
+
+func (m *StatusResponse) GetEndpoints() []*EndpointStatus {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+func (m *StatusResponse) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+// SetRoutesRequest replaces the Routes of a Site
+type SetRoutesRequest struct {
+	Hostname string   `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Routes   []*Route `protobuf:"bytes,2,rep,name=routes" json:"routes,omitempty"`
+}
+
+func (m *SetRoutesRequest) Reset()                    { *m = SetRoutesRequest{} }
+func (m *SetRoutesRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetRoutesRequest) ProtoMessage()               {}
+func (*SetRoutesRequest) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{52} }
+
+func (m *SetRoutesRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *SetRoutesRequest) GetRoutes() []*Route {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
+// SetSplitRequest changes the weights of the split of a Route
+type SetSplitRequest struct {
+	Hostname string              `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Route    string              `protobuf:"bytes,2,opt,name=route,proto3" json:"route,omitempty"`
+	Split    []*WeightedUpstream `protobuf:"bytes,3,rep,name=split" json:"split,omitempty"`
+	Ramp     int64               `protobuf:"varint,4,opt,name=ramp,proto3" json:"ramp,omitempty"`
+}
+
+func (m *SetSplitRequest) Reset()                    { *m = SetSplitRequest{} }
+func (m *SetSplitRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetSplitRequest) ProtoMessage()               {}
+func (*SetSplitRequest) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{53} }
+
+func (m *SetSplitRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *SetSplitRequest) GetRoute() string {
+	if m != nil {
+		return m.Route
+	}
+	return ""
+}
+
+func (m *SetSplitRequest) GetSplit() []*WeightedUpstream {
+	if m != nil {
+		return m.Split
+	}
+	return nil
+}
+
+func (m *SetSplitRequest) GetRamp() int64 {
+	if m != nil {
+		return m.Ramp
+	}
+	return 0
+}
+
+// MirrorStatsRequest requests the stats of the mirrors of a Site
+type MirrorStatsRequest struct {
+	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+}
+
+func (m *MirrorStatsRequest) Reset()                    { *m = MirrorStatsRequest{} }
+func (m *MirrorStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*MirrorStatsRequest) ProtoMessage()               {}
+func (*MirrorStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{54} }
+
+func (m *MirrorStatsRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+// MirrorStatsResponse is the stats of the mirrors on the node answering
+type MirrorStatsResponse struct {
+	Mirrors []*MirrorStats `protobuf:"bytes,1,rep,name=mirrors" json:"mirrors,omitempty"`
+}
+
+func (m *MirrorStatsResponse) Reset()                    { *m = MirrorStatsResponse{} }
+func (m *MirrorStatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*MirrorStatsResponse) ProtoMessage()               {}
+func (*MirrorStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{55} }
+
+func (m *MirrorStatsResponse) GetMirrors() []*MirrorStats {
+	if m != nil {
+		return m.Mirrors
+	}
+	return nil
+}
+
+// PurgeCacheRequest purges cached responses of a Site
+type PurgeCacheRequest struct {
+	Hostname string   `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Urls     []string `protobuf:"bytes,2,rep,name=urls" json:"urls,omitempty"`
+	Prefixes []string `protobuf:"bytes,3,rep,name=prefixes" json:"prefixes,omitempty"`
+	Tags     []string `protobuf:"bytes,4,rep,name=tags" json:"tags,omitempty"`
+	All      bool     `protobuf:"varint,5,opt,name=all,proto3" json:"all,omitempty"`
+}
+
+func (m *PurgeCacheRequest) Reset()                    { *m = PurgeCacheRequest{} }
+func (m *PurgeCacheRequest) String() string            { return proto.CompactTextString(m) }
+func (*PurgeCacheRequest) ProtoMessage()               {}
+func (*PurgeCacheRequest) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{56} }
+
+func (m *PurgeCacheRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *PurgeCacheRequest) GetUrls() []string {
+	if m != nil {
+		return m.Urls
+	}
+	return nil
+}
+
+func (m *PurgeCacheRequest) GetPrefixes() []string {
+	if m != nil {
+		return m.Prefixes
+	}
+	return nil
+}
+
+func (m *PurgeCacheRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *PurgeCacheRequest) GetAll() bool {
+	if m != nil {
+		return m.All
+	}
+	return false
+}
+
+// PurgeCacheResponse is the stored purge
+type PurgeCacheResponse struct {
+	Purge *CachePurge `protobuf:"bytes,1,opt,name=purge" json:"purge,omitempty"`
+}
+
+func (m *PurgeCacheResponse) Reset()                    { *m = PurgeCacheResponse{} }
+func (m *PurgeCacheResponse) String() string            { return proto.CompactTextString(m) }
+func (*PurgeCacheResponse) ProtoMessage()               {}
+func (*PurgeCacheResponse) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{57} }
+
+func (m *PurgeCacheResponse) GetPurge() *CachePurge {
+	if m != nil {
+		return m.Purge
+	}
+	return nil
+}
+
+// CacheStatsRequest requests the stats of the cache of a Site
+type CacheStatsRequest struct {
+	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+}
+
+func (m *CacheStatsRequest) Reset()                    { *m = CacheStatsRequest{} }
+func (m *CacheStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*CacheStatsRequest) ProtoMessage()               {}
+func (*CacheStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{58} }
+
+func (m *CacheStatsRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+// CacheStatsResponse is the stats of the caches on the node answering
+type CacheStatsResponse struct {
+	Caches []*CacheStats `protobuf:"bytes,1,rep,name=caches" json:"caches,omitempty"`
+}
+
+func (m *CacheStatsResponse) Reset()                    { *m = CacheStatsResponse{} }
+func (m *CacheStatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*CacheStatsResponse) ProtoMessage()               {}
+func (*CacheStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptorSites, []int{59} }
+
+func (m *CacheStatsResponse) GetCaches() []*CacheStats {
+	if m != nil {
+		return m.Caches
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Site)(nil), "sites.Site")
+	proto.RegisterType((*ErrorPage)(nil), "sites.ErrorPage")
+	proto.RegisterType((*Maintenance)(nil), "sites.Maintenance")
+	proto.RegisterType((*Redirect)(nil), "sites.Redirect")
+	proto.RegisterType((*Rewrite)(nil), "sites.Rewrite")
+	proto.RegisterType((*HeaderRules)(nil), "sites.HeaderRules")
+	proto.RegisterType((*Header)(nil), "sites.Header")
+	proto.RegisterType((*SecurityHeaders)(nil), "sites.SecurityHeaders")
+	proto.RegisterType((*Compression)(nil), "sites.Compression")
+	proto.RegisterType((*Cache)(nil), "sites.Cache")
+	proto.RegisterType((*CachePurge)(nil), "sites.CachePurge")
+	proto.RegisterType((*CacheStats)(nil), "sites.CacheStats")
+	proto.RegisterType((*WebSocket)(nil), "sites.WebSocket")
+	proto.RegisterType((*Affinity)(nil), "sites.Affinity")
+	proto.RegisterType((*ValueMatch)(nil), "sites.ValueMatch")
+	proto.RegisterType((*Route)(nil), "sites.Route")
+	proto.RegisterType((*Mirror)(nil), "sites.Mirror")
+	proto.RegisterType((*StatusClass)(nil), "sites.StatusClass")
+	proto.RegisterType((*MirrorStats)(nil), "sites.MirrorStats")
+	proto.RegisterType((*WeightedUpstream)(nil), "sites.WeightedUpstream")
+	proto.RegisterType((*SplitRamp)(nil), "sites.SplitRamp")
+	proto.RegisterType((*Timeouts)(nil), "sites.Timeouts")
+	proto.RegisterType((*RetryPolicy)(nil), "sites.RetryPolicy")
+	proto.RegisterType((*CircuitBreaker)(nil), "sites.CircuitBreaker")
+	proto.RegisterType((*Resilience)(nil), "sites.Resilience")
+	proto.RegisterType((*HashPolicy)(nil), "sites.HashPolicy")
+	proto.RegisterType((*EndpointTLS)(nil), "sites.EndpointTLS")
+	proto.RegisterType((*Endpoint)(nil), "sites.Endpoint")
+	proto.RegisterType((*HealthCheck)(nil), "sites.HealthCheck")
+	proto.RegisterType((*OutlierDetection)(nil), "sites.OutlierDetection")
+	proto.RegisterType((*Upstream)(nil), "sites.Upstream")
+	proto.RegisterType((*EndpointHealth)(nil), "sites.EndpointHealth")
+	proto.RegisterType((*EndpointStatus)(nil), "sites.EndpointStatus")
+	proto.RegisterType((*Balancer)(nil), "sites.Balancer")
+	proto.RegisterType((*SiteCertificate)(nil), "sites.SiteCertificate")
+	proto.RegisterType((*AcmeAccount)(nil), "sites.AcmeAccount")
+	proto.RegisterType((*UploadCertificateRequest)(nil), "sites.UploadCertificateRequest")
+	proto.RegisterType((*UploadCertificateResponse)(nil), "sites.UploadCertificateResponse")
+	proto.RegisterType((*DeleteCertificateRequest)(nil), "sites.DeleteCertificateRequest")
+	proto.RegisterType((*DeleteCertificateResponse)(nil), "sites.DeleteCertificateResponse")
+	proto.RegisterType((*CreateSiteRequest)(nil), "sites.CreateSiteRequest")
+	proto.RegisterType((*GetSiteRequest)(nil), "sites.GetSiteRequest")
+	proto.RegisterType((*ListSitesRequest)(nil), "sites.ListSitesRequest")
+	proto.RegisterType((*SiteInfo)(nil), "sites.SiteInfo")
+	proto.RegisterType((*ListSitesResponse)(nil), "sites.ListSitesResponse")
+	proto.RegisterType((*UpdateSiteRequest)(nil), "sites.UpdateSiteRequest")
+	proto.RegisterType((*DeleteSiteRequest)(nil), "sites.DeleteSiteRequest")
+	proto.RegisterType((*DeleteSiteResponse)(nil), "sites.DeleteSiteResponse")
+	proto.RegisterType((*PutUpstreamRequest)(nil), "sites.PutUpstreamRequest")
+	proto.RegisterType((*DeleteUpstreamRequest)(nil), "sites.DeleteUpstreamRequest")
+	proto.RegisterType((*StatusRequest)(nil), "sites.StatusRequest")
+	proto.RegisterType((*StatusResponse)(nil), "sites.StatusResponse")
+	proto.RegisterType((*SetRoutesRequest)(nil), "sites.SetRoutesRequest")
+	proto.RegisterType((*SetSplitRequest)(nil), "sites.SetSplitRequest")
+	proto.RegisterType((*MirrorStatsRequest)(nil), "sites.MirrorStatsRequest")
+	proto.RegisterType((*MirrorStatsResponse)(nil), "sites.MirrorStatsResponse")
+	proto.RegisterType((*PurgeCacheRequest)(nil), "sites.PurgeCacheRequest")
+	proto.RegisterType((*PurgeCacheResponse)(nil), "sites.PurgeCacheResponse")
+	proto.RegisterType((*CacheStatsRequest)(nil), "sites.CacheStatsRequest")
+	proto.RegisterType((*CacheStatsResponse)(nil), "sites.CacheStatsResponse")
+	proto.RegisterEnum("sites.PathMatch", PathMatch_name, PathMatch_value)
+	proto.RegisterEnum("sites.Strategy", Strategy_name, Strategy_value)
+	proto.RegisterEnum("sites.HashSource", HashSource_name, HashSource_value)
+	proto.RegisterEnum("sites.HealthCheckType", HealthCheckType_name, HealthCheckType_value)
+	proto.RegisterEnum("sites.ForwardedHeader", ForwardedHeader_name, ForwardedHeader_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for SitesService service
+
+type SitesServiceClient interface {
+	// CreateSite adds a Site to the Balancer on a port
+	CreateSite(ctx context.Context, in *CreateSiteRequest, opts ...grpc.CallOption) (*SiteInfo, error)
+	// GetSite returns a Site by hostname
+	GetSite(ctx context.Context, in *GetSiteRequest, opts ...grpc.CallOption) (*SiteInfo, error)
+	// ListSites returns every Site
+	ListSites(ctx context.Context, in *ListSitesRequest, opts ...grpc.CallOption) (*ListSitesResponse, error)
+	// UpdateSite replaces a Site, on every Balancer that serves it
+	UpdateSite(ctx context.Context, in *UpdateSiteRequest, opts ...grpc.CallOption) (*SiteInfo, error)
+	// DeleteSite removes a Site
+	DeleteSite(ctx context.Context, in *DeleteSiteRequest, opts ...grpc.CallOption) (*DeleteSiteResponse, error)
+	// PutUpstream creates or replaces an Upstream of a Site
+	PutUpstream(ctx context.Context, in *PutUpstreamRequest, opts ...grpc.CallOption) (*SiteInfo, error)
+	// DeleteUpstream removes an Upstream of a Site
+	DeleteUpstream(ctx context.Context, in *DeleteUpstreamRequest, opts ...grpc.CallOption) (*SiteInfo, error)
+	// SetRoutes replaces the Routes of a Site
+	SetRoutes(ctx context.Context, in *SetRoutesRequest, opts ...grpc.CallOption) (*SiteInfo, error)
+	// SetSplit changes the weights of the split of a Route, at once or over time
+	SetSplit(ctx context.Context, in *SetSplitRequest, opts ...grpc.CallOption) (*SiteInfo, error)
+	// Status returns the health of the Endpoints of a Site
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// MirrorStats compares the primary and shadow responses of the mirrored Routes of a Site
+	MirrorStats(ctx context.Context, in *MirrorStatsRequest, opts ...grpc.CallOption) (*MirrorStatsResponse, error)
+	// PurgeCache removes cached responses of a Site from the cache of every node
+	PurgeCache(ctx context.Context, in *PurgeCacheRequest, opts ...grpc.CallOption) (*PurgeCacheResponse, error)
+	// CacheStats returns the hits, misses and size of the cache of a Site
+	CacheStats(ctx context.Context, in *CacheStatsRequest, opts ...grpc.CallOption) (*CacheStatsResponse, error)
+	// UploadCertificate stores a custom certificate for a Site, that replaces any issued through ACME
+	UploadCertificate(ctx context.Context, in *UploadCertificateRequest, opts ...grpc.CallOption) (*UploadCertificateResponse, error)
+	// DeleteCertificate removes the certificate of a Site, so it is issued through ACME again or served
+	// with the default certificate
+	DeleteCertificate(ctx context.Context, in *DeleteCertificateRequest, opts ...grpc.CallOption) (*DeleteCertificateResponse, error)
+}
+
+type sitesServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSitesServiceClient(cc *grpc.ClientConn) SitesServiceClient {
+	return &sitesServiceClient{cc}
+}
+
+func (c *sitesServiceClient) CreateSite(ctx context.Context, in *CreateSiteRequest, opts ...grpc.CallOption) (*SiteInfo, error) {
+	out := new(SiteInfo)
+	err := grpc.Invoke(ctx, "/sites.SitesService/CreateSite", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sitesServiceClient) GetSite(ctx context.Context, in *GetSiteRequest, opts ...grpc.CallOption) (*SiteInfo, error) {
+	out := new(SiteInfo)
+	err := grpc.Invoke(ctx, "/sites.SitesService/GetSite", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sitesServiceClient) ListSites(ctx context.Context, in *ListSitesRequest, opts ...grpc.CallOption) (*ListSitesResponse, error) {
+	out := new(ListSitesResponse)
+	err := grpc.Invoke(ctx, "/sites.SitesService/ListSites", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sitesServiceClient) UpdateSite(ctx context.Context, in *UpdateSiteRequest, opts ...grpc.CallOption) (*SiteInfo, error) {
+	out := new(SiteInfo)
+	err := grpc.Invoke(ctx, "/sites.SitesService/UpdateSite", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sitesServiceClient) DeleteSite(ctx context.Context, in *DeleteSiteRequest, opts ...grpc.CallOption) (*DeleteSiteResponse, error) {
+	out := new(DeleteSiteResponse)
+	err := grpc.Invoke(ctx, "/sites.SitesService/DeleteSite", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sitesServiceClient) PutUpstream(ctx context.Context, in *PutUpstreamRequest, opts ...grpc.CallOption) (*SiteInfo, error) {
+	out := new(SiteInfo)
+	err := grpc.Invoke(ctx, "/sites.SitesService/PutUpstream", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sitesServiceClient) DeleteUpstream(ctx context.Context, in *DeleteUpstreamRequest, opts ...grpc.CallOption) (*SiteInfo, error) {
+	out := new(SiteInfo)
+	err := grpc.Invoke(ctx, "/sites.SitesService/DeleteUpstream", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sitesServiceClient) SetRoutes(ctx context.Context, in *SetRoutesRequest, opts ...grpc.CallOption) (*SiteInfo, error) {
+	out := new(SiteInfo)
+	err := grpc.Invoke(ctx, "/sites.SitesService/SetRoutes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sitesServiceClient) SetSplit(ctx context.Context, in *SetSplitRequest, opts ...grpc.CallOption) (*SiteInfo, error) {
+	out := new(SiteInfo)
+	err := grpc.Invoke(ctx, "/sites.SitesService/SetSplit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sitesServiceClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := grpc.Invoke(ctx, "/sites.SitesService/Status", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sitesServiceClient) MirrorStats(ctx context.Context, in *MirrorStatsRequest, opts ...grpc.CallOption) (*MirrorStatsResponse, error) {
+	out := new(MirrorStatsResponse)
+	err := grpc.Invoke(ctx, "/sites.SitesService/MirrorStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sitesServiceClient) PurgeCache(ctx context.Context, in *PurgeCacheRequest, opts ...grpc.CallOption) (*PurgeCacheResponse, error) {
+	out := new(PurgeCacheResponse)
+	err := grpc.Invoke(ctx, "/sites.SitesService/PurgeCache", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sitesServiceClient) CacheStats(ctx context.Context, in *CacheStatsRequest, opts ...grpc.CallOption) (*CacheStatsResponse, error) {
+	out := new(CacheStatsResponse)
+	err := grpc.Invoke(ctx, "/sites.SitesService/CacheStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sitesServiceClient) UploadCertificate(ctx context.Context, in *UploadCertificateRequest, opts ...grpc.CallOption) (*UploadCertificateResponse, error) {
+	out := new(UploadCertificateResponse)
+	err := grpc.Invoke(ctx, "/sites.SitesService/UploadCertificate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sitesServiceClient) DeleteCertificate(ctx context.Context, in *DeleteCertificateRequest, opts ...grpc.CallOption) (*DeleteCertificateResponse, error) {
+	out := new(DeleteCertificateResponse)
+	err := grpc.Invoke(ctx, "/sites.SitesService/DeleteCertificate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for SitesService service
+
+type SitesServiceServer interface {
+	// CreateSite adds a Site to the Balancer on a port
+	CreateSite(context.Context, *CreateSiteRequest) (*SiteInfo, error)
+	// GetSite returns a Site by hostname
+	GetSite(context.Context, *GetSiteRequest) (*SiteInfo, error)
+	// ListSites returns every Site
+	ListSites(context.Context, *ListSitesRequest) (*ListSitesResponse, error)
+	// UpdateSite replaces a Site, on every Balancer that serves it
+	UpdateSite(context.Context, *UpdateSiteRequest) (*SiteInfo, error)
+	// DeleteSite removes a Site
+	DeleteSite(context.Context, *DeleteSiteRequest) (*DeleteSiteResponse, error)
+	// PutUpstream creates or replaces an Upstream of a Site
+	PutUpstream(context.Context, *PutUpstreamRequest) (*SiteInfo, error)
+	// DeleteUpstream removes an Upstream of a Site
+	DeleteUpstream(context.Context, *DeleteUpstreamRequest) (*SiteInfo, error)
+	// SetRoutes replaces the Routes of a Site
+	SetRoutes(context.Context, *SetRoutesRequest) (*SiteInfo, error)
+	// SetSplit changes the weights of the split of a Route, at once or over time
+	SetSplit(context.Context, *SetSplitRequest) (*SiteInfo, error)
+	// Status returns the health of the Endpoints of a Site
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	// MirrorStats compares the primary and shadow responses of the mirrored Routes of a Site
+	MirrorStats(context.Context, *MirrorStatsRequest) (*MirrorStatsResponse, error)
+	// PurgeCache removes cached responses of a Site from the cache of every node
+	PurgeCache(context.Context, *PurgeCacheRequest) (*PurgeCacheResponse, error)
+	// CacheStats returns the hits, misses and size of the cache of a Site
+	CacheStats(context.Context, *CacheStatsRequest) (*CacheStatsResponse, error)
+	// UploadCertificate stores a custom certificate for a Site, that replaces any issued through ACME
+	UploadCertificate(context.Context, *UploadCertificateRequest) (*UploadCertificateResponse, error)
+	// DeleteCertificate removes the certificate of a Site, so it is issued through ACME again or served
+	// with the default certificate
+	DeleteCertificate(context.Context, *DeleteCertificateRequest) (*DeleteCertificateResponse, error)
+}
+
+func RegisterSitesServiceServer(s *grpc.Server, srv SitesServiceServer) {
+	s.RegisterService(&_SitesService_serviceDesc, srv)
+}
+
+func _SitesService_CreateSite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSiteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SitesServiceServer).CreateSite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sites.SitesService/CreateSite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SitesServiceServer).CreateSite(ctx, req.(*CreateSiteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SitesService_GetSite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSiteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SitesServiceServer).GetSite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sites.SitesService/GetSite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SitesServiceServer).GetSite(ctx, req.(*GetSiteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SitesService_ListSites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSitesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SitesServiceServer).ListSites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sites.SitesService/ListSites",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SitesServiceServer).ListSites(ctx, req.(*ListSitesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SitesService_UpdateSite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSiteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SitesServiceServer).UpdateSite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sites.SitesService/UpdateSite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SitesServiceServer).UpdateSite(ctx, req.(*UpdateSiteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SitesService_DeleteSite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSiteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SitesServiceServer).DeleteSite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sites.SitesService/DeleteSite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SitesServiceServer).DeleteSite(ctx, req.(*DeleteSiteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SitesService_PutUpstream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutUpstreamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SitesServiceServer).PutUpstream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sites.SitesService/PutUpstream",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SitesServiceServer).PutUpstream(ctx, req.(*PutUpstreamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SitesService_DeleteUpstream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUpstreamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SitesServiceServer).DeleteUpstream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sites.SitesService/DeleteUpstream",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SitesServiceServer).DeleteUpstream(ctx, req.(*DeleteUpstreamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SitesService_SetRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SitesServiceServer).SetRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sites.SitesService/SetRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SitesServiceServer).SetRoutes(ctx, req.(*SetRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SitesService_SetSplit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSplitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SitesServiceServer).SetSplit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sites.SitesService/SetSplit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SitesServiceServer).SetSplit(ctx, req.(*SetSplitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SitesService_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SitesServiceServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sites.SitesService/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SitesServiceServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SitesService_MirrorStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MirrorStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SitesServiceServer).MirrorStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sites.SitesService/MirrorStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SitesServiceServer).MirrorStats(ctx, req.(*MirrorStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SitesService_PurgeCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SitesServiceServer).PurgeCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sites.SitesService/PurgeCache",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SitesServiceServer).PurgeCache(ctx, req.(*PurgeCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SitesService_CacheStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SitesServiceServer).CacheStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sites.SitesService/CacheStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SitesServiceServer).CacheStats(ctx, req.(*CacheStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SitesService_UploadCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SitesServiceServer).UploadCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sites.SitesService/UploadCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SitesServiceServer).UploadCertificate(ctx, req.(*UploadCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SitesService_DeleteCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SitesServiceServer).DeleteCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sites.SitesService/DeleteCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SitesServiceServer).DeleteCertificate(ctx, req.(*DeleteCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SitesService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sites.SitesService",
+	HandlerType: (*SitesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSite",
+			Handler:    _SitesService_CreateSite_Handler,
+		},
+		{
+			MethodName: "GetSite",
+			Handler:    _SitesService_GetSite_Handler,
+		},
+		{
+			MethodName: "ListSites",
+			Handler:    _SitesService_ListSites_Handler,
+		},
+		{
+			MethodName: "UpdateSite",
+			Handler:    _SitesService_UpdateSite_Handler,
+		},
+		{
+			MethodName: "DeleteSite",
+			Handler:    _SitesService_DeleteSite_Handler,
+		},
+		{
+			MethodName: "PutUpstream",
+			Handler:    _SitesService_PutUpstream_Handler,
+		},
+		{
+			MethodName: "DeleteUpstream",
+			Handler:    _SitesService_DeleteUpstream_Handler,
+		},
+		{
+			MethodName: "SetRoutes",
+			Handler:    _SitesService_SetRoutes_Handler,
+		},
+		{
+			MethodName: "SetSplit",
+			Handler:    _SitesService_SetSplit_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _SitesService_Status_Handler,
+		},
+		{
+			MethodName: "MirrorStats",
+			Handler:    _SitesService_MirrorStats_Handler,
+		},
+		{
+			MethodName: "PurgeCache",
+			Handler:    _SitesService_PurgeCache_Handler,
+		},
+		{
+			MethodName: "CacheStats",
+			Handler:    _SitesService_CacheStats_Handler,
+		},
+		{
+			MethodName: "UploadCertificate",
+			Handler:    _SitesService_UploadCertificate_Handler,
+		},
+		{
+			MethodName: "DeleteCertificate",
+			Handler:    _SitesService_DeleteCertificate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/services/protos/sites/sites.proto",
+}
+
+func (m *Site) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Site) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.Alias) > 0 {
+		for _, s := range m.Alias {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.Secure {
+		dAtA[i] = 0x28
+		i++
+		if m.Secure {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Autoencrypt {
+		dAtA[i] = 0x30
+		i++
+		if m.Autoencrypt {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Upstreams) > 0 {
+		for _, msg := range m.Upstreams {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Resilience != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Resilience.Size()))
+		n1, err := m.Resilience.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	if len(m.Routes) > 0 {
+		for _, msg := range m.Routes {
+			dAtA[i] = 0x4a
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Affinity != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Affinity.Size()))
+		n2, err := m.Affinity.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if m.Websocket != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Websocket.Size()))
+		n3, err := m.Websocket.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.Cache != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Cache.Size()))
+		n4, err := m.Cache.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	if m.Compression != nil {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Compression.Size()))
+		n5, err := m.Compression.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if len(m.Redirects) > 0 {
+		for _, msg := range m.Redirects {
+			dAtA[i] = 0x72
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Rewrites) > 0 {
+		for _, msg := range m.Rewrites {
+			dAtA[i] = 0x7a
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.RequestHeaders != nil {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.RequestHeaders.Size()))
+		n6, err := m.RequestHeaders.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	if m.ResponseHeaders != nil {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.ResponseHeaders.Size()))
+		n7, err := m.ResponseHeaders.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	if m.SecurityHeaders != nil {
+		dAtA[i] = 0x92
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.SecurityHeaders.Size()))
+		n8, err := m.SecurityHeaders.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	if len(m.ErrorPages) > 0 {
+		for _, msg := range m.ErrorPages {
+			dAtA[i] = 0x9a
+			i++
+			dAtA[i] = 0x1
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Maintenance != nil {
+		dAtA[i] = 0xa2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Maintenance.Size()))
+		n9, err := m.Maintenance.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	return i, nil
+}
+
+func (m *ErrorPage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ErrorPage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Status != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Status))
+	}
+	if len(m.ContentType) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.ContentType)))
+		i += copy(dAtA[i:], m.ContentType)
+	}
+	if len(m.Template) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Template)))
+		i += copy(dAtA[i:], m.Template)
+	}
+	return i, nil
+}
+
+func (m *Maintenance) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Maintenance) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Enabled {
+		dAtA[i] = 0x8
+		i++
+		if m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Allow) > 0 {
+		for _, s := range m.Allow {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Users) > 0 {
+		for _, s := range m.Users {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Template) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Template)))
+		i += copy(dAtA[i:], m.Template)
+	}
+	if len(m.ContentType) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.ContentType)))
+		i += copy(dAtA[i:], m.ContentType)
+	}
+	if m.RetryAfter != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.RetryAfter))
+	}
+	return i, nil
+}
+
+func (m *Redirect) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Redirect) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Regex) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Regex)))
+		i += copy(dAtA[i:], m.Regex)
+	}
+	if len(m.Replacement) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Replacement)))
+		i += copy(dAtA[i:], m.Replacement)
+	}
+	if len(m.Host) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Host)))
+		i += copy(dAtA[i:], m.Host)
+	}
+	if m.Https {
+		dAtA[i] = 0x28
+		i++
+		if m.Https {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Status != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Status))
+	}
+	return i, nil
+}
+
+func (m *Rewrite) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Rewrite) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Regex) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Regex)))
+		i += copy(dAtA[i:], m.Regex)
+	}
+	if len(m.Replacement) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Replacement)))
+		i += copy(dAtA[i:], m.Replacement)
+	}
+	return i, nil
+}
+
+func (m *HeaderRules) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HeaderRules) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Remove) > 0 {
+		for _, s := range m.Remove {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Set) > 0 {
+		for _, msg := range m.Set {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Add) > 0 {
+		for _, msg := range m.Add {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *Header) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Header) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	return i, nil
+}
+
+func (m *SecurityHeaders) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SecurityHeaders) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.HstsMaxAge != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.HstsMaxAge))
+	}
+	if m.HstsIncludeSubdomains {
+		dAtA[i] = 0x10
+		i++
+		if m.HstsIncludeSubdomains {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.HstsPreload {
+		dAtA[i] = 0x18
+		i++
+		if m.HstsPreload {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.ContentSecurityPolicy) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.ContentSecurityPolicy)))
+		i += copy(dAtA[i:], m.ContentSecurityPolicy)
+	}
+	if len(m.FrameOptions) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.FrameOptions)))
+		i += copy(dAtA[i:], m.FrameOptions)
+	}
+	if m.Nosniff {
+		dAtA[i] = 0x30
+		i++
+		if m.Nosniff {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.ReferrerPolicy) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.ReferrerPolicy)))
+		i += copy(dAtA[i:], m.ReferrerPolicy)
+	}
+	if len(m.PermissionsPolicy) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.PermissionsPolicy)))
+		i += copy(dAtA[i:], m.PermissionsPolicy)
+	}
+	return i, nil
+}
+
+func (m *Compression) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Compression) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Encodings) > 0 {
+		for _, s := range m.Encodings {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.ContentTypes) > 0 {
+		for _, s := range m.ContentTypes {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.MinSize != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.MinSize))
+	}
+	return i, nil
+}
+
+func (m *Cache) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Cache) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MaxSize != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.MaxSize))
+	}
+	if m.MaxObjectSize != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.MaxObjectSize))
+	}
+	if m.DefaultTtl != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.DefaultTtl))
+	}
+	if len(m.DiskPath) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.DiskPath)))
+		i += copy(dAtA[i:], m.DiskPath)
+	}
+	if m.DiskMaxSize != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.DiskMaxSize))
+	}
+	if len(m.TagHeader) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.TagHeader)))
+		i += copy(dAtA[i:], m.TagHeader)
+	}
+	return i, nil
+}
+
+func (m *CachePurge) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CachePurge) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.Urls) > 0 {
+		for _, s := range m.Urls {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Prefixes) > 0 {
+		for _, s := range m.Prefixes {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			dAtA[i] = 0x2a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.CreatedAt != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.CreatedAt))
+	}
+	return i, nil
+}
+
+func (m *CacheStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CacheStats) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if m.Hits != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Hits))
+	}
+	if m.Misses != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Misses))
+	}
+	if m.Revalidated != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Revalidated))
+	}
+	if m.Stored != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Stored))
+	}
+	if m.Evicted != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Evicted))
+	}
+	if m.Purged != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Purged))
+	}
+	if m.Entries != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Entries))
+	}
+	if m.Bytes != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Bytes))
+	}
+	if m.DiskEntries != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.DiskEntries))
+	}
+	if m.DiskBytes != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.DiskBytes))
+	}
+	return i, nil
+}
+
+func (m *WebSocket) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WebSocket) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Disabled {
+		dAtA[i] = 0x8
+		i++
+		if m.Disabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.IdleTimeout != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.IdleTimeout))
+	}
+	if m.MaxFrameSize != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.MaxFrameSize))
+	}
+	if m.MaxMessageSize != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.MaxMessageSize))
+	}
+	if len(m.Deny) > 0 {
+		for _, s := range m.Deny {
+			dAtA[i] = 0x2a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *Affinity) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Affinity) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Cookie) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Cookie)))
+		i += copy(dAtA[i:], m.Cookie)
+	}
+	if m.Ttl != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Ttl))
+	}
+	return i, nil
+}
+
+func (m *ValueMatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValueMatch) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	if m.Regex {
+		dAtA[i] = 0x18
+		i++
+		if m.Regex {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *Route) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Route) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.PathMatch != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.PathMatch))
+	}
+	if len(m.Path) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if len(m.Methods) > 0 {
+		for _, s := range m.Methods {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Headers) > 0 {
+		for _, msg := range m.Headers {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Query) > 0 {
+		for _, msg := range m.Query {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Upstream) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Upstream)))
+		i += copy(dAtA[i:], m.Upstream)
+	}
+	if m.StripPrefix {
+		dAtA[i] = 0x40
+		i++
+		if m.StripPrefix {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Rewrite) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Rewrite)))
+		i += copy(dAtA[i:], m.Rewrite)
+	}
+	if m.Resilience != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Resilience.Size()))
+		n10, err := m.Resilience.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if len(m.Split) > 0 {
+		for _, msg := range m.Split {
+			dAtA[i] = 0x5a
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Sticky != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Sticky.Size()))
+		n11, err := m.Sticky.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	if m.Ramp != nil {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Ramp.Size()))
+		n12, err := m.Ramp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
+	if m.Mirror != nil {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Mirror.Size()))
+		n13, err := m.Mirror.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	if m.Deny {
+		dAtA[i] = 0x78
+		i++
+		if m.Deny {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *Mirror) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Mirror) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Upstream) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Upstream)))
+		i += copy(dAtA[i:], m.Upstream)
+	}
+	if m.Percent != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Percent))
+	}
+	return i, nil
+}
+
+func (m *StatusClass) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StatusClass) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Class) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Class)))
+		i += copy(dAtA[i:], m.Class)
+	}
+	if m.Primary != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Primary))
+	}
+	if m.Shadow != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Shadow))
+	}
+	return i, nil
+}
+
+func (m *MirrorStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MirrorStats) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.Route) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Route)))
+		i += copy(dAtA[i:], m.Route)
+	}
+	if len(m.Upstream) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Upstream)))
+		i += copy(dAtA[i:], m.Upstream)
+	}
+	if m.Mirrored != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Mirrored))
+	}
+	if m.Dropped != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Dropped))
+	}
+	if m.Failed != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Failed))
+	}
+	if m.Matched != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Matched))
+	}
+	if m.Mismatched != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Mismatched))
+	}
+	if len(m.Statuses) > 0 {
+		for _, msg := range m.Statuses {
+			dAtA[i] = 0x4a
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.PrimaryLatency != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.PrimaryLatency))
+	}
+	if m.ShadowLatency != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.ShadowLatency))
+	}
+	return i, nil
+}
+
+func (m *WeightedUpstream) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WeightedUpstream) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Upstream) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Upstream)))
+		i += copy(dAtA[i:], m.Upstream)
+	}
+	if m.Weight != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Weight))
+	}
+	return i, nil
+}
+
+func (m *SplitRamp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SplitRamp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.From) > 0 {
+		for _, msg := range m.From {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Start != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Start))
+	}
+	if m.End != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.End))
+	}
+	return i, nil
+}
+
+func (m *Timeouts) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Timeouts) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Connect != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Connect))
+	}
+	if m.PerTry != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.PerTry))
+	}
+	if m.Overall != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Overall))
+	}
+	return i, nil
+}
+
+func (m *RetryPolicy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RetryPolicy) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Attempts != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Attempts))
+	}
+	if m.BudgetPercent != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.BudgetPercent))
+	}
+	if m.MinRetries != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.MinRetries))
+	}
+	if m.BaseBackoff != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.BaseBackoff))
+	}
+	if m.MaxBackoff != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.MaxBackoff))
+	}
+	return i, nil
+}
+
+func (m *CircuitBreaker) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CircuitBreaker) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MaxPending != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.MaxPending))
+	}
+	if m.MaxConnections != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.MaxConnections))
+	}
+	if m.ErrorPercent != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.ErrorPercent))
+	}
+	if m.MinRequests != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.MinRequests))
+	}
+	if m.Window != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Window))
+	}
+	if m.Open != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Open))
+	}
+	return i, nil
+}
+
+func (m *Resilience) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Resilience) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Timeouts != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Timeouts.Size()))
+		n14, err := m.Timeouts.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	if m.Retry != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Retry.Size()))
+		n15, err := m.Retry.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	if m.CircuitBreaker != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.CircuitBreaker.Size()))
+		n16, err := m.CircuitBreaker.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	return i, nil
+}
+
+func (m *HashPolicy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HashPolicy) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Source != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Source))
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	return i, nil
+}
+
+func (m *EndpointTLS) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EndpointTLS) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.ServerName) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.ServerName)))
+		i += copy(dAtA[i:], m.ServerName)
+	}
+	if len(m.Ca) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Ca)))
+		i += copy(dAtA[i:], m.Ca)
+	}
+	if m.InsecureSkipVerify {
+		dAtA[i] = 0x18
+		i++
+		if m.InsecureSkipVerify {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *Endpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Endpoint) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Address) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Address)))
+		i += copy(dAtA[i:], m.Address)
+	}
+	if m.Weight != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Weight))
+	}
+	if m.Port != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Port))
+	}
+	if len(m.Scheme) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Scheme)))
+		i += copy(dAtA[i:], m.Scheme)
+	}
+	if m.Tls != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Tls.Size()))
+		n17, err := m.Tls.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	if m.MaxConnections != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.MaxConnections))
+	}
+	if len(m.Labels) > 0 {
+		for k, _ := range m.Labels {
+			dAtA[i] = 0x3a
+			i++
+			v := m.Labels[k]
+			mapSize := 1 + len(k) + sovSites(uint64(len(k))) + 1 + len(v) + sovSites(uint64(len(v)))
+			i = encodeVarintSites(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	return i, nil
+}
+
+func (m *HealthCheck) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HealthCheck) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Type != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Type))
+	}
+	if len(m.Path) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if m.ExpectedStatus != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.ExpectedStatus))
+	}
+	if len(m.BodyRegex) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.BodyRegex)))
+		i += copy(dAtA[i:], m.BodyRegex)
+	}
+	if m.Interval != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Interval))
+	}
+	if m.Timeout != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Timeout))
+	}
+	if m.HealthyThreshold != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.HealthyThreshold))
+	}
+	if m.UnhealthyThreshold != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.UnhealthyThreshold))
+	}
+	return i, nil
+}
+
+func (m *OutlierDetection) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OutlierDetection) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Consecutive_5Xx != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Consecutive_5Xx))
+	}
+	if m.ConsecutiveConnectErrors != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.ConsecutiveConnectErrors))
+	}
+	if m.BaseEjection != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.BaseEjection))
+	}
+	if m.MaxEjection != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.MaxEjection))
+	}
+	return i, nil
+}
+
+func (m *Upstream) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Upstream) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Endpoints) > 0 {
+		for _, msg := range m.Endpoints {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Strategy != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Strategy))
+	}
+	if m.Hash != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Hash.Size()))
+		n18, err := m.Hash.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
+	}
+	if m.HealthCheck != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.HealthCheck.Size()))
+		n19, err := m.HealthCheck.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
+	}
+	if m.OutlierDetection != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.OutlierDetection.Size()))
+		n20, err := m.OutlierDetection.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
+	}
+	if m.ProxyProtocol != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.ProxyProtocol))
+	}
+	return i, nil
+}
+
+func (m *EndpointHealth) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EndpointHealth) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.Upstream) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Upstream)))
+		i += copy(dAtA[i:], m.Upstream)
+	}
+	if len(m.Address) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Address)))
+		i += copy(dAtA[i:], m.Address)
+	}
+	if m.Healthy {
+		dAtA[i] = 0x20
+		i++
+		if m.Healthy {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.ChangedAt != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.ChangedAt))
+	}
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
+	}
+	return i, nil
+}
+
+func (m *EndpointStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EndpointStatus) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Health != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Health.Size()))
+		n21, err := m.Health.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
+	}
+	if m.EjectedUntil != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.EjectedUntil))
+	}
+	if m.ActiveRequests != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.ActiveRequests))
+	}
+	return i, nil
+}
+
+func (m *Balancer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Balancer) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Sites) > 0 {
+		for _, msg := range m.Sites {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Notes) > 0 {
+		for _, msg := range m.Notes {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Proto) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Proto)))
+		i += copy(dAtA[i:], m.Proto)
+	}
+	if len(m.Port) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Port)))
+		i += copy(dAtA[i:], m.Port)
+	}
+	if m.Strategy != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Strategy))
+	}
+	if m.Hash != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Hash.Size()))
+		n22, err := m.Hash.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n22
+	}
+	if len(m.Endpoints) > 0 {
+		for _, msg := range m.Endpoints {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Affinity != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Affinity.Size()))
+		n23, err := m.Affinity.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
+	}
+	if len(m.Allow) > 0 {
+		for _, s := range m.Allow {
+			dAtA[i] = 0x4a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Deny) > 0 {
+		for _, s := range m.Deny {
+			dAtA[i] = 0x52
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.IdleTimeout != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.IdleTimeout))
+	}
+	if len(m.DefaultSite) > 0 {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.DefaultSite)))
+		i += copy(dAtA[i:], m.DefaultSite)
+	}
+	if len(m.ProxyProtocolFrom) > 0 {
+		for _, s := range m.ProxyProtocolFrom {
+			dAtA[i] = 0x6a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.ProxyProtocol != 0 {
+		dAtA[i] = 0x70
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.ProxyProtocol))
+	}
+	if len(m.TrustedProxies) > 0 {
+		for _, s := range m.TrustedProxies {
+			dAtA[i] = 0x7a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.ForwardedHeader != 0 {
+		dAtA[i] = 0x80
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.ForwardedHeader))
+	}
+	if m.HealthCheck != nil {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.HealthCheck.Size()))
+		n24, err := m.HealthCheck.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
+	}
+	if m.OutlierDetection != nil {
+		dAtA[i] = 0x92
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.OutlierDetection.Size()))
+		n25, err := m.OutlierDetection.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n25
+	}
+	return i, nil
+}
+
+func (m *SiteCertificate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SiteCertificate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.Certificate) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Certificate)))
+		i += copy(dAtA[i:], m.Certificate)
+	}
+	if len(m.Key) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if m.NotAfter != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.NotAfter))
+	}
+	if m.Acme {
+		dAtA[i] = 0x28
+		i++
+		if m.Acme {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *AcmeAccount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AcmeAccount) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Directory) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Directory)))
+		i += copy(dAtA[i:], m.Directory)
+	}
+	if len(m.Uri) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Uri)))
+		i += copy(dAtA[i:], m.Uri)
+	}
+	if len(m.Key) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	return i, nil
+}
+
+func (m *UploadCertificateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UploadCertificateRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.Certificate) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Certificate)))
+		i += copy(dAtA[i:], m.Certificate)
+	}
+	if len(m.Key) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	return i, nil
+}
+
+func (m *UploadCertificateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UploadCertificateResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.DnsNames) > 0 {
+		for _, s := range m.DnsNames {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.NotAfter != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.NotAfter))
+	}
+	return i, nil
+}
+
+func (m *DeleteCertificateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteCertificateRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	return i, nil
+}
+
+func (m *DeleteCertificateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteCertificateResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *CreateSiteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateSiteRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Port) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Port)))
+		i += copy(dAtA[i:], m.Port)
+	}
+	if m.Site != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Site.Size()))
+		n26, err := m.Site.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n26
+	}
+	return i, nil
+}
+
+func (m *GetSiteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetSiteRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	return i, nil
+}
+
+func (m *ListSitesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListSitesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *SiteInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SiteInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Site != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Site.Size()))
+		n27, err := m.Site.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n27
+	}
+	if len(m.Ports) > 0 {
+		for _, s := range m.Ports {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *ListSitesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListSitesResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Sites) > 0 {
+		for _, msg := range m.Sites {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *UpdateSiteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateSiteRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Site != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Site.Size()))
+		n28, err := m.Site.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n28
+	}
+	return i, nil
+}
+
+func (m *DeleteSiteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteSiteRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.Port) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Port)))
+		i += copy(dAtA[i:], m.Port)
+	}
+	return i, nil
+}
+
+func (m *DeleteSiteResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteSiteResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *PutUpstreamRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PutUpstreamRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if m.Upstream != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Upstream.Size()))
+		n29, err := m.Upstream.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n29
+	}
+	return i, nil
+}
+
+func (m *DeleteUpstreamRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteUpstreamRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	return i, nil
+}
+
+func (m *StatusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	return i, nil
+}
+
+func (m *StatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Endpoints) > 0 {
+		for _, msg := range m.Endpoints {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Node) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Node)))
+		i += copy(dAtA[i:], m.Node)
+	}
+	return i, nil
+}
+
+func (m *SetRoutesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetRoutesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.Routes) > 0 {
+		for _, msg := range m.Routes {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *SetSplitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetSplitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.Route) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Route)))
+		i += copy(dAtA[i:], m.Route)
+	}
+	if len(m.Split) > 0 {
+		for _, msg := range m.Split {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Ramp != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Ramp))
+	}
+	return i, nil
+}
+
+func (m *MirrorStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MirrorStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	return i, nil
+}
+
+func (m *MirrorStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MirrorStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Mirrors) > 0 {
+		for _, msg := range m.Mirrors {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *PurgeCacheRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PurgeCacheRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.Urls) > 0 {
+		for _, s := range m.Urls {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Prefixes) > 0 {
+		for _, s := range m.Prefixes {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.All {
+		dAtA[i] = 0x28
+		i++
+		if m.All {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *PurgeCacheResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PurgeCacheResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Purge != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(m.Purge.Size()))
+		n30, err := m.Purge.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n30
+	}
+	return i, nil
+}
+
+func (m *CacheStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CacheStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSites(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	return i, nil
+}
+
+func (m *CacheStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CacheStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Caches) > 0 {
+		for _, msg := range m.Caches {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintSites(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func encodeFixed64Sites(dAtA []byte, offset int, v uint64) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	dAtA[offset+4] = uint8(v >> 32)
+	dAtA[offset+5] = uint8(v >> 40)
+	dAtA[offset+6] = uint8(v >> 48)
+	dAtA[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32Sites(dAtA []byte, offset int, v uint32) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	return offset + 4
+}
+func encodeVarintSites(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Site) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if len(m.Alias) > 0 {
+		for _, s := range m.Alias {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.Secure {
+		n += 2
+	}
+	if m.Autoencrypt {
+		n += 2
+	}
+	if len(m.Upstreams) > 0 {
+		for _, e := range m.Upstreams {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.Resilience != nil {
+		l = m.Resilience.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if len(m.Routes) > 0 {
+		for _, e := range m.Routes {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.Affinity != nil {
+		l = m.Affinity.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Websocket != nil {
+		l = m.Websocket.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Cache != nil {
+		l = m.Cache.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Compression != nil {
+		l = m.Compression.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if len(m.Redirects) > 0 {
+		for _, e := range m.Redirects {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if len(m.Rewrites) > 0 {
+		for _, e := range m.Rewrites {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.RequestHeaders != nil {
+		l = m.RequestHeaders.Size()
+		n += 2 + l + sovSites(uint64(l))
+	}
+	if m.ResponseHeaders != nil {
+		l = m.ResponseHeaders.Size()
+		n += 2 + l + sovSites(uint64(l))
+	}
+	if m.SecurityHeaders != nil {
+		l = m.SecurityHeaders.Size()
+		n += 2 + l + sovSites(uint64(l))
+	}
+	if len(m.ErrorPages) > 0 {
+		for _, e := range m.ErrorPages {
+			l = e.Size()
+			n += 2 + l + sovSites(uint64(l))
+		}
+	}
+	if m.Maintenance != nil {
+		l = m.Maintenance.Size()
+		n += 2 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *ErrorPage) Size() (n int) {
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovSites(uint64(m.Status))
+	}
+	l = len(m.ContentType)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Template)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *Maintenance) Size() (n int) {
+	var l int
+	_ = l
+	if m.Enabled {
+		n += 2
+	}
+	if len(m.Allow) > 0 {
+		for _, s := range m.Allow {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if len(m.Users) > 0 {
+		for _, s := range m.Users {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	l = len(m.Template)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.ContentType)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.RetryAfter != 0 {
+		n += 1 + sovSites(uint64(m.RetryAfter))
+	}
+	return n
+}
+
+func (m *Redirect) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Regex)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Replacement)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Host)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Https {
+		n += 2
+	}
+	if m.Status != 0 {
+		n += 1 + sovSites(uint64(m.Status))
+	}
+	return n
+}
+
+func (m *Rewrite) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Regex)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Replacement)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *HeaderRules) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Remove) > 0 {
+		for _, s := range m.Remove {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if len(m.Set) > 0 {
+		for _, e := range m.Set {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if len(m.Add) > 0 {
+		for _, e := range m.Add {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Header) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *SecurityHeaders) Size() (n int) {
+	var l int
+	_ = l
+	if m.HstsMaxAge != 0 {
+		n += 1 + sovSites(uint64(m.HstsMaxAge))
+	}
+	if m.HstsIncludeSubdomains {
+		n += 2
+	}
+	if m.HstsPreload {
+		n += 2
+	}
+	l = len(m.ContentSecurityPolicy)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.FrameOptions)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Nosniff {
+		n += 2
+	}
+	l = len(m.ReferrerPolicy)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.PermissionsPolicy)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *Compression) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Encodings) > 0 {
+		for _, s := range m.Encodings {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if len(m.ContentTypes) > 0 {
+		for _, s := range m.ContentTypes {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.MinSize != 0 {
+		n += 1 + sovSites(uint64(m.MinSize))
+	}
+	return n
+}
+
+func (m *Cache) Size() (n int) {
+	var l int
+	_ = l
+	if m.MaxSize != 0 {
+		n += 1 + sovSites(uint64(m.MaxSize))
+	}
+	if m.MaxObjectSize != 0 {
+		n += 1 + sovSites(uint64(m.MaxObjectSize))
+	}
+	if m.DefaultTtl != 0 {
+		n += 1 + sovSites(uint64(m.DefaultTtl))
+	}
+	l = len(m.DiskPath)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.DiskMaxSize != 0 {
+		n += 1 + sovSites(uint64(m.DiskMaxSize))
+	}
+	l = len(m.TagHeader)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *CachePurge) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if len(m.Urls) > 0 {
+		for _, s := range m.Urls {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if len(m.Prefixes) > 0 {
+		for _, s := range m.Prefixes {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.CreatedAt != 0 {
+		n += 1 + sovSites(uint64(m.CreatedAt))
+	}
+	return n
+}
+
+func (m *CacheStats) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Hits != 0 {
+		n += 1 + sovSites(uint64(m.Hits))
+	}
+	if m.Misses != 0 {
+		n += 1 + sovSites(uint64(m.Misses))
+	}
+	if m.Revalidated != 0 {
+		n += 1 + sovSites(uint64(m.Revalidated))
+	}
+	if m.Stored != 0 {
+		n += 1 + sovSites(uint64(m.Stored))
+	}
+	if m.Evicted != 0 {
+		n += 1 + sovSites(uint64(m.Evicted))
+	}
+	if m.Purged != 0 {
+		n += 1 + sovSites(uint64(m.Purged))
+	}
+	if m.Entries != 0 {
+		n += 1 + sovSites(uint64(m.Entries))
+	}
+	if m.Bytes != 0 {
+		n += 1 + sovSites(uint64(m.Bytes))
+	}
+	if m.DiskEntries != 0 {
+		n += 1 + sovSites(uint64(m.DiskEntries))
+	}
+	if m.DiskBytes != 0 {
+		n += 1 + sovSites(uint64(m.DiskBytes))
+	}
+	return n
+}
+
+func (m *WebSocket) Size() (n int) {
+	var l int
+	_ = l
+	if m.Disabled {
+		n += 2
+	}
+	if m.IdleTimeout != 0 {
+		n += 1 + sovSites(uint64(m.IdleTimeout))
+	}
+	if m.MaxFrameSize != 0 {
+		n += 1 + sovSites(uint64(m.MaxFrameSize))
+	}
+	if m.MaxMessageSize != 0 {
+		n += 1 + sovSites(uint64(m.MaxMessageSize))
+	}
+	if len(m.Deny) > 0 {
+		for _, s := range m.Deny {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Affinity) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Cookie)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Ttl != 0 {
+		n += 1 + sovSites(uint64(m.Ttl))
+	}
+	return n
+}
+
+func (m *ValueMatch) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Regex {
+		n += 2
+	}
+	return n
+}
+
+func (m *Route) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.PathMatch != 0 {
+		n += 1 + sovSites(uint64(m.PathMatch))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if len(m.Methods) > 0 {
+		for _, s := range m.Methods {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if len(m.Headers) > 0 {
+		for _, e := range m.Headers {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if len(m.Query) > 0 {
+		for _, e := range m.Query {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	l = len(m.Upstream)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.StripPrefix {
+		n += 2
+	}
+	l = len(m.Rewrite)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Resilience != nil {
+		l = m.Resilience.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if len(m.Split) > 0 {
+		for _, e := range m.Split {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.Sticky != nil {
+		l = m.Sticky.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Ramp != nil {
+		l = m.Ramp.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Mirror != nil {
+		l = m.Mirror.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Deny {
+		n += 2
+	}
+	return n
+}
+
+func (m *Mirror) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Upstream)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Percent != 0 {
+		n += 1 + sovSites(uint64(m.Percent))
+	}
+	return n
+}
+
+func (m *StatusClass) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Class)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Primary != 0 {
+		n += 1 + sovSites(uint64(m.Primary))
+	}
+	if m.Shadow != 0 {
+		n += 1 + sovSites(uint64(m.Shadow))
+	}
+	return n
+}
+
+func (m *MirrorStats) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Route)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Upstream)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Mirrored != 0 {
+		n += 1 + sovSites(uint64(m.Mirrored))
+	}
+	if m.Dropped != 0 {
+		n += 1 + sovSites(uint64(m.Dropped))
+	}
+	if m.Failed != 0 {
+		n += 1 + sovSites(uint64(m.Failed))
+	}
+	if m.Matched != 0 {
+		n += 1 + sovSites(uint64(m.Matched))
+	}
+	if m.Mismatched != 0 {
+		n += 1 + sovSites(uint64(m.Mismatched))
+	}
+	if len(m.Statuses) > 0 {
+		for _, e := range m.Statuses {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.PrimaryLatency != 0 {
+		n += 1 + sovSites(uint64(m.PrimaryLatency))
+	}
+	if m.ShadowLatency != 0 {
+		n += 1 + sovSites(uint64(m.ShadowLatency))
+	}
+	return n
+}
+
+func (m *WeightedUpstream) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Upstream)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Weight != 0 {
+		n += 1 + sovSites(uint64(m.Weight))
+	}
+	return n
+}
+
+func (m *SplitRamp) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.From) > 0 {
+		for _, e := range m.From {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.Start != 0 {
+		n += 1 + sovSites(uint64(m.Start))
+	}
+	if m.End != 0 {
+		n += 1 + sovSites(uint64(m.End))
+	}
+	return n
+}
+
+func (m *Timeouts) Size() (n int) {
+	var l int
+	_ = l
+	if m.Connect != 0 {
+		n += 1 + sovSites(uint64(m.Connect))
+	}
+	if m.PerTry != 0 {
+		n += 1 + sovSites(uint64(m.PerTry))
+	}
+	if m.Overall != 0 {
+		n += 1 + sovSites(uint64(m.Overall))
+	}
+	return n
+}
+
+func (m *RetryPolicy) Size() (n int) {
+	var l int
+	_ = l
+	if m.Attempts != 0 {
+		n += 1 + sovSites(uint64(m.Attempts))
+	}
+	if m.BudgetPercent != 0 {
+		n += 1 + sovSites(uint64(m.BudgetPercent))
+	}
+	if m.MinRetries != 0 {
+		n += 1 + sovSites(uint64(m.MinRetries))
+	}
+	if m.BaseBackoff != 0 {
+		n += 1 + sovSites(uint64(m.BaseBackoff))
+	}
+	if m.MaxBackoff != 0 {
+		n += 1 + sovSites(uint64(m.MaxBackoff))
+	}
+	return n
+}
+
+func (m *CircuitBreaker) Size() (n int) {
+	var l int
+	_ = l
+	if m.MaxPending != 0 {
+		n += 1 + sovSites(uint64(m.MaxPending))
+	}
+	if m.MaxConnections != 0 {
+		n += 1 + sovSites(uint64(m.MaxConnections))
+	}
+	if m.ErrorPercent != 0 {
+		n += 1 + sovSites(uint64(m.ErrorPercent))
+	}
+	if m.MinRequests != 0 {
+		n += 1 + sovSites(uint64(m.MinRequests))
+	}
+	if m.Window != 0 {
+		n += 1 + sovSites(uint64(m.Window))
+	}
+	if m.Open != 0 {
+		n += 1 + sovSites(uint64(m.Open))
+	}
+	return n
+}
+
+func (m *Resilience) Size() (n int) {
+	var l int
+	_ = l
+	if m.Timeouts != nil {
+		l = m.Timeouts.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Retry != nil {
+		l = m.Retry.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.CircuitBreaker != nil {
+		l = m.CircuitBreaker.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *HashPolicy) Size() (n int) {
+	var l int
+	_ = l
+	if m.Source != 0 {
+		n += 1 + sovSites(uint64(m.Source))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *EndpointTLS) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.ServerName)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Ca)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.InsecureSkipVerify {
+		n += 2
+	}
+	return n
+}
+
+func (m *Endpoint) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Weight != 0 {
+		n += 1 + sovSites(uint64(m.Weight))
+	}
+	if m.Port != 0 {
+		n += 1 + sovSites(uint64(m.Port))
+	}
+	l = len(m.Scheme)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Tls != nil {
+		l = m.Tls.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.MaxConnections != 0 {
+		n += 1 + sovSites(uint64(m.MaxConnections))
+	}
+	if len(m.Labels) > 0 {
+		for k, v := range m.Labels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovSites(uint64(len(k))) + 1 + len(v) + sovSites(uint64(len(v)))
+			n += mapEntrySize + 1 + sovSites(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *HealthCheck) Size() (n int) {
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovSites(uint64(m.Type))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.ExpectedStatus != 0 {
+		n += 1 + sovSites(uint64(m.ExpectedStatus))
+	}
+	l = len(m.BodyRegex)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Interval != 0 {
+		n += 1 + sovSites(uint64(m.Interval))
+	}
+	if m.Timeout != 0 {
+		n += 1 + sovSites(uint64(m.Timeout))
+	}
+	if m.HealthyThreshold != 0 {
+		n += 1 + sovSites(uint64(m.HealthyThreshold))
+	}
+	if m.UnhealthyThreshold != 0 {
+		n += 1 + sovSites(uint64(m.UnhealthyThreshold))
+	}
+	return n
+}
+
+func (m *OutlierDetection) Size() (n int) {
+	var l int
+	_ = l
+	if m.Consecutive_5Xx != 0 {
+		n += 1 + sovSites(uint64(m.Consecutive_5Xx))
+	}
+	if m.ConsecutiveConnectErrors != 0 {
+		n += 1 + sovSites(uint64(m.ConsecutiveConnectErrors))
+	}
+	if m.BaseEjection != 0 {
+		n += 1 + sovSites(uint64(m.BaseEjection))
+	}
+	if m.MaxEjection != 0 {
+		n += 1 + sovSites(uint64(m.MaxEjection))
+	}
+	return n
+}
+
+func (m *Upstream) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if len(m.Endpoints) > 0 {
+		for _, e := range m.Endpoints {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.Strategy != 0 {
+		n += 1 + sovSites(uint64(m.Strategy))
+	}
+	if m.Hash != nil {
+		l = m.Hash.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.HealthCheck != nil {
+		l = m.HealthCheck.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.OutlierDetection != nil {
+		l = m.OutlierDetection.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.ProxyProtocol != 0 {
+		n += 1 + sovSites(uint64(m.ProxyProtocol))
+	}
+	return n
+}
+
+func (m *EndpointHealth) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Upstream)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Healthy {
+		n += 2
+	}
+	if m.ChangedAt != 0 {
+		n += 1 + sovSites(uint64(m.ChangedAt))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *EndpointStatus) Size() (n int) {
+	var l int
+	_ = l
+	if m.Health != nil {
+		l = m.Health.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.EjectedUntil != 0 {
+		n += 1 + sovSites(uint64(m.EjectedUntil))
+	}
+	if m.ActiveRequests != 0 {
+		n += 1 + sovSites(uint64(m.ActiveRequests))
+	}
+	return n
+}
+
+func (m *Balancer) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Sites) > 0 {
+		for _, e := range m.Sites {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if len(m.Notes) > 0 {
+		for _, e := range m.Notes {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	l = len(m.Proto)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Port)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Strategy != 0 {
+		n += 1 + sovSites(uint64(m.Strategy))
+	}
+	if m.Hash != nil {
+		l = m.Hash.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if len(m.Endpoints) > 0 {
+		for _, e := range m.Endpoints {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.Affinity != nil {
+		l = m.Affinity.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if len(m.Allow) > 0 {
+		for _, s := range m.Allow {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if len(m.Deny) > 0 {
+		for _, s := range m.Deny {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.IdleTimeout != 0 {
+		n += 1 + sovSites(uint64(m.IdleTimeout))
+	}
+	l = len(m.DefaultSite)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if len(m.ProxyProtocolFrom) > 0 {
+		for _, s := range m.ProxyProtocolFrom {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.ProxyProtocol != 0 {
+		n += 1 + sovSites(uint64(m.ProxyProtocol))
+	}
+	if len(m.TrustedProxies) > 0 {
+		for _, s := range m.TrustedProxies {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.ForwardedHeader != 0 {
+		n += 2 + sovSites(uint64(m.ForwardedHeader))
+	}
+	if m.HealthCheck != nil {
+		l = m.HealthCheck.Size()
+		n += 2 + l + sovSites(uint64(l))
+	}
+	if m.OutlierDetection != nil {
+		l = m.OutlierDetection.Size()
+		n += 2 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *SiteCertificate) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Certificate)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.NotAfter != 0 {
+		n += 1 + sovSites(uint64(m.NotAfter))
+	}
+	if m.Acme {
+		n += 2
+	}
+	return n
+}
+
+func (m *AcmeAccount) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Directory)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Uri)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *UploadCertificateRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Certificate)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *UploadCertificateResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if len(m.DnsNames) > 0 {
+		for _, s := range m.DnsNames {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.NotAfter != 0 {
+		n += 1 + sovSites(uint64(m.NotAfter))
+	}
+	return n
+}
+
+func (m *DeleteCertificateRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *DeleteCertificateResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *CreateSiteRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Port)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Site != nil {
+		l = m.Site.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *GetSiteRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *ListSitesRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *SiteInfo) Size() (n int) {
+	var l int
+	_ = l
+	if m.Site != nil {
+		l = m.Site.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if len(m.Ports) > 0 {
+		for _, s := range m.Ports {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ListSitesResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Sites) > 0 {
+		for _, e := range m.Sites {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *UpdateSiteRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Site != nil {
+		l = m.Site.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *DeleteSiteRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Port)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *DeleteSiteResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *PutUpstreamRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if m.Upstream != nil {
+		l = m.Upstream.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *DeleteUpstreamRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *StatusRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *StatusResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Endpoints) > 0 {
+		for _, e := range m.Endpoints {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	l = len(m.Node)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *SetRoutesRequest) Size() (n int) {
 	var l int
 	_ = l
-	if len(m.Sites) > 0 {
-		for _, e := range m.Sites {
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if len(m.Routes) > 0 {
+		for _, e := range m.Routes {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SetSplitRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	l = len(m.Route)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if len(m.Split) > 0 {
+		for _, e := range m.Split {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.Ramp != 0 {
+		n += 1 + sovSites(uint64(m.Ramp))
+	}
+	return n
+}
+
+func (m *MirrorStatsRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *MirrorStatsResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Mirrors) > 0 {
+		for _, e := range m.Mirrors {
+			l = e.Size()
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PurgeCacheRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	if len(m.Urls) > 0 {
+		for _, s := range m.Urls {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if len(m.Prefixes) > 0 {
+		for _, s := range m.Prefixes {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			l = len(s)
+			n += 1 + l + sovSites(uint64(l))
+		}
+	}
+	if m.All {
+		n += 2
+	}
+	return n
+}
+
+func (m *PurgeCacheResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Purge != nil {
+		l = m.Purge.Size()
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *CacheStatsRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovSites(uint64(l))
+	}
+	return n
+}
+
+func (m *CacheStatsResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Caches) > 0 {
+		for _, e := range m.Caches {
 			l = e.Size()
 			n += 1 + l + sovSites(uint64(l))
 		}
 	}
-	if len(m.Notes) > 0 {
-		for _, e := range m.Notes {
-			l = e.Size()
-			n += 1 + l + sovSites(uint64(l))
+	return n
+}
+
+func sovSites(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozSites(x uint64) (n int) {
+	return sovSites(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Site) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Site: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Site: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Alias", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Alias = append(m.Alias, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Secure", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Secure = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Autoencrypt", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Autoencrypt = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upstreams", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Upstreams = append(m.Upstreams, &Upstream{})
+			if err := m.Upstreams[len(m.Upstreams)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resilience", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Resilience == nil {
+				m.Resilience = &Resilience{}
+			}
+			if err := m.Resilience.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Routes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Routes = append(m.Routes, &Route{})
+			if err := m.Routes[len(m.Routes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Affinity", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Affinity == nil {
+				m.Affinity = &Affinity{}
+			}
+			if err := m.Affinity.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Websocket", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Websocket == nil {
+				m.Websocket = &WebSocket{}
+			}
+			if err := m.Websocket.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cache", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Cache == nil {
+				m.Cache = &Cache{}
+			}
+			if err := m.Cache.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Compression == nil {
+				m.Compression = &Compression{}
+			}
+			if err := m.Compression.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Redirects", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Redirects = append(m.Redirects, &Redirect{})
+			if err := m.Redirects[len(m.Redirects)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rewrites", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Rewrites = append(m.Rewrites, &Rewrite{})
+			if err := m.Rewrites[len(m.Rewrites)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestHeaders", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RequestHeaders == nil {
+				m.RequestHeaders = &HeaderRules{}
+			}
+			if err := m.RequestHeaders.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResponseHeaders", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ResponseHeaders == nil {
+				m.ResponseHeaders = &HeaderRules{}
+			}
+			if err := m.ResponseHeaders.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecurityHeaders", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SecurityHeaders == nil {
+				m.SecurityHeaders = &SecurityHeaders{}
+			}
+			if err := m.SecurityHeaders.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorPages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErrorPages = append(m.ErrorPages, &ErrorPage{})
+			if err := m.ErrorPages[len(m.ErrorPages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Maintenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Maintenance == nil {
+				m.Maintenance = &Maintenance{}
+			}
+			if err := m.Maintenance.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ErrorPage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ErrorPage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ErrorPage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContentType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Template", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Template = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Maintenance) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Maintenance: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Maintenance: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enabled = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Allow", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Allow = append(m.Allow, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Users", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Users = append(m.Users, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Template", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Template = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContentType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetryAfter", wireType)
+			}
+			m.RetryAfter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetryAfter |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Redirect) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Redirect: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Redirect: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Regex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Regex = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replacement", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Replacement = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Host", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Host = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Https", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Https = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Rewrite) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Rewrite: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Rewrite: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Regex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Regex = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replacement", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Replacement = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HeaderRules) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HeaderRules: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HeaderRules: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Remove", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Remove = append(m.Remove, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Set", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Set = append(m.Set, &Header{})
+			if err := m.Set[len(m.Set)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Add", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Add = append(m.Add, &Header{})
+			if err := m.Add[len(m.Add)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Header) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Header: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Header: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SecurityHeaders) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SecurityHeaders: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SecurityHeaders: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HstsMaxAge", wireType)
+			}
+			m.HstsMaxAge = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HstsMaxAge |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HstsIncludeSubdomains", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HstsIncludeSubdomains = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HstsPreload", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HstsPreload = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentSecurityPolicy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContentSecurityPolicy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FrameOptions", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FrameOptions = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nosniff", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Nosniff = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReferrerPolicy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReferrerPolicy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PermissionsPolicy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PermissionsPolicy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Compression) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Compression: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Compression: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encodings", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Encodings = append(m.Encodings, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentTypes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContentTypes = append(m.ContentTypes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinSize", wireType)
+			}
+			m.MinSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinSize |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Cache) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Cache: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Cache: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxSize", wireType)
+			}
+			m.MaxSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxSize |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxObjectSize", wireType)
+			}
+			m.MaxObjectSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxObjectSize |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultTtl", wireType)
+			}
+			m.DefaultTtl = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DefaultTtl |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DiskPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskMaxSize", wireType)
+			}
+			m.DiskMaxSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskMaxSize |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TagHeader", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TagHeader = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CachePurge) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CachePurge: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CachePurge: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Urls", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Urls = append(m.Urls, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefixes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefixes = append(m.Prefixes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			m.CreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CacheStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CacheStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CacheStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hits", wireType)
+			}
+			m.Hits = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Hits |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Misses", wireType)
+			}
+			m.Misses = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Misses |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revalidated", wireType)
+			}
+			m.Revalidated = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Revalidated |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stored", wireType)
+			}
+			m.Stored = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Stored |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evicted", wireType)
+			}
+			m.Evicted = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Evicted |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Purged", wireType)
+			}
+			m.Purged = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Purged |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			m.Entries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Entries |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			m.Bytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Bytes |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskEntries", wireType)
+			}
+			m.DiskEntries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskEntries |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskBytes", wireType)
+			}
+			m.DiskBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskBytes |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WebSocket) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WebSocket: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WebSocket: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Disabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Disabled = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdleTimeout", wireType)
+			}
+			m.IdleTimeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IdleTimeout |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxFrameSize", wireType)
+			}
+			m.MaxFrameSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxFrameSize |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxMessageSize", wireType)
+			}
+			m.MaxMessageSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxMessageSize |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deny", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Deny = append(m.Deny, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Affinity) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Affinity: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Affinity: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cookie", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cookie = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ttl", wireType)
+			}
+			m.Ttl = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Ttl |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValueMatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValueMatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValueMatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Regex", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Regex = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Route) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Route: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Route: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PathMatch", wireType)
+			}
+			m.PathMatch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PathMatch |= (PathMatch(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Methods", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Methods = append(m.Methods, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Headers = append(m.Headers, &ValueMatch{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = append(m.Query, &ValueMatch{})
+			if err := m.Query[len(m.Query)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upstream", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Upstream = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StripPrefix", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StripPrefix = bool(v != 0)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rewrite", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Rewrite = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resilience", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Resilience == nil {
+				m.Resilience = &Resilience{}
+			}
+			if err := m.Resilience.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Split", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Split = append(m.Split, &WeightedUpstream{})
+			if err := m.Split[len(m.Split)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sticky", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Sticky == nil {
+				m.Sticky = &HashPolicy{}
+			}
+			if err := m.Sticky.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ramp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ramp == nil {
+				m.Ramp = &SplitRamp{}
+			}
+			if err := m.Ramp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mirror", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Mirror == nil {
+				m.Mirror = &Mirror{}
+			}
+			if err := m.Mirror.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deny", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Deny = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Mirror) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Mirror: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Mirror: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upstream", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Upstream = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Percent", wireType)
+			}
+			m.Percent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Percent |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StatusClass) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatusClass: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatusClass: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Class", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Class = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Primary", wireType)
+			}
+			m.Primary = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Primary |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shadow", wireType)
+			}
+			m.Shadow = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Shadow |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MirrorStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MirrorStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MirrorStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Route", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Route = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upstream", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Upstream = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mirrored", wireType)
+			}
+			m.Mirrored = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Mirrored |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dropped", wireType)
+			}
+			m.Dropped = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Dropped |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failed", wireType)
+			}
+			m.Failed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Failed |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matched", wireType)
+			}
+			m.Matched = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Matched |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mismatched", wireType)
+			}
+			m.Mismatched = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Mismatched |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Statuses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Statuses = append(m.Statuses, &StatusClass{})
+			if err := m.Statuses[len(m.Statuses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrimaryLatency", wireType)
+			}
+			m.PrimaryLatency = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PrimaryLatency |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShadowLatency", wireType)
+			}
+			m.ShadowLatency = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShadowLatency |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WeightedUpstream) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WeightedUpstream: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WeightedUpstream: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upstream", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Upstream = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Weight", wireType)
+			}
+			m.Weight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Weight |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SplitRamp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SplitRamp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SplitRamp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.From = append(m.From, &WeightedUpstream{})
+			if err := m.From[len(m.From)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			m.Start = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Start |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			}
+			m.End = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.End |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Timeouts) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Timeouts: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Timeouts: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connect", wireType)
+			}
+			m.Connect = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Connect |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PerTry", wireType)
+			}
+			m.PerTry = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PerTry |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Overall", wireType)
+			}
+			m.Overall = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Overall |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RetryPolicy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RetryPolicy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RetryPolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attempts", wireType)
+			}
+			m.Attempts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Attempts |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BudgetPercent", wireType)
+			}
+			m.BudgetPercent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BudgetPercent |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinRetries", wireType)
+			}
+			m.MinRetries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinRetries |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseBackoff", wireType)
+			}
+			m.BaseBackoff = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BaseBackoff |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBackoff", wireType)
+			}
+			m.MaxBackoff = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBackoff |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CircuitBreaker) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CircuitBreaker: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CircuitBreaker: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPending", wireType)
+			}
+			m.MaxPending = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxPending |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxConnections", wireType)
+			}
+			m.MaxConnections = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxConnections |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorPercent", wireType)
+			}
+			m.ErrorPercent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ErrorPercent |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinRequests", wireType)
+			}
+			m.MinRequests = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinRequests |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Window", wireType)
+			}
+			m.Window = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Window |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Open", wireType)
+			}
+			m.Open = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Open |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Resilience) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Resilience: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Resilience: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeouts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Timeouts == nil {
+				m.Timeouts = &Timeouts{}
+			}
+			if err := m.Timeouts.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Retry", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Retry == nil {
+				m.Retry = &RetryPolicy{}
+			}
+			if err := m.Retry.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CircuitBreaker", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CircuitBreaker == nil {
+				m.CircuitBreaker = &CircuitBreaker{}
+			}
+			if err := m.CircuitBreaker.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HashPolicy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HashPolicy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HashPolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			m.Source = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Source |= (HashSource(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EndpointTLS) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EndpointTLS: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EndpointTLS: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServerName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ca", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ca = append(m.Ca[:0], dAtA[iNdEx:postIndex]...)
+			if m.Ca == nil {
+				m.Ca = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InsecureSkipVerify", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.InsecureSkipVerify = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Endpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Endpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Endpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Weight", wireType)
+			}
+			m.Weight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Weight |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			m.Port = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Port |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scheme", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Scheme = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tls", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Tls == nil {
+				m.Tls = &EndpointTLS{}
+			}
+			if err := m.Tls.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxConnections", wireType)
+			}
+			m.MaxConnections = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxConnections |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var keykey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				keykey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			var stringLenmapkey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLenmapkey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLenmapkey := int(stringLenmapkey)
+			if intStringLenmapkey < 0 {
+				return ErrInvalidLengthSites
+			}
+			postStringIndexmapkey := iNdEx + intStringLenmapkey
+			if postStringIndexmapkey > l {
+				return io.ErrUnexpectedEOF
+			}
+			mapkey := string(dAtA[iNdEx:postStringIndexmapkey])
+			iNdEx = postStringIndexmapkey
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
+			}
+			if iNdEx < postIndex {
+				var valuekey uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowSites
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					valuekey |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				var stringLenmapvalue uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowSites
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				intStringLenmapvalue := int(stringLenmapvalue)
+				if intStringLenmapvalue < 0 {
+					return ErrInvalidLengthSites
+				}
+				postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+				if postStringIndexmapvalue > l {
+					return io.ErrUnexpectedEOF
+				}
+				mapvalue := string(dAtA[iNdEx:postStringIndexmapvalue])
+				iNdEx = postStringIndexmapvalue
+				m.Labels[mapkey] = mapvalue
+			} else {
+				var mapvalue string
+				m.Labels[mapkey] = mapvalue
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HealthCheck) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HealthCheck: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HealthCheck: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= (HealthCheckType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedStatus", wireType)
+			}
+			m.ExpectedStatus = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpectedStatus |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BodyRegex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BodyRegex = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
+			}
+			m.Interval = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Interval |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			m.Timeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timeout |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HealthyThreshold", wireType)
+			}
+			m.HealthyThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HealthyThreshold |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnhealthyThreshold", wireType)
+			}
+			m.UnhealthyThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UnhealthyThreshold |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OutlierDetection) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OutlierDetection: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OutlierDetection: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Consecutive_5Xx", wireType)
+			}
+			m.Consecutive_5Xx = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Consecutive_5Xx |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsecutiveConnectErrors", wireType)
+			}
+			m.ConsecutiveConnectErrors = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConsecutiveConnectErrors |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseEjection", wireType)
+			}
+			m.BaseEjection = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BaseEjection |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxEjection", wireType)
+			}
+			m.MaxEjection = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxEjection |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Upstream) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Upstream: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Upstream: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoints", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Endpoints = append(m.Endpoints, &Endpoint{})
+			if err := m.Endpoints[len(m.Endpoints)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Strategy", wireType)
+			}
+			m.Strategy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Strategy |= (Strategy(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Hash == nil {
+				m.Hash = &HashPolicy{}
+			}
+			if err := m.Hash.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HealthCheck", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HealthCheck == nil {
+				m.HealthCheck = &HealthCheck{}
+			}
+			if err := m.HealthCheck.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutlierDetection", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.OutlierDetection == nil {
+				m.OutlierDetection = &OutlierDetection{}
+			}
+			if err := m.OutlierDetection.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProxyProtocol", wireType)
+			}
+			m.ProxyProtocol = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProxyProtocol |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EndpointHealth) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EndpointHealth: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EndpointHealth: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upstream", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Upstream = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Healthy", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Healthy = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangedAt", wireType)
+			}
+			m.ChangedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChangedAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EndpointStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EndpointStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EndpointStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Health", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Health == nil {
+				m.Health = &EndpointHealth{}
+			}
+			if err := m.Health.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EjectedUntil", wireType)
+			}
+			m.EjectedUntil = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EjectedUntil |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActiveRequests", wireType)
+			}
+			m.ActiveRequests = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ActiveRequests |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Balancer) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Balancer: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Balancer: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sites", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sites = append(m.Sites, &Site{})
+			if err := m.Sites[len(m.Sites)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Notes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Notes = append(m.Notes, &nodes.Node{})
+			if err := m.Notes[len(m.Notes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Proto = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Port = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Strategy", wireType)
+			}
+			m.Strategy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Strategy |= (Strategy(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Hash == nil {
+				m.Hash = &HashPolicy{}
+			}
+			if err := m.Hash.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoints", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Endpoints = append(m.Endpoints, &Endpoint{})
+			if err := m.Endpoints[len(m.Endpoints)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Affinity", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Affinity == nil {
+				m.Affinity = &Affinity{}
+			}
+			if err := m.Affinity.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Allow", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Allow = append(m.Allow, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deny", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Deny = append(m.Deny, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdleTimeout", wireType)
+			}
+			m.IdleTimeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IdleTimeout |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultSite", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DefaultSite = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProxyProtocolFrom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProxyProtocolFrom = append(m.ProxyProtocolFrom, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProxyProtocol", wireType)
+			}
+			m.ProxyProtocol = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProxyProtocol |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrustedProxies", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TrustedProxies = append(m.TrustedProxies, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ForwardedHeader", wireType)
+			}
+			m.ForwardedHeader = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ForwardedHeader |= (ForwardedHeader(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HealthCheck", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HealthCheck == nil {
+				m.HealthCheck = &HealthCheck{}
+			}
+			if err := m.HealthCheck.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutlierDetection", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.OutlierDetection == nil {
+				m.OutlierDetection = &OutlierDetection{}
+			}
+			if err := m.OutlierDetection.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SiteCertificate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SiteCertificate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SiteCertificate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Certificate = append(m.Certificate[:0], dAtA[iNdEx:postIndex]...)
+			if m.Certificate == nil {
+				m.Certificate = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotAfter", wireType)
+			}
+			m.NotAfter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NotAfter |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Acme", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Acme = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AcmeAccount) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AcmeAccount: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AcmeAccount: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Directory", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Directory = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uri", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Uri = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UploadCertificateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UploadCertificateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UploadCertificateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Certificate = append(m.Certificate[:0], dAtA[iNdEx:postIndex]...)
+			if m.Certificate == nil {
+				m.Certificate = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UploadCertificateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UploadCertificateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UploadCertificateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DnsNames", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DnsNames = append(m.DnsNames, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotAfter", wireType)
+			}
+			m.NotAfter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NotAfter |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteCertificateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteCertificateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteCertificateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteCertificateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteCertificateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteCertificateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateSiteRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateSiteRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateSiteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Port = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Site", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Site == nil {
+				m.Site = &Site{}
+			}
+			if err := m.Site.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetSiteRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetSiteRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetSiteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListSitesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListSitesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListSitesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SiteInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SiteInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SiteInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Site", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Site == nil {
+				m.Site = &Site{}
+			}
+			if err := m.Site.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ports", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ports = append(m.Ports, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListSitesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListSitesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListSitesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sites", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sites = append(m.Sites, &SiteInfo{})
+			if err := m.Sites[len(m.Sites)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateSiteRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateSiteRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateSiteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Site", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Site == nil {
+				m.Site = &Site{}
+			}
+			if err := m.Site.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteSiteRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteSiteRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteSiteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Port = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteSiteResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteSiteResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteSiteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PutUpstreamRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PutUpstreamRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PutUpstreamRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upstream", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Upstream == nil {
+				m.Upstream = &Upstream{}
+			}
+			if err := m.Upstream.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteUpstreamRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteUpstreamRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteUpstreamRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StatusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoints", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Endpoints = append(m.Endpoints, &EndpointStatus{})
+			if err := m.Endpoints[len(m.Endpoints)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Node = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetRoutesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetRoutesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetRoutesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Routes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Routes = append(m.Routes, &Route{})
+			if err := m.Routes[len(m.Routes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetSplitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetSplitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetSplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Route", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Route = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Split", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Split = append(m.Split, &WeightedUpstream{})
+			if err := m.Split[len(m.Split)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ramp", wireType)
+			}
+			m.Ramp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Ramp |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MirrorStatsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MirrorStatsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MirrorStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MirrorStatsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MirrorStatsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MirrorStatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mirrors", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Mirrors = append(m.Mirrors, &MirrorStats{})
+			if err := m.Mirrors[len(m.Mirrors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	l = len(m.Proto)
-	if l > 0 {
-		n += 1 + l + sovSites(uint64(l))
-	}
-	l = len(m.Port)
-	if l > 0 {
-		n += 1 + l + sovSites(uint64(l))
-	}
-	return n
-}
 
-func sovSites(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozSites(x uint64) (n int) {
-	return sovSites(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *Site) Unmarshal(dAtA []byte) error {
+func (m *PurgeCacheRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -341,10 +16692,10 @@ func (m *Site) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Site: wiretype end group for non-group")
+			return fmt.Errorf("proto: PurgeCacheRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Site: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PurgeCacheRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -378,7 +16729,7 @@ func (m *Site) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Alias", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Urls", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -403,13 +16754,13 @@ func (m *Site) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Alias = append(m.Alias, string(dAtA[iNdEx:postIndex]))
+			m.Urls = append(m.Urls, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Secure", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefixes", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSites
@@ -419,15 +16770,53 @@ func (m *Site) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Secure = bool(v != 0)
-		case 6:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefixes = append(m.Prefixes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSites
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSites
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Autoencrypt", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field All", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -444,7 +16833,7 @@ func (m *Site) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Autoencrypt = bool(v != 0)
+			m.All = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSites(dAtA[iNdEx:])
@@ -466,7 +16855,7 @@ func (m *Site) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Balancer) Unmarshal(dAtA []byte) error {
+func (m *PurgeCacheResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -489,15 +16878,15 @@ func (m *Balancer) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Balancer: wiretype end group for non-group")
+			return fmt.Errorf("proto: PurgeCacheResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Balancer: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PurgeCacheResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sites", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Purge", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -521,45 +16910,66 @@ func (m *Balancer) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Sites = append(m.Sites, &Site{})
-			if err := m.Sites[len(m.Sites)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Purge == nil {
+				m.Purge = &CachePurge{}
+			}
+			if err := m.Purge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Notes", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSites
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthSites
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Notes = append(m.Notes, &nodes.Node{})
-			if err := m.Notes[len(m.Notes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CacheStatsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
 			}
-			iNdEx = postIndex
-		case 3:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CacheStatsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CacheStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -584,13 +16994,63 @@ func (m *Balancer) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Proto = string(dAtA[iNdEx:postIndex])
+			m.Hostname = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSites(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSites
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CacheStatsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSites
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CacheStatsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CacheStatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Caches", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSites
@@ -600,20 +17060,22 @@ func (m *Balancer) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthSites
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Port = string(dAtA[iNdEx:postIndex])
+			m.Caches = append(m.Caches, &CacheStats{})
+			if err := m.Caches[len(m.Caches)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -744,22 +17206,247 @@ var (
 func init() { proto.RegisterFile("pkg/services/protos/sites/sites.proto", fileDescriptorSites) }
 
 var fileDescriptorSites = []byte{
-	// 272 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x8f, 0x41, 0x4a, 0xc3, 0x40,
-	0x18, 0x85, 0x9d, 0x36, 0x09, 0xe9, 0x64, 0x23, 0x83, 0xc8, 0xd0, 0x45, 0x88, 0x05, 0x21, 0xab,
-	0x0c, 0xe8, 0xde, 0x45, 0x0f, 0xe0, 0x22, 0x9e, 0x60, 0x9a, 0xfe, 0x6d, 0x83, 0xed, 0xfc, 0xe1,
-	0x9f, 0x89, 0x52, 0x4f, 0xe2, 0x91, 0x5c, 0x7a, 0x04, 0x89, 0x17, 0x91, 0xcc, 0x04, 0x71, 0xe1,
-	0x66, 0x98, 0xef, 0xbd, 0xc7, 0xcf, 0x7b, 0xfc, 0xb6, 0x7b, 0xde, 0x2b, 0x0b, 0xf4, 0xd2, 0x36,
-	0x60, 0x55, 0x47, 0xe8, 0xd0, 0x2a, 0xdb, 0x3a, 0x98, 0xde, 0xca, 0x4b, 0x22, 0xf6, 0xb0, 0x7c,
-	0xd8, 0xb7, 0xee, 0xd0, 0x6f, 0xaa, 0x06, 0x4f, 0xaa, 0x37, 0x40, 0x84, 0xa4, 0x5e, 0xf5, 0x6e,
-	0x77, 0x56, 0xff, 0x9d, 0x31, 0xb8, 0x85, 0xe9, 0x0d, 0x67, 0x56, 0xc4, 0xa3, 0xa7, 0xd6, 0x81,
-	0x58, 0xf2, 0xf4, 0x80, 0xd6, 0x19, 0x7d, 0x02, 0xc9, 0x0a, 0x56, 0x2e, 0xea, 0x5f, 0x16, 0x57,
-	0x3c, 0xd6, 0xc7, 0x56, 0x5b, 0x39, 0x2b, 0xe6, 0xe5, 0xa2, 0x0e, 0x20, 0xae, 0x79, 0x62, 0xa1,
-	0xe9, 0x09, 0x64, 0x5c, 0xb0, 0x32, 0xad, 0x27, 0x12, 0x05, 0xcf, 0x74, 0xef, 0x10, 0x4c, 0x43,
-	0xe7, 0xce, 0xc9, 0xc4, 0x9b, 0x7f, 0xa5, 0xd5, 0x1b, 0x4f, 0xd7, 0xfa, 0xa8, 0x4d, 0x03, 0x24,
-	0x6e, 0x78, 0x18, 0x22, 0x59, 0x31, 0x2f, 0xb3, 0xbb, 0xac, 0x0a, 0x1b, 0xc7, 0x4e, 0x75, 0x70,
-	0xc6, 0x88, 0xc1, 0x31, 0x32, 0x9b, 0x22, 0xa1, 0xff, 0x23, 0x6e, 0xa1, 0x0e, 0xce, 0xd8, 0xd0,
-	0xcf, 0x91, 0x73, 0x5f, 0x3d, 0x80, 0x10, 0x3c, 0xea, 0x90, 0x9c, 0x8c, 0xbc, 0xe8, 0xff, 0xeb,
-	0xcb, 0x8f, 0x21, 0x67, 0x9f, 0x43, 0xce, 0xbe, 0x86, 0x9c, 0xbd, 0x7f, 0xe7, 0x17, 0x9b, 0xc4,
-	0x87, 0xef, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x42, 0x16, 0xc9, 0x0a, 0x78, 0x01, 0x00, 0x00,
+	// 3860 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xcd, 0x6f, 0x1b, 0x49,
+	0x76, 0x37, 0x3f, 0x44, 0x91, 0x8f, 0x12, 0x45, 0x95, 0xed, 0x99, 0xb6, 0x66, 0xd7, 0xd6, 0x74,
+	0xec, 0x8c, 0xd7, 0x5e, 0xdb, 0x3b, 0xf6, 0x78, 0x37, 0xbb, 0xc9, 0xce, 0x42, 0x96, 0xe9, 0xb1,
+	0xb0, 0xb6, 0x25, 0x14, 0xe5, 0xf1, 0xe4, 0x10, 0x74, 0x4a, 0xdd, 0x45, 0xb2, 0x47, 0x64, 0x77,
+	0x4f, 0x75, 0x51, 0x26, 0xf7, 0x9e, 0xdc, 0x72, 0xc9, 0x25, 0x0b, 0xe4, 0x98, 0x43, 0x90, 0x4b,
+	0x0e, 0xf9, 0xf8, 0x03, 0x72, 0x08, 0x10, 0x20, 0x40, 0x90, 0x5c, 0x03, 0x04, 0x08, 0x66, 0xff,
+	0x91, 0xe0, 0xd5, 0x47, 0x7f, 0x90, 0x94, 0xad, 0x41, 0xe6, 0x42, 0xf4, 0xfb, 0xa8, 0xaf, 0x57,
+	0xef, 0xfd, 0xde, 0xab, 0x2a, 0xc2, 0xad, 0xe4, 0x74, 0xf8, 0x20, 0xe5, 0xe2, 0x2c, 0xf4, 0x79,
+	0xfa, 0x20, 0x11, 0xb1, 0x8c, 0xd3, 0x07, 0x69, 0x28, 0xb9, 0xf9, 0xbd, 0xaf, 0x58, 0x64, 0x4d,
+	0x11, 0x3b, 0x9f, 0x0f, 0x43, 0x39, 0x9a, 0x9e, 0xdc, 0xf7, 0xe3, 0xc9, 0x83, 0x69, 0xc4, 0x85,
+	0x88, 0xc5, 0x83, 0xb7, 0x6c, 0x30, 0x98, 0x3f, 0x58, 0xd5, 0x4d, 0x14, 0x07, 0xdc, 0xfc, 0xea,
+	0x6e, 0xdc, 0x7f, 0x6a, 0x40, 0xbd, 0x1f, 0x4a, 0x4e, 0x76, 0xa0, 0x39, 0x8a, 0x53, 0x19, 0xb1,
+	0x09, 0x77, 0x2a, 0xbb, 0x95, 0xdb, 0x2d, 0x9a, 0xd1, 0xe4, 0x0a, 0xac, 0xb1, 0x71, 0xc8, 0x52,
+	0xa7, 0xba, 0x5b, 0xbb, 0xdd, 0xa2, 0x9a, 0x20, 0x1f, 0x40, 0x23, 0xe5, 0xfe, 0x54, 0x70, 0x67,
+	0x6d, 0xb7, 0x72, 0xbb, 0x49, 0x0d, 0x45, 0x76, 0xa1, 0xcd, 0xa6, 0x32, 0xe6, 0x91, 0x2f, 0xe6,
+	0x89, 0x74, 0x1a, 0x4a, 0x58, 0x64, 0x91, 0x7b, 0xd0, 0x9a, 0x26, 0xa9, 0x14, 0x9c, 0x4d, 0x52,
+	0x67, 0x7d, 0xb7, 0x76, 0xbb, 0xfd, 0x70, 0xeb, 0xbe, 0x5e, 0xdc, 0x6b, 0xc3, 0xa7, 0xb9, 0x06,
+	0xf9, 0x14, 0x40, 0xf0, 0x34, 0x1c, 0x87, 0x3c, 0xf2, 0xb9, 0xd3, 0xdc, 0xad, 0xdc, 0x6e, 0x3f,
+	0xdc, 0x36, 0xfa, 0x34, 0x13, 0xd0, 0x82, 0x12, 0xb9, 0x09, 0x0d, 0x11, 0x4f, 0x25, 0x4f, 0x9d,
+	0x96, 0xea, 0x7e, 0xc3, 0xaa, 0x23, 0x93, 0x1a, 0x19, 0xb9, 0x0b, 0x4d, 0x36, 0x18, 0x84, 0x51,
+	0x28, 0xe7, 0x0e, 0xec, 0x56, 0x0a, 0xd3, 0xd8, 0x33, 0x6c, 0x9a, 0x29, 0x90, 0xfb, 0xd0, 0x7a,
+	0xcb, 0x4f, 0xd2, 0xd8, 0x3f, 0xe5, 0xd2, 0x69, 0x2b, 0xed, 0xae, 0xd1, 0x7e, 0xc3, 0x4f, 0xfa,
+	0x8a, 0x4f, 0x73, 0x15, 0xe2, 0xc2, 0x9a, 0xcf, 0xfc, 0x11, 0x77, 0x36, 0x76, 0x2b, 0x85, 0x19,
+	0xec, 0x23, 0x8f, 0x6a, 0x11, 0xf9, 0x0c, 0xda, 0x7e, 0x3c, 0x49, 0x04, 0x4f, 0xd3, 0x30, 0x8e,
+	0x9c, 0x4d, 0xa5, 0x49, 0xac, 0x66, 0x2e, 0xa1, 0x45, 0x35, 0x34, 0x9f, 0xe0, 0x41, 0x28, 0xb8,
+	0x2f, 0x53, 0xa7, 0x53, 0x32, 0x1f, 0x35, 0x7c, 0x9a, 0x6b, 0x90, 0x3b, 0xd0, 0x14, 0xfc, 0xad,
+	0x40, 0xb9, 0xb3, 0xa5, 0xb4, 0x3b, 0x99, 0xb6, 0x62, 0xd3, 0x4c, 0x4e, 0xfe, 0x10, 0xb6, 0x04,
+	0xff, 0x66, 0xca, 0x53, 0xe9, 0x8d, 0x38, 0x0b, 0xb8, 0x48, 0x9d, 0x6e, 0x69, 0x52, 0xcf, 0x15,
+	0x97, 0x4e, 0xc7, 0x3c, 0xa5, 0x1d, 0xa3, 0xaa, 0x79, 0x29, 0xf9, 0x25, 0x74, 0x05, 0x4f, 0x93,
+	0x38, 0x4a, 0x79, 0xd6, 0x7a, 0xfb, 0xdc, 0xd6, 0x5b, 0x56, 0xd7, 0x36, 0xdf, 0x83, 0xae, 0xf2,
+	0xa0, 0x50, 0xce, 0xb3, 0xe6, 0x44, 0x35, 0xff, 0xc0, 0x34, 0xef, 0x1b, 0xb1, 0x69, 0x41, 0xb7,
+	0xd2, 0x32, 0x83, 0x7c, 0x0a, 0x6d, 0x15, 0x02, 0x5e, 0xc2, 0x86, 0x3c, 0x75, 0x2e, 0xef, 0xd6,
+	0x0a, 0xbb, 0xd4, 0x43, 0xc9, 0x11, 0x1b, 0x72, 0x0a, 0xdc, 0x7e, 0xa6, 0xb8, 0x05, 0x13, 0x16,
+	0x46, 0x92, 0x47, 0x0c, 0xbd, 0xeb, 0x4a, 0x69, 0xbe, 0x2f, 0x73, 0x09, 0x2d, 0xaa, 0xb9, 0x27,
+	0xd0, 0xca, 0xba, 0x53, 0x81, 0x20, 0x99, 0x9c, 0xa6, 0x2a, 0x70, 0x36, 0xa9, 0xa1, 0xc8, 0xc7,
+	0xb0, 0xe1, 0xc7, 0xd8, 0x44, 0x7a, 0x72, 0x9e, 0x70, 0xa7, 0xaa, 0xc2, 0xaa, 0x6d, 0x78, 0xc7,
+	0xf3, 0x44, 0x45, 0x9d, 0xe4, 0x93, 0x64, 0xcc, 0x24, 0x77, 0x6a, 0x3a, 0xea, 0x2c, 0xed, 0xfe,
+	0x63, 0x05, 0xda, 0x85, 0x09, 0x10, 0x07, 0xd6, 0x79, 0xc4, 0x4e, 0xc6, 0x3c, 0x50, 0xe3, 0x34,
+	0xa9, 0x25, 0x75, 0x7c, 0x8e, 0xe3, 0xb7, 0x79, 0x7c, 0x8e, 0xe3, 0xb7, 0xc8, 0x9d, 0xa6, 0x68,
+	0xc4, 0x9a, 0xe6, 0x2a, 0xa2, 0x34, 0x62, 0xbd, 0x3c, 0xe2, 0xd2, 0x84, 0xd7, 0x96, 0x27, 0x7c,
+	0x03, 0xda, 0x82, 0x4b, 0x31, 0xf7, 0xd8, 0x40, 0x72, 0xa1, 0x82, 0xbb, 0x86, 0x91, 0x27, 0xc5,
+	0x7c, 0x0f, 0x39, 0xee, 0x6f, 0x2b, 0xd0, 0xb4, 0x5e, 0x48, 0x08, 0xd4, 0x0b, 0x80, 0x52, 0xb7,
+	0x60, 0x22, 0xf8, 0x90, 0xcf, 0x8c, 0x39, 0x34, 0x81, 0xa0, 0x21, 0x78, 0x32, 0x66, 0x3e, 0x9f,
+	0xf0, 0x48, 0x1a, 0x5b, 0x14, 0x59, 0xd8, 0x17, 0x02, 0x92, 0x99, 0xb4, 0xfa, 0xc6, 0xbe, 0x46,
+	0x52, 0x26, 0xa9, 0x41, 0x20, 0x4d, 0x14, 0xf6, 0xa3, 0x51, 0xdc, 0x0f, 0xf7, 0x35, 0xac, 0x1b,
+	0x8f, 0xff, 0x3e, 0x27, 0xe6, 0x0e, 0xa1, 0x5d, 0xf0, 0x6b, 0x1c, 0x5d, 0xf0, 0x49, 0x7c, 0x86,
+	0x9d, 0xa3, 0xdd, 0x0d, 0x45, 0x6e, 0x40, 0x2d, 0xe5, 0x52, 0x6d, 0x51, 0xfb, 0xe1, 0x66, 0x39,
+	0x20, 0x50, 0x82, 0x0a, 0x2c, 0x08, 0x9c, 0xda, 0x4a, 0x05, 0x16, 0x04, 0xee, 0x43, 0x68, 0x68,
+	0xf2, 0xbc, 0xe9, 0x9f, 0xb1, 0xf1, 0xd4, 0xba, 0x99, 0x26, 0xdc, 0xff, 0xa9, 0xc2, 0xd6, 0x42,
+	0xd8, 0x90, 0x5d, 0xd8, 0x18, 0xa5, 0x32, 0xf5, 0x26, 0x6c, 0xe6, 0xb1, 0xa1, 0xee, 0xa5, 0x46,
+	0x01, 0x79, 0x2f, 0xd9, 0x6c, 0x6f, 0xc8, 0xc9, 0x4f, 0xe1, 0x43, 0xa5, 0x11, 0x46, 0xfe, 0x78,
+	0x1a, 0x70, 0x2f, 0x9d, 0x9e, 0x04, 0x31, 0xfa, 0x7f, 0xaa, 0x7a, 0x6f, 0xd2, 0xab, 0x28, 0x3e,
+	0xd0, 0xd2, 0x7e, 0x26, 0x44, 0x07, 0x52, 0xed, 0x12, 0xc1, 0xc7, 0x31, 0x0b, 0x94, 0xb5, 0x9a,
+	0xb4, 0x8d, 0xbc, 0x23, 0xcd, 0xc2, 0xae, 0xad, 0x8f, 0x65, 0xd1, 0x9e, 0xc4, 0xe3, 0xd0, 0x9f,
+	0x9b, 0x9d, 0xbd, 0x6a, 0xc4, 0x76, 0xd6, 0x47, 0x4a, 0x48, 0x7e, 0x0f, 0x36, 0x07, 0x82, 0x4d,
+	0xb8, 0x17, 0x27, 0x32, 0x8c, 0xa3, 0xd4, 0x38, 0xe7, 0x86, 0x62, 0x1e, 0x6a, 0x1e, 0x86, 0x48,
+	0x14, 0xa7, 0x51, 0x38, 0x18, 0x98, 0xb4, 0x63, 0x49, 0xf2, 0x09, 0x02, 0xdb, 0x80, 0x0b, 0xc1,
+	0x85, 0x1d, 0x6e, 0x5d, 0x75, 0xd0, 0xb1, 0x6c, 0x33, 0xce, 0x3d, 0x20, 0x09, 0x17, 0x93, 0x50,
+	0x41, 0x6d, 0x6a, 0x75, 0x9b, 0x4a, 0x77, 0xbb, 0x20, 0xd1, 0xea, 0xee, 0x29, 0xb4, 0x0b, 0x38,
+	0x4d, 0x7e, 0x00, 0x2d, 0x1e, 0xf9, 0x71, 0x10, 0x46, 0xc3, 0xd4, 0xec, 0x7f, 0xce, 0xc0, 0x35,
+	0x14, 0xe3, 0xcb, 0xe6, 0xd3, 0x8d, 0x42, 0x80, 0xa5, 0xe4, 0x1a, 0x34, 0x27, 0x61, 0xe4, 0xa5,
+	0xe1, 0x6f, 0x34, 0x24, 0xd4, 0xe8, 0xfa, 0x24, 0x8c, 0xfa, 0xe1, 0x6f, 0xb8, 0xfb, 0xef, 0x15,
+	0x58, 0x53, 0xf9, 0x43, 0x29, 0xb1, 0x99, 0x56, 0xaa, 0x18, 0x25, 0x36, 0x43, 0x25, 0xf2, 0xfb,
+	0xb0, 0x85, 0xa2, 0xf8, 0xe4, 0x6b, 0xee, 0x4b, 0xad, 0x51, 0x55, 0x1a, 0x9b, 0x13, 0x36, 0x3b,
+	0x54, 0x5c, 0xa5, 0x77, 0x03, 0xda, 0x01, 0x1f, 0xb0, 0xe9, 0x58, 0x7a, 0x52, 0x8e, 0xcd, 0x50,
+	0x60, 0x58, 0xc7, 0x72, 0x4c, 0x3e, 0x82, 0x56, 0x10, 0xa6, 0xa7, 0x5e, 0xc2, 0xe4, 0xc8, 0x42,
+	0x05, 0x32, 0x8e, 0x98, 0x1c, 0x11, 0x17, 0x36, 0x95, 0x30, 0x9b, 0xc5, 0x9a, 0x6a, 0xdf, 0x46,
+	0xe6, 0x4b, 0x33, 0x93, 0x1f, 0x02, 0x48, 0x36, 0x34, 0x58, 0xae, 0x36, 0xa4, 0x45, 0x5b, 0x92,
+	0x0d, 0xb5, 0x1f, 0xba, 0x7f, 0x5d, 0x01, 0x50, 0xab, 0x39, 0x9a, 0x8a, 0x21, 0x27, 0x1d, 0xa8,
+	0x86, 0x81, 0xf1, 0xe8, 0x6a, 0x18, 0x94, 0x0a, 0x92, 0xea, 0x42, 0x41, 0x42, 0xa0, 0x3e, 0x15,
+	0x63, 0x8b, 0x6c, 0xea, 0x1b, 0xf5, 0x13, 0xc1, 0x07, 0xe1, 0x8c, 0xa7, 0x4e, 0x5d, 0xf1, 0x33,
+	0x1a, 0xf5, 0x25, 0x1b, 0xa2, 0xcf, 0x28, 0x7d, 0xfc, 0xc6, 0xd9, 0xf9, 0x82, 0x33, 0xc9, 0x03,
+	0x8f, 0x49, 0x03, 0x64, 0x2d, 0xc3, 0xd9, 0x93, 0xee, 0x3f, 0x54, 0xcd, 0xec, 0xfa, 0x92, 0xc9,
+	0xf4, 0x9d, 0xe5, 0x11, 0x22, 0x53, 0x28, 0x75, 0x68, 0xd4, 0xa9, 0xfa, 0x46, 0x14, 0x40, 0x4f,
+	0xe1, 0xa9, 0x32, 0x6c, 0x9d, 0x1a, 0x4a, 0xc3, 0xc9, 0x19, 0x1b, 0x87, 0x01, 0x8e, 0xa3, 0xcc,
+	0x5a, 0xa7, 0x45, 0x96, 0x46, 0xaf, 0x58, 0xf0, 0x40, 0x99, 0xb4, 0x4e, 0x0d, 0xa5, 0xe0, 0xff,
+	0x2c, 0xf4, 0xb1, 0x55, 0x43, 0x09, 0x2c, 0x89, 0x2d, 0x12, 0x34, 0x61, 0xa0, 0x5c, 0xba, 0x4e,
+	0x0d, 0xa5, 0x5a, 0x44, 0x52, 0x84, 0x3c, 0x75, 0x9a, 0xa6, 0x85, 0x26, 0x11, 0x2b, 0x4e, 0xe6,
+	0xba, 0x3a, 0x42, 0xbe, 0x26, 0x30, 0x7a, 0xd5, 0x9e, 0xda, 0x46, 0xa0, 0x27, 0x87, 0xbc, 0x9e,
+	0x69, 0xf8, 0x43, 0x00, 0xa5, 0xa2, 0x5b, 0xb7, 0x95, 0x82, 0xf2, 0x92, 0x27, 0xc8, 0x70, 0xff,
+	0xbe, 0x02, 0xad, 0xac, 0x18, 0x42, 0x9b, 0x05, 0x61, 0x5a, 0xcc, 0x58, 0x19, 0x8d, 0x63, 0x85,
+	0xc1, 0x98, 0x7b, 0x32, 0x9c, 0xf0, 0x78, 0x2a, 0x8d, 0x8b, 0xb6, 0x91, 0x77, 0xac, 0x59, 0xe4,
+	0x26, 0x74, 0xd0, 0xbb, 0x74, 0xd4, 0x17, 0xc2, 0x61, 0x63, 0xc2, 0x66, 0xcf, 0x90, 0xa9, 0x9c,
+	0xec, 0x36, 0x74, 0x51, 0x6b, 0xc2, 0xd3, 0x94, 0x0d, 0x8d, 0x5e, 0x5d, 0xe9, 0x61, 0xeb, 0x97,
+	0x9a, 0xad, 0x34, 0x09, 0xd4, 0x03, 0x1e, 0xcd, 0xad, 0x13, 0xe0, 0xb7, 0xfb, 0x19, 0x34, 0x6d,
+	0xa9, 0x87, 0x66, 0xf4, 0xe3, 0xf8, 0x34, 0xb4, 0x1b, 0x6c, 0x28, 0xd2, 0x85, 0x1a, 0x06, 0x88,
+	0x9e, 0x21, 0x7e, 0xba, 0x2f, 0x00, 0xbe, 0x44, 0x74, 0x7d, 0xc9, 0xa4, 0x3f, 0xba, 0x38, 0x18,
+	0xe7, 0x19, 0x46, 0xe3, 0xa2, 0x26, 0xdc, 0xbf, 0xaa, 0xc3, 0x9a, 0xaa, 0x4b, 0x57, 0xf6, 0xf4,
+	0x00, 0x00, 0x03, 0xd0, 0x9b, 0xe0, 0x58, 0xaa, 0xbb, 0x4e, 0x56, 0xd1, 0x60, 0x24, 0xaa, 0x39,
+	0xd0, 0x56, 0x62, 0x3f, 0xb1, 0x13, 0x24, 0x4c, 0xa6, 0x52, 0xdf, 0xe8, 0x09, 0x13, 0x2e, 0x47,
+	0x71, 0x60, 0x43, 0xc3, 0x92, 0xe4, 0x2e, 0xac, 0xdb, 0x5a, 0x6b, 0x6d, 0xb7, 0x56, 0x28, 0xac,
+	0xf3, 0x05, 0x52, 0xab, 0x41, 0x3e, 0x81, 0xb5, 0x6f, 0xa6, 0x5c, 0xcc, 0x9d, 0xc6, 0x79, 0xaa,
+	0x5a, 0x8e, 0x3b, 0x6f, 0xcb, 0x77, 0x03, 0xb3, 0x19, 0x8d, 0x3b, 0x9f, 0x4a, 0x11, 0x26, 0x9e,
+	0x8e, 0x4e, 0xe5, 0x9a, 0x4d, 0xda, 0x56, 0xbc, 0x23, 0xc5, 0xc2, 0xe9, 0x9a, 0x8a, 0x54, 0x39,
+	0x68, 0x8b, 0x5a, 0x72, 0xe1, 0x28, 0x00, 0x17, 0x39, 0x0a, 0xdc, 0x83, 0xb5, 0x34, 0x19, 0x87,
+	0x58, 0xb3, 0xe3, 0xa4, 0x3f, 0xcc, 0x6a, 0xf6, 0x70, 0x38, 0x92, 0x3c, 0xc8, 0x0e, 0x1c, 0x5a,
+	0x8b, 0xfc, 0x08, 0xc3, 0x2f, 0xf4, 0x4f, 0xe7, 0xce, 0x46, 0xa9, 0xf7, 0xe7, 0x2c, 0x1d, 0x69,
+	0xcc, 0xa7, 0x46, 0x81, 0xdc, 0x84, 0xba, 0x60, 0x93, 0xc4, 0x94, 0xed, 0x76, 0x53, 0xfa, 0xd8,
+	0x0d, 0x65, 0x93, 0x84, 0x2a, 0x29, 0xb9, 0x85, 0x48, 0x80, 0xb5, 0xa2, 0xd3, 0xd9, 0xad, 0x14,
+	0x32, 0xfb, 0x4b, 0xc5, 0xa4, 0x46, 0x98, 0x79, 0xe7, 0x96, 0x32, 0x87, 0xf6, 0xce, 0xcf, 0xa1,
+	0xa1, 0xb5, 0x4a, 0x06, 0xad, 0x2c, 0x18, 0xd4, 0x81, 0xf5, 0x84, 0x0b, 0x9f, 0x47, 0x3a, 0x8a,
+	0xd6, 0xa8, 0x25, 0xdd, 0xd7, 0xd0, 0xee, 0xab, 0xd2, 0x67, 0x7f, 0xcc, 0x52, 0x15, 0xf5, 0x3e,
+	0x7e, 0x98, 0x1e, 0x34, 0xa1, 0x9a, 0x8b, 0x70, 0xc2, 0xc4, 0xdc, 0x00, 0x98, 0x25, 0x15, 0x12,
+	0x8d, 0x58, 0x10, 0xbf, 0xb5, 0x18, 0xa6, 0x29, 0xf7, 0x77, 0x55, 0x68, 0xeb, 0x79, 0xbd, 0x1f,
+	0x1b, 0xd1, 0xe5, 0xd1, 0xb7, 0xb3, 0xa2, 0x0a, 0x89, 0xd2, 0x72, 0x6a, 0x0b, 0xcb, 0xd9, 0xc1,
+	0xfc, 0x87, 0x9d, 0x67, 0xf0, 0x98, 0xd1, 0x38, 0xd7, 0x40, 0xc4, 0x49, 0x92, 0x81, 0xa3, 0x25,
+	0x71, 0xae, 0x03, 0x16, 0x8e, 0x33, 0x70, 0x34, 0x94, 0xf2, 0x7c, 0xf4, 0xcc, 0x0c, 0x1c, 0x2d,
+	0x49, 0xae, 0x03, 0x4c, 0xc2, 0xd4, 0x0a, 0x35, 0x40, 0x16, 0x38, 0xe4, 0x3e, 0x34, 0x75, 0xdd,
+	0x98, 0x1d, 0x22, 0xed, 0xa9, 0xa0, 0x60, 0x53, 0x9a, 0xe9, 0x60, 0x85, 0x61, 0x0c, 0xe7, 0x61,
+	0x31, 0x1d, 0xf9, 0xfa, 0x4c, 0x59, 0xa3, 0x1d, 0xc3, 0x7e, 0xa1, 0xb9, 0xe4, 0x16, 0x74, 0xb4,
+	0x21, 0x33, 0xbd, 0xb6, 0xce, 0xcf, 0x9a, 0x6b, 0xd4, 0xdc, 0x67, 0xd0, 0x5d, 0xf4, 0xd1, 0x77,
+	0xba, 0xc1, 0x07, 0xd0, 0x78, 0xab, 0xf4, 0x95, 0xa9, 0x37, 0xa9, 0xa1, 0xdc, 0x3f, 0x85, 0x56,
+	0xe6, 0x92, 0xe4, 0x2e, 0xd4, 0x07, 0x22, 0x9e, 0x38, 0x95, 0x77, 0xc7, 0x82, 0x52, 0xc2, 0xbd,
+	0x4b, 0x25, 0x13, 0x16, 0x9c, 0x35, 0x81, 0x70, 0xc8, 0xa3, 0xc0, 0x60, 0x31, 0x7e, 0xba, 0x6f,
+	0xa0, 0x69, 0x30, 0x5b, 0x79, 0x93, 0x1f, 0x47, 0x11, 0xf7, 0xa5, 0xad, 0x4b, 0x0c, 0x49, 0x3e,
+	0x54, 0x6e, 0xea, 0x49, 0xe3, 0x67, 0x35, 0xda, 0x48, 0xb8, 0x38, 0x16, 0x73, 0x6c, 0x12, 0x9f,
+	0x71, 0xc1, 0xc6, 0xb6, 0x08, 0xb1, 0xa4, 0xfb, 0xcf, 0x15, 0x68, 0x53, 0x3c, 0x5a, 0x98, 0xda,
+	0x6c, 0x07, 0x9a, 0x4c, 0xe2, 0x69, 0x45, 0xda, 0xa3, 0x56, 0x46, 0xa3, 0x55, 0x4f, 0xa6, 0xc1,
+	0x90, 0x4b, 0xaf, 0x18, 0x0c, 0x9b, 0x74, 0x53, 0x73, 0x8f, 0x34, 0x13, 0xab, 0x1e, 0xac, 0xae,
+	0x04, 0xd7, 0x29, 0xae, 0xa6, 0x74, 0x60, 0x12, 0x46, 0x54, 0x73, 0x10, 0x9e, 0x4e, 0x58, 0xca,
+	0xbd, 0x13, 0xe6, 0x9f, 0xc6, 0x83, 0x81, 0xc9, 0x25, 0x6d, 0xe4, 0x3d, 0xd1, 0x2c, 0xd5, 0x07,
+	0x9b, 0x65, 0x1a, 0xba, 0xf2, 0x81, 0x09, 0x9b, 0x19, 0x05, 0xf7, 0x3f, 0x2a, 0xd0, 0xd9, 0x0f,
+	0x85, 0x3f, 0x0d, 0xe5, 0x13, 0xc1, 0xd9, 0x29, 0x17, 0xb6, 0x4d, 0xc2, 0x23, 0x2c, 0x05, 0xcd,
+	0xec, 0xb1, 0xcd, 0x91, 0xe6, 0xa0, 0xfb, 0xa0, 0x82, 0xb1, 0x96, 0xaa, 0x70, 0xf5, 0x02, 0x30,
+	0x8d, 0xed, 0xe7, 0x5c, 0x2c, 0x22, 0xcd, 0x19, 0xd7, 0xac, 0x53, 0xaf, 0x61, 0x43, 0x9f, 0x69,
+	0xcd, 0x32, 0x3f, 0x86, 0x0d, 0xbd, 0x4c, 0x75, 0x40, 0x4f, 0xd5, 0x2a, 0x36, 0x69, 0x5b, 0xad,
+	0x53, 0xb3, 0x94, 0xbf, 0x84, 0x11, 0x46, 0xb7, 0x5e, 0x80, 0xa1, 0x10, 0x88, 0xe2, 0x84, 0x47,
+	0xa6, 0x22, 0x52, 0xdf, 0xee, 0xdf, 0x54, 0x00, 0x72, 0x78, 0xc5, 0x7b, 0x13, 0x93, 0xb7, 0xf5,
+	0x3e, 0xe4, 0xf7, 0x0f, 0xd6, 0x0f, 0x68, 0xa6, 0x40, 0x6e, 0x63, 0xd2, 0xb3, 0xbb, 0x9e, 0x07,
+	0x51, 0x61, 0x5f, 0xa9, 0x56, 0x20, 0x9f, 0xc3, 0x96, 0xaf, 0xad, 0xe6, 0x9d, 0x68, 0xb3, 0xa9,
+	0xb5, 0xb5, 0x1f, 0x5e, 0xb5, 0x37, 0x22, 0x25, 0x9b, 0xd2, 0x8e, 0x5f, 0xa2, 0xdd, 0x5f, 0x03,
+	0xe4, 0x28, 0xad, 0x80, 0x3c, 0x9e, 0x0a, 0x5f, 0x63, 0x52, 0xa7, 0x04, 0xe4, 0x7d, 0x25, 0xa0,
+	0x46, 0x21, 0xcb, 0xbb, 0xd5, 0x3c, 0xef, 0xba, 0x09, 0xb4, 0x7b, 0x51, 0x90, 0xc4, 0x61, 0x24,
+	0x8f, 0x5f, 0xf4, 0x71, 0xff, 0xf0, 0x32, 0x8d, 0x0b, 0xaf, 0x00, 0x73, 0xa0, 0x59, 0xaf, 0x10,
+	0xe8, 0x3a, 0x50, 0xf5, 0x99, 0xea, 0x61, 0x83, 0x56, 0x7d, 0x46, 0x7e, 0x02, 0x57, 0xc2, 0x48,
+	0xdf, 0x88, 0x79, 0xe9, 0x69, 0x98, 0x78, 0x67, 0x5c, 0x84, 0x83, 0xb9, 0x49, 0xfd, 0xc4, 0xca,
+	0xfa, 0xa7, 0x61, 0xf2, 0xa5, 0x92, 0xb8, 0x7f, 0x5b, 0x85, 0xa6, 0x1d, 0x12, 0x83, 0x82, 0x05,
+	0x81, 0xe0, 0x19, 0x5a, 0x5b, 0xf2, 0xbc, 0x38, 0x57, 0x79, 0x3f, 0x16, 0xd6, 0x1d, 0xd4, 0x37,
+	0xea, 0xa6, 0xfe, 0x88, 0x4f, 0xec, 0x51, 0xdf, 0x50, 0xe4, 0x26, 0xd4, 0xe4, 0x58, 0x1f, 0xa1,
+	0xf2, 0x1d, 0x29, 0x2c, 0x97, 0xa2, 0x78, 0x95, 0x4b, 0x36, 0x56, 0xba, 0xe4, 0x23, 0x68, 0x8c,
+	0xd9, 0x09, 0x1f, 0xdb, 0xcb, 0xbc, 0x8f, 0x16, 0x7a, 0xbc, 0xff, 0x42, 0x49, 0xb1, 0x88, 0x9c,
+	0x53, 0xa3, 0xba, 0xf3, 0x73, 0x68, 0x17, 0xd8, 0x08, 0x2b, 0xa7, 0x7c, 0x6e, 0x16, 0x8b, 0x9f,
+	0xab, 0x6b, 0xa8, 0x5f, 0x54, 0xff, 0xa0, 0xe2, 0xfe, 0x5d, 0x55, 0x1d, 0xb9, 0xc7, 0x72, 0xb4,
+	0x3f, 0xe2, 0xfe, 0x29, 0xb9, 0x03, 0x75, 0x75, 0x5f, 0xa1, 0x37, 0xfa, 0x83, 0xfc, 0xe8, 0x6c,
+	0x35, 0xf0, 0x64, 0x45, 0x95, 0x4e, 0x56, 0x1e, 0x55, 0x0b, 0xe5, 0xd1, 0x27, 0xb0, 0xc5, 0x67,
+	0x09, 0xc7, 0x62, 0xda, 0x33, 0x37, 0x07, 0xda, 0x8a, 0x1d, 0xcb, 0xd6, 0x90, 0x8f, 0xe5, 0xef,
+	0x49, 0x1c, 0xcc, 0x3d, 0x5d, 0xc5, 0x69, 0x9b, 0xb6, 0x90, 0x43, 0x91, 0x81, 0xf8, 0x14, 0x46,
+	0x92, 0x8b, 0x33, 0x36, 0x36, 0x41, 0x95, 0xd1, 0xb8, 0xa1, 0xb6, 0xd6, 0xd5, 0x91, 0x65, 0x49,
+	0x72, 0x17, 0xb6, 0x47, 0x6a, 0xaa, 0x73, 0x4f, 0x8e, 0x04, 0x4f, 0x47, 0xf1, 0x58, 0x27, 0xab,
+	0x4d, 0xda, 0x35, 0x82, 0x63, 0xcb, 0x27, 0x0f, 0xe0, 0xf2, 0x34, 0x5a, 0x56, 0x6f, 0x2a, 0x75,
+	0x32, 0x8d, 0x16, 0x1b, 0xb8, 0xff, 0x52, 0x81, 0xee, 0xe1, 0x54, 0x8e, 0x43, 0x2e, 0x9e, 0x72,
+	0xa9, 0x77, 0x0c, 0x17, 0xec, 0xc7, 0xca, 0x03, 0x65, 0x78, 0xc6, 0xbd, 0xc7, 0xb3, 0x99, 0x41,
+	0xa4, 0x4e, 0x81, 0xfd, 0x78, 0x36, 0x23, 0x7f, 0x04, 0x3b, 0x45, 0x45, 0xe3, 0x0a, 0x9e, 0xc2,
+	0x1a, 0x0b, 0x50, 0x4e, 0x41, 0xc3, 0x78, 0x85, 0xba, 0x1b, 0x53, 0x50, 0xa5, 0xb0, 0x94, 0x7f,
+	0xad, 0xc7, 0xb5, 0x05, 0x3c, 0x32, 0x7b, 0x86, 0xa7, 0xa0, 0x8a, 0xcd, 0x72, 0x1d, 0x03, 0xb8,
+	0x13, 0x36, 0xb3, 0x2a, 0xee, 0xbf, 0x56, 0xa1, 0x99, 0xe5, 0xc0, 0x55, 0x45, 0xf2, 0x3d, 0x3c,
+	0x76, 0x6b, 0x5f, 0x4b, 0xcd, 0x0d, 0xcb, 0xd6, 0x82, 0x0f, 0xd2, 0x5c, 0x03, 0xf1, 0x2b, 0x95,
+	0x82, 0x49, 0x3e, 0xd4, 0xf1, 0xd8, 0xc9, 0xb4, 0xfb, 0x86, 0x4d, 0x33, 0x05, 0x72, 0x0b, 0xea,
+	0x23, 0x96, 0xea, 0x13, 0xf0, 0xca, 0x72, 0x50, 0x89, 0xc9, 0x63, 0xd8, 0xd0, 0xb6, 0xf7, 0x7c,
+	0xf4, 0xb8, 0x85, 0xd8, 0x2a, 0xf8, 0x22, 0x6d, 0x8f, 0x72, 0x82, 0x3c, 0x85, 0xed, 0x58, 0xef,
+	0x8e, 0x17, 0xd8, 0xed, 0x51, 0x0e, 0x92, 0x67, 0xe7, 0xc5, 0xdd, 0xa3, 0xdd, 0x78, 0x71, 0x3f,
+	0x6f, 0x41, 0x27, 0x11, 0xf1, 0x6c, 0xee, 0xa9, 0x4b, 0x7d, 0x3f, 0x1e, 0x1b, 0xff, 0xd9, 0x54,
+	0xdc, 0x23, 0xc3, 0xc4, 0x1b, 0xc5, 0x8e, 0xb5, 0x87, 0x9e, 0xd1, 0x3b, 0x6b, 0xb7, 0x62, 0xb5,
+	0x51, 0x5d, 0x2e, 0x3a, 0x2d, 0x3e, 0xd5, 0xca, 0xf8, 0xe4, 0xc0, 0xba, 0x71, 0x42, 0x65, 0xb2,
+	0x26, 0xb5, 0xa4, 0x3a, 0x71, 0x8f, 0x58, 0x34, 0xd4, 0x27, 0xee, 0x35, 0x73, 0xe2, 0xd6, 0x9c,
+	0x3d, 0xa9, 0x2f, 0xce, 0x58, 0x6a, 0xd6, 0xdf, 0xa2, 0x86, 0x72, 0xff, 0xa2, 0x30, 0x6b, 0x13,
+	0x87, 0xf7, 0xa0, 0xa1, 0x3b, 0x75, 0x2a, 0xa5, 0x04, 0x51, 0x5e, 0x1c, 0x35, 0x4a, 0x2a, 0x65,
+	0x7e, 0xad, 0xc3, 0x7b, 0x1a, 0xc9, 0xd0, 0x9e, 0xe5, 0x36, 0x0c, 0xf3, 0x35, 0xf2, 0x30, 0x26,
+	0x98, 0xaf, 0xbc, 0x3c, 0xcb, 0x9a, 0xda, 0x5d, 0x3b, 0x9a, 0x6d, 0x13, 0xa7, 0xfb, 0xdf, 0x6b,
+	0xd0, 0x7c, 0xc2, 0xc6, 0x78, 0x27, 0x2b, 0xc8, 0xc7, 0xa0, 0x1f, 0x62, 0x4c, 0x45, 0xd5, 0xb6,
+	0x7e, 0x14, 0x4a, 0x4e, 0xb5, 0x04, 0x55, 0xa2, 0x58, 0x72, 0xeb, 0x98, 0xed, 0xfb, 0xfa, 0xfd,
+	0xe5, 0x55, 0x1c, 0x70, 0xaa, 0x25, 0x08, 0x75, 0x6a, 0xe7, 0x8c, 0x2d, 0x35, 0x91, 0x21, 0xba,
+	0xb9, 0xf1, 0xc4, 0xef, 0x92, 0xeb, 0xae, 0x5d, 0xd4, 0x75, 0x1b, 0xef, 0x76, 0xdd, 0x52, 0xf4,
+	0xac, 0x5f, 0x24, 0x7a, 0xb2, 0x57, 0x93, 0xe6, 0xfb, 0x5e, 0x4d, 0xb2, 0xab, 0xe9, 0x56, 0xf1,
+	0x6a, 0xda, 0x1e, 0x76, 0x20, 0x3f, 0x8a, 0x2f, 0xdd, 0x08, 0xb4, 0x97, 0x6f, 0x04, 0xf0, 0x82,
+	0xc2, 0x5c, 0x59, 0xe1, 0x80, 0xea, 0x84, 0xd6, 0xa2, 0xf6, 0x1a, 0x4b, 0x3d, 0x63, 0xdd, 0x87,
+	0xcb, 0xe5, 0x48, 0xf0, 0x54, 0xbd, 0xbb, 0xa9, 0x06, 0xda, 0x2e, 0x85, 0xc3, 0x33, 0xac, 0x71,
+	0x97, 0x23, 0xa7, 0xb3, 0x22, 0x72, 0xd0, 0x39, 0xa4, 0x98, 0xa6, 0xe8, 0x41, 0x28, 0x08, 0xcd,
+	0x53, 0x4a, 0x8b, 0x76, 0x0c, 0xfb, 0x48, 0x73, 0xf1, 0x11, 0x63, 0x10, 0x8b, 0xb7, 0x4c, 0x04,
+	0x3c, 0xb0, 0x37, 0x5f, 0xdd, 0x52, 0x5a, 0x7a, 0x66, 0xc5, 0xe6, 0x6a, 0x77, 0x6b, 0x50, 0x66,
+	0x2c, 0x21, 0xc9, 0xf6, 0xff, 0x03, 0x49, 0xc8, 0x77, 0x44, 0x12, 0xf7, 0x2f, 0x2b, 0xb0, 0x85,
+	0x86, 0xdc, 0xe7, 0x42, 0x86, 0x83, 0xd0, 0x67, 0xef, 0x79, 0x1a, 0xdc, 0x85, 0xb6, 0x9f, 0xab,
+	0x9a, 0xfa, 0xa7, 0xc8, 0xb2, 0x89, 0xbd, 0xa6, 0x24, 0xf8, 0x89, 0x17, 0x8b, 0x51, 0x2c, 0xcd,
+	0x0b, 0x82, 0x86, 0xfb, 0x66, 0x14, 0x4b, 0xf5, 0x7e, 0x80, 0xae, 0xc1, 0xfc, 0x89, 0x7d, 0x53,
+	0x54, 0xdf, 0xee, 0x21, 0xb4, 0xf7, 0xfc, 0x09, 0xdf, 0xf3, 0xfd, 0x78, 0x1a, 0x49, 0xbc, 0x64,
+	0xd5, 0xef, 0x0b, 0xb1, 0xb0, 0x05, 0x43, 0xce, 0xc0, 0xf1, 0xa6, 0x22, 0x34, 0x80, 0x85, 0x9f,
+	0xcb, 0x33, 0x70, 0xbf, 0x06, 0xe7, 0x75, 0x82, 0xd7, 0xd1, 0x85, 0x65, 0x9a, 0xf8, 0xfe, 0xbe,
+	0x57, 0xeb, 0x7e, 0x03, 0xd7, 0x56, 0x8c, 0xa5, 0x9f, 0xbe, 0xde, 0x39, 0x18, 0xde, 0xbf, 0x46,
+	0xa9, 0xaa, 0x37, 0xed, 0x4d, 0x71, 0x33, 0x88, 0x52, 0xac, 0x36, 0xd3, 0xb2, 0x0d, 0x6b, 0x65,
+	0x1b, 0xba, 0x3f, 0x05, 0xe7, 0x29, 0x1f, 0x73, 0xc9, 0xbf, 0xdb, 0xf2, 0xdc, 0x8f, 0xe0, 0xda,
+	0x8a, 0x76, 0x7a, 0xaa, 0xee, 0x73, 0xd8, 0xde, 0x57, 0xb7, 0xa3, 0xfd, 0x30, 0xef, 0xcd, 0x42,
+	0x54, 0xa5, 0x00, 0x51, 0x37, 0xa0, 0x9e, 0x86, 0xc6, 0x3a, 0x0b, 0x88, 0xa8, 0x04, 0xee, 0x8f,
+	0xa1, 0xf3, 0x05, 0x97, 0xfd, 0xf0, 0x62, 0x93, 0x22, 0xd0, 0x7d, 0x11, 0xa6, 0x4a, 0x3d, 0x35,
+	0xfa, 0xee, 0x1e, 0x34, 0x91, 0x3e, 0x88, 0x06, 0x71, 0x36, 0x5c, 0xe5, 0x9c, 0xe1, 0x14, 0xb8,
+	0xc6, 0x42, 0x66, 0xaf, 0xd7, 0x8a, 0x70, 0x7f, 0x01, 0xdb, 0x85, 0x6e, 0xcd, 0x76, 0xdc, 0x2a,
+	0xa3, 0xf9, 0x56, 0xa1, 0x33, 0x1c, 0xcb, 0x20, 0xba, 0xfb, 0x19, 0x6c, 0xbf, 0x4e, 0x82, 0x05,
+	0x53, 0xbc, 0x6f, 0x1e, 0xee, 0x3e, 0x6c, 0x6b, 0xeb, 0x5e, 0x70, 0xe5, 0x99, 0x71, 0xab, 0xb9,
+	0x71, 0xdd, 0x2b, 0x40, 0x8a, 0x9d, 0x98, 0xbd, 0xf9, 0x13, 0x20, 0x47, 0x53, 0x99, 0x1d, 0xdf,
+	0x2f, 0xd0, 0xf7, 0xdd, 0x85, 0xdc, 0xbe, 0xe2, 0x05, 0x3e, 0x53, 0x70, 0xbf, 0x80, 0xab, 0x7a,
+	0xd0, 0xef, 0x32, 0xc2, 0xaa, 0x43, 0xd5, 0x5d, 0xd8, 0xd4, 0x19, 0xfc, 0x22, 0x1b, 0xff, 0xc7,
+	0xd0, 0xb1, 0xca, 0x66, 0x7b, 0x1e, 0x15, 0x13, 0x95, 0xde, 0xa2, 0xc5, 0xcc, 0x6f, 0x5a, 0xe4,
+	0x7a, 0x6a, 0x1e, 0x71, 0x90, 0xcf, 0x23, 0x0e, 0xb8, 0x7b, 0x0c, 0xdd, 0x3e, 0x97, 0xea, 0xd2,
+	0xf5, 0x22, 0x53, 0x29, 0xfc, 0x9d, 0xa0, 0x7a, 0xfe, 0xdf, 0x09, 0xdc, 0x3f, 0x47, 0xec, 0xe4,
+	0x52, 0xdf, 0xb6, 0x5c, 0xa0, 0xd7, 0xd5, 0x77, 0x63, 0xd9, 0x7d, 0x65, 0xed, 0x42, 0xf7, 0x95,
+	0xc4, 0x5c, 0x42, 0x6a, 0x1c, 0x55, 0xdf, 0xee, 0x4f, 0x80, 0x14, 0xee, 0xe7, 0x2e, 0x62, 0xeb,
+	0x7d, 0xb8, 0x5c, 0x6a, 0x61, 0x0c, 0xfe, 0x63, 0x58, 0xd7, 0x77, 0x6f, 0xd6, 0xdc, 0xa4, 0x74,
+	0x79, 0xa9, 0x95, 0xad, 0x8a, 0xfb, 0x67, 0x15, 0xd8, 0x56, 0x6f, 0x39, 0xfa, 0x3f, 0x0e, 0x17,
+	0xf3, 0x11, 0xf5, 0x8e, 0x53, 0x3d, 0xe7, 0x1d, 0xa7, 0x76, 0xce, 0x3b, 0x4e, 0xbd, 0xf0, 0x8e,
+	0xd3, 0x85, 0x1a, 0x5e, 0x1d, 0xe9, 0x7c, 0x81, 0x9f, 0xee, 0x2f, 0x81, 0x14, 0xa7, 0x61, 0xd6,
+	0xf2, 0x09, 0xac, 0xa9, 0x77, 0x11, 0xa7, 0x52, 0xaa, 0x86, 0xf2, 0x17, 0x28, 0xaa, 0xe5, 0xee,
+	0x03, 0xd8, 0xce, 0x1f, 0x7e, 0x2e, 0x62, 0xbc, 0x5f, 0x01, 0x29, 0x36, 0x30, 0xe3, 0xfd, 0x08,
+	0x1a, 0xea, 0x4f, 0x1e, 0xd6, 0x74, 0xa5, 0x01, 0xb5, 0xaa, 0x51, 0xb8, 0x73, 0x0f, 0x5a, 0xd9,
+	0x55, 0x3e, 0x01, 0x68, 0x1c, 0xd1, 0xde, 0xb3, 0x83, 0xaf, 0xba, 0x97, 0x48, 0x0b, 0xd6, 0x7a,
+	0x5f, 0xed, 0xed, 0x1f, 0x77, 0x2b, 0xf8, 0x49, 0x7b, 0x5f, 0xf4, 0xbe, 0xea, 0x56, 0xef, 0xa4,
+	0xd0, 0xb4, 0xc5, 0x1e, 0xd9, 0x82, 0x36, 0x3d, 0x7c, 0xfd, 0xea, 0xa9, 0x47, 0x0f, 0x9f, 0x1c,
+	0xbc, 0xea, 0x5e, 0x22, 0x0e, 0x5c, 0x79, 0xd3, 0x3b, 0xf8, 0xe2, 0xf9, 0x71, 0xef, 0xa9, 0x57,
+	0x94, 0x54, 0xc8, 0x55, 0xd8, 0x7e, 0xd1, 0xdb, 0xeb, 0x1f, 0x7b, 0xfb, 0x87, 0xaf, 0x5e, 0xf5,
+	0xf6, 0x8f, 0x0f, 0x0e, 0x5f, 0xf5, 0xbb, 0x55, 0xd2, 0x85, 0x8d, 0xa3, 0xc3, 0x37, 0x3d, 0xea,
+	0x1d, 0x3e, 0xf3, 0x8e, 0xdf, 0x1c, 0x76, 0x6b, 0xe4, 0x32, 0x6c, 0xed, 0x1f, 0xbe, 0xea, 0x1f,
+	0xf4, 0x8f, 0x7b, 0xaf, 0x8e, 0xbd, 0xe7, 0x7b, 0xfd, 0xe7, 0xdd, 0xfa, 0x9d, 0x47, 0x00, 0xf9,
+	0xcd, 0x09, 0xd9, 0x84, 0xd6, 0xfe, 0x8b, 0x03, 0x14, 0x1f, 0x1c, 0x75, 0x2f, 0xe1, 0x9c, 0x9f,
+	0xf7, 0xf6, 0x9e, 0xf6, 0x68, 0xb7, 0x82, 0xdf, 0xfb, 0x87, 0x87, 0xbf, 0x3e, 0xe8, 0x75, 0xab,
+	0x77, 0x6e, 0xc2, 0xd6, 0xc2, 0x29, 0x9c, 0x34, 0xa1, 0xfe, 0xfc, 0xf8, 0x18, 0x1b, 0xad, 0x43,
+	0xed, 0x78, 0xff, 0xa8, 0x5b, 0xb9, 0xf3, 0x18, 0xb6, 0x16, 0x8a, 0x22, 0x9c, 0xc2, 0x57, 0xde,
+	0xb3, 0x43, 0xfa, 0x66, 0x8f, 0x3e, 0xed, 0x3d, 0xc5, 0xaf, 0xee, 0x25, 0x1c, 0x34, 0x63, 0x75,
+	0x2b, 0x0f, 0xff, 0x6b, 0x1d, 0x36, 0x14, 0x7c, 0xf7, 0xf5, 0xbf, 0x9c, 0xc8, 0xcf, 0x00, 0xf2,
+	0x0c, 0x45, 0x1c, 0x6b, 0xef, 0xc5, 0xa4, 0xb5, 0xb3, 0x08, 0xeb, 0xe4, 0x53, 0x58, 0x37, 0x09,
+	0x89, 0x58, 0x3c, 0x29, 0x27, 0xa8, 0xe5, 0x26, 0x9f, 0x43, 0x2b, 0x4b, 0x1f, 0xc4, 0xc6, 0xe8,
+	0x62, 0x9e, 0xda, 0x71, 0x96, 0x05, 0xc6, 0x3b, 0x7e, 0x06, 0x90, 0xa7, 0x90, 0x6c, 0xae, 0x4b,
+	0x59, 0x65, 0x79, 0xe0, 0x3d, 0x80, 0x3c, 0x01, 0x64, 0x0d, 0x97, 0x12, 0xcb, 0xce, 0xb5, 0x15,
+	0x12, 0x33, 0xf6, 0xcf, 0xa1, 0x5d, 0xc8, 0x16, 0xc4, 0x6a, 0x2e, 0x67, 0x90, 0xe5, 0xd1, 0x7f,
+	0x05, 0x9d, 0x72, 0x26, 0x20, 0x3f, 0x28, 0x8d, 0xf3, 0xde, 0x0e, 0x1e, 0x43, 0x2b, 0x43, 0xde,
+	0xcc, 0x6e, 0x8b, 0x58, 0xbc, 0xdc, 0xec, 0x11, 0x34, 0x2d, 0xb2, 0x92, 0xfc, 0xdf, 0x40, 0x25,
+	0xa8, 0x5d, 0x35, 0x56, 0xc3, 0x9c, 0x17, 0xaf, 0x94, 0x6e, 0xee, 0x6d, 0x83, 0xab, 0x0b, 0x5c,
+	0x63, 0x9e, 0xa7, 0xe5, 0xd7, 0x8d, 0x6b, 0x2b, 0x20, 0xcf, 0x74, 0xb0, 0xb3, 0x4a, 0x64, 0x7a,
+	0xd9, 0x03, 0xc8, 0x41, 0x28, 0xdb, 0xa7, 0x25, 0x78, 0xdc, 0xb9, 0xb6, 0x42, 0x92, 0x77, 0x51,
+	0x78, 0x81, 0x76, 0x96, 0xf1, 0x63, 0xa1, 0x8b, 0x15, 0x20, 0xf4, 0x25, 0x6c, 0x2f, 0x15, 0x9f,
+	0xe4, 0x46, 0xe6, 0x6d, 0xab, 0x4b, 0xe0, 0x9d, 0xdd, 0xf3, 0x15, 0xf2, 0x7e, 0x97, 0x2a, 0xc5,
+	0xac, 0xdf, 0xf3, 0x6a, 0xcf, 0x9d, 0xdd, 0xf3, 0x15, 0x74, 0xbf, 0x4f, 0xba, 0xff, 0xf6, 0xed,
+	0xf5, 0xca, 0x7f, 0x7e, 0x7b, 0xbd, 0xf2, 0xbf, 0xdf, 0x5e, 0xaf, 0xfc, 0xf6, 0x77, 0xd7, 0x2f,
+	0x9d, 0x34, 0xd4, 0xc1, 0xec, 0xd1, 0xff, 0x05, 0x00, 0x00, 0xff, 0xff, 0x8b, 0x2e, 0x7c, 0x2e,
+	0x17, 0x29, 0x00, 0x00,
 }
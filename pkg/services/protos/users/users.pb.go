@@ -9,6 +9,8 @@
 
 	It has these top-level messages:
 		User
+		ExportRequest
+		ExportResponse
 */
 package users
 
@@ -17,6 +19,11 @@ import fmt "fmt"
 import math "math"
 import certificates "github.com/unerror/waffy/pkg/services/protos/certificates"
 
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
 import io "io"
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -52,6 +59,28 @@ func (x Role) String() string {
 }
 func (Role) EnumDescriptor() ([]byte, []int) { return fileDescriptorUsers, []int{0} }
 
+// BundleFormat is the format of an exported user certificate bundle
+type BundleFormat int32
+
+const (
+	BundleFormat_P12        BundleFormat = 0
+	BundleFormat_PEM_BUNDLE BundleFormat = 1
+)
+
+var BundleFormat_name = map[int32]string{
+	0: "P12",
+	1: "PEM_BUNDLE",
+}
+var BundleFormat_value = map[string]int32{
+	"P12":        0,
+	"PEM_BUNDLE": 1,
+}
+
+func (x BundleFormat) String() string {
+	return proto.EnumName(BundleFormat_name, int32(x))
+}
+func (BundleFormat) EnumDescriptor() ([]byte, []int) { return fileDescriptorUsers, []int{1} }
+
 // User is a user who can access the system
 type User struct {
 	Email       string                    `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
@@ -93,10 +122,136 @@ func (m *User) GetCertificate() *certificates.Certificate {
 	return nil
 }
 
+type ExportRequest struct {
+	Email    string       `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Format   BundleFormat `protobuf:"varint,2,opt,name=format,proto3,enum=users.BundleFormat" json:"format,omitempty"`
+	Password string       `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+}
+
+func (m *ExportRequest) Reset()                    { *m = ExportRequest{} }
+func (m *ExportRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportRequest) ProtoMessage()               {}
+func (*ExportRequest) Descriptor() ([]byte, []int) { return fileDescriptorUsers, []int{1} }
+
+func (m *ExportRequest) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *ExportRequest) GetFormat() BundleFormat {
+	if m != nil {
+		return m.Format
+	}
+	return BundleFormat_P12
+}
+
+func (m *ExportRequest) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type ExportResponse struct {
+	Bundle []byte `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+}
+
+func (m *ExportResponse) Reset()                    { *m = ExportResponse{} }
+func (m *ExportResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExportResponse) ProtoMessage()               {}
+func (*ExportResponse) Descriptor() ([]byte, []int) { return fileDescriptorUsers, []int{2} }
+
+func (m *ExportResponse) GetBundle() []byte {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*User)(nil), "users.User")
+	proto.RegisterType((*ExportRequest)(nil), "users.ExportRequest")
+	proto.RegisterType((*ExportResponse)(nil), "users.ExportResponse")
 	proto.RegisterEnum("users.Role", Role_name, Role_value)
+	proto.RegisterEnum("users.BundleFormat", BundleFormat_name, BundleFormat_value)
 }
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for UsersService service
+
+type UsersServiceClient interface {
+	// Export a user's key and certificate, with the CA chain, as a bundle
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error)
+}
+
+type usersServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewUsersServiceClient(cc *grpc.ClientConn) UsersServiceClient {
+	return &usersServiceClient{cc}
+}
+
+func (c *usersServiceClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error) {
+	out := new(ExportResponse)
+	err := grpc.Invoke(ctx, "/users.UsersService/Export", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for UsersService service
+
+type UsersServiceServer interface {
+	// Export a user's key and certificate, with the CA chain, as a bundle
+	Export(context.Context, *ExportRequest) (*ExportResponse, error)
+}
+
+func RegisterUsersServiceServer(s *grpc.Server, srv UsersServiceServer) {
+	s.RegisterService(&_UsersService_serviceDesc, srv)
+}
+
+func _UsersService_Export_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).Export(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/users.UsersService/Export",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).Export(ctx, req.(*ExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _UsersService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "users.UsersService",
+	HandlerType: (*UsersServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Export",
+			Handler:    _UsersService_Export_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/services/protos/users/users.proto",
+}
+
 func (m *User) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -142,6 +297,65 @@ func (m *User) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *ExportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Email) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintUsers(dAtA, i, uint64(len(m.Email)))
+		i += copy(dAtA[i:], m.Email)
+	}
+	if m.Format != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintUsers(dAtA, i, uint64(m.Format))
+	}
+	if len(m.Password) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintUsers(dAtA, i, uint64(len(m.Password)))
+		i += copy(dAtA[i:], m.Password)
+	}
+	return i, nil
+}
+
+func (m *ExportResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Bundle) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintUsers(dAtA, i, uint64(len(m.Bundle)))
+		i += copy(dAtA[i:], m.Bundle)
+	}
+	return i, nil
+}
+
 func encodeFixed64Users(dAtA []byte, offset int, v uint64) int {
 	dAtA[offset] = uint8(v)
 	dAtA[offset+1] = uint8(v >> 8)
@@ -190,6 +404,33 @@ func (m *User) Size() (n int) {
 	return n
 }
 
+func (m *ExportRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Email)
+	if l > 0 {
+		n += 1 + l + sovUsers(uint64(l))
+	}
+	if m.Format != 0 {
+		n += 1 + sovUsers(uint64(m.Format))
+	}
+	l = len(m.Password)
+	if l > 0 {
+		n += 1 + l + sovUsers(uint64(l))
+	}
+	return n
+}
+
+func (m *ExportResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Bundle)
+	if l > 0 {
+		n += 1 + l + sovUsers(uint64(l))
+	}
+	return n
+}
+
 func sovUsers(x uint64) (n int) {
 	for {
 		n++
@@ -363,6 +604,214 @@ func (m *User) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ExportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUsers
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsers
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUsers
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Email = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+			}
+			m.Format = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsers
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Format |= (BundleFormat(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Password", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsers
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUsers
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Password = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUsers(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthUsers
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExportResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUsers
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bundle", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUsers
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthUsers
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bundle = append(m.Bundle[:0], dAtA[iNdEx:postIndex]...)
+			if m.Bundle == nil {
+				m.Bundle = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUsers(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthUsers
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipUsers(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -471,21 +920,30 @@ var (
 func init() { proto.RegisterFile("pkg/services/protos/users/users.proto", fileDescriptorUsers) }
 
 var fileDescriptorUsers = []byte{
-	// 244 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x2d, 0xc8, 0x4e, 0xd7,
-	0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0x2d, 0xd6, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0x2f, 0xd6,
-	0x2f, 0x2d, 0x4e, 0x2d, 0x82, 0x92, 0x7a, 0x60, 0x21, 0x21, 0x56, 0x30, 0x47, 0xca, 0x27, 0x3d,
-	0xb3, 0x24, 0xa3, 0x34, 0x49, 0x2f, 0x39, 0x3f, 0x57, 0xbf, 0x34, 0x2f, 0xb5, 0xa8, 0x28, 0xbf,
-	0x48, 0xbf, 0x3c, 0x31, 0x2d, 0xad, 0x52, 0x1f, 0x9b, 0x31, 0xc9, 0xa9, 0x45, 0x25, 0x99, 0x69,
-	0x99, 0xc9, 0x89, 0x25, 0xa9, 0xa8, 0x1c, 0x88, 0xa1, 0x4a, 0x7d, 0x8c, 0x5c, 0x2c, 0xa1, 0xc5,
-	0xa9, 0x45, 0x42, 0x22, 0x5c, 0xac, 0xa9, 0xb9, 0x89, 0x99, 0x39, 0x12, 0x8c, 0x0a, 0x8c, 0x1a,
-	0x9c, 0x41, 0x10, 0x8e, 0x90, 0x10, 0x17, 0x4b, 0x5e, 0x62, 0x6e, 0xaa, 0x04, 0x13, 0x58, 0x10,
-	0xcc, 0x16, 0x92, 0xe7, 0x62, 0x29, 0xca, 0xcf, 0x49, 0x95, 0x60, 0x56, 0x60, 0xd4, 0xe0, 0x33,
-	0xe2, 0xd6, 0x83, 0xb8, 0x31, 0x28, 0x3f, 0x27, 0x35, 0x08, 0x2c, 0x21, 0x64, 0xcd, 0xc5, 0x8d,
-	0x64, 0x93, 0x04, 0x8b, 0x02, 0xa3, 0x06, 0xb7, 0x91, 0xa4, 0x1e, 0x8a, 0xed, 0xce, 0x08, 0x4e,
-	0x10, 0xb2, 0x6a, 0x2d, 0x69, 0x2e, 0x16, 0x90, 0x51, 0x42, 0x1c, 0x5c, 0x2c, 0xa1, 0xc1, 0xae,
-	0x41, 0x02, 0x0c, 0x42, 0x9c, 0x5c, 0xac, 0x8e, 0x2e, 0xbe, 0x9e, 0x7e, 0x02, 0x8c, 0x4e, 0x02,
-	0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x8c, 0xc7, 0x72,
-	0x0c, 0x49, 0x6c, 0x60, 0x6f, 0x18, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0x54, 0xad, 0x63, 0x91,
-	0x44, 0x01, 0x00, 0x00,
+	// 386 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0xd1, 0x8a, 0xda, 0x40,
+	0x14, 0x86, 0x9d, 0x1a, 0x53, 0x3d, 0xda, 0x10, 0xa6, 0xb6, 0xa4, 0x16, 0x52, 0x11, 0x4a, 0x83,
+	0x85, 0x84, 0xa6, 0xf4, 0xaa, 0x57, 0xb5, 0xa6, 0x50, 0x50, 0x91, 0x11, 0xaf, 0x4b, 0x8c, 0x13,
+	0x1b, 0x9a, 0x64, 0xd2, 0x99, 0x64, 0xdd, 0x7d, 0x89, 0xbd, 0xde, 0x47, 0xda, 0xcb, 0x7d, 0x84,
+	0xc5, 0x7d, 0x91, 0xc5, 0x49, 0x76, 0x57, 0x97, 0xdd, 0x9b, 0x90, 0xff, 0x9c, 0xc3, 0xf9, 0xbf,
+	0x7f, 0x0e, 0x7c, 0xcc, 0xfe, 0x6d, 0x1c, 0x41, 0xf9, 0x49, 0x14, 0x50, 0xe1, 0x64, 0x9c, 0xe5,
+	0x4c, 0x38, 0x85, 0xa0, 0xbc, 0xfa, 0xda, 0xb2, 0x84, 0x1b, 0x52, 0xf4, 0x26, 0x9b, 0x28, 0xff,
+	0x5b, 0xac, 0xec, 0x80, 0x25, 0x4e, 0x91, 0x52, 0xce, 0x19, 0x77, 0xb6, 0x7e, 0x18, 0x9e, 0x39,
+	0x4f, 0xad, 0x09, 0x28, 0xcf, 0xa3, 0x30, 0x0a, 0xfc, 0x9c, 0x1e, 0x8b, 0x72, 0xe9, 0xe0, 0x1c,
+	0x81, 0xb2, 0x14, 0x94, 0xe3, 0x2e, 0x34, 0x68, 0xe2, 0x47, 0xb1, 0x81, 0xfa, 0xc8, 0x6a, 0x91,
+	0x52, 0x60, 0x0c, 0x4a, 0xea, 0x27, 0xd4, 0x78, 0x21, 0x8b, 0xf2, 0x1f, 0x7f, 0x00, 0x85, 0xb3,
+	0x98, 0x1a, 0xf5, 0x3e, 0xb2, 0x34, 0xb7, 0x6d, 0x97, 0x8c, 0x84, 0xc5, 0x94, 0xc8, 0x06, 0xfe,
+	0x0e, 0xed, 0x03, 0x27, 0x43, 0xe9, 0x23, 0xab, 0xed, 0xbe, 0xb3, 0x8f, 0xdc, 0x7f, 0x3e, 0x08,
+	0x72, 0x38, 0x3d, 0x48, 0xe1, 0x95, 0x77, 0x9a, 0x31, 0x9e, 0x13, 0xfa, 0xbf, 0xa0, 0x22, 0x7f,
+	0x06, 0xec, 0x33, 0xa8, 0x21, 0xe3, 0x89, 0x9f, 0x4b, 0x34, 0xcd, 0x7d, 0x5d, 0x61, 0x8c, 0x8a,
+	0x74, 0x1d, 0xd3, 0x5f, 0xb2, 0x45, 0xaa, 0x11, 0xdc, 0x83, 0x66, 0xe6, 0x0b, 0xb1, 0x65, 0x7c,
+	0x2d, 0xa9, 0x5b, 0xe4, 0x5e, 0x0f, 0x2c, 0xd0, 0xee, 0xfc, 0x44, 0xc6, 0x52, 0x41, 0xf1, 0x5b,
+	0x50, 0x57, 0x72, 0x8b, 0x74, 0xec, 0x90, 0x4a, 0x0d, 0xdf, 0x83, 0xb2, 0x0f, 0x89, 0x9b, 0xa0,
+	0x2c, 0x17, 0x1e, 0xd1, 0x6b, 0xb8, 0x05, 0x8d, 0x1f, 0xe3, 0xe9, 0xef, 0x99, 0x8e, 0x86, 0x9f,
+	0xa0, 0x73, 0x68, 0x8d, 0x5f, 0x42, 0x7d, 0xfe, 0xc5, 0xd5, 0x6b, 0x58, 0x03, 0x98, 0x7b, 0xd3,
+	0x3f, 0xa3, 0xe5, 0x6c, 0x3c, 0xf1, 0x74, 0xe4, 0x7a, 0xd0, 0xd9, 0xbf, 0xb7, 0x58, 0x94, 0x87,
+	0xc2, 0xdf, 0x40, 0x2d, 0xfd, 0x71, 0xb7, 0x8a, 0x70, 0x14, 0xbf, 0xf7, 0xe6, 0x51, 0xb5, 0x84,
+	0x1c, 0xe9, 0x97, 0x3b, 0x13, 0x5d, 0xed, 0x4c, 0x74, 0xbd, 0x33, 0xd1, 0xc5, 0x8d, 0x59, 0x5b,
+	0xa9, 0xf2, 0xa0, 0x5f, 0x6f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x4d, 0xcb, 0xac, 0x4f, 0x4e, 0x02,
+	0x00, 0x00,
 }
@@ -5,30 +5,75 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+
+	"github.com/unerror/waffy/pkg/config"
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/services/protos/certificates"
+	"github.com/unerror/waffy/pkg/services/protos/nodes"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+	"github.com/unerror/waffy/pkg/services/protos/users"
 )
 
-// Serve blocks and services the RPC
-func Serve(listen string, caPool *x509.CertPool, keypair tls.Certificate) error {
+// Serve blocks and services the RPC. Client certificates are verified against the roots through the
+// intermediates trusted at the time only, so a retired intermediate stops being accepted once its
+// Trusted-Until has passed, even if the client sends it. Revoked client certificates are refused.
+// The state of the proxy of this node is reported from proxy.
+func Serve(
+	listen string,
+	roots *x509.CertPool,
+	intermediates *config.IntermediatePool,
+	getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error),
+	db data.Consensus,
+	proxy ProxyStatus,
+) error {
 	lis, err := net.Listen("tcp", listen)
 	if err != nil {
 		return fmt.Errorf("unable to start listener: %s", err)
 	}
 
-	if err != nil {
-		return fmt.Errorf("unable to load keypair for listener: %s", err)
-	}
-
 	creds := credentials.NewTLS(&tls.Config{
-		ClientAuth:   tls.RequireAndVerifyClientCert,
-		MinVersion:   tls.VersionTLS12,
-		ClientCAs:    caPool,
-		Certificates: []tls.Certificate{keypair},
+		ClientAuth:            tls.RequireAnyClientCert,
+		MinVersion:            tls.VersionTLS12,
+		ClientCAs:             roots,
+		GetCertificate:        getCertificate,
+		VerifyPeerCertificate: verifyClientChain(roots, intermediates, db.Weak()),
 	})
 
 	server := grpc.NewServer(grpc.Creds(creds))
+	certificates.RegisterCertificatesServiceServer(server, NewCertificatesService(db))
+	nodes.RegisterJoinServiceServer(server, NewJoinService(db))
+	sites.RegisterSitesServiceServer(server, NewSitesService(db, proxy))
+	users.RegisterUsersServiceServer(server, NewUsersService(db))
 
 	return server.Serve(lis)
 }
+
+// verifyClientChain verifies the client certificate against the roots and the intermediates trusted now,
+// and that it is not revoked in the certificates bucket of db
+func verifyClientChain(roots *x509.CertPool, intermediates *config.IntermediatePool, db data.Store) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return fmt.Errorf("no client certificate provided")
+		}
+
+		cert, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return fmt.Errorf("unable to parse client certificate: %s", err)
+		}
+
+		if err := crypto.Verify(cert, roots, intermediates.Trusted(time.Now()), x509.ExtKeyUsageClientAuth); err != nil {
+			return fmt.Errorf("unable to verify client certificate: %s", err)
+		}
+
+		if revoked(db, cert) {
+			return fmt.Errorf("client certificate %x is revoked", cert.SerialNumber)
+		}
+
+		return nil
+	}
+}
@@ -1 +1,1056 @@
 package services
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/unerror/waffy/pkg/config"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+)
+
+// ProxyStatus reports the state of the proxy of this node, for the Site hostname or for every Site if
+// hostname is empty
+type ProxyStatus interface {
+	// Status returns the health of the Endpoints
+	Status(hostname string) []*sites.EndpointStatus
+
+	// MirrorStats returns the stats of the mirrored Routes
+	MirrorStats(hostname string) []*sites.MirrorStats
+
+	// CacheStats returns the stats of the caches of the Sites
+	CacheStats(hostname string) []*sites.CacheStats
+}
+
+// SitesService manages Sites and their certificates
+type SitesService struct {
+	db    data.Store
+	proxy ProxyStatus
+}
+
+// NewSitesService creates a SitesService backed by the data.Store db, reporting the state of the proxy
+func NewSitesService(db data.Store, proxy ProxyStatus) *SitesService {
+	return &SitesService{db: db, proxy: proxy}
+}
+
+// CreateSite adds a Site to the Balancer on a port
+func (s *SitesService) CreateSite(ctx context.Context, req *sites.CreateSiteRequest) (*sites.SiteInfo, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	if err := validateSite(req.Site); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	if _, err := repository.FindBalancer(s.db, req.Port); err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", err)
+	}
+
+	if err := repository.CreateSite(s.db, req.Port, req.Site); err != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "%s", err)
+	}
+
+	return s.siteInfo(req.Site.Hostname)
+}
+
+// GetSite returns a Site by hostname
+func (s *SitesService) GetSite(ctx context.Context, req *sites.GetSiteRequest) (*sites.SiteInfo, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	return s.siteInfo(req.Hostname)
+}
+
+// ListSites returns every Site
+func (s *SitesService) ListSites(ctx context.Context, req *sites.ListSitesRequest) (*sites.ListSitesResponse, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	balancers, err := repository.ListBalancers(s.db)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to list balancers: %s", err)
+	}
+
+	resp := &sites.ListSitesResponse{}
+	infos := make(map[string]*sites.SiteInfo)
+	for _, b := range balancers {
+		for _, site := range b.Sites {
+			info, ok := infos[site.Hostname]
+			if !ok {
+				info = &sites.SiteInfo{Site: site}
+				infos[site.Hostname] = info
+				resp.Sites = append(resp.Sites, info)
+			}
+			info.Ports = append(info.Ports, b.Port)
+		}
+	}
+
+	return resp, nil
+}
+
+// UpdateSite replaces a Site, on every Balancer that serves it
+func (s *SitesService) UpdateSite(ctx context.Context, req *sites.UpdateSiteRequest) (*sites.SiteInfo, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	if err := validateSite(req.Site); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	if err := repository.UpdateSite(s.db, req.Site); err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", err)
+	}
+
+	return s.siteInfo(req.Site.Hostname)
+}
+
+// DeleteSite removes a Site, from the Balancer on a port or from every Balancer
+func (s *SitesService) DeleteSite(ctx context.Context, req *sites.DeleteSiteRequest) (*sites.DeleteSiteResponse, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	if err := repository.DeleteSite(s.db, req.Hostname, req.Port); err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", err)
+	}
+
+	return &sites.DeleteSiteResponse{}, nil
+}
+
+// PutUpstream creates or replaces an Upstream of a Site by name
+func (s *SitesService) PutUpstream(ctx context.Context, req *sites.PutUpstreamRequest) (*sites.SiteInfo, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	if err := validateUpstream(req.Upstream); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	site, err := repository.FindSite(s.db, req.Hostname)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", err)
+	}
+
+	replaced := false
+	for i, u := range site.Upstreams {
+		if u.Name == req.Upstream.Name {
+			site.Upstreams[i] = req.Upstream
+			replaced = true
+		}
+	}
+	if !replaced {
+		site.Upstreams = append(site.Upstreams, req.Upstream)
+	}
+
+	if err := repository.UpdateSite(s.db, site); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to save site: %s", err)
+	}
+
+	return s.siteInfo(site.Hostname)
+}
+
+// DeleteUpstream removes an Upstream of a Site
+func (s *SitesService) DeleteUpstream(ctx context.Context, req *sites.DeleteUpstreamRequest) (*sites.SiteInfo, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	site, err := repository.FindSite(s.db, req.Hostname)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", err)
+	}
+
+	var upstreams []*sites.Upstream
+	for _, u := range site.Upstreams {
+		if u.Name != req.Name {
+			upstreams = append(upstreams, u)
+		}
+	}
+	if len(upstreams) == len(site.Upstreams) {
+		return nil, status.Errorf(codes.NotFound, "upstream %s does not exist on %s", req.Name, req.Hostname)
+	}
+
+	site.Upstreams = upstreams
+	if err := validateSite(site); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+	}
+
+	if err := repository.UpdateSite(s.db, site); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to save site: %s", err)
+	}
+
+	return s.siteInfo(site.Hostname)
+}
+
+// SetRoutes replaces the Routes of a Site
+func (s *SitesService) SetRoutes(ctx context.Context, req *sites.SetRoutesRequest) (*sites.SiteInfo, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	site, err := repository.FindSite(s.db, req.Hostname)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", err)
+	}
+
+	site.Routes = req.Routes
+	if err := validateSite(site); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	if err := repository.UpdateSite(s.db, site); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to save site: %s", err)
+	}
+
+	return s.siteInfo(site.Hostname)
+}
+
+// SetSplit changes the weights of the split of a Route. With a ramp the weights move from their
+// current weights, part way along any earlier ramp, to the new weights over the ramp.
+func (s *SitesService) SetSplit(ctx context.Context, req *sites.SetSplitRequest) (*sites.SiteInfo, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	if req.Ramp < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "ramp can not be negative")
+	}
+
+	site, err := repository.FindSite(s.db, req.Hostname)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", err)
+	}
+
+	var route *sites.Route
+	for _, r := range site.Routes {
+		if r.Name == req.Route {
+			route = r
+		}
+	}
+	if route == nil {
+		return nil, status.Errorf(codes.NotFound, "route %s does not exist on %s", req.Route, req.Hostname)
+	}
+
+	now := time.Now()
+	current := repository.SplitWeights(route, now)
+
+	split := req.Split
+	if req.Ramp > 0 {
+		ramp := &sites.SplitRamp{Start: now.Unix(), End: now.Unix() + req.Ramp}
+		named := make(map[string]bool)
+		for _, w := range split {
+			named[w.Upstream] = true
+		}
+
+		for i, w := range route.Split {
+			ramp.From = append(ramp.From, &sites.WeightedUpstream{Upstream: w.Upstream, Weight: uint32(current[i] + 0.5)})
+
+			// upstreams left out of the new split ramp down to nothing, rather than dropping at once
+			if !named[w.Upstream] {
+				split = append(split, &sites.WeightedUpstream{Upstream: w.Upstream})
+			}
+		}
+		route.Ramp = ramp
+	} else {
+		route.Ramp = nil
+	}
+	route.Split = split
+
+	if err := validateSite(site); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	if err := repository.UpdateSite(s.db, site); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to save site: %s", err)
+	}
+
+	return s.siteInfo(site.Hostname)
+}
+
+// Status returns the health of the Endpoints of a Site, or of a Balancer by :port, as seen by this node
+func (s *SitesService) Status(ctx context.Context, req *sites.StatusRequest) (*sites.StatusResponse, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	if port := strings.TrimPrefix(req.Hostname, ":"); port != req.Hostname {
+		if _, err := repository.FindBalancer(s.db, port); err != nil {
+			return nil, status.Errorf(codes.NotFound, "no balancer on port %s", port)
+		}
+	} else if req.Hostname != "" {
+		if _, err := repository.FindSite(s.db, req.Hostname); err != nil {
+			return nil, status.Errorf(codes.NotFound, "no site %s", req.Hostname)
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to load config: %s", err)
+	}
+
+	return &sites.StatusResponse{Endpoints: s.proxy.Status(req.Hostname), Node: cfg.RaftListen}, nil
+}
+
+// MirrorStats compares the primary and shadow responses of the mirrored Routes of a Site on this node
+func (s *SitesService) MirrorStats(ctx context.Context, req *sites.MirrorStatsRequest) (*sites.MirrorStatsResponse, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	if req.Hostname != "" {
+		if _, err := repository.FindSite(s.db, req.Hostname); err != nil {
+			return nil, status.Errorf(codes.NotFound, "no site %s", req.Hostname)
+		}
+	}
+
+	return &sites.MirrorStatsResponse{Mirrors: s.proxy.MirrorStats(req.Hostname)}, nil
+}
+
+// PurgeCache stores a purge of the cached responses of a Site, that every node applies when it next
+// reloads. Purges older than repository.CachePurgeRetention are removed.
+func (s *SitesService) PurgeCache(ctx context.Context, req *sites.PurgeCacheRequest) (*sites.PurgeCacheResponse, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	site, err := repository.FindSite(s.db, req.Hostname)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", err)
+	}
+
+	if site.Cache == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "site %s is not cached", site.Hostname)
+	}
+
+	if err := validatePurge(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to create purge: %s", err)
+	}
+
+	now := time.Now()
+	purge := &sites.CachePurge{
+		Id:        hex.EncodeToString(id),
+		Hostname:  site.Hostname,
+		Urls:      req.Urls,
+		Prefixes:  req.Prefixes,
+		Tags:      req.Tags,
+		CreatedAt: now.Unix(),
+	}
+	if err := repository.SaveCachePurge(s.db, purge); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to save purge: %s", err)
+	}
+
+	purges, err := repository.ListCachePurges(s.db)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to list purges: %s", err)
+	}
+	for _, p := range purges {
+		if now.Sub(time.Unix(p.CreatedAt, 0)) > repository.CachePurgeRetention {
+			if err := repository.DeleteCachePurge(s.db, p.Id); err != nil {
+				return nil, status.Errorf(codes.Internal, "unable to delete purge: %s", err)
+			}
+		}
+	}
+
+	return &sites.PurgeCacheResponse{Purge: purge}, nil
+}
+
+// CacheStats returns the hits, misses and size of the cache of a Site on this node
+func (s *SitesService) CacheStats(ctx context.Context, req *sites.CacheStatsRequest) (*sites.CacheStatsResponse, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	if req.Hostname != "" {
+		if _, err := repository.FindSite(s.db, req.Hostname); err != nil {
+			return nil, status.Errorf(codes.NotFound, "no site %s", req.Hostname)
+		}
+	}
+
+	return &sites.CacheStatsResponse{Caches: s.proxy.CacheStats(req.Hostname)}, nil
+}
+
+// siteInfo returns the Site hostname, with the ports of the Balancers that serve it
+func (s *SitesService) siteInfo(hostname string) (*sites.SiteInfo, error) {
+	site, err := repository.FindSite(s.db, hostname)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", err)
+	}
+
+	ports, err := repository.SitePorts(s.db, hostname)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to list balancers: %s", err)
+	}
+
+	return &sites.SiteInfo{Site: site, Ports: ports}, nil
+}
+
+// UploadCertificate stores a custom certificate and key for a Site. The certificate must match the
+// key, and be valid for the Site hostname or one of its aliases.
+func (s *SitesService) UploadCertificate(ctx context.Context, req *sites.UploadCertificateRequest) (*sites.UploadCertificateResponse, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	site, err := repository.FindSite(s.db, req.Hostname)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", err)
+	}
+
+	keypair, err := tls.X509KeyPair(req.Certificate, req.Key)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid certificate or key: %s", err)
+	}
+
+	leaf, err := x509.ParseCertificate(keypair.Certificate[0])
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to parse certificate: %s", err)
+	}
+
+	if !covers(leaf, site) {
+		return nil, status.Errorf(codes.InvalidArgument, "certificate is not valid for %s or its aliases", site.Hostname)
+	}
+
+	err = repository.SaveSiteCertificate(s.db, &sites.SiteCertificate{
+		Hostname:    site.Hostname,
+		Certificate: req.Certificate,
+		Key:         req.Key,
+		NotAfter:    leaf.NotAfter.Unix(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to save certificate: %s", err)
+	}
+
+	return &sites.UploadCertificateResponse{
+		Hostname: site.Hostname,
+		DnsNames: leaf.DNSNames,
+		NotAfter: leaf.NotAfter.Unix(),
+	}, nil
+}
+
+// DeleteCertificate removes the certificate of a Site
+func (s *SitesService) DeleteCertificate(ctx context.Context, req *sites.DeleteCertificateRequest) (*sites.DeleteCertificateResponse, error) {
+	if _, err := requireAdmin(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	if _, err := repository.FindSiteCertificate(s.db, req.Hostname); err != nil {
+		return nil, status.Errorf(codes.NotFound, "no certificate for %s", req.Hostname)
+	}
+
+	if err := repository.DeleteSiteCertificate(s.db, req.Hostname); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to delete certificate: %s", err)
+	}
+
+	return &sites.DeleteCertificateResponse{}, nil
+}
+
+// covers returns if the certificate is valid for the Site hostname or any of its aliases
+func covers(cert *x509.Certificate, site *sites.Site) bool {
+	for _, name := range append([]string{site.Hostname}, site.Alias...) {
+		if cert.VerifyHostname(name) == nil {
+			return true
+		}
+
+		// wildcard aliases are not valid hostnames, so match them against the certificate names
+		for _, n := range cert.DNSNames {
+			if strings.EqualFold(n, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// validateSite checks a Site has a hostname, and that its Upstreams are valid and uniquely named
+func validateSite(site *sites.Site) error {
+	if site == nil || site.Hostname == "" {
+		return fmt.Errorf("a site needs a hostname")
+	}
+
+	if err := validateResilience(site.Resilience); err != nil {
+		return fmt.Errorf("site %s has invalid resilience: %s", site.Hostname, err)
+	}
+
+	if err := validateAffinity(site.Affinity); err != nil {
+		return fmt.Errorf("site %s has invalid affinity: %s", site.Hostname, err)
+	}
+
+	if err := validateWebSocket(site.Websocket); err != nil {
+		return fmt.Errorf("site %s has invalid websocket: %s", site.Hostname, err)
+	}
+
+	if err := validateCache(site.Cache); err != nil {
+		return fmt.Errorf("site %s has invalid cache: %s", site.Hostname, err)
+	}
+
+	if err := validateCompression(site.Compression); err != nil {
+		return fmt.Errorf("site %s has invalid compression: %s", site.Hostname, err)
+	}
+
+	redirects := make(map[string]bool)
+	for _, r := range site.Redirects {
+		if err := validateRedirect(r); err != nil {
+			return err
+		}
+
+		if redirects[r.Name] {
+			return fmt.Errorf("redirect %s is defined more than once", r.Name)
+		}
+		redirects[r.Name] = true
+	}
+
+	rewrites := make(map[string]bool)
+	for _, r := range site.Rewrites {
+		if err := validateRewrite(r); err != nil {
+			return err
+		}
+
+		if rewrites[r.Name] {
+			return fmt.Errorf("rewrite %s is defined more than once", r.Name)
+		}
+		rewrites[r.Name] = true
+	}
+
+	if err := validateHeaderRules(site.RequestHeaders); err != nil {
+		return fmt.Errorf("site %s has invalid request headers: %s", site.Hostname, err)
+	}
+
+	if err := validateHeaderRules(site.ResponseHeaders); err != nil {
+		return fmt.Errorf("site %s has invalid response headers: %s", site.Hostname, err)
+	}
+
+	if err := validateSecurityHeaders(site.SecurityHeaders); err != nil {
+		return fmt.Errorf("site %s has invalid security headers: %s", site.Hostname, err)
+	}
+
+	pages := make(map[uint32]bool)
+	for _, p := range site.ErrorPages {
+		if err := validateErrorPage(p); err != nil {
+			return fmt.Errorf("site %s has an invalid error page: %s", site.Hostname, err)
+		}
+
+		if pages[p.Status] {
+			return fmt.Errorf("error page %d is defined more than once", p.Status)
+		}
+		pages[p.Status] = true
+	}
+
+	if err := validateMaintenance(site.Maintenance); err != nil {
+		return fmt.Errorf("site %s has invalid maintenance: %s", site.Hostname, err)
+	}
+
+	names := make(map[string]bool)
+	for _, u := range site.Upstreams {
+		if err := validateUpstream(u); err != nil {
+			return err
+		}
+
+		if names[u.Name] {
+			return fmt.Errorf("upstream %s is defined more than once", u.Name)
+		}
+		names[u.Name] = true
+	}
+
+	routes := make(map[string]bool)
+	for _, r := range site.Routes {
+		if err := validateRoute(r, names); err != nil {
+			return err
+		}
+
+		if routes[r.Name] {
+			return fmt.Errorf("route %s is defined more than once", r.Name)
+		}
+		routes[r.Name] = true
+	}
+
+	return nil
+}
+
+// validateRoute checks a Route is named, sends requests to one of the upstreams unless it denies
+// them, and that its patterns compile
+func validateRoute(r *sites.Route, upstreams map[string]bool) error {
+	if r == nil || r.Name == "" {
+		return fmt.Errorf("a route needs a name")
+	}
+
+	if r.Deny {
+		if r.Upstream != "" || len(r.Split) > 0 || r.Mirror != nil {
+			return fmt.Errorf("route %s denies requests, so can not send them to an upstream", r.Name)
+		}
+	} else if err := validateSplit(r, upstreams); err != nil {
+		return err
+	}
+
+	switch r.PathMatch {
+	case sites.PathMatch_REGEX:
+		if _, err := regexp.Compile(r.Path); err != nil {
+			return fmt.Errorf("route %s has an invalid path regex: %s", r.Name, err)
+		}
+	default:
+		if r.Path != "" && !strings.HasPrefix(r.Path, "/") {
+			return fmt.Errorf("route %s path must start with /", r.Name)
+		}
+	}
+
+	if r.StripPrefix && (r.PathMatch != sites.PathMatch_PREFIX || r.Rewrite != "") {
+		return fmt.Errorf("route %s can only strip the prefix of a prefix route with no rewrite", r.Name)
+	}
+
+	for _, m := range append(append([]*sites.ValueMatch{}, r.Headers...), r.Query...) {
+		if m.Name == "" {
+			return fmt.Errorf("route %s matches a header or query parameter with no name", r.Name)
+		}
+
+		if m.Regex {
+			if _, err := regexp.Compile(m.Value); err != nil {
+				return fmt.Errorf("route %s has an invalid regex for %s: %s", r.Name, m.Name, err)
+			}
+		}
+	}
+
+	if err := validateResilience(r.Resilience); err != nil {
+		return fmt.Errorf("route %s has invalid resilience: %s", r.Name, err)
+	}
+
+	if m := r.Mirror; m != nil {
+		if !upstreams[m.Upstream] {
+			return fmt.Errorf("route %s mirrors requests to unknown upstream %s", r.Name, m.Upstream)
+		}
+
+		if m.Percent < -1 || m.Percent > 100 {
+			return fmt.Errorf("route %s mirrors %d%% of requests, not 0 to 100, or -1 for every request", r.Name, m.Percent)
+		}
+	}
+
+	return nil
+}
+
+// validateUpstream checks an Upstream is named, can be balanced by its strategy, and that its
+// Endpoints can be connected to
+func validateUpstream(u *sites.Upstream) error {
+	if u == nil || u.Name == "" {
+		return fmt.Errorf("an upstream needs a name")
+	}
+
+	if u.Strategy == sites.Strategy_CONSISTENT_HASH && u.Hash != nil &&
+		u.Hash.Source != sites.HashSource_CLIENT_IP && u.Hash.Name == "" {
+		return fmt.Errorf("upstream %s needs a header or cookie name to hash", u.Name)
+	}
+
+	if err := validateHealthCheck(u.HealthCheck); err != nil {
+		return fmt.Errorf("upstream %s has an invalid health check: %s", u.Name, err)
+	}
+
+	if u.ProxyProtocol > 2 {
+		return fmt.Errorf("upstream %s has an unknown PROXY protocol version %d", u.Name, u.ProxyProtocol)
+	}
+
+	for _, e := range u.Endpoints {
+		if e.Address == "" {
+			return fmt.Errorf("upstream %s has an endpoint with no address", u.Name)
+		}
+
+		if e.Scheme != "" && e.Scheme != "http" && e.Scheme != "https" && e.Scheme != "h2c" {
+			return fmt.Errorf("endpoint %s has an unknown scheme %s", e.Address, e.Scheme)
+		}
+
+		// h2c connections are shared by the requests of every client, so can not be given the address of one
+		if e.Scheme == "h2c" && u.ProxyProtocol != 0 {
+			return fmt.Errorf("endpoint %s is h2c, which can not be sent the PROXY protocol", e.Address)
+		}
+
+		if e.Tls != nil && len(e.Tls.Ca) > 0 && !x509.NewCertPool().AppendCertsFromPEM(e.Tls.Ca) {
+			return fmt.Errorf("endpoint %s has no certificates in its CA", e.Address)
+		}
+	}
+
+	return nil
+}
+
+// validateHealthCheck checks the body regex of a HealthCheck compiles, and that it times out before
+// the next check
+func validateHealthCheck(c *sites.HealthCheck) error {
+	if c == nil {
+		return nil
+	}
+
+	if c.BodyRegex != "" {
+		if _, err := regexp.Compile(c.BodyRegex); err != nil {
+			return fmt.Errorf("invalid body regex: %s", err)
+		}
+	}
+
+	if c.Interval > 0 && c.Timeout > c.Interval {
+		return fmt.Errorf("timeout is longer than the interval")
+	}
+
+	return nil
+}
+
+// validateResilience checks the percentages of a Resilience are at most 100, and its durations are
+// not negative
+func validateResilience(r *sites.Resilience) error {
+	if r == nil {
+		return nil
+	}
+
+	if t := r.Timeouts; t != nil && (t.Connect < 0 || t.PerTry < 0 || t.Overall < 0) {
+		return fmt.Errorf("timeouts can not be negative")
+	}
+
+	if rt := r.Retry; rt != nil {
+		if rt.BudgetPercent > 100 {
+			return fmt.Errorf("retry budget is over 100%%")
+		}
+		if rt.BaseBackoff < 0 || rt.MaxBackoff < 0 {
+			return fmt.Errorf("retry backoff can not be negative")
+		}
+	}
+
+	if c := r.CircuitBreaker; c != nil {
+		if c.ErrorPercent > 100 {
+			return fmt.Errorf("circuit breaker error percent is over 100%%")
+		}
+		if c.Window < 0 || c.Open < 0 {
+			return fmt.Errorf("circuit breaker durations can not be negative")
+		}
+	}
+
+	return nil
+}
+
+// validateAffinity checks the cookie of an Affinity is a valid cookie name, and its ttl is not negative
+func validateAffinity(a *sites.Affinity) error {
+	if a == nil {
+		return nil
+	}
+
+	if strings.ContainsAny(a.Cookie, "()<>@,;:\\\"/[]?={} \t") {
+		return fmt.Errorf("invalid cookie name %q", a.Cookie)
+	}
+
+	if a.Ttl < 0 {
+		return fmt.Errorf("ttl can not be negative")
+	}
+
+	return nil
+}
+
+// validateWebSocket checks the limits of a WebSocket are not negative, and its deny rules compile
+func validateWebSocket(w *sites.WebSocket) error {
+	if w == nil {
+		return nil
+	}
+
+	if w.IdleTimeout < 0 || w.MaxFrameSize < 0 || w.MaxMessageSize < 0 {
+		return fmt.Errorf("limits can not be negative")
+	}
+
+	for _, d := range w.Deny {
+		if _, err := regexp.Compile(d); err != nil {
+			return fmt.Errorf("invalid deny regex %q: %s", d, err)
+		}
+	}
+
+	return nil
+}
+
+// validateCache checks the sizes of a Cache are not negative, and its disk path is absolute
+func validateCache(c *sites.Cache) error {
+	if c == nil {
+		return nil
+	}
+
+	if c.MaxSize < 0 || c.MaxObjectSize < 0 || c.DefaultTtl < 0 || c.DiskMaxSize < 0 {
+		return fmt.Errorf("sizes can not be negative")
+	}
+
+	if c.DiskPath != "" && !filepath.IsAbs(c.DiskPath) {
+		return fmt.Errorf("disk path %s is not absolute", c.DiskPath)
+	}
+
+	if strings.ContainsAny(c.TagHeader, "()<>@,;:\\\"/[]?={} \t") {
+		return fmt.Errorf("invalid tag header %q", c.TagHeader)
+	}
+
+	return nil
+}
+
+// validateCompression checks a Compression has known encodings, each once, content types as
+// type/subtype or type/*, and a min size that is not negative
+func validateCompression(c *sites.Compression) error {
+	if c == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range c.Encodings {
+		switch e {
+		case "br", "zstd", "gzip":
+		default:
+			return fmt.Errorf("unknown encoding %s, expected br, zstd or gzip", e)
+		}
+
+		if seen[e] {
+			return fmt.Errorf("duplicate encoding %s", e)
+		}
+		seen[e] = true
+	}
+
+	for _, t := range c.ContentTypes {
+		parts := strings.Split(t, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[0] == "*" || parts[1] == "" {
+			return fmt.Errorf("invalid content type %s, expected type/subtype or type/*", t)
+		}
+	}
+
+	if c.MinSize < 0 {
+		return fmt.Errorf("min size can not be negative")
+	}
+
+	return nil
+}
+
+// validateRedirect checks a Redirect is named, has a valid regex and status, and redirects somewhere
+func validateRedirect(r *sites.Redirect) error {
+	if r.Name == "" {
+		return fmt.Errorf("a redirect needs a name")
+	}
+
+	if r.Regex != "" {
+		if _, err := regexp.Compile(r.Regex); err != nil {
+			return fmt.Errorf("redirect %s has an invalid regex: %s", r.Name, err)
+		}
+	}
+
+	if r.Replacement == "" && r.Host == "" && !r.Https {
+		return fmt.Errorf("redirect %s needs a replacement, host or https to redirect to", r.Name)
+	}
+
+	if strings.ContainsAny(r.Host, "/?#@ \t") {
+		return fmt.Errorf("redirect %s has an invalid host %s", r.Name, r.Host)
+	}
+
+	if strings.ContainsAny(r.Replacement, "\r\n") {
+		return fmt.Errorf("redirect %s has an invalid replacement", r.Name)
+	}
+
+	switch r.Status {
+	case 0, 301, 302, 307, 308:
+	default:
+		return fmt.Errorf("redirect %s has status %d, expected 301, 302, 307 or 308", r.Name, r.Status)
+	}
+
+	return nil
+}
+
+// validateRewrite checks a Rewrite is named and has a valid regex
+func validateRewrite(r *sites.Rewrite) error {
+	if r.Name == "" {
+		return fmt.Errorf("a rewrite needs a name")
+	}
+
+	if r.Regex == "" {
+		return fmt.Errorf("rewrite %s needs a regex", r.Name)
+	}
+
+	if _, err := regexp.Compile(r.Regex); err != nil {
+		return fmt.Errorf("rewrite %s has an invalid regex: %s", r.Name, err)
+	}
+
+	return nil
+}
+
+// validateHeaderRules checks HeaderRules name valid headers, with values of a single line
+func validateHeaderRules(rules *sites.HeaderRules) error {
+	if rules == nil {
+		return nil
+	}
+
+	names := append([]string{}, rules.Remove...)
+	for _, h := range append(append([]*sites.Header{}, rules.Set...), rules.Add...) {
+		if strings.ContainsAny(h.Value, "\r\n") {
+			return fmt.Errorf("header %s has a value of more than one line", h.Name)
+		}
+		names = append(names, h.Name)
+	}
+
+	for _, name := range names {
+		if name == "" || strings.ContainsAny(name, "()<>@,;:\\\"/[]?={} \t\r\n") {
+			return fmt.Errorf("invalid header name %q", name)
+		}
+	}
+
+	return nil
+}
+
+// validateSecurityHeaders checks SecurityHeaders have a max-age that is not negative, a known frame
+// option, and values of a single line
+func validateSecurityHeaders(sh *sites.SecurityHeaders) error {
+	if sh == nil {
+		return nil
+	}
+
+	if sh.HstsMaxAge < 0 {
+		return fmt.Errorf("hsts max-age can not be negative")
+	}
+
+	switch strings.ToUpper(sh.FrameOptions) {
+	case "", "DENY", "SAMEORIGIN":
+	default:
+		return fmt.Errorf("frame options %s, expected DENY or SAMEORIGIN", sh.FrameOptions)
+	}
+
+	for _, v := range []string{sh.ContentSecurityPolicy, sh.ReferrerPolicy, sh.PermissionsPolicy} {
+		if strings.ContainsAny(v, "\r\n") {
+			return fmt.Errorf("security header %q has more than one line", v)
+		}
+	}
+
+	return nil
+}
+
+// validateErrorPage checks an ErrorPage is for a status the proxy answers requests with itself, and
+// that its content type and template parse
+func validateErrorPage(p *sites.ErrorPage) error {
+	switch p.Status {
+	case 403, 429, 502, 503, 504:
+	default:
+		return fmt.Errorf("status %d, expected 403, 429, 502, 503 or 504", p.Status)
+	}
+
+	if p.Template == "" {
+		return fmt.Errorf("page %d needs a template", p.Status)
+	}
+
+	return validatePage(p.ContentType, p.Template)
+}
+
+// validateMaintenance checks the CIDRs and users that bypass a Maintenance, and that its page parses
+func validateMaintenance(m *sites.Maintenance) error {
+	if m == nil {
+		return nil
+	}
+
+	for _, c := range m.Allow {
+		if _, _, err := net.ParseCIDR(c); err != nil && net.ParseIP(c) == nil {
+			return fmt.Errorf("invalid CIDR %s", c)
+		}
+	}
+
+	for _, u := range m.Users {
+		if !strings.Contains(u, "@") {
+			return fmt.Errorf("invalid user %s, expected an email", u)
+		}
+	}
+
+	if m.RetryAfter < 0 {
+		return fmt.Errorf("retry after can not be negative")
+	}
+
+	if m.Template == "" {
+		return nil
+	}
+
+	return validatePage(m.ContentType, m.Template)
+}
+
+// validatePage checks the content type of a page, if it is set, and that its template parses
+func validatePage(contentType, text string) error {
+	if contentType != "" {
+		if _, _, err := mime.ParseMediaType(contentType); err != nil {
+			return fmt.Errorf("invalid content type %s: %s", contentType, err)
+		}
+	}
+
+	if _, err := template.New("page").Parse(text); err != nil {
+		return fmt.Errorf("invalid template: %s", err)
+	}
+
+	return nil
+}
+
+// validatePurge checks a purge names responses by path, or purges them all
+func validatePurge(req *sites.PurgeCacheRequest) error {
+	matches := len(req.Urls) + len(req.Prefixes) + len(req.Tags)
+	switch {
+	case req.All && matches > 0:
+		return fmt.Errorf("a purge of every response can not also name urls, prefixes or tags")
+	case !req.All && matches == 0:
+		return fmt.Errorf("a purge needs urls, prefixes or tags, or to purge every response")
+	}
+
+	for _, u := range append(append([]string{}, req.Urls...), req.Prefixes...) {
+		if !strings.HasPrefix(u, "/") {
+			return fmt.Errorf("invalid path %s, expected it to start with /", u)
+		}
+	}
+
+	return nil
+}
+
+// validateSplit checks a Route sends requests to one of the upstreams, or splits them by weight across
+// distinct upstreams, sticking by a named header or cookie
+func validateSplit(r *sites.Route, upstreams map[string]bool) error {
+	if len(r.Split) == 0 {
+		if !upstreams[r.Upstream] {
+			return fmt.Errorf("route %s sends requests to unknown upstream %s", r.Name, r.Upstream)
+		}
+
+		return nil
+	}
+
+	var total uint32
+	seen := make(map[string]bool)
+	for _, w := range r.Split {
+		if !upstreams[w.Upstream] {
+			return fmt.Errorf("route %s splits requests to unknown upstream %s", r.Name, w.Upstream)
+		}
+
+		if seen[w.Upstream] {
+			return fmt.Errorf("route %s splits requests to upstream %s more than once", r.Name, w.Upstream)
+		}
+		seen[w.Upstream] = true
+		total += w.Weight
+	}
+
+	if total == 0 && r.Ramp == nil {
+		return fmt.Errorf("route %s splits requests with no weight", r.Name)
+	}
+
+	if r.Sticky != nil && r.Sticky.Source != sites.HashSource_CLIENT_IP && r.Sticky.Name == "" {
+		return fmt.Errorf("route %s needs a header or cookie name to stick to", r.Name)
+	}
+
+	if r.Ramp != nil && r.Ramp.End <= r.Ramp.Start {
+		return fmt.Errorf("route %s ramp ends before it starts", r.Name)
+	}
+
+	return nil
+}
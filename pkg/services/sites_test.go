@@ -0,0 +1,293 @@
+package services
+
+import (
+	"crypto/x509"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/unerror/waffy/pkg/config"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/sites"
+	"github.com/unerror/waffy/pkg/services/protos/users"
+)
+
+// fakeProxy reports the status of an Endpoint for any hostname
+type fakeProxy struct{}
+
+func (fakeProxy) Status(hostname string) []*sites.EndpointStatus {
+	return []*sites.EndpointStatus{{
+		Health:       &sites.EndpointHealth{Hostname: hostname, Address: "127.0.0.1:8080", Healthy: true},
+		EjectedUntil: 1,
+	}}
+}
+
+func (fakeProxy) MirrorStats(hostname string) []*sites.MirrorStats { return nil }
+func (fakeProxy) CacheStats(hostname string) []*sites.CacheStats   { return nil }
+
+func TestSiteStatus(t *testing.T) {
+	ca := newTestCA(t)
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+
+	if err := repository.SaveBalancer(db, &sites.Balancer{Proto: "tcp", Port: "9000"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repository.CreateSite(db, "9000", &sites.Site{Hostname: "example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	admin := ca.issue(t, "admin@example.com")
+	createUser(t, db, admin, users.Role_ADMIN)
+
+	s := NewSitesService(db, fakeProxy{})
+	cfg, _ := config.Load()
+
+	Convey("The status should name the node its ejections are from", t, func() {
+		for _, hostname := range []string{"", "example.com", ":9000"} {
+			resp, err := s.Status(peerContext(admin), &sites.StatusRequest{Hostname: hostname})
+			So(err, ShouldBeNil)
+			So(resp.Node, ShouldEqual, cfg.RaftListen)
+			So(resp.Endpoints, ShouldHaveLength, 1)
+		}
+	})
+
+	Convey("The status of no Site or Balancer should not be found", t, func() {
+		for _, hostname := range []string{"example.org", ":9001"} {
+			_, err := s.Status(peerContext(admin), &sites.StatusRequest{Hostname: hostname})
+			So(status.Code(err), ShouldEqual, codes.NotFound)
+		}
+	})
+}
+
+func TestSitesServiceAccess(t *testing.T) {
+	ca := newTestCA(t)
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+
+	if err := repository.SaveBalancer(db, &sites.Balancer{Proto: "tcp", Port: "9000"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repository.CreateSite(db, "9000", &sites.Site{Hostname: "example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	admin := ca.issue(t, "admin@example.com")
+	createUser(t, db, admin, users.Role_ADMIN)
+	user := ca.issue(t, "user@example.com")
+	createUser(t, db, user, users.Role_USER)
+	stranger := ca.issue(t, "stranger@example.com")
+
+	s := NewSitesService(db, fakeProxy{})
+
+	Convey("An admin should read the Sites and their stats", t, func() {
+		ctx := peerContext(admin)
+
+		info, err := s.GetSite(ctx, &sites.GetSiteRequest{Hostname: "example.com"})
+		So(err, ShouldBeNil)
+		So(info.Ports, ShouldResemble, []string{"9000"})
+
+		list, err := s.ListSites(ctx, &sites.ListSitesRequest{})
+		So(err, ShouldBeNil)
+		So(list.Sites, ShouldHaveLength, 1)
+
+		_, err = s.MirrorStats(ctx, &sites.MirrorStatsRequest{Hostname: "example.com"})
+		So(err, ShouldBeNil)
+
+		_, err = s.CacheStats(ctx, &sites.CacheStatsRequest{Hostname: "example.com"})
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Users that are not admins should be denied reading the Sites and their stats", t, func() {
+		for _, c := range []*x509.Certificate{user, stranger} {
+			ctx := peerContext(c)
+
+			_, err := s.GetSite(ctx, &sites.GetSiteRequest{Hostname: "example.com"})
+			So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+
+			_, err = s.ListSites(ctx, &sites.ListSitesRequest{})
+			So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+
+			_, err = s.Status(ctx, &sites.StatusRequest{Hostname: "example.com"})
+			So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+
+			_, err = s.MirrorStats(ctx, &sites.MirrorStatsRequest{Hostname: "example.com"})
+			So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+
+			_, err = s.CacheStats(ctx, &sites.CacheStatsRequest{Hostname: "example.com"})
+			So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+		}
+	})
+
+	Convey("A client with no certificate should be denied reading the Sites", t, func() {
+		_, err := s.ListSites(context.Background(), &sites.ListSitesRequest{})
+		So(status.Code(err), ShouldEqual, codes.Unauthenticated)
+	})
+}
+
+func TestUpstreams(t *testing.T) {
+	ca := newTestCA(t)
+	admin := ca.issue(t, "admin@example.com")
+	user := ca.issue(t, "user@example.com")
+
+	Convey("With a Site with an Upstream a Route sends requests to", t, func() {
+		db, cleanup := newTestDB(t)
+		defer cleanup()
+
+		createUser(t, db, admin, users.Role_ADMIN)
+		createUser(t, db, user, users.Role_USER)
+
+		So(repository.SaveBalancer(db, &sites.Balancer{Proto: "tcp", Port: "9000"}), ShouldBeNil)
+		So(repository.CreateSite(db, "9000", &sites.Site{
+			Hostname:  "example.com",
+			Upstreams: []*sites.Upstream{{Name: "web", Endpoints: []*sites.Endpoint{{Address: "10.0.0.1:80"}}}},
+			Routes:    []*sites.Route{{Name: "all", Upstream: "web"}},
+		}), ShouldBeNil)
+
+		s := NewSitesService(db, fakeProxy{})
+		ctx := peerContext(admin)
+
+		Convey("A new Upstream should be added to the Site", func() {
+			info, err := s.PutUpstream(ctx, &sites.PutUpstreamRequest{
+				Hostname: "example.com",
+				Upstream: &sites.Upstream{Name: "api", Endpoints: []*sites.Endpoint{{Address: "10.0.0.2:80"}}},
+			})
+			So(err, ShouldBeNil)
+			So(info.Site.Upstreams, ShouldHaveLength, 2)
+			So(info.Site.Upstreams[1].Name, ShouldEqual, "api")
+			So(info.Ports, ShouldResemble, []string{"9000"})
+
+			stored, err := repository.FindSite(db, "example.com")
+			So(err, ShouldBeNil)
+			So(stored.Upstreams, ShouldHaveLength, 2)
+		})
+
+		Convey("An Upstream of the same name should be replaced", func() {
+			info, err := s.PutUpstream(ctx, &sites.PutUpstreamRequest{
+				Hostname: "example.com",
+				Upstream: &sites.Upstream{Name: "web", Endpoints: []*sites.Endpoint{{Address: "10.0.0.3:80"}}},
+			})
+			So(err, ShouldBeNil)
+			So(info.Site.Upstreams, ShouldHaveLength, 1)
+			So(info.Site.Upstreams[0].Endpoints[0].Address, ShouldEqual, "10.0.0.3:80")
+		})
+
+		Convey("An invalid Upstream should not be stored", func() {
+			_, err := s.PutUpstream(ctx, &sites.PutUpstreamRequest{
+				Hostname: "example.com",
+				Upstream: &sites.Upstream{Name: "web", Endpoints: []*sites.Endpoint{{}}},
+			})
+			So(status.Code(err), ShouldEqual, codes.InvalidArgument)
+
+			stored, err := repository.FindSite(db, "example.com")
+			So(err, ShouldBeNil)
+			So(stored.Upstreams[0].Endpoints[0].Address, ShouldEqual, "10.0.0.1:80")
+		})
+
+		Convey("An Upstream of no Site should not be found", func() {
+			_, err := s.PutUpstream(ctx, &sites.PutUpstreamRequest{Hostname: "example.org", Upstream: &sites.Upstream{Name: "web"}})
+			So(status.Code(err), ShouldEqual, codes.NotFound)
+
+			_, err = s.DeleteUpstream(ctx, &sites.DeleteUpstreamRequest{Hostname: "example.org", Name: "web"})
+			So(status.Code(err), ShouldEqual, codes.NotFound)
+
+			_, err = s.DeleteUpstream(ctx, &sites.DeleteUpstreamRequest{Hostname: "example.com", Name: "api"})
+			So(status.Code(err), ShouldEqual, codes.NotFound)
+		})
+
+		Convey("An Upstream a Route sends requests to should not be deleted", func() {
+			_, err := s.DeleteUpstream(ctx, &sites.DeleteUpstreamRequest{Hostname: "example.com", Name: "web"})
+			So(status.Code(err), ShouldEqual, codes.FailedPrecondition)
+
+			stored, err := repository.FindSite(db, "example.com")
+			So(err, ShouldBeNil)
+			So(stored.Upstreams, ShouldHaveLength, 1)
+		})
+
+		Convey("An Upstream no Route sends requests to should be deleted", func() {
+			_, err := s.PutUpstream(ctx, &sites.PutUpstreamRequest{Hostname: "example.com", Upstream: &sites.Upstream{Name: "api"}})
+			So(err, ShouldBeNil)
+
+			info, err := s.DeleteUpstream(ctx, &sites.DeleteUpstreamRequest{Hostname: "example.com", Name: "api"})
+			So(err, ShouldBeNil)
+			So(info.Site.Upstreams, ShouldHaveLength, 1)
+			So(info.Site.Upstreams[0].Name, ShouldEqual, "web")
+		})
+
+		Convey("Users that are not admins should be denied changing the Upstreams", func() {
+			_, err := s.PutUpstream(peerContext(user), &sites.PutUpstreamRequest{Hostname: "example.com", Upstream: &sites.Upstream{Name: "api"}})
+			So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+
+			_, err = s.DeleteUpstream(peerContext(user), &sites.DeleteUpstreamRequest{Hostname: "example.com", Name: "web"})
+			So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+		})
+	})
+}
+
+func TestValidateUpstream(t *testing.T) {
+	Convey("A valid Upstream should be accepted", t, func() {
+		So(validateUpstream(&sites.Upstream{
+			Name:        "web",
+			Strategy:    sites.Strategy_CONSISTENT_HASH,
+			Hash:        &sites.HashPolicy{Source: sites.HashSource_CLIENT_IP},
+			HealthCheck: &sites.HealthCheck{BodyRegex: "^ok$", Interval: 10, Timeout: 5},
+			Endpoints: []*sites.Endpoint{
+				{Address: "10.0.0.1:80"},
+				{Address: "10.0.0.2:443", Scheme: "https", Tls: &sites.EndpointTLS{ServerName: "example.com"}},
+				{Address: "10.0.0.3:80", Scheme: "h2c"},
+			},
+		}), ShouldBeNil)
+	})
+
+	Convey("An invalid Upstream should be refused", t, func() {
+		for _, u := range []*sites.Upstream{
+			nil,
+			{},
+			{Name: "web", Strategy: sites.Strategy_CONSISTENT_HASH, Hash: &sites.HashPolicy{Source: sites.HashSource_HEADER}},
+			{Name: "web", HealthCheck: &sites.HealthCheck{BodyRegex: "("}},
+			{Name: "web", HealthCheck: &sites.HealthCheck{Interval: 5, Timeout: 10}},
+			{Name: "web", ProxyProtocol: 3},
+			{Name: "web", Endpoints: []*sites.Endpoint{{}}},
+			{Name: "web", Endpoints: []*sites.Endpoint{{Address: "10.0.0.1:80", Scheme: "ftp"}}},
+			{Name: "web", ProxyProtocol: 1, Endpoints: []*sites.Endpoint{{Address: "10.0.0.1:80", Scheme: "h2c"}}},
+			{Name: "web", Endpoints: []*sites.Endpoint{{Address: "10.0.0.1:443", Tls: &sites.EndpointTLS{Ca: []byte("not a certificate")}}}},
+		} {
+			So(validateUpstream(u), ShouldNotBeNil)
+		}
+	})
+
+	Convey("A Site should refuse Upstreams of the same name", t, func() {
+		So(validateSite(&sites.Site{
+			Hostname:  "example.com",
+			Upstreams: []*sites.Upstream{{Name: "web"}, {Name: "web"}},
+		}), ShouldNotBeNil)
+	})
+}
+
+func TestValidateMirror(t *testing.T) {
+	upstreams := map[string]bool{"web": true, "shadow": true}
+	route := func(percent int32) *sites.Route {
+		return &sites.Route{Name: "all", Upstream: "web", Mirror: &sites.Mirror{Upstream: "shadow", Percent: percent}}
+	}
+
+	Convey("A mirror of every request, none, or a percent of them should be accepted", t, func() {
+		for _, percent := range []int32{-1, 0, 50, 100} {
+			So(validateRoute(route(percent), upstreams), ShouldBeNil)
+		}
+	})
+
+	Convey("A mirror of a percent outside of 0 to 100 should be refused", t, func() {
+		for _, percent := range []int32{-2, 101} {
+			So(validateRoute(route(percent), upstreams), ShouldNotBeNil)
+		}
+	})
+
+	Convey("A mirror to an unknown upstream should be refused", t, func() {
+		r := route(-1)
+		r.Mirror.Upstream = "missing"
+		So(validateRoute(r, upstreams), ShouldNotBeNil)
+	})
+}
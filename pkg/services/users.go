@@ -1 +1,102 @@
 package services
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/unerror/waffy/pkg/config"
+	"github.com/unerror/waffy/pkg/crypto"
+	"github.com/unerror/waffy/pkg/data"
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/users"
+)
+
+// UsersService manages users
+type UsersService struct {
+	db data.Store
+}
+
+// NewUsersService creates a UsersService backed by the data.Store db
+func NewUsersService(db data.Store) *UsersService {
+	return &UsersService{db: db}
+}
+
+// Export exports a user's key and certificate as a bundle. Admins can export any user, other users
+// only themselves, with the certificate they were last issued and only while it is not revoked.
+func (s *UsersService) Export(ctx context.Context, req *users.ExportRequest) (*users.ExportResponse, error) {
+	cert, err := peerCertificate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	self := cert.Subject.CommonName == req.Email
+	if !self {
+		if _, err := requireAdmin(ctx, s.db); err != nil {
+			return nil, err
+		}
+	}
+
+	u, err := repository.FindUserByEmail(s.db, req.Email)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user %s not found", req.Email)
+	}
+
+	// a certificate with the user's name is not enough to be given their key, it must be theirs
+	if self && !currentCertificate(s.db, u, cert) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not the current certificate of %s", cert.SerialNumber.Text(16), u.Email)
+	}
+
+	bundle, err := ExportUserBundle(req.Email, req.Format, req.Password)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return &users.ExportResponse{Bundle: bundle}, nil
+}
+
+// ExportUserBundle encodes the user's key and certificate from the CertPath, along with the CA chain
+// that issued it, in the given format
+func ExportUserBundle(email string, format users.BundleFormat, password string) ([]byte, error) {
+	cert, key, err := config.LoadClientCert(email)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := config.LoadChain(cert)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load CA chain: %s", err)
+	}
+
+	switch format {
+	case users.BundleFormat_P12:
+		return crypto.EncodePKCS12(key, cert, chain, password)
+	case users.BundleFormat_PEM_BUNDLE:
+		return crypto.EncodePEMBundle(key, cert, chain), nil
+	}
+
+	return nil, fmt.Errorf("unknown bundle format %s", format)
+}
+
+// ParseBundleFormat parses the name of a bundle format, p12 or pem-bundle
+func ParseBundleFormat(name string) (users.BundleFormat, error) {
+	switch name {
+	case "p12":
+		return users.BundleFormat_P12, nil
+	case "pem-bundle":
+		return users.BundleFormat_PEM_BUNDLE, nil
+	}
+
+	return 0, fmt.Errorf("unknown bundle format %s, expected p12 or pem-bundle", name)
+}
+
+// BundleExtension returns the file extension for a bundle format
+func BundleExtension(format users.BundleFormat) string {
+	if format == users.BundleFormat_P12 {
+		return "p12"
+	}
+
+	return "pem"
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/unerror/waffy/pkg/repository"
+	"github.com/unerror/waffy/pkg/services/protos/users"
+)
+
+func TestUsersServiceExport(t *testing.T) {
+	ca := newTestCA(t)
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+
+	admin := ca.issue(t, "admin@example.com")
+	createUser(t, db, admin, users.Role_ADMIN)
+	user := ca.issue(t, "user@example.com")
+	createUser(t, db, user, users.Role_USER)
+	other := ca.issue(t, "other@example.com")
+	createUser(t, db, other, users.Role_USER)
+
+	revokedUser := ca.issue(t, "revoked@example.com")
+	createUser(t, db, revokedUser, users.Role_USER)
+	if err := repository.CreateCertificate(db, repository.NewCertificate(revokedUser)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repository.RevokeCertificate(db, revokedUser.SerialNumber.Bytes(), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewUsersService(db)
+	export := func(email string) *users.ExportRequest {
+		return &users.ExportRequest{Email: email, Format: users.BundleFormat_PEM_BUNDLE}
+	}
+
+	// the bundles are not in the CertPath of the test, so exports that are allowed fail to load them
+	Convey("A user with their current certificate should be allowed to export themselves", t, func() {
+		_, err := s.Export(peerContext(user), export("user@example.com"))
+		So(status.Code(err), ShouldNotEqual, codes.PermissionDenied)
+	})
+
+	Convey("An admin should be allowed to export any user", t, func() {
+		_, err := s.Export(peerContext(admin), export("user@example.com"))
+		So(status.Code(err), ShouldNotEqual, codes.PermissionDenied)
+	})
+
+	Convey("A user should be denied exporting another user", t, func() {
+		_, err := s.Export(peerContext(other), export("user@example.com"))
+		So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+	})
+
+	Convey("A certificate with the user's name that is not their current one should be denied", t, func() {
+		_, err := s.Export(peerContext(ca.issue(t, "user@example.com")), export("user@example.com"))
+		So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+	})
+
+	Convey("A user whose current certificate is revoked should be denied", t, func() {
+		_, err := s.Export(peerContext(revokedUser), export("revoked@example.com"))
+		So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+	})
+}